@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"time"
+)
+
+// Day is a convenience for password ages, which are measured in days.
+const Day = 24 * time.Hour
+
+// AgeBucket groups findings by how long ago the password was changed.
+// Fields:
+//   - Label (string): A short description such as "1-3 months".
+//   - Max (time.Duration): The upper bound of the bucket; 0 for the oldest
+//     bucket and for passwords of unknown age.
+//   - Findings ([]int): Indexes of the findings in the bucket.
+type AgeBucket struct {
+	Label    string
+	Max      time.Duration
+	Findings []int
+}
+
+// ageBounds defines the buckets of AgeHistogram, youngest first.
+var ageBounds = []struct {
+	label string
+	max   time.Duration
+}{
+	{"< 1 month", 30 * Day},
+	{"1-3 months", 91 * Day},
+	{"3-6 months", 182 * Day},
+	{"6-12 months", 365 * Day},
+	{"1-2 years", 730 * Day},
+	{"> 2 years", 0},
+}
+
+// AgeHistogram sorts findings into age buckets.
+// Purpose:
+//
+//	Shows at a glance how stale saved passwords are. Findings without a
+//	change date, which many exports omit, go into a final "unknown" bucket.
+//
+// Parameters:
+//   - findings ([]Finding): The audit results.
+//   - now (time.Time): The time ages are measured from.
+//
+// Returns:
+//
+//	[]AgeBucket: The buckets from youngest to oldest, then "unknown".
+//
+// Example:
+//
+//	for _, bucket := range audit.AgeHistogram(findings, time.Now()) {
+//		fmt.Println(bucket.Label, len(bucket.Findings))
+//	}
+func AgeHistogram(findings []Finding, now time.Time) []AgeBucket {
+	buckets := make([]AgeBucket, 0, len(ageBounds)+1)
+	for _, bound := range ageBounds {
+		buckets = append(buckets, AgeBucket{Label: bound.label, Max: bound.max})
+	}
+	unknown := AgeBucket{Label: "unknown"}
+
+	for i, finding := range findings {
+		if finding.Entry.Changed.IsZero() {
+			unknown.Findings = append(unknown.Findings, i)
+			continue
+		}
+		age := now.Sub(finding.Entry.Changed)
+		for b := range buckets {
+			if buckets[b].Max == 0 || age < buckets[b].Max {
+				buckets[b].Findings = append(buckets[b].Findings, i)
+				break
+			}
+		}
+	}
+	return append(buckets, unknown)
+}
@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+var auditNow = time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+// TestAgeHistogram verifies that findings land in the bucket of their age.
+func TestAgeHistogram(t *testing.T) {
+	findings := []Finding{
+		{Entry: Entry{Changed: auditNow.Add(-10 * Day)}},
+		{Entry: Entry{Changed: auditNow.Add(-100 * Day)}},
+		{Entry: Entry{Changed: auditNow.Add(-1000 * Day)}},
+		{Entry: Entry{}},
+	}
+	buckets := AgeHistogram(findings, auditNow)
+	counts := make(map[string][]int)
+	for _, bucket := range buckets {
+		counts[bucket.Label] = bucket.Findings
+	}
+	want := map[string]int{"< 1 month": 0, "3-6 months": 1, "> 2 years": 2, "unknown": 3}
+	for label, index := range want {
+		if got := counts[label]; len(got) != 1 || got[0] != index {
+			t.Errorf("Expected finding %d in %q, but got %v", index, label, got)
+		}
+	}
+	if last := buckets[len(buckets)-1].Label; last != "unknown" {
+		t.Errorf("Expected the unknown bucket last, but got %q", last)
+	}
+}
+
+// TestRun_Stale verifies that old passwords are flagged and get a replacement.
+func TestRun_Stale(t *testing.T) {
+	csv := "url,username,password,timePasswordChanged\n" +
+		"https://old.example,me,Xk9#mQ2$vL7!pR4z,1577836800000\n" +
+		"https://new.example,me,Vb3@nT8%wY1^cF6d,2024-05-20\n"
+	entries, err := ReadBrowserCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if entries[0].Changed.Year() != 2020 {
+		t.Fatalf("Expected the Firefox timestamp to be read, but got %v", entries[0].Changed)
+	}
+
+	findings := Run(entries, Options{Base: passgen.PasswordOptions{Length: 20, IncludeLower: true}, MaxAge: 365 * Day, Now: auditNow})
+	if !findings[0].Stale || findings[0].Replacement == "" {
+		t.Errorf("Expected the old password to be stale with a replacement, but got %+v", findings[0])
+	}
+	if findings[1].Stale {
+		t.Errorf("Expected the recent password not to be stale")
+	}
+}
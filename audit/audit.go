@@ -0,0 +1,212 @@
+/**
+ * Password Generator - Credential Audit
+ *
+ * This file scores existing credentials, flags weak, reused and breached
+ * passwords and those breaking a password policy, and proposes a strong
+ * replacement for every flagged entry. Site
+ * rules are honoured when the entry's URL is known, so replacements are
+ * accepted by the site. Everything runs locally; no password leaves the
+ * machine.
+ */
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/policy"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/siterules"
+)
+
+// Entry is a single stored credential. Changed is when the password was last
+// changed, or zero if the export does not say.
+type Entry struct {
+	Name     string
+	URL      string
+	Username string
+	Password string
+	Changed  time.Time
+}
+
+// Checker reports whether a password is known to be breached.
+type Checker interface {
+	Breached(password string) bool
+}
+
+// Finding is the audit result for one entry.
+// Fields:
+//   - Entry (Entry): The audited credential.
+//   - Length (int): The number of characters in the password.
+//   - Classes ([]string): Which of lower, upper, digit and symbol it uses.
+//   - Entropy (float64): Estimated entropy of the password in bits, from
+//     passgen.AnalyzeStrength, so words, keyboard walks and dates count
+//     for little.
+//   - Strength (passgen.Strength): Rating derived from Entropy.
+//   - Weak (bool): The password is rated below fair or is a common password.
+//   - ReusedCount (int): How many entries share this password, 1 if unique.
+//   - Breached (bool): The password appears in a breach list.
+//   - Stale (bool): The password is older than Options.MaxAge.
+//   - Violations ([]string): The rules of Options.Policy it breaks.
+//   - Replacement (string): A proposed new password, set for flagged entries.
+//   - ReplacementError (error): Why no replacement could be generated for
+//     a flagged entry, e.g. site rules no password can meet.
+type Finding struct {
+	Entry       Entry
+	Length      int
+	Classes     []string
+	Entropy     float64
+	Strength    passgen.Strength
+	Weak        bool
+	ReusedCount int
+	Breached    bool
+	Stale       bool
+	Violations  []string
+	Replacement string
+
+	ReplacementError error
+}
+
+// Flagged reports whether the entry needs attention.
+func (f Finding) Flagged() bool {
+	return f.Weak || f.ReusedCount > 1 || f.Breached || f.Stale || len(f.Violations) > 0
+}
+
+// Proposal returns the replacement, or why none could be generated, for
+// showing next to the entry.
+func (f Finding) Proposal() string {
+	if f.ReplacementError != nil {
+		return "none: " + f.ReplacementError.Error()
+	}
+	return f.Replacement
+}
+
+// Issues lists the reasons the entry was flagged.
+func (f Finding) Issues() []string {
+	var issues []string
+	if f.Weak {
+		issues = append(issues, "weak")
+	}
+	if f.ReusedCount > 1 {
+		issues = append(issues, "reused")
+	}
+	if f.Breached {
+		issues = append(issues, "breached")
+	}
+	if f.Stale {
+		issues = append(issues, "stale")
+	}
+	if len(f.Violations) > 0 {
+		issues = append(issues, "policy")
+	}
+	return issues
+}
+
+// Options controls how replacements are generated and breaches detected.
+// Fields:
+//   - Base (passgen.PasswordOptions): Options used for replacement passwords.
+//   - Sites (siterules.Database): Site rules applied to replacements; may be nil.
+//   - Breached (Checker): Additional breach list; common passwords are always flagged.
+//   - MaxAge (time.Duration): Passwords changed longer ago are flagged as
+//     stale; 0 disables the check.
+//   - Now (time.Time): The time ages are measured from; time.Now() if zero.
+//   - Generator (*passgen.Generator): Generates the replacements; the
+//     package-level passgen.GeneratePasswords when nil.
+//   - Policy (*policy.Policy): Passwords breaking it are flagged, and
+//     replacements follow it; may be nil.
+type Options struct {
+	Base      passgen.PasswordOptions
+	Sites     siterules.Database
+	Breached  Checker
+	MaxAge    time.Duration
+	Now       time.Time
+	Generator *passgen.Generator
+	Policy    *policy.Policy
+}
+
+// Run audits the given entries.
+// Purpose:
+//
+//	Rates every password, counts reuse across all entries, checks the breach
+//	lists, the password age and the policy, and generates a replacement for
+//	each flagged entry. An entry whose replacement fails keeps the error in
+//	ReplacementError, and the other entries are still audited.
+//
+// Parameters:
+//   - entries ([]Entry): The credentials to audit.
+//   - opts (Options): Replacement and breach-check settings.
+//
+// Returns:
+//
+//	[]Finding: One finding per entry, in input order.
+//
+// Example:
+//
+//	findings := audit.Run(entries, audit.Options{Base: *config.GetDefaultOptions()})
+func Run(entries []Entry, opts Options) []Finding {
+	uses := make(map[string]int)
+	for _, entry := range entries {
+		uses[entry.Password]++
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	findings := make([]Finding, 0, len(entries))
+	for _, entry := range entries {
+		finding := Finding{
+			Entry:       entry,
+			Length:      len([]rune(entry.Password)),
+			Classes:     policy.Classes(entry.Password),
+			Entropy:     passgen.AnalyzeStrength(entry.Password).Bits,
+			ReusedCount: uses[entry.Password],
+		}
+		finding.Strength = passgen.RateEntropy(finding.Entropy)
+		common := IsCommon(entry.Password)
+		finding.Weak = finding.Strength < passgen.Fair || common
+		finding.Breached = common || (opts.Breached != nil && opts.Breached.Breached(entry.Password))
+		finding.Stale = opts.MaxAge > 0 && !entry.Changed.IsZero() && now.Sub(entry.Changed) > opts.MaxAge
+		if opts.Policy != nil {
+			finding.Violations = opts.Policy.Check(entry.Password)
+		}
+
+		if finding.Flagged() {
+			finding.Replacement, finding.ReplacementError = replacementFor(entry, opts)
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// replacementFor generates a new password for entry, following its site
+// rules and the policy. Only a Generator with the policy's constraint also
+// avoids its banned substrings and repeats.
+func replacementFor(entry Entry, opts Options) (string, error) {
+	genOpts := opts.Base
+	genOpts.Quantity = 1
+	g := opts.Generator
+	if g == nil {
+		g = passgen.NewGenerator()
+	}
+	if _, rules, ok := opts.Sites.Lookup(entry.URL); ok {
+		genOpts = rules.Apply(genOpts)
+		g = g.With(passgen.WithConstraints(rules.Constraint()))
+	}
+	if opts.Policy != nil {
+		genOpts = opts.Policy.Apply(genOpts)
+	}
+	passwords, err := g.GeneratePasswords(context.Background(), genOpts)
+	if err != nil {
+		return "", err
+	}
+	return passwords[0], nil
+}
+
+// IsCommon reports whether password is on the bundled list of the most
+// frequently used passwords, ignoring case. These appear in every breach.
+func IsCommon(password string) bool {
+	return passgen.IsCommonPassword(password)
+}
@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/siterules"
+)
+
+// TestRun flags weak, reused and common passwords and proposes replacements.
+func TestRun(t *testing.T) {
+	entries := []Entry{
+		{URL: "https://a.example", Password: "Xq7#vB9!mK2$pL4&"},
+		{URL: "https://b.example", Password: "password1"},
+		{URL: "https://c.example", Password: "Zr8@wN3^hT6*jF1%"},
+		{URL: "https://d.example", Password: "Zr8@wN3^hT6*jF1%"},
+	}
+	base := passgen.PasswordOptions{Length: 20, IncludeLower: true, IncludeUpper: true, IncludeNumbers: true}
+	findings := Run(entries, Options{Base: base})
+
+	if findings[0].Flagged() || findings[0].Replacement != "" {
+		t.Errorf("Expected the first entry to pass, but got %+v", findings[0])
+	}
+	if !findings[1].Weak || !findings[1].Breached {
+		t.Errorf("Expected a common password to be weak and breached, but got %+v", findings[1])
+	}
+	if findings[2].ReusedCount != 2 || findings[3].ReusedCount != 2 {
+		t.Errorf("Expected reuse count 2, but got %d and %d", findings[2].ReusedCount, findings[3].ReusedCount)
+	}
+	for _, finding := range findings[1:] {
+		if len(finding.Replacement) != 20 {
+			t.Errorf("Expected a 20 character replacement, but got %q", finding.Replacement)
+		}
+	}
+}
+
+// TestRun_SiteRules generates replacements within a site's limits.
+func TestRun_SiteRules(t *testing.T) {
+	rules, _ := siterules.Parse("maxlength: 10; allowed: digit;")
+	opts := Options{
+		Base:  passgen.PasswordOptions{Length: 20, IncludeLower: true},
+		Sites: siterules.Database{"example.com": rules},
+	}
+	findings := Run([]Entry{{URL: "https://login.example.com", Password: "1234"}}, opts)
+	replacement := findings[0].Replacement
+	if len(replacement) != 10 || strings.Trim(replacement, passgen.Digits) != "" {
+		t.Errorf("Expected a 10 digit replacement, but got %q", replacement)
+	}
+}
+
+// TestRun_ReplacementFails verifies that an entry whose site rules no
+// password can meet records the error, and the other entries still get a
+// replacement.
+func TestRun_ReplacementFails(t *testing.T) {
+	rules, err := siterules.Parse("allowed: [a]; max-consecutive: 1;")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	opts := Options{
+		Base:  passgen.PasswordOptions{Length: 20, IncludeLower: true},
+		Sites: siterules.Database{"example.com": rules},
+	}
+	findings := Run([]Entry{
+		{URL: "https://login.example.com", Password: "1234"},
+		{URL: "https://other.example", Password: "1234"},
+	}, opts)
+	if findings[0].ReplacementError == nil || findings[0].Replacement != "" {
+		t.Errorf("Expected the replacement to fail, but got %q", findings[0].Replacement)
+	}
+	if !strings.HasPrefix(findings[0].Proposal(), "none: ") {
+		t.Errorf("Expected the proposal to give the reason, but got %q", findings[0].Proposal())
+	}
+	if findings[1].ReplacementError != nil || len(findings[1].Replacement) != 20 {
+		t.Errorf("Expected a 20 character replacement, but got %q, %v", findings[1].Replacement, findings[1].ReplacementError)
+	}
+}
@@ -0,0 +1,221 @@
+package audit
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// browserColumns maps the column names used by browser password exports to
+// Entry fields. Chrome and Edge write name,url,username,password,note;
+// Firefox writes url,username,password,httpRealm,formActionOrigin,...,
+// timePasswordChanged; KeePass writes "Last Modified".
+var browserColumns = map[string]string{
+	"name":                "name",
+	"title":               "name",
+	"url":                 "url",
+	"origin":              "url",
+	"username":            "username",
+	"login":               "username",
+	"password":            "password",
+	"timepasswordchanged": "changed",
+	"last modified":       "changed",
+	"modified":            "changed",
+}
+
+// changedLayouts lists the date formats accepted in the changed column,
+// besides Firefox's milliseconds since the epoch.
+var changedLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+// parseChanged reads a password change time, or returns the zero time.
+func parseChanged(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil && ms > 0 {
+		return time.UnixMilli(ms)
+	}
+	for _, layout := range changedLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// passwordColumns lists the header names recognised as the password column
+// when none is given explicitly.
+var passwordColumns = []string{"password", "pass", "passwd", "pwd"}
+
+// Table is a CSV file of credentials, kept whole so it can be annotated.
+// Fields:
+//   - Header ([]string): The column names.
+//   - Records ([][]string): The data rows.
+//   - Password (int): Index of the password column.
+type Table struct {
+	Header   []string
+	Records  [][]string
+	Password int
+}
+
+// ReadTable reads a CSV file with a header row and a password column.
+// Purpose:
+//
+//	Accepts any spreadsheet export. The password column is found by name,
+//	ignoring case; an empty column name tries the usual ones such as
+//	"password" and "pwd".
+//
+// Parameters:
+//   - r (io.Reader): The CSV file.
+//   - column (string): Name of the password column, or "" to detect it.
+//
+// Returns:
+//
+//	*Table: The parsed file.
+//	error: An error if the CSV is malformed or has no password column.
+//
+// Example:
+//
+//	table, err := audit.ReadTable(file, "Secret")
+func ReadTable(r io.Reader, column string) (*Table, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+
+	candidates := passwordColumns
+	if column != "" {
+		candidates = []string{column}
+	}
+	table := &Table{Header: header, Password: -1}
+	for _, candidate := range candidates {
+		if table.Password = table.column(candidate); table.Password >= 0 {
+			break
+		}
+	}
+	if table.Password < 0 {
+		if column != "" {
+			return nil, fmt.Errorf("no %q column found", column)
+		}
+		return nil, errors.New("no password column found")
+	}
+
+	if table.Records, err = reader.ReadAll(); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// Entries converts the rows to credentials, filling name, URL and user name
+// from the browser export columns when present.
+func (t *Table) Entries() []Entry {
+	index := make(map[string]int)
+	for i, name := range t.Header {
+		if field, ok := browserColumns[strings.ToLower(name)]; ok {
+			if _, seen := index[field]; !seen {
+				index[field] = i
+			}
+		}
+	}
+	index["password"] = t.Password
+
+	entries := make([]Entry, 0, len(t.Records))
+	for _, record := range t.Records {
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+		entries = append(entries, Entry{
+			Name:     field("name"),
+			URL:      field("url"),
+			Username: field("username"),
+			Password: field("password"),
+			Changed:  parseChanged(field("changed")),
+		})
+	}
+	return entries
+}
+
+// column returns the index of the named column, ignoring case, or -1.
+func (t *Table) column(name string) int {
+	for i, header := range t.Header {
+		if strings.EqualFold(header, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ReadBrowserCSV reads a password export from Chrome, Edge or Firefox.
+// Purpose:
+//
+//	Locates the columns by their header names, so exports from different
+//	browsers and versions are accepted without configuration.
+//
+// Parameters:
+//   - r (io.Reader): The exported CSV file.
+//
+// Returns:
+//
+//	[]Entry: The credentials in file order.
+//	error: An error if the CSV is malformed or has no password column.
+//
+// Example:
+//
+//	entries, err := audit.ReadBrowserCSV(file)
+func ReadBrowserCSV(r io.Reader) ([]Entry, error) {
+	table, err := ReadTable(r, "password")
+	if err != nil {
+		return nil, err
+	}
+	return table.Entries(), nil
+}
+
+// WriteReport writes the table back as CSV with the audit results appended
+// to every row. Existing passwords are masked, but the replacement column
+// holds the proposed passwords in plain text, so the report is as secret as
+// the export it came from.
+// Parameters:
+//   - w (io.Writer): Destination of the report.
+//   - t (*Table): The audited table.
+//   - findings ([]Finding): The result of Run for t.Entries(), in row order.
+//
+// Returns:
+//
+//	error: An error if writing fails.
+func WriteReport(w io.Writer, t *Table, findings []Finding) error {
+	writer := csv.NewWriter(w)
+	header := append(append([]string(nil), t.Header...), "strength", "entropy_bits", "issues", "replacement")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for i, record := range t.Records {
+		row := append([]string(nil), record...)
+		for len(row) < len(t.Header) {
+			row = append(row, "")
+		}
+		row[t.Password] = strings.Repeat("*", len([]rune(row[t.Password])))
+
+		finding := findings[i]
+		row = append(row,
+			finding.Strength.String(),
+			strconv.FormatFloat(finding.Entropy, 'f', 1, 64),
+			strings.Join(finding.Issues(), " "),
+			finding.Proposal(),
+		)
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// TestReadBrowserCSV_Chrome reads a Chrome-style export.
+func TestReadBrowserCSV_Chrome(t *testing.T) {
+	csv := "name,url,username,password,note\nexample.com,https://example.com/,alice,hunter2,\n"
+	entries, err := ReadBrowserCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	want := Entry{Name: "example.com", URL: "https://example.com/", Username: "alice", Password: "hunter2"}
+	if len(entries) != 1 || entries[0] != want {
+		t.Errorf("Expected %+v, but got %+v", want, entries)
+	}
+}
+
+// TestReadBrowserCSV_Firefox reads a Firefox-style export with quoted fields.
+func TestReadBrowserCSV_Firefox(t *testing.T) {
+	csv := "\"url\",\"username\",\"password\",\"httpRealm\"\n\"https://example.org\",\"bob\",\"a,b\"\"c\",\"\"\n"
+	entries, err := ReadBrowserCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(entries) != 1 || entries[0].Password != "a,b\"c" || entries[0].Username != "bob" {
+		t.Errorf("Unexpected entries %+v", entries)
+	}
+}
+
+// TestReadBrowserCSV_NoPasswordColumn rejects unrelated CSV files.
+func TestReadBrowserCSV_NoPasswordColumn(t *testing.T) {
+	if _, err := ReadBrowserCSV(strings.NewReader("a,b\n1,2\n")); err == nil {
+		t.Errorf("Expected an error, but got nil")
+	}
+}
+
+// TestReadTable_DetectsColumn finds a differently named password column.
+func TestReadTable_DetectsColumn(t *testing.T) {
+	table, err := ReadTable(strings.NewReader("Host,PWD\nsrv1,secret\n"), "")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if table.Password != 1 || table.Entries()[0].Password != "secret" {
+		t.Errorf("Expected the PWD column, but got index %d", table.Password)
+	}
+	if _, err := ReadTable(strings.NewReader("Host,PWD\n"), "Secret"); err == nil {
+		t.Errorf("Expected an error for a missing named column, but got nil")
+	}
+}
+
+// TestWriteReport appends results and masks the existing passwords.
+func TestWriteReport(t *testing.T) {
+	table, _ := ReadTable(strings.NewReader("host,password\nsrv1,123456\n"), "")
+	findings := Run(table.Entries(), Options{Base: passgen.PasswordOptions{Length: 3, IncludeLower: true}})
+	findings[0].Replacement = "new"
+
+	var report bytes.Buffer
+	if err := WriteReport(&report, table, findings); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	want := "host,password,strength,entropy_bits,issues,replacement\nsrv1,******,very weak,1.0,weak breached,new\n"
+	if report.String() != want {
+		t.Errorf("Expected %q, but got %q", want, report.String())
+	}
+}
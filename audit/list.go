@@ -0,0 +1,79 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadList reads a plain list of passwords, one per line, such as one
+// pasted into the GUI. Blank lines are skipped; each entry is named after
+// its line number, so the report can point back to it.
+// Parameters:
+//   - r (io.Reader): The list.
+//
+// Returns:
+//
+//	[]Entry: One entry per password, in list order.
+//	error: An error if reading fails or the list holds no password.
+//
+// Example:
+//
+//	entries, err := audit.ReadList(strings.NewReader(pasted))
+func ReadList(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		password := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == 1 {
+			password = strings.TrimPrefix(password, "\ufeff")
+		}
+		if strings.TrimSpace(password) == "" {
+			continue
+		}
+		entries = append(entries, Entry{Name: "line " + strconv.Itoa(line), Password: password})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("no passwords found")
+	}
+	return entries, nil
+}
+
+// WriteListReport writes one CSV row per finding with its length, entropy,
+// rating, character classes, issues and policy violations. The passwords
+// and their replacements are left out, so the report can be shared.
+// Parameters:
+//   - w (io.Writer): Destination of the report.
+//   - findings ([]Finding): The result of Run.
+//
+// Returns:
+//
+//	error: An error if writing fails.
+func WriteListReport(w io.Writer, findings []Finding) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"entry", "length", "entropy_bits", "strength", "classes", "issues", "violations"}); err != nil {
+		return err
+	}
+	for _, finding := range findings {
+		err := writer.Write([]string{
+			finding.Entry.Name,
+			strconv.Itoa(finding.Length),
+			strconv.FormatFloat(finding.Entropy, 'f', 1, 64),
+			finding.Strength.String(),
+			strings.Join(finding.Classes, " "),
+			strings.Join(finding.Issues(), " "),
+			strings.Join(finding.Violations, "; "),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/policy"
+)
+
+// TestReadList reads a pasted list, skipping blank lines.
+func TestReadList(t *testing.T) {
+	entries, err := ReadList(strings.NewReader("hunter2\r\n\n  \nCorrect Horse 1\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(entries) != 2 || entries[0].Password != "hunter2" || entries[1].Password != "Correct Horse 1" {
+		t.Fatalf("Unexpected entries %+v", entries)
+	}
+	if entries[1].Name != "line 4" {
+		t.Errorf("Expected the second entry to be named after line 4, but got %q", entries[1].Name)
+	}
+	if _, err := ReadList(strings.NewReader("\n\n")); err == nil {
+		t.Errorf("Expected an error for an empty list, but got nil")
+	}
+}
+
+// TestRun_Policy flags passwords that break a policy and reports their
+// classes, and writes the list report without the passwords.
+func TestRun_Policy(t *testing.T) {
+	p, err := policy.Lookup("pci-dss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := ReadList(strings.NewReader("Xq7#vB9!mK2$pL4&\nXq#vB!mK$pL&wZ\n"))
+	base := passgen.PasswordOptions{Length: 8, IncludeLower: true, IncludeUpper: true}
+	findings := Run(entries, Options{Base: base, Policy: &p})
+
+	if findings[0].Flagged() || findings[0].Length != 16 || len(findings[0].Classes) != 4 {
+		t.Errorf("Expected the first password to pass with 16 characters of 4 classes, but got %+v", findings[0])
+	}
+	if len(findings[1].Violations) != 1 || !strings.Contains(strings.Join(findings[1].Issues(), " "), "policy") {
+		t.Errorf("Expected a missing digit to break the policy, but got %+v", findings[1])
+	}
+	if v := p.Check(findings[1].Replacement); len(v) != 0 {
+		t.Errorf("Expected the replacement to follow the policy, but it breaks %v", v)
+	}
+
+	var report bytes.Buffer
+	if err := WriteListReport(&report, findings); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if strings.Contains(report.String(), "Xq7#vB9!mK2$pL4&") {
+		t.Errorf("Expected the report to leave out the passwords, but got %q", report.String())
+	}
+	for _, finding := range findings {
+		if finding.Replacement != "" && strings.Contains(report.String(), finding.Replacement) {
+			t.Errorf("Expected the report to leave out the replacements, but got %q", report.String())
+		}
+	}
+	if !strings.Contains(report.String(), "line 2,14,") || !strings.Contains(report.String(), "missing a digit") {
+		t.Errorf("Unexpected report %q", report.String())
+	}
+}
@@ -0,0 +1,106 @@
+/**
+ * Password Generator - Throughput Benchmark
+ *
+ * This file measures how fast passwords are generated in different modes on
+ * the current machine. Results are reported as passwords per second and as
+ * bytes of entropy per second, so regressions between releases are visible
+ * to users generating large batches.
+ */
+
+package bench
+
+import (
+	"context"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// DefaultDuration is the measuring time per mode used by the bench command.
+const DefaultDuration = time.Second
+
+// Mode is a named set of options to benchmark.
+type Mode struct {
+	Name    string
+	Options passgen.PasswordOptions
+}
+
+// Result is the measured throughput of one mode.
+// Fields:
+//   - Mode (Mode): The benchmarked mode.
+//   - Passwords (int): How many passwords were generated.
+//   - Elapsed (time.Duration): The time they took.
+//   - PasswordsPerSecond (float64): Generation rate.
+//   - EntropyBytesPerSecond (float64): Rate of generated entropy, from EstimateEntropy.
+type Result struct {
+	Mode                  Mode
+	Passwords             int
+	Elapsed               time.Duration
+	PasswordsPerSecond    float64
+	EntropyBytesPerSecond float64
+}
+
+// DefaultModes returns the modes benchmarked by the bench command.
+func DefaultModes() []Mode {
+	all := passgen.PasswordOptions{Length: 16, IncludeSymbols: true, IncludeNumbers: true, IncludeUpper: true, IncludeLower: true}
+	with := func(change func(*passgen.PasswordOptions)) passgen.PasswordOptions {
+		opts := all
+		change(&opts)
+		return opts
+	}
+	return []Mode{
+		{"all classes, 16 chars", all},
+		{"all classes, 64 chars", with(func(o *passgen.PasswordOptions) { o.Length = 64 })},
+		{"lowercase only, 16 chars", passgen.PasswordOptions{Length: 16, IncludeLower: true}},
+		{"begin with letter", with(func(o *passgen.PasswordOptions) { o.BeginWithLetter = true })},
+		{"no similar/duplicates/sequential", with(func(o *passgen.PasswordOptions) { o.NoSimilar, o.NoDuplicates, o.NoSequential = true, true, true })},
+		{"alternate hands (qwerty)", with(func(o *passgen.PasswordOptions) { o.AlternateHands = true })},
+	}
+}
+
+// Run benchmarks each mode for roughly duration.
+// Purpose:
+//
+//	Generates passwords in batches until the duration has passed, then
+//	derives the rates from the total count and elapsed time.
+//
+// Parameters:
+//   - modes ([]Mode): The modes to measure.
+//   - duration (time.Duration): Measuring time per mode.
+//
+// Returns:
+//
+//	[]Result: One result per mode, in order.
+//	error: An error if a mode's options cannot generate passwords.
+//
+// Example:
+//
+//	results, err := bench.Run(bench.DefaultModes(), time.Second)
+func Run(modes []Mode, duration time.Duration) ([]Result, error) {
+	const batch = 100
+	results := make([]Result, 0, len(modes))
+	for _, mode := range modes {
+		opts := mode.Options
+		opts.Quantity = batch
+
+		count := 0
+		start := time.Now()
+		for time.Since(start) < duration || count == 0 {
+			if _, err := passgen.GeneratePasswords(context.Background(), opts); err != nil {
+				return nil, err
+			}
+			count += batch
+		}
+		elapsed := time.Since(start)
+
+		rate := float64(count) / elapsed.Seconds()
+		results = append(results, Result{
+			Mode:                  mode,
+			Passwords:             count,
+			Elapsed:               elapsed,
+			PasswordsPerSecond:    rate,
+			EntropyBytesPerSecond: rate * passgen.EstimateEntropy(mode.Options) / 8,
+		})
+	}
+	return results, nil
+}
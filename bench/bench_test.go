@@ -0,0 +1,31 @@
+package bench
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// TestRun measures every default mode briefly.
+func TestRun(t *testing.T) {
+	results, err := Run(DefaultModes(), time.Millisecond)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(results) != len(DefaultModes()) {
+		t.Fatalf("Expected %d results, but got %d", len(DefaultModes()), len(results))
+	}
+	for _, result := range results {
+		if result.Passwords == 0 || result.PasswordsPerSecond <= 0 || result.EntropyBytesPerSecond <= 0 {
+			t.Errorf("Expected positive rates for %q, but got %+v", result.Mode.Name, result)
+		}
+	}
+}
+
+// TestRun_InvalidMode reports options that cannot generate passwords.
+func TestRun_InvalidMode(t *testing.T) {
+	if _, err := Run([]Mode{{"empty", passgen.PasswordOptions{Length: 8}}}, time.Millisecond); err == nil {
+		t.Errorf("Expected an error, but got nil")
+	}
+}
@@ -0,0 +1,178 @@
+package breach
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// bloomMagic starts every filter file. It is followed by the number of
+// hash functions (uint32), four reserved bytes, the number of bits (uint64)
+// and the number of hashes added (uint64), all big-endian, and then the
+// bits themselves.
+const bloomMagic = "PGBLOOM1"
+
+// bloomHeaderSize is the length of the filter header in bytes.
+const bloomHeaderSize = 32
+
+// DefaultFalsePositiveRate is the share of passwords a filter built by
+// breach-import wrongly reports as breached. At 0.1% a generated password
+// is redrawn about once in a thousand; the filter for the full dataset of
+// about 900 million hashes takes about 1.6 GB.
+const DefaultFalsePositiveRate = 0.001
+
+// filter is an opened Bloom filter file.
+type filter struct {
+	r      io.ReaderAt
+	hashes uint32
+	bits   uint64
+}
+
+// openFilter reads the filter header of a file. ok is false if the file
+// is not a filter at all.
+func openFilter(r io.ReaderAt, size int64) (f *filter, ok bool, err error) {
+	var header [bloomHeaderSize]byte
+	if size < int64(len(bloomMagic)) {
+		return nil, false, nil
+	}
+	n, err := r.ReadAt(header[:], 0)
+	if n < len(bloomMagic) || string(header[:len(bloomMagic)]) != bloomMagic {
+		return nil, false, nil
+	}
+	if n < bloomHeaderSize {
+		return nil, true, errors.New("truncated breach filter")
+	}
+	f = &filter{
+		r:      r,
+		hashes: binary.BigEndian.Uint32(header[8:]),
+		bits:   binary.BigEndian.Uint64(header[16:]),
+	}
+	if f.hashes == 0 || f.bits == 0 {
+		return nil, true, errors.New("invalid breach filter header")
+	}
+	if want := bloomHeaderSize + int64((f.bits+7)/8); size != want {
+		return nil, true, fmt.Errorf("breach filter is %d bytes, expected %d", size, want)
+	}
+	return f, true, nil
+}
+
+func (f *filter) contains(sum [sha1.Size]byte) (bool, error) {
+	var b [1]byte
+	for _, bit := range bloomBits(sum, f.hashes, f.bits) {
+		if _, err := f.r.ReadAt(b[:], bloomHeaderSize+int64(bit/8)); err != nil {
+			return false, err
+		}
+		if b[0]&(1<<(bit%8)) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// bloomBits returns the bits a digest sets, derived from two halves of
+// the digest by double hashing. SHA-1 output is uniform, so no further
+// hashing is needed.
+func bloomBits(sum [sha1.Size]byte, hashes uint32, bits uint64) []uint64 {
+	h1 := binary.BigEndian.Uint64(sum[0:8])
+	h2 := binary.BigEndian.Uint64(sum[8:16]) | 1
+	positions := make([]uint64, hashes)
+	for i := range positions {
+		positions[i] = (h1 + uint64(i)*h2) % bits
+	}
+	return positions
+}
+
+// BuildFilter builds a Bloom filter from a list of SHA-1 hashes.
+// Purpose:
+//
+//	Converts the Have I Been Pwned SHA-1 dataset, or any file with one
+//	hex SHA-1 hash per line and an optional ":count", into the compact
+//	format read by Open. The lines need not be sorted. The filter is
+//	built in memory, so this needs about as much memory as the result.
+//
+// Parameters:
+//   - w (io.Writer): Where to write the filter.
+//   - hashes (io.Reader): The hash list.
+//   - count (uint64): The number of hashes in the list, which sizes the filter.
+//   - falsePositiveRate (float64): The wanted share of false matches, e.g.
+//     DefaultFalsePositiveRate.
+//
+// Returns:
+//
+//	error: An error if a line is not a SHA-1 hash, the list holds more
+//	than count hashes, or writing fails.
+//
+// Example:
+//
+//	err := breach.BuildFilter(out, in, lines, breach.DefaultFalsePositiveRate)
+func BuildFilter(w io.Writer, hashes io.Reader, count uint64, falsePositiveRate float64) error {
+	if count == 0 {
+		return errors.New("the hash list is empty")
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		return fmt.Errorf("false positive rate %g is not between 0 and 1", falsePositiveRate)
+	}
+	bits := uint64(math.Ceil(-float64(count) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashCount := uint32(math.Round(float64(bits) / float64(count) * math.Ln2))
+	if hashCount == 0 {
+		hashCount = 1
+	}
+	data := make([]byte, (bits+7)/8)
+
+	scanner := bufio.NewScanner(hashes)
+	var added uint64
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		sum, err := parseHash(text)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if added++; added > count {
+			return fmt.Errorf("the hash list holds more than the %d hashes counted", count)
+		}
+		for _, bit := range bloomBits(sum, hashCount, bits) {
+			data[bit/8] |= 1 << (bit % 8)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	var header [bloomHeaderSize]byte
+	copy(header[:], bloomMagic)
+	binary.BigEndian.PutUint32(header[8:], hashCount)
+	binary.BigEndian.PutUint64(header[16:], bits)
+	binary.BigEndian.PutUint64(header[24:], added)
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := io.Copy(w, bytes.NewReader(data))
+	return err
+}
+
+// parseHash reads the SHA-1 hash at the start of a list line, before any
+// ":count".
+func parseHash(line string) ([sha1.Size]byte, error) {
+	var sum [sha1.Size]byte
+	digits, _, _ := strings.Cut(line, ":")
+	if len(digits) == 32 {
+		return sum, errors.New("this looks like the NTLM dataset; download the SHA-1 hashes instead")
+	}
+	if len(digits) != hex.EncodedLen(sha1.Size) {
+		return sum, fmt.Errorf("%q is not a SHA-1 hash", digits)
+	}
+	if _, err := hex.Decode(sum[:], []byte(digits)); err != nil {
+		return sum, fmt.Errorf("%q is not a SHA-1 hash", digits)
+	}
+	return sum, nil
+}
@@ -0,0 +1,155 @@
+/**
+ * Password Generator - Offline Breach Lists
+ *
+ * This file checks passwords against a local list of breached passwords,
+ * for airgapped machines that cannot query an online service. Two formats
+ * are read: the SHA-1 file of the Have I Been Pwned downloadable dataset,
+ * ordered by hash, which is searched in place; and a compact Bloom filter
+ * built from it with BuildFilter. Neither is loaded into memory, so even
+ * the full dataset needs only a file handle.
+ */
+
+package breach
+
+import (
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// Formats of a List.
+const (
+	FormatBloom  = "bloom"
+	FormatSorted = "sorted"
+)
+
+// lookup finds the SHA-1 digest of a password in a list file.
+type lookup interface {
+	contains(sum [sha1.Size]byte) (bool, error)
+}
+
+// List is an opened breach list.
+// Fields:
+//   - Path (string): The file the list was opened from.
+//   - Format (string): FormatBloom or FormatSorted.
+type List struct {
+	Path   string
+	Format string
+	lookup lookup
+	file   *os.File
+}
+
+// Open opens the breach list at path, detecting its format.
+// Purpose:
+//
+//	Accepts a Bloom filter written by BuildFilter or a text file of
+//	SHA-1 hashes, one per line and sorted, optionally followed by ":count"
+//	as in pwned-passwords-sha1-ordered-by-hash.txt.
+//
+// Parameters:
+//   - path (string): The list file.
+//
+// Returns:
+//
+//	*List: The opened list; Close it when done.
+//	error: An error if the file cannot be read or is in neither format.
+//
+// Example:
+//
+//	list, err := breach.Open("/srv/pwned-passwords.bloom")
+func Open(path string) (*List, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	list := &List{Path: path, file: file}
+	if filter, ok, err := openFilter(file, info.Size()); ok || err != nil {
+		list.Format, list.lookup = FormatBloom, filter
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return list, nil
+	}
+	sorted, err := openSorted(file, info.Size())
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	list.Format, list.lookup = FormatSorted, sorted
+	return list, nil
+}
+
+// Contains reports whether password is on the list. A Bloom filter
+// answers true for a small share of passwords that are not, set when it is
+// built; it never misses a listed one.
+func (l *List) Contains(password string) (bool, error) {
+	return l.lookup.contains(sha1.Sum([]byte(password)))
+}
+
+// Breached reports whether password is on the list, as audit.Checker
+// expects. The list fails closed: a password that cannot be looked up
+// counts as breached.
+func (l *List) Breached(password string) bool {
+	found, err := l.Contains(password)
+	return found || err != nil
+}
+
+// Close closes the list file.
+func (l *List) Close() error {
+	return l.file.Close()
+}
+
+// Constraint returns a constraint that rejects passwords on the list, so
+// a Generator built with passgen.WithConstraints draws another password
+// whenever a candidate is breached.
+func (l *List) Constraint() passgen.Constraint {
+	return notBreached{l}
+}
+
+// notBreached implements List.Constraint.
+type notBreached struct {
+	list *List
+}
+
+func (notBreached) Apply(pool string, _ []byte, _ int) string { return pool }
+
+func (c notBreached) Check(candidate []byte) bool {
+	return !c.list.Breached(string(candidate))
+}
+
+// readLine returns the text from offset up to the next newline, without
+// the newline and any carriage return, and the offset after it.
+func readLine(r io.ReaderAt, offset, size int64) (string, int64, error) {
+	buf := make([]byte, 128)
+	n, err := r.ReadAt(buf, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", 0, err
+	}
+	buf = buf[:n]
+	end := len(buf)
+	next := offset + int64(n)
+	for i, b := range buf {
+		if b == '\n' {
+			end, next = i, offset+int64(i)+1
+			break
+		}
+	}
+	if next > size {
+		next = size
+	}
+	line := buf[:end]
+	if len(line) > 0 && line[len(line)-1] == '\r' {
+		line = line[:len(line)-1]
+	}
+	return string(line), next, nil
+}
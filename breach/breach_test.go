@@ -0,0 +1,137 @@
+package breach
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// breached are the passwords written to the test lists.
+var breached = []string{"password", "123456", "qwerty", "letmein", "dragon", "monkey", "iloveyou", "trustno1"}
+
+// writeSorted writes a sorted hash list in the HIBP format and returns its path.
+func writeSorted(t *testing.T, passwords []string, newline string) string {
+	t.Helper()
+	lines := make([]string, len(passwords))
+	for i, p := range passwords {
+		lines[i] = fmt.Sprintf("%X:%d", sha1.Sum([]byte(p)), i+1)
+	}
+	sort.Strings(lines)
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, newline)+newline), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// checkList verifies that every breached password is found and a few
+// generated ones are not.
+func checkList(t *testing.T, list *List) {
+	t.Helper()
+	for _, p := range breached {
+		if found, err := list.Contains(p); !found || err != nil {
+			t.Errorf("Expected %q to be breached, but got %v, %v", p, found, err)
+		}
+	}
+	for _, p := range []string{"x7Kp93fQLmR2", "Tq8#vLw2!pZ4", "correct horse battery staple"} {
+		if found, err := list.Contains(p); found || err != nil {
+			t.Errorf("Expected %q not to be breached, but got %v, %v", p, found, err)
+		}
+	}
+}
+
+// TestOpen_Sorted verifies lookups in a sorted hash file, with Unix and
+// Windows line endings.
+func TestOpen_Sorted(t *testing.T) {
+	for _, newline := range []string{"\n", "\r\n"} {
+		list, err := Open(writeSorted(t, breached, newline))
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if list.Format != FormatSorted {
+			t.Errorf("Expected format %q, but got %q", FormatSorted, list.Format)
+		}
+		checkList(t, list)
+		list.Close()
+	}
+}
+
+// TestBuildFilter verifies that a filter built from a hash file finds every
+// hash in it.
+func TestBuildFilter(t *testing.T) {
+	in, err := os.Open(writeSorted(t, breached, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	var out bytes.Buffer
+	if err := BuildFilter(&out, in, uint64(len(breached)), DefaultFalsePositiveRate); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "pwned.bloom")
+	if err := os.WriteFile(path, out.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := Open(path)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	defer list.Close()
+	if list.Format != FormatBloom {
+		t.Errorf("Expected format %q, but got %q", FormatBloom, list.Format)
+	}
+	checkList(t, list)
+	if list.Constraint().Check([]byte("password")) {
+		t.Errorf("Expected the constraint to reject a breached password, but it passed")
+	}
+	if !list.Constraint().Check([]byte("x7Kp93fQLmR2")) {
+		t.Errorf("Expected the constraint to pass a fresh password, but it rejected it")
+	}
+}
+
+// TestBuildFilter_Errors verifies that unusable input is rejected.
+func TestBuildFilter_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		count uint64
+		want  string
+	}{
+		{"NTLM", "8846F7EAEE8FB117AD06BDD830B7586C:3\n", 1, "NTLM"},
+		{"not a hash", "password\n", 1, "not a SHA-1 hash"},
+		{"too many", fmt.Sprintf("%X\n%X\n", sha1.Sum([]byte("a")), sha1.Sum([]byte("b"))), 1, "more than"},
+		{"empty", "", 0, "empty"},
+	}
+	for _, tt := range tests {
+		err := BuildFilter(&bytes.Buffer{}, strings.NewReader(tt.input), tt.count, DefaultFalsePositiveRate)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Expected an error containing %q, but got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+// TestOpen_Invalid verifies that files in neither format are rejected.
+func TestOpen_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"empty.txt":     "",
+		"plain.txt":     "password\n123456\n",
+		"ntlm.txt":      "8846F7EAEE8FB117AD06BDD830B7586C:3\n",
+		"truncated.bin": bloomMagic + "\x00\x00\x00\x07",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if list, err := Open(path); err == nil {
+			list.Close()
+			t.Errorf("%s: Expected an error, but got none", name)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package breach
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// sorted is an opened text file of SHA-1 hashes in ascending order.
+type sorted struct {
+	r    io.ReaderAt
+	size int64
+}
+
+// openSorted checks that a file starts with a hash line.
+func openSorted(r io.ReaderAt, size int64) (*sorted, error) {
+	if size == 0 {
+		return nil, errors.New("the breach list is empty")
+	}
+	line, _, err := readLine(r, 0, size)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := parseHash(line); err != nil {
+		return nil, fmt.Errorf("not a breach filter or sorted SHA-1 list: %w", err)
+	}
+	return &sorted{r: r, size: size}, nil
+}
+
+// contains binary-searches the file by byte offset: each step reads the
+// first line starting at or after the middle of the remaining range.
+func (s *sorted) contains(sum [sha1.Size]byte) (bool, error) {
+	lo, hi := int64(0), s.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start := mid
+		if mid > 0 {
+			// Skip the rest of the line mid falls in.
+			_, next, err := readLine(s.r, mid-1, s.size)
+			if err != nil {
+				return false, err
+			}
+			start = next
+		}
+		if start >= hi {
+			hi = mid
+			continue
+		}
+		line, next, err := readLine(s.r, start, s.size)
+		if err != nil {
+			return false, err
+		}
+		if line == "" {
+			// Only a trailing blank line is expected; search before it.
+			hi = mid
+			continue
+		}
+		found, err := parseHash(line)
+		if err != nil {
+			return false, fmt.Errorf("offset %d: %w", start, err)
+		}
+		switch cmp := bytes.Compare(found[:], sum[:]); {
+		case cmp == 0:
+			return true, nil
+		case cmp < 0:
+			lo = next
+		default:
+			hi = mid
+		}
+	}
+	return false, nil
+}
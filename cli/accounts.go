@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/export"
+)
+
+// accountFlags holds the user names passwords are generated for.
+type accountFlags struct {
+	users string
+	names []string
+}
+
+// register adds the account flags to fs.
+func (f *accountFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.users, "users", "", "generate one password per user and label it with the name, for -format shadow or htpasswd: comma-separated names, or @file with one per line")
+}
+
+// enabled reports whether user names were given.
+func (f *accountFlags) enabled() bool {
+	return f.users != ""
+}
+
+// load reads the user names and returns how many passwords they need.
+func (f *accountFlags) load() (int, error) {
+	list := f.users
+	separator := ","
+	if path, ok := strings.CutPrefix(list, "@"); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 0, err
+		}
+		list, separator = string(data), "\n"
+	}
+	f.names = nil
+	for _, name := range strings.Split(list, separator) {
+		if name = strings.TrimSpace(name); name != "" {
+			f.names = append(f.names, name)
+		}
+	}
+	if len(f.names) == 0 {
+		return 0, errors.New("-users names no users")
+	}
+	return len(f.names), nil
+}
+
+// label sets the label of each result to its user name.
+func (f *accountFlags) label(results []export.Result) {
+	for i := range results {
+		if i < len(f.names) {
+			results[i].Label = f.names[i]
+		}
+	}
+}
+
+// checkAccounts checks that -format shadow and htpasswd have user names,
+// and sets the quantity to one password per user.
+func checkAccounts(fs *flag.FlagSet, accounts *accountFlags, format string, quantity *int) error {
+	if !accounts.enabled() {
+		if format == formatShadow || format == formatHtpasswd {
+			return fmt.Errorf("-format %s needs -users", format)
+		}
+		return nil
+	}
+	count, err := accounts.load()
+	if err != nil {
+		return err
+	}
+	explicit := false
+	fs.Visit(func(fl *flag.Flag) { explicit = explicit || fl.Name == "count" })
+	if explicit && *quantity != count {
+		return fmt.Errorf("-count %d does not match the %d users of -users", *quantity, count)
+	}
+	*quantity = count
+	return nil
+}
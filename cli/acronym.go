@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// acronymFlags holds the options for passwords derived from a sentence.
+type acronymFlags struct {
+	sentence string
+	opts     passgen.AcronymOptions
+}
+
+// register adds the acronym flags to fs.
+func (f *acronymFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.sentence, "acronym", "", "derive passwords from this sentence: first letters, numbers and punctuation, plus random characters")
+	fs.BoolVar(&f.opts.Substitute, "acronym-substitute", false, "replace letters with look-alikes in -acronym passwords, e.g. o with 0")
+	fs.IntVar(&f.opts.RandomDigits, "acronym-digits", 2, "random digits appended to -acronym passwords")
+	fs.IntVar(&f.opts.RandomSymbols, "acronym-symbols", 1, "random symbols appended to -acronym passwords")
+}
+
+// enabled reports whether acronym passwords were requested.
+func (f *acronymFlags) enabled() bool {
+	return f.sentence != ""
+}
+
+// acronymAttempts bounds the variants drawn for one password before the
+// constraints are given up on; only the random characters change.
+const acronymAttempts = 100
+
+// run prints count variants of the acronym password, with a note on their
+// entropy on stderr. Variants that fail one of constraints, e.g. of the
+// policy or the breach list, are drawn again, and the managed settings
+// are checked.
+func (f *acronymFlags) run(count int, managed config.Managed, constraints []passgen.Constraint, stdout, stderr io.Writer) error {
+	for i := 0; i < count; i++ {
+		acronym, err := f.generate(constraints)
+		if err != nil {
+			return err
+		}
+		if err := managed.CheckAcronym(acronym); err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, acronym.Password)
+	}
+	fmt.Fprintln(stderr, acronymWarning(f.opts))
+	return nil
+}
+
+// generate draws variants until one passes every constraint.
+func (f *acronymFlags) generate(constraints []passgen.Constraint) (passgen.Acronym, error) {
+	for attempt := 0; attempt < acronymAttempts; attempt++ {
+		acronym, err := passgen.GenerateAcronym(f.sentence, f.opts)
+		if err != nil {
+			return passgen.Acronym{}, err
+		}
+		if passes(acronym.Password, constraints) {
+			return acronym, nil
+		}
+	}
+	return passgen.Acronym{}, errors.New("no password from this sentence meets the policy or breach list; add random digits or symbols, or choose another sentence")
+}
+
+// passes reports whether password passes every constraint.
+func passes(password string, constraints []passgen.Constraint) bool {
+	for _, c := range constraints {
+		if !c.Check([]byte(password)) {
+			return false
+		}
+	}
+	return true
+}
+
+// acronymWarning explains how much of an acronym password is random.
+func acronymWarning(opts passgen.AcronymOptions) string {
+	bits := passgen.AcronymEntropy(opts)
+	note := fmt.Sprintf("The random characters give about %.0f bits; the rest is only as strong as the sentence is unknown, and quotes, lyrics and sayings are guessed early.", bits)
+	if bits < passgen.AcronymWeakBits {
+		return "Warning: " + note + " Add random digits or symbols."
+	}
+	return note
+}
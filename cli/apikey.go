@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// apiKeyFlags holds the options for prefixed API keys.
+type apiKeyFlags struct {
+	on   bool
+	opts passgen.APIKeyOptions
+}
+
+// register adds the API key flags to fs.
+func (f *apiKeyFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.on, "api-key", false, "generate API keys of a prefix and random base62 characters, e.g. sk_live_..., instead of passwords")
+	fs.StringVar(&f.opts.Prefix, "api-key-prefix", "", fmt.Sprintf("prefix of -api-key keys, up to %d letters, digits and underscores, e.g. sk_live_", passgen.MaxAPIKeyPrefix))
+	fs.IntVar(&f.opts.Length, "api-key-length", passgen.DefaultAPIKeyLength, fmt.Sprintf("random characters of -api-key keys (%d-%d)", passgen.MinAPIKeyLength, passgen.MaxAPIKeyLength))
+	fs.BoolVar(&f.opts.Checksum, "api-key-checksum", false, "append a CRC32 checksum to -api-key keys, so secret scanners can validate them")
+}
+
+// enabled reports whether API keys were requested.
+func (f *apiKeyFlags) enabled() bool {
+	return f.on
+}
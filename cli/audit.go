@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/audit"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/siterules"
+)
+
+// auditFlags holds the options for auditing existing passwords.
+type auditFlags struct {
+	file     string
+	csvFile  string
+	listFile string
+	report   string
+	column   string
+	maxAge   int
+}
+
+// register adds the audit flags to fs.
+func (f *auditFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.file, "audit", "", "audit a Chrome, Edge or Firefox password export CSV instead of generating")
+	fs.StringVar(&f.csvFile, "audit-csv", "", "audit any CSV with a password column and print it annotated as CSV")
+	fs.StringVar(&f.listFile, "audit-list", "", "audit a list of passwords, one per line, with length, entropy, character classes and -policy violations (- reads stdin)")
+	fs.StringVar(&f.report, "audit-report", "", "also write the -audit-list results as CSV to this file, without the passwords or replacements")
+	fs.StringVar(&f.column, "audit-column", "", "name of the password column for -audit-csv (default: detect)")
+	fs.IntVar(&f.maxAge, "audit-max-age", 365, "flag passwords last changed more than this many days ago, when the export records it (0 disables)")
+}
+
+// enabled reports whether an audit was requested.
+func (f *auditFlags) enabled() bool {
+	return f.file != "" || f.csvFile != "" || f.listFile != ""
+}
+
+// run performs the requested audit. Replacements for flagged entries are
+// generated with opts and the bundled site rules; checks holds the breach
+// list, policy and generator, so that passwords on the list or breaking the
+// policy are flagged and never offered as replacements.
+func (f *auditFlags) run(opts passgen.PasswordOptions, checks audit.Options, stdout io.Writer) error {
+	checks.Base = opts
+	checks.MaxAge = time.Duration(f.maxAge) * audit.Day
+	checks.Sites, _ = siterules.Bundled()
+	switch {
+	case f.listFile != "":
+		return f.runList(checks, stdout)
+	case f.csvFile != "":
+		return f.runCSV(checks, stdout)
+	default:
+		return f.runBrowserExport(checks, stdout)
+	}
+}
+
+// runCSV audits an arbitrary credentials spreadsheet and writes the annotated
+// CSV report, with existing passwords masked.
+func (f *auditFlags) runCSV(checks audit.Options, stdout io.Writer) error {
+	file, err := os.Open(f.csvFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	table, err := audit.ReadTable(file, f.column)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.csvFile, err)
+	}
+	findings := audit.Run(table.Entries(), checks)
+	return audit.WriteReport(stdout, table, findings)
+}
+
+// runBrowserExport audits a browser export and prints one line per entry.
+func (f *auditFlags) runBrowserExport(checks audit.Options, stdout io.Writer) error {
+	file, err := os.Open(f.file)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	entries, err := audit.ReadBrowserCSV(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.file, err)
+	}
+	findings := audit.Run(entries, checks)
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SITE\tUSERNAME\tSTRENGTH\tISSUES\tREPLACEMENT")
+	flagged := 0
+	for _, finding := range findings {
+		site := finding.Entry.URL
+		if site == "" {
+			site = finding.Entry.Name
+		}
+		issues := strings.Join(finding.Issues(), ",")
+		if issues == "" {
+			issues = "-"
+		} else {
+			flagged++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", site, finding.Entry.Username, finding.Strength, issues, finding.Proposal())
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "\n%d of %d entries need attention.\n", flagged, len(findings))
+
+	buckets := audit.AgeHistogram(findings, time.Now())
+	largest := 1
+	for _, bucket := range buckets {
+		if len(bucket.Findings) > largest {
+			largest = len(bucket.Findings)
+		}
+	}
+	fmt.Fprintln(stdout, "\nPassword age:")
+	for _, bucket := range buckets {
+		bar := strings.Repeat("#", (len(bucket.Findings)*40+largest-1)/largest)
+		fmt.Fprintf(stdout, "  %-12s %5d %s\n", bucket.Label, len(bucket.Findings), bar)
+	}
+	return nil
+}
+
+// runList audits a plain list of passwords and prints one line per
+// password, without the password itself, and writes the CSV report if
+// -audit-report is given.
+func (f *auditFlags) runList(checks audit.Options, stdout io.Writer) error {
+	in := io.Reader(os.Stdin)
+	if f.listFile != "-" {
+		file, err := os.Open(f.listFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	entries, err := audit.ReadList(in)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.listFile, err)
+	}
+	findings := audit.Run(entries, checks)
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ENTRY\tLENGTH\tBITS\tSTRENGTH\tCLASSES\tISSUES\tVIOLATIONS\tREPLACEMENT")
+	flagged := 0
+	for _, finding := range findings {
+		issues, violations := strings.Join(finding.Issues(), ","), strings.Join(finding.Violations, "; ")
+		if issues == "" {
+			issues = "-"
+		} else {
+			flagged++
+		}
+		if violations == "" {
+			violations = "-"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%.0f\t%s\t%s\t%s\t%s\t%s\n", finding.Entry.Name, finding.Length, finding.Entropy, finding.Strength, strings.Join(finding.Classes, ","), issues, violations, finding.Proposal())
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "\n%d of %d passwords need attention.\n", flagged, len(findings))
+
+	if f.report == "" {
+		return nil
+	}
+	report, err := os.OpenFile(f.report, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := audit.WriteListReport(report, findings); err != nil {
+		report.Close()
+		return err
+	}
+	return report.Close()
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"runtime"
+	"text/tabwriter"
+
+	"github.com/PaulBaker1/Password-Generator-GO/bench"
+	"github.com/PaulBaker1/Password-Generator-GO/version"
+)
+
+// benchCommand is the first argument that selects the benchmark.
+const benchCommand = "bench"
+
+// runBench measures generation throughput in every mode and prints a report.
+// Parameters:
+//   - args ([]string): Flags of the bench command.
+//   - stdout, stderr (io.Writer): Destinations for the report and diagnostics.
+//
+// Returns:
+//
+//	int: The process exit code.
+func runBench(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("password-generator-cli "+benchCommand, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	duration := fs.Duration("duration", bench.DefaultDuration, "measuring time per mode")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	results, err := bench.Run(bench.DefaultModes(), *duration)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "password-generator %s, %s, %s/%s, %d CPUs\n\n", version.String(), runtime.Version(), runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "MODE\tPASSWORDS/S\tENTROPY KB/S\t")
+	for _, result := range results {
+		fmt.Fprintf(w, "%s\t%.0f\t%.1f\t\n", result.Mode.Name, result.PasswordsPerSecond, result.EntropyBytesPerSecond/1024)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	return 0
+}
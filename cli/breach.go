@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/PaulBaker1/Password-Generator-GO/audit"
+	"github.com/PaulBaker1/Password-Generator-GO/breach"
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// breachImportCommand is the first argument that selects the breach list
+// importer.
+const breachImportCommand = "breach-import"
+
+// breachFlags holds the options for the offline breach list.
+type breachFlags struct {
+	path string
+	list *breach.List
+}
+
+// register adds the breach list flag to fs.
+func (f *breachFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.path, "breach-list", "", "generate again any password found in this breach filter or sorted SHA-1 list, and flag audited passwords found in it (see "+breachImportCommand+")")
+}
+
+// enforce makes the breach list required by the organization, if any, the
+// one in use.
+func (f *breachFlags) enforce(managed config.Managed) error {
+	if managed.BreachList == "" {
+		return nil
+	}
+	if f.path != "" && f.path != managed.BreachList {
+		return fmt.Errorf("-breach-list: your organization requires %s", managed.BreachList)
+	}
+	f.path = managed.BreachList
+	return nil
+}
+
+// open opens the list given with -breach-list, if any.
+func (f *breachFlags) open() error {
+	if f.path == "" {
+		return nil
+	}
+	list, err := breach.Open(f.path)
+	if err != nil {
+		return err
+	}
+	f.list = list
+	return nil
+}
+
+// close closes the list, if one was opened.
+func (f *breachFlags) close() {
+	if f.list != nil {
+		f.list.Close()
+	}
+}
+
+// constraints returns the constraint that skips breached passwords, or
+// none without a list.
+func (f *breachFlags) constraints() []passgen.Constraint {
+	if f.list == nil {
+		return nil
+	}
+	return []passgen.Constraint{f.list.Constraint()}
+}
+
+// checker returns the list for audits, or nil without one.
+func (f *breachFlags) checker() audit.Checker {
+	if f.list == nil {
+		return nil
+	}
+	return f.list
+}
+
+// runBreachImport builds a Bloom filter from a downloaded SHA-1 hash list.
+// Parameters:
+//   - args ([]string): Flags of the breach-import command and the hash list.
+//   - stderr (io.Writer): Destination for progress and diagnostics.
+//
+// Returns:
+//
+//	int: The process exit code.
+func runBreachImport(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("password-generator-cli "+breachImportCommand, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	out := fs.String("out", "", "file to write the filter to")
+	rate := fs.Float64("fp", breach.DefaultFalsePositiveRate, "share of passwords the filter may wrongly report as breached")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: password-generator-cli %s -out FILE pwned-passwords-sha1-ordered-by-hash.txt\n", breachImportCommand)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *out == "" || fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	if err := importBreachList(fs.Arg(0), *out, *rate, stderr); err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	return 0
+}
+
+// importBreachList counts the hashes in the input, which sizes the filter,
+// and then builds it. A failed import removes the incomplete file.
+func importBreachList(in, out string, rate float64, stderr io.Writer) error {
+	file, err := os.Open(in)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	fmt.Fprintln(stderr, "Counting hashes in", in)
+	count, err := countLines(file)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stderr, "Building a filter of %d hashes\n", count)
+	dst, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(dst)
+	err = breach.BuildFilter(w, bufio.NewReaderSize(file, 1<<20), count, rate)
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(out)
+		return err
+	}
+	fmt.Fprintln(stderr, "Wrote", out)
+	return nil
+}
+
+// countLines returns the number of non-empty lines in r.
+func countLines(r io.Reader) (uint64, error) {
+	var count uint64
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) > 0 {
+			count++
+		}
+	}
+	return count, scanner.Err()
+}
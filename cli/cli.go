@@ -0,0 +1,669 @@
+/**
+ * Password Generator - Command Line Interface
+ *
+ * This file implements the command line front end. It maps flags onto
+ * PasswordOptions, delegates generation to the controller, and prints one
+ * password per line so the output can be piped into other tools. Plain output
+ * is streamed as it is generated, so even huge batches use little memory.
+ */
+
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/audit"
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/controller"
+	"github.com/PaulBaker1/Password-Generator-GO/export"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/shamir"
+	"github.com/PaulBaker1/Password-Generator-GO/version"
+)
+
+// command is one run of the command line interface: the parsed flags, the
+// options they set and the controller that generates with them.
+type command struct {
+	fs             *flag.FlagSet
+	args           []string
+	stdout, stderr io.Writer
+	ctrl           *controller.GeneratorController
+	managed        config.Managed
+	opts           passgen.PasswordOptions
+	// constraints are those of the breach list and the policy; a site adds
+	// its own rules to them.
+	constraints []passgen.Constraint
+
+	showVersion bool
+	strength    bool
+	pin         bool
+	pinLength   int
+	keyBytes    int
+	keyEncoding string
+	lang        string
+	pattern     string
+	verify      bool
+	format      string
+
+	apiKey      apiKeyFlags
+	auditExport auditFlags
+	decoys      decoyFlags
+	site        siteFlags
+	sharing     shamirFlags
+	rotation    rotateFlags
+	ldap        ldapFlags
+	kpxc        keepassxcFlags
+	webhook     webhookFlags
+	container   containerFlags
+	push        pushFlags
+	passStore   passFlags
+	qrCode      qrFlags
+	username    usernameFlags
+	recovery    recoveryFlags
+	totp        otpFlags
+	hashes      hashFlags
+	accounts    accountFlags
+	systemd     systemdFlags
+	protected   dpapiFlags
+	bundle      bundleFlags
+	receipt     receiptFlags
+	shareLink   shareFlags
+	qa          qaFlags
+	stream      streamFlags
+	preset      presetFlags
+	acronym     acronymFlags
+	breaches    breachFlags
+	pol         policyFlags
+}
+
+// mode is a way of running that prints something other than a batch of
+// passwords, such as PINs or an audit report.
+type mode struct {
+	enabled bool
+	run     func() error
+}
+
+// Run executes the command line interface.
+// Purpose:
+//
+//	Parses command line flags, generates passwords, and writes them to stdout.
+//
+// Parameters:
+//   - args ([]string): Command line arguments without the program name.
+//   - stdout, stderr (io.Writer): Destinations for output and diagnostics.
+//
+// Returns:
+//
+//	int: The process exit code (0 on success, 1 on failure, 2 on usage errors).
+//
+// Example:
+//
+//	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+func Run(args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case gitCredentialCommand:
+			return runGitCredential(args[1:], os.Stdin, stdout, stderr)
+		case benchCommand:
+			return runBench(args[1:], stdout, stderr)
+		case shareServerCommand:
+			return runShareServer(args[1:], stderr)
+		case breachImportCommand:
+			return runBreachImport(args[1:], stderr)
+		}
+	}
+
+	c, err := newCommand(stdout, stderr)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	if err := c.parse(args); err != nil {
+		return 2
+	}
+	return c.run()
+}
+
+// newCommand loads the managed settings and the settings file and registers
+// the flags, whose defaults they set.
+func newCommand(stdout, stderr io.Writer) (*command, error) {
+	// Settings managed by the organization replace the defaults, and flags
+	// that contradict them are refused in check.
+	managed, err := config.LoadSystemManaged()
+	if err != nil {
+		return nil, err
+	}
+	// The settings file and its environment overrides replace the built-in
+	// defaults; flags override both.
+	settingsPath, _ := config.SettingsPath()
+	settings, err := config.LoadSettings(settingsPath)
+	if err != nil {
+		return nil, fmt.Errorf("settings: %w", err)
+	}
+	c := &command{stdout: stdout, stderr: stderr, ctrl: controller.NewGeneratorController(), managed: managed}
+	*c.ctrl.Config = managed.Apply(settings.Defaults)
+	c.opts = *c.ctrl.Config
+	c.opts.Length = c.opts.DefaultLength
+	c.register(settings)
+	return c, nil
+}
+
+// register defines the flags of a run, with the defaults of settings.
+func (c *command) register(settings config.Settings) {
+	fs := flag.NewFlagSet("password-generator-cli", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	c.fs = fs
+	opts := &c.opts
+	fs.IntVar(&opts.Length, "length", opts.Length, "length of each password")
+	fs.IntVar(&opts.Quantity, "count", opts.Quantity, "number of passwords to generate")
+	fs.BoolVar(&opts.IncludeSymbols, "symbols", opts.IncludeSymbols, "include symbols")
+	fs.BoolVar(&opts.IncludeNumbers, "numbers", opts.IncludeNumbers, "include numbers")
+	fs.BoolVar(&opts.IncludeUpper, "upper", opts.IncludeUpper, "include uppercase letters")
+	fs.BoolVar(&opts.IncludeLower, "lower", opts.IncludeLower, "include lowercase letters")
+	fs.BoolVar(&opts.BeginWithLetter, "begin-with-letter", opts.BeginWithLetter, "start every password with a letter")
+	fs.BoolVar(&opts.NoSimilar, "no-similar", opts.NoSimilar, "exclude similar characters such as i, l, 1, O and 0")
+	fs.BoolVar(&opts.NoDuplicates, "no-duplicates", opts.NoDuplicates, "do not repeat characters")
+	fs.BoolVar(&opts.NoSequential, "no-sequential", opts.NoSequential, "avoid sequences such as abc or 321")
+	fs.BoolVar(&opts.AlternateHands, "alternate-hands", opts.AlternateHands, "alternate between left- and right-hand keys")
+	fs.StringVar(&opts.KeyboardLayout, "keyboard-layout", passgen.DefaultHandLayout, "keyboard layout used by -alternate-hands")
+	fs.StringVar(&opts.ExcludeCharacters, "exclude", "", "characters never to use, e.g. for keys that do not work")
+	fs.StringVar(&opts.MustInclude, "must-include", "", "characters that must appear at least once in every password")
+	fs.IntVar(&opts.GroupSize, "group-size", 0, "split each password into groups of this many characters, e.g. x7Kp-93fQ-LmR2")
+	fs.StringVar(&opts.GroupSeparator, "group-separator", passgen.DefaultGroupSeparator, "character between groups with -group-size")
+	fs.Float64Var(&opts.MinEntropy, "min-entropy", opts.MinEntropy, "safety floor: refuse options whose estimated entropy is below this many bits")
+	fs.BoolVar(&c.showVersion, "version", false, "print version information and exit")
+	fs.BoolVar(&c.pin, "pin", false, "generate numeric PINs instead of passwords")
+	fs.IntVar(&c.pinLength, "pin-length", c.ctrl.PINConfig.DefaultLength, fmt.Sprintf("number of digits of each PIN (%d-%d)", passgen.MinPINLength, passgen.MaxPINLength))
+	fs.IntVar(&c.keyBytes, "key-bytes", 0, fmt.Sprintf("generate random keys of this many bytes (%d-%d) instead of passwords", passgen.MinTokenBytes, passgen.MaxTokenBytes))
+	fs.StringVar(&c.keyEncoding, "key-encoding", passgen.EncodingHex, "encoding of -key-bytes keys: "+strings.Join(passgen.Encodings, ", "))
+	c.apiKey.register(fs)
+	defaultLang := settings.UI.Language
+	if defaultLang == "" {
+		defaultLang = config.SystemLocale()
+	}
+	fs.StringVar(&c.lang, "lang", defaultLang, "language of error messages: "+strings.Join(passgen.Locales(), ", "))
+	fs.StringVar(&c.pattern, "pattern", "", "generate from a pattern such as Cvcvc-99-!! (C/c consonant, V/v vowel, A/a letter, 9 digit, ! symbol, * any; \\ escapes)")
+	fs.BoolVar(&c.verify, "verify", false, "re-check every generated password against the options and fail on any violation")
+	fs.StringVar(&c.format, "format", formatText, "output format of the passwords: text (one per line), json (with length, entropy, options and time), csv (with index, entropy, classes and time), shadow or htpasswd (account lines for -users), or bitwarden, 1password or lastpass (CSV those password managers import)")
+	fs.BoolVar(&c.strength, "check-strength", false, "rate the password read from stdin with crack times and the common patterns it contains")
+	c.auditExport.register(fs)
+	c.decoys.register(fs)
+	c.site.register(fs)
+	c.sharing.register(fs)
+	c.rotation.register(fs)
+	c.ldap.register(fs)
+	c.kpxc.register(fs)
+	c.webhook.register(fs)
+	c.container.register(fs)
+	c.push.register(fs)
+	c.passStore.register(fs)
+	c.qrCode.register(fs)
+	c.username.register(fs)
+	c.recovery.register(fs)
+	c.totp.register(fs)
+	c.hashes.register(fs)
+	c.accounts.register(fs)
+	c.systemd.register(fs)
+	c.protected.register(fs)
+	c.bundle.register(fs)
+	c.receipt.register(fs)
+	c.shareLink.register(fs)
+	c.qa.register(fs)
+	c.stream.register(fs)
+	c.preset = presetFlags{profiles: settings.Profiles}
+	c.preset.register(fs)
+	c.acronym.register(fs)
+	c.breaches.register(fs)
+
+	c.pol = policyFlags{name: settings.Policy}
+	if c.managed.Policy != "" {
+		c.pol.name = c.managed.Policy
+	}
+	c.pol.register(fs)
+}
+
+// parse parses args into the flags. Like the flag package for malformed
+// flags, it reports values no run can use, such as -count 0, on stderr.
+func (c *command) parse(args []string) error {
+	c.args = args
+	if err := c.fs.Parse(args); err != nil {
+		return err
+	}
+	if c.opts.Quantity < 1 {
+		err := fmt.Errorf("-count must be at least 1, not %d", c.opts.Quantity)
+		fmt.Fprintln(c.stderr, "Error:", err)
+		return err
+	}
+	return nil
+}
+
+// reparse parses the flags again after a preset or remembered site options
+// have changed the values behind them, so the flags of this run win.
+func (c *command) reparse() {
+	_ = c.fs.Parse(c.args)
+}
+
+// fail reports err on stderr, in the language of -lang where it has a
+// translation, and returns the exit code of a failed run.
+func (c *command) fail(err error) int {
+	fmt.Fprintln(c.stderr, "Error:", passgen.Localize(err, c.lang))
+	return 1
+}
+
+// run executes the parsed command and returns the exit code.
+func (c *command) run() int {
+	if c.showVersion {
+		fmt.Fprintln(c.stdout, "password-generator", version.String())
+		return 0
+	}
+	if c.strength {
+		if err := checkStrength(os.Stdin, c.stdout); err != nil {
+			return c.fail(err)
+		}
+		return 0
+	}
+
+	defer c.breaches.close()
+	if err := c.check(); err != nil {
+		return c.fail(err)
+	}
+	for _, m := range c.modes() {
+		if m.enabled {
+			if err := m.run(); err != nil {
+				return c.fail(err)
+			}
+			return 0
+		}
+	}
+
+	if err := c.applyPreset(); err != nil {
+		return c.fail(err)
+	}
+	if c.preset.save != "" {
+		if err := c.preset.store(c.opts, c.pattern); err != nil {
+			return c.fail(err)
+		}
+		fmt.Fprintln(c.stderr, "Saved preset", c.preset.save)
+		return 0
+	}
+	if c.sharing.combine != "" {
+		if err := c.sharing.combineShares(c.stdout); err != nil {
+			return c.fail(err)
+		}
+		return 0
+	}
+	if err := c.applySite(); err != nil {
+		return c.fail(err)
+	}
+
+	// A policy tightens the options of presets and sites, and neither can
+	// undo managed settings.
+	c.opts = c.pol.apply(c.opts, c.stderr)
+	c.opts = c.managed.Apply(c.opts)
+
+	if c.stream.enabled() {
+		if err := c.stream.run(c.opts, c.ctrl.Generator); err != nil {
+			return c.fail(err)
+		}
+		fmt.Fprintf(c.stderr, "Wrote %d passwords to %s\n", c.opts.Quantity, c.stream.out)
+		return 0
+	}
+	if c.pattern != "" {
+		if parsed, err := passgen.ParsePattern(c.pattern, c.opts); err == nil {
+			if err := c.managed.CheckPattern(parsed); err != nil {
+				return c.fail(err)
+			}
+		}
+		return runPattern(c.ctrl, c.pattern, c.opts, c.verify, c.format, c.hashes, c.accounts, c.lang, c.stdout, c.stderr)
+	}
+	if !c.batch() {
+		return c.runStream()
+	}
+	return c.runBatch()
+}
+
+// check refuses flags that contradict each other or the managed settings,
+// opens the breach list and loads the policy, whose constraints it sets on
+// the controller.
+func (c *command) check() error {
+	if err := checkFormat(c.format); err != nil {
+		return err
+	}
+	if err := checkAccounts(c.fs, &c.accounts, c.format, &c.opts.Quantity); err != nil {
+		return err
+	}
+	if err := c.hashes.forFormat(c.format); err != nil {
+		return err
+	}
+	if c.hashes.enabled() {
+		if _, err := c.hashes.hasher(); err != nil {
+			return fmt.Errorf("-hash: %w", err)
+		}
+		if c.stream.enabled() {
+			return errors.New("-hash cannot be combined with -out")
+		}
+	}
+	if err := c.managed.Check(c.opts); err != nil {
+		return err
+	}
+	if err := c.breaches.enforce(c.managed); err != nil {
+		return err
+	}
+	if err := c.breaches.open(); err != nil {
+		return err
+	}
+	if err := c.pol.enforce(c.managed); err != nil {
+		return err
+	}
+	if err := c.pol.load(); err != nil {
+		return err
+	}
+	c.constraints = append(c.breaches.constraints(), c.pol.constraints()...)
+	c.ctrl.SetConstraints(c.constraints...)
+	return nil
+}
+
+// modes returns the modes that print something other than a batch of
+// passwords, in the order they take precedence. The first enabled one is
+// the whole run.
+func (c *command) modes() []mode {
+	ctx := context.Background()
+	return []mode{
+		{c.keyBytes > 0, c.printKeys},
+		{c.username.enabled(), func() error { return c.username.run(ctx, c.ctrl, c.opts, c.stdout, c.stderr) }},
+		{c.recovery.enabled(), c.printRecoveryCodes},
+		{c.totp.enabled(), func() error { return c.totp.run(&c.qrCode, c.stdout) }},
+		{c.apiKey.enabled(), c.printAPIKeys},
+		{c.pin, c.printPINs},
+		{c.acronym.enabled(), func() error { return c.acronym.run(c.opts.Quantity, c.managed, c.constraints, c.stdout, c.stderr) }},
+		{c.auditExport.enabled(), func() error {
+			return c.auditExport.run(c.opts, audit.Options{Breached: c.breaches.checker(), Generator: c.ctrl.Generator, Policy: c.pol.policy}, c.stdout)
+		}},
+		{c.qa.enabled(), func() error { return c.qa.run(c.opts, c.stdout) }},
+		{c.decoys.enabled(), func() error { return c.decoys.run(c.opts.Quantity, c.stdout) }},
+		{c.protected.read != "", func() error { return c.protected.print(c.stdout) }},
+		{c.bundle.verify != "", func() error { return c.bundle.check(c.stdout) }},
+		{c.receipt.verify != "", func() error { return c.receipt.check(os.Stdin, c.stdout) }},
+		{c.shareLink.open != "", func() error { return c.shareLink.fetch(c.stdout) }},
+		{c.rotation.enabled(), func() error { return c.rotation.run(c.managed, c.pol.policy, c.ctrl.Generator, c.stderr) }},
+		{c.preset.diff != "", func() error { return c.preset.compare(c.fs.Arg(0), c.stdout) }},
+		{c.preset.keepassImport != "", func() error { return c.preset.importKeePass(c.opts, c.stderr) }},
+	}
+}
+
+// printKeys prints -count random keys of -key-bytes bytes.
+func (c *command) printKeys() error {
+	keys, err := c.ctrl.GenerateTokens(context.Background(), c.keyBytes, c.keyEncoding, c.opts.Quantity)
+	if err != nil {
+		return err
+	}
+	return printLines(c.stdout, keys)
+}
+
+// printRecoveryCodes prints -count sets of recovery codes.
+func (c *command) printRecoveryCodes() error {
+	if c.format != formatText {
+		return errors.New("-recovery-codes only prints text; use -hash for server-side storage")
+	}
+	return c.recovery.run(context.Background(), c.ctrl, c.opts.Quantity, &c.hashes, c.stdout)
+}
+
+// printAPIKeys prints -count API keys.
+func (c *command) printAPIKeys() error {
+	keys, err := c.ctrl.GenerateAPIKeys(context.Background(), c.apiKey.opts, c.opts.Quantity)
+	if err != nil {
+		return err
+	}
+	return printLines(c.stdout, keys)
+}
+
+// printPINs prints -count PINs of -pin-length digits.
+func (c *command) printPINs() error {
+	pins, err := c.ctrl.GeneratePINs(context.Background(), passgen.PINOptions{Length: c.pinLength, Quantity: c.opts.Quantity})
+	if err != nil {
+		return err
+	}
+	return printLines(c.stdout, pins)
+}
+
+// applyPreset applies the defaults of an -audience and then a -preset, each
+// overridden by the flags of this run.
+func (c *command) applyPreset() error {
+	if c.preset.audience != "" {
+		if err := c.preset.applyAudience(&c.opts); err != nil {
+			return err
+		}
+		c.reparse()
+	}
+	if c.preset.name != "" {
+		presetPattern, err := c.preset.apply(&c.opts)
+		if err != nil {
+			return err
+		}
+		// As with remembered site options, flags given on this run win.
+		c.reparse()
+		if c.pattern == "" {
+			c.pattern = presetPattern
+		}
+	}
+	return nil
+}
+
+// applySite applies the options remembered for -site, or else its password
+// rules, and adds the rules' constraints to the controller.
+func (c *command) applySite() error {
+	if !c.site.enabled() {
+		return nil
+	}
+	recalled, err := c.site.recall(&c.opts, c.stderr)
+	if err != nil {
+		return err
+	}
+	if recalled {
+		// Parsing again lets the flags given on this run override
+		// the remembered options, which now back the flag variables.
+		c.reparse()
+	} else if c.opts, err = c.site.apply(c.opts, c.stderr); err != nil {
+		return err
+	}
+	siteConstraints, err := c.site.constraints()
+	if err != nil {
+		return err
+	}
+	c.ctrl.SetConstraints(append(c.constraints, siteConstraints...)...)
+	return nil
+}
+
+// batch reports whether the passwords must be generated as a whole batch:
+// plain output is printed as it is generated, so that huge -count values
+// never sit in memory, but every other consumer needs all of them.
+func (c *command) batch() bool {
+	return c.verify || c.format != formatText || c.hashes.enabled() || c.bundle.out != "" || c.receipt.out != "" || c.ldap.enabled() || c.kpxc.enabled() || c.webhook.enabled() ||
+		c.container.enabled() || c.push.enabled() || c.passStore.enabled() || c.qrCode.enabled() || c.systemd.enabled() || c.shareLink.enabled() || c.protected.out != "" || c.sharing.splitting()
+}
+
+// runStream prints the passwords as they are generated.
+func (c *command) runStream() int {
+	if err := printStream(c.ctrl, c.opts, c.stdout); err != nil {
+		return c.fail(err)
+	}
+	c.rememberSite()
+	return 0
+}
+
+// rememberSite keeps the options of this run for -site; failing to is only
+// a warning, as the passwords are already out.
+func (c *command) rememberSite() {
+	if !c.site.enabled() {
+		return
+	}
+	if err := c.site.remember(c.opts); err != nil {
+		fmt.Fprintln(c.stderr, "Warning: options not remembered for the site:", err)
+	}
+}
+
+// runBatch generates the whole batch, records and delivers it as the flags
+// ask, and prints it unless a delivery target keeps the passwords off the
+// terminal.
+func (c *command) runBatch() int {
+	if err := c.bundle.seedGenerator(c.ctrl); err != nil {
+		return c.fail(err)
+	}
+	passwords, err := c.ctrl.GeneratePasswords(context.Background(), c.opts)
+	if err != nil {
+		return c.fail(err)
+	}
+	if c.verify {
+		if err := passgen.VerifyPasswords(passwords, c.opts); err != nil {
+			return c.fail(err)
+		}
+		if err := c.pol.verify(passwords); err != nil {
+			return c.fail(err)
+		}
+	}
+	c.rememberSite()
+	if c.bundle.out != "" {
+		if err := c.bundle.write(c.opts, passwords, c.stderr); err != nil {
+			return c.fail(err)
+		}
+	}
+	if c.receipt.out != "" {
+		// Written before delivery, so every delivered password has a receipt.
+		if err := c.receipt.write(passwords, c.stderr); err != nil {
+			return c.fail(err)
+		}
+	}
+	done, err := c.deliver(passwords)
+	if err != nil {
+		return c.fail(err)
+	}
+	if done {
+		return 0
+	}
+	var shares []shamir.Share
+	if c.sharing.splitting() {
+		if shares, err = c.sharing.split(passwords); err != nil {
+			return c.fail(err)
+		}
+		fmt.Fprintf(c.stderr, "Split into %d shares; any %d recover the password.\n", len(shares), c.sharing.threshold)
+	}
+	results := export.NewResults(passwords, c.opts, time.Now().UTC())
+	c.accounts.label(results)
+	if err := c.hashes.apply(results); err != nil {
+		return c.fail(err)
+	}
+	if err := printPasswords(results, c.opts, c.format, c.stdout, c.stderr); err != nil {
+		return c.fail(err)
+	}
+	for _, share := range shares {
+		fmt.Fprintln(c.stdout, share)
+	}
+	return 0
+}
+
+// deliver hands the passwords to the delivery targets of the flags. The
+// directory, key store and webhook receive a copy; every other target
+// stores the passwords instead of printing them, so done reports that the
+// run is over.
+func (c *command) deliver(passwords []string) (done bool, err error) {
+	if c.ldap.enabled() {
+		if err := c.ldap.reset(passwords); err != nil {
+			return false, err
+		}
+		fmt.Fprintln(c.stderr, "Password reset for", c.ldap.userDN)
+	}
+	if c.kpxc.enabled() {
+		if err := c.kpxc.store(passwords, c.stderr); err != nil {
+			return false, err
+		}
+		fmt.Fprintln(c.stderr, "Stored in KeePassXC for", c.kpxc.url)
+	}
+	if c.webhook.enabled() {
+		if err := c.webhook.send(passwords); err != nil {
+			return false, err
+		}
+		fmt.Fprintln(c.stderr, "Sent to webhook as", c.webhook.name)
+	}
+	switch {
+	case c.container.enabled():
+		// The operator never sees the plaintext: the secret is not printed.
+		if err := c.container.store(passwords); err != nil {
+			return false, err
+		}
+		fmt.Fprintln(c.stderr, "Created", c.container.target())
+	case c.push.enabled():
+		// Like container secrets, the pushed value is never printed.
+		target, err := c.push.push(passwords)
+		if err != nil {
+			return false, err
+		}
+		fmt.Fprintln(c.stderr, "Stored in", target)
+	case c.passStore.enabled():
+		if err := c.passStore.store(passwords); err != nil {
+			return false, err
+		}
+		fmt.Fprintln(c.stderr, "Inserted into the password store as", c.passStore.target.Path)
+	case c.systemd.enabled():
+		if err := c.systemd.store(passwords); err != nil {
+			return false, err
+		}
+		fmt.Fprintln(c.stderr, "Wrote encrypted credential", c.systemd.target.Path)
+	case c.shareLink.enabled():
+		// Only the link is printed; the plaintext never reaches the terminal.
+		if err := c.shareLink.publish(passwords, c.stdout); err != nil {
+			return false, err
+		}
+		fmt.Fprintln(c.stderr, "The link opens once and expires in", c.shareLink.ttl)
+	case c.qrCode.enabled():
+		if err := c.qrCode.write(passwords); err != nil {
+			return false, err
+		}
+		fmt.Fprintln(c.stderr, "Wrote the QR code to", c.qrCode.out)
+	case c.protected.out != "":
+		if err := c.protected.save(passwords); err != nil {
+			return false, err
+		}
+		fmt.Fprintln(c.stderr, "Saved encrypted for the current user to", c.protected.out)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// printLines writes one line per value to stdout.
+func printLines(stdout io.Writer, lines []string) error {
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(stdout, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printStream writes one password per line to stdout as they are generated.
+func printStream(ctrl *controller.GeneratorController, opts passgen.PasswordOptions, stdout io.Writer) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := ctrl.GenerateStream(ctx, opts)
+	if err != nil {
+		return err
+	}
+	out := bufio.NewWriter(stdout)
+	for r := range results {
+		if r.Err != nil {
+			return r.Err
+		}
+		if _, err := fmt.Fprintln(out, r.Password); err != nil {
+			return err
+		}
+	}
+	return out.Flush()
+}
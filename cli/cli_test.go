@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+)
+
+// runCLI runs the command line interface with args, isolated from the
+// settings and locale of the machine, and returns its exit code and output.
+func runCLI(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv(config.SettingsEnv, "")
+	t.Setenv("LC_ALL", "C")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "")
+	var stdout, stderr strings.Builder
+	code := Run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// TestRun verifies the exit codes and output of successful runs and of the
+// error paths: 2 for usage errors, 1 for options that cannot be generated.
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		lines  int
+		stdout string
+		stderr string
+	}{
+		{"passwords", []string{"-count", "3", "-length", "12"}, 0, 3, "", ""},
+		{"version", []string{"-version"}, 0, 1, "password-generator", ""},
+		{"pins", []string{"-pin", "-count", "2"}, 0, 2, "", ""},
+		{"keys", []string{"-key-bytes", "16", "-count", "2"}, 0, 2, "", ""},
+		{"json", []string{"-format", "json", "-count", "2"}, 0, -1, `"results": [`, ""},
+		{"csv", []string{"-format", "csv", "-count", "2"}, 0, 3, "index,password,length", ""},
+		{"zero count", []string{"-count", "0"}, 2, 0, "", "-count must be at least 1, not 0"},
+		{"negative count", []string{"-count", "-1"}, 2, 0, "", "-count must be at least 1, not -1"},
+		{"unknown flag", []string{"-no-such-flag"}, 2, 0, "", "flag provided but not defined"},
+		{"negative length", []string{"-length", "-3"}, 1, 0, "", "Error: length must be at least 1"},
+		{"unknown format", []string{"-format", "xml"}, 1, 0, "", "-format must be"},
+		{"shadow without users", []string{"-format", "shadow"}, 1, 0, "", "-format shadow needs -users"},
+		{"hash with out", []string{"-hash", "bcrypt", "-out", "passwords.txt"}, 1, 0, "", "-hash cannot be combined with -out"},
+		{"recovery codes as json", []string{"-recovery-codes", "8", "-format", "json"}, 1, 0, "", "-recovery-codes only prints text"},
+		{"short pin", []string{"-pin", "-pin-length", "2"}, 1, 0, "", "PIN length must be between 4 and 12"},
+		{"localized error", []string{"-pin", "-pin-length", "2", "-lang", "de"}, 1, 0, "", "die PIN-Länge muss zwischen 4 und 12 liegen"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := runCLI(t, tt.args...)
+			if code != tt.code {
+				t.Fatalf("Expected exit code %d, but got %d with %q", tt.code, code, stderr)
+			}
+			if tt.lines >= 0 && strings.Count(stdout, "\n") != tt.lines {
+				t.Errorf("Expected %d lines, but got %q", tt.lines, stdout)
+			}
+			if !strings.Contains(stdout, tt.stdout) {
+				t.Errorf("Expected %q on stdout, but got %q", tt.stdout, stdout)
+			}
+			if !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("Expected %q on stderr, but got %q", tt.stderr, stderr)
+			}
+		})
+	}
+}
+
+// TestRun_Length verifies that every password has the requested length.
+func TestRun_Length(t *testing.T) {
+	code, stdout, stderr := runCLI(t, "-count", "5", "-length", "20", "-format", "text", "-verify")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, but got %d with %q", code, stderr)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(stdout, "\n"), "\n") {
+		if len(line) != 20 {
+			t.Errorf("Expected 20 characters, but got %q", line)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/delivery"
+)
+
+// containerFlags holds the options for storing a password as a container secret.
+type containerFlags struct {
+	docker  string
+	podman  string
+	replace bool
+}
+
+// register adds the container secret flags to fs.
+func (f *containerFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.docker, "docker-secret", "", "store the generated password as this Docker secret instead of printing it")
+	fs.StringVar(&f.podman, "podman-secret", "", "store the generated password as this Podman secret instead of printing it")
+	fs.BoolVar(&f.replace, "secret-replace", false, "replace an existing Docker or Podman secret of the same name")
+}
+
+// enabled reports whether a container secret should be created.
+func (f *containerFlags) enabled() bool {
+	return f.docker != "" || f.podman != ""
+}
+
+// target returns the configured engine target.
+func (f *containerFlags) target() delivery.ContainerSecretTarget {
+	if f.podman != "" {
+		return delivery.ContainerSecretTarget{Engine: delivery.TypePodman, Name: f.podman, Replace: f.replace}
+	}
+	return delivery.ContainerSecretTarget{Engine: delivery.TypeDocker, Name: f.docker, Replace: f.replace}
+}
+
+// store creates the container secret from the single generated password.
+func (f *containerFlags) store(passwords []string) error {
+	if len(passwords) != 1 {
+		return errors.New("-docker-secret and -podman-secret require -count 1")
+	}
+	if f.docker != "" && f.podman != "" {
+		return errors.New("use either -docker-secret or -podman-secret")
+	}
+	target := f.target()
+	return target.Deliver(context.Background(), delivery.Secret{Name: target.Name, Value: passwords[0], Generated: time.Now()})
+}
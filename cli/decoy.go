@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/PaulBaker1/Password-Generator-GO/decoy"
+)
+
+// decoyKeyEnv names the environment variable holding the decoy tagging key.
+const decoyKeyEnv = "PASSGEN_DECOY_KEY"
+
+// decoyFlags holds the options for generating and detecting decoy passwords.
+type decoyFlags struct {
+	generate  bool
+	checkFile string
+	tagDigits int
+}
+
+// register adds the decoy flags to fs.
+func (f *decoyFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.generate, "decoy", false, "generate tagged decoy passwords for honeypot accounts (key read from "+decoyKeyEnv+")")
+	fs.StringVar(&f.checkFile, "decoy-check", "", "print the lines of this file (- for stdin) that are decoys made with the key")
+	fs.IntVar(&f.tagDigits, "decoy-tag-digits", decoy.DefaultTagDigits, "how many of the four decoy digits carry the tag")
+}
+
+// enabled reports whether a decoy action was requested.
+func (f *decoyFlags) enabled() bool {
+	return f.generate || f.checkFile != ""
+}
+
+// run generates count decoys, or scans the check file for decoys.
+func (f *decoyFlags) run(count int, stdout io.Writer) error {
+	key := os.Getenv(decoyKeyEnv)
+	if key == "" {
+		return errors.New(decoyKeyEnv + " must hold the decoy key")
+	}
+	g := decoy.Generator{Key: []byte(key), TagDigits: f.tagDigits}
+
+	if f.checkFile != "" {
+		return f.check(g, stdout)
+	}
+	for i := 0; i < count; i++ {
+		password, err := g.Generate()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, password)
+	}
+	return nil
+}
+
+// check prints every line of the check file that is a decoy.
+func (f *decoyFlags) check(g decoy.Generator, stdout io.Writer) error {
+	in := io.Reader(os.Stdin)
+	if f.checkFile != "-" {
+		file, err := os.Open(f.checkFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if g.IsDecoy(scanner.Text()) {
+			fmt.Fprintln(stdout, scanner.Text())
+		}
+	}
+	return scanner.Err()
+}
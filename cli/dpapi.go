@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/dpapi"
+)
+
+// dpapiFlags holds the options for Windows DPAPI-encrypted exports.
+type dpapiFlags struct {
+	out  string
+	read string
+}
+
+// register adds the DPAPI flags to fs.
+func (f *dpapiFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.out, "dpapi-out", "", "Windows: save the passwords to this file encrypted for the current user instead of printing them")
+	fs.StringVar(&f.read, "dpapi-read", "", "Windows: decrypt and print a file saved with -dpapi-out")
+}
+
+// save encrypts the passwords, one per line, into the output file.
+func (f *dpapiFlags) save(passwords []string) error {
+	blob, err := dpapi.Protect([]byte(strings.Join(passwords, "\n") + "\n"))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.out, blob, 0600)
+}
+
+// print decrypts the file given with -dpapi-read to stdout.
+func (f *dpapiFlags) print(stdout io.Writer) error {
+	blob, err := os.ReadFile(f.read)
+	if err != nil {
+		return err
+	}
+	plain, err := dpapi.Unprotect(blob)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.read, err)
+	}
+	_, err = stdout.Write(plain)
+	return err
+}
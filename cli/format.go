@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/export"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// Output formats of generated passwords.
+const (
+	formatText = "text"
+	formatJSON = "json"
+	formatCSV  = "csv"
+	// formatShadow and formatHtpasswd print account lines with hashes.
+	formatShadow   = "shadow"
+	formatHtpasswd = "htpasswd"
+)
+
+// checkFormat returns an error unless format is a known output format: an
+// own format or the CSV import layout of a password manager.
+func checkFormat(format string) error {
+	switch format {
+	case formatText, formatJSON, formatCSV, formatShadow, formatHtpasswd:
+		return nil
+	}
+	if isManager(format) {
+		return nil
+	}
+	return fmt.Errorf("-format must be %s, %s, %s, %s, %s or one of %s, not %q", formatText, formatJSON, formatCSV, formatShadow, formatHtpasswd, strings.Join(export.Managers, ", "), format)
+}
+
+// isManager reports whether format names a password manager layout.
+func isManager(format string) bool {
+	for _, manager := range export.Managers {
+		if format == manager {
+			return true
+		}
+	}
+	return false
+}
+
+// printPasswords writes results generated with opts to stdout in format:
+// see printText, printJSON and printAccounts, or CSV for bulk provisioning,
+// in the generator's own columns or a password manager's import layout.
+func printPasswords(results []export.Result, opts passgen.PasswordOptions, format string, stdout, stderr io.Writer) error {
+	switch {
+	case format == formatShadow || format == formatHtpasswd:
+		return printAccounts(results, format, stdout, stderr)
+	case format == formatCSV:
+		return export.CSV(stdout, results)
+	case isManager(format):
+		return export.ManagerCSV(stdout, format, results)
+	case format == formatJSON:
+		return printJSON(results, opts, stdout)
+	}
+	return printText(results, stdout)
+}
+
+// printText writes the passwords one per line, followed by a tab and the
+// hash if they have one.
+func printText(results []export.Result, stdout io.Writer) error {
+	for _, result := range results {
+		line := result.Password
+		if result.Hash != "" {
+			line += "\t" + result.Hash
+		}
+		if _, err := fmt.Fprintln(stdout, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printJSON writes the results as one JSON object with their length and
+// entropy, the options and the generation time.
+func printJSON(results []export.Result, opts passgen.PasswordOptions, stdout io.Writer) error {
+	var at time.Time
+	if len(results) > 0 {
+		at = results[0].GeneratedAt
+	}
+	data, err := export.NewBatch(results, opts, at).JSON()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "%s\n", data)
+	return err
+}
+
+// printAccounts writes /etc/shadow or htpasswd lines to stdout, and the
+// passwords to stderr as user, tab, password, so the two can be redirected
+// apart.
+func printAccounts(results []export.Result, format string, stdout, stderr io.Writer) error {
+	write := export.Shadow
+	if format == formatHtpasswd {
+		write = export.Htpasswd
+	}
+	if err := write(stdout, results); err != nil {
+		return err
+	}
+	for _, result := range results {
+		if _, err := fmt.Fprintf(stderr, "%s\t%s\n", result.Label, result.Password); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/gitcred"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/siterules"
+)
+
+// gitCredentialCommand is the first argument that selects the git helper mode.
+const gitCredentialCommand = "git-credential"
+
+// runGitCredential implements a git credential helper that answers get
+// requests with a freshly generated password.
+// Purpose:
+//
+//	Meant to be listed after a storing helper, so known remotes are answered
+//	by the store and only new remotes reach this helper. Git then hands the
+//	accepted credential to every helper's store operation. This helper keeps
+//	nothing itself, so store and erase are no-ops.
+//
+// Parameters:
+//   - args ([]string): Flags followed by the operation: get, store or erase.
+//   - stdin (io.Reader): The credential attributes sent by git.
+//   - stdout, stderr (io.Writer): The reply to git and diagnostics.
+//
+// Returns:
+//
+//	int: The process exit code.
+func runGitCredential(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	managed, err := config.LoadSystemManaged()
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	opts := managed.Apply(*config.GetDefaultOptions())
+	opts.Length = 24
+	if opts.Length < opts.MinLength {
+		opts.Length = opts.MinLength
+	}
+	fs := flag.NewFlagSet("password-generator-cli "+gitCredentialCommand, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.IntVar(&opts.Length, "length", opts.Length, "length of generated passwords")
+	hosts := fs.String("hosts", "", "comma-separated hosts to generate passwords for; no other host gets one")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "usage: password-generator-cli git-credential [flags] get|store|erase")
+		return 2
+	}
+	if fs.Arg(0) != gitcred.OpGet {
+		return 0
+	}
+	if strings.TrimSpace(*hosts) == "" {
+		fmt.Fprintln(stderr, "Warning: no -hosts given; not generating a password for any host")
+		return 0
+	}
+
+	cred, err := gitcred.Read(stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	if cred.Password != "" || cred.Host == "" || !hostAllowed(*hosts, cred.Host) {
+		return 0
+	}
+
+	if err := managed.Check(opts); err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	g := passgen.NewGenerator()
+	if sites, err := siterules.Bundled(); err == nil {
+		if _, rules, ok := sites.Lookup(cred.Host); ok {
+			opts = managed.Apply(rules.Apply(opts))
+			g = g.With(passgen.WithConstraints(rules.Constraint()))
+		}
+	}
+	passwords, err := g.GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	cred.Password = passwords[0]
+	if err := cred.Write(stdout); err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	return 0
+}
+
+// hostAllowed reports whether host is in the comma-separated list; an
+// empty list allows no host, so a helper listed without -hosts never
+// answers for a remote it was not meant for.
+func hostAllowed(list, host string) bool {
+	for _, allowed := range strings.Split(list, ",") {
+		if strings.EqualFold(strings.TrimSpace(allowed), host) {
+			return true
+		}
+	}
+	return false
+}
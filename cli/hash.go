@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/PaulBaker1/Password-Generator-GO/export"
+	"github.com/PaulBaker1/Password-Generator-GO/kdf"
+)
+
+// hashFlags holds the options for printing a hash next to each password.
+type hashFlags struct {
+	scheme  string
+	cost    int
+	rounds  int
+	time    uint
+	memory  uint
+	threads uint
+}
+
+// register adds the hash flags to fs.
+func (f *hashFlags) register(fs *flag.FlagSet) {
+	d := export.DefaultHasher
+	fs.StringVar(&f.scheme, "hash", "", "also print the hash of each password, for seeding user databases: bcrypt, argon2id or sha512-crypt")
+	fs.IntVar(&f.cost, "bcrypt-cost", d.Cost, "cost of -hash bcrypt")
+	fs.IntVar(&f.rounds, "crypt-rounds", d.Rounds, "rounds of -hash sha512-crypt")
+	fs.UintVar(&f.time, "argon2-time", uint(d.Time), "passes of -hash argon2id")
+	fs.UintVar(&f.memory, "argon2-memory", uint(d.Memory), "memory of -hash argon2id in KiB")
+	fs.UintVar(&f.threads, "argon2-threads", uint(d.Threads), "threads of -hash argon2id")
+}
+
+// enabled reports whether passwords should be hashed.
+func (f *hashFlags) enabled() bool {
+	return f.scheme != ""
+}
+
+// hasher returns the configured hasher, checked.
+func (f *hashFlags) hasher() (export.Hasher, error) {
+	h := export.Hasher{Scheme: f.scheme, Cost: f.cost, Rounds: f.rounds, Params: kdf.Params{Time: uint32(f.time), Memory: uint32(f.memory), Threads: uint8(f.threads)}}
+	if f.threads > 255 {
+		h.Threads = 0
+	}
+	return h, h.Validate()
+}
+
+// forFormat picks the hash format needs: SHA-512-crypt for shadow and
+// bcrypt for htpasswd, unless -hash chose it already.
+func (f *hashFlags) forFormat(format string) error {
+	want := map[string]string{formatShadow: export.HashSHA512Crypt, formatHtpasswd: export.HashBcrypt}[format]
+	switch {
+	case want == "":
+	case f.scheme == "":
+		f.scheme = want
+	case f.scheme != want:
+		return fmt.Errorf("-format %s needs -hash %s", format, want)
+	}
+	return nil
+}
+
+// apply hashes the results if a hash was requested.
+func (f *hashFlags) apply(results []export.Result) error {
+	if !f.enabled() {
+		return nil
+	}
+	h, err := f.hasher()
+	if err != nil {
+		return err
+	}
+	return export.AddHashes(results, h)
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/keepassxc"
+)
+
+// keepassxcFlags holds the options for storing a password in KeePassXC.
+type keepassxcFlags struct {
+	url   string
+	login string
+}
+
+// register adds the KeePassXC flags to fs.
+func (f *keepassxcFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.url, "keepassxc-url", "", "store the generated password in KeePassXC for this site URL")
+	fs.StringVar(&f.login, "keepassxc-login", "", "user name stored with the KeePassXC entry")
+}
+
+// enabled reports whether the password should be sent to KeePassXC.
+func (f *keepassxcFlags) enabled() bool {
+	return f.url != ""
+}
+
+// store sends the single generated password to the running KeePassXC database,
+// associating first if no stored association is accepted.
+func (f *keepassxcFlags) store(passwords []string, stderr io.Writer) error {
+	if len(passwords) != 1 {
+		return errors.New("-keepassxc-url requires -count 1")
+	}
+	dir, err := config.Dir()
+	if err != nil {
+		return err
+	}
+	associationPath := filepath.Join(dir, "keepassxc.json")
+
+	conn, err := keepassxc.Dial()
+	if err != nil {
+		return err
+	}
+	client, err := keepassxc.NewClient(conn)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	association, err := keepassxc.LoadAssociation(associationPath)
+	if err != nil || client.TestAssociate(association) != nil {
+		fmt.Fprintln(stderr, "Approve the connection request in KeePassXC...")
+		if association, err = client.Associate(); err != nil {
+			return err
+		}
+		if err := keepassxc.SaveAssociation(associationPath, association); err != nil {
+			return err
+		}
+	}
+	return client.SetLogin(association, f.url, f.login, passwords[0])
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/PaulBaker1/Password-Generator-GO/ldapreset"
+)
+
+// ldapPasswordEnv names the environment variable holding the bind password,
+// keeping it out of the process list and shell history.
+const ldapPasswordEnv = "PASSGEN_LDAP_BIND_PASSWORD"
+
+// ldapFlags holds the options of the directory password reset integration.
+type ldapFlags struct {
+	cfg    ldapreset.Config
+	userDN string
+}
+
+// register adds the directory reset flags to fs.
+func (f *ldapFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.userDN, "ldap-reset", "", "reset the password of this directory account DN to the generated password")
+	fs.StringVar(&f.cfg.URL, "ldap-url", "", "ldaps:// URL of the directory server")
+	fs.StringVar(&f.cfg.BindDN, "ldap-bind-dn", "", "administrator DN used for the reset (password read from "+ldapPasswordEnv+")")
+	fs.StringVar(&f.cfg.Mode, "ldap-mode", ldapreset.ModeActiveDirectory, "directory type: ad or ldap")
+	fs.StringVar(&f.cfg.CAFile, "ldap-ca-file", "", "PEM file with the CA certificates of the directory server")
+}
+
+// enabled reports whether a directory reset was requested.
+func (f *ldapFlags) enabled() bool {
+	return f.userDN != ""
+}
+
+// reset pushes the single generated password to the directory account.
+func (f *ldapFlags) reset(passwords []string) error {
+	if len(passwords) != 1 {
+		return errors.New("-ldap-reset requires -count 1")
+	}
+	f.cfg.BindPassword = os.Getenv(ldapPasswordEnv)
+	if f.cfg.BindPassword == "" {
+		return errors.New(ldapPasswordEnv + " must hold the bind password")
+	}
+	return ldapreset.ResetPassword(f.cfg, f.userDN, passwords[0])
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/otp"
+)
+
+// otpFlags holds the options for generating a TOTP secret.
+type otpFlags struct {
+	key otp.Key
+}
+
+// register adds the TOTP flags to fs.
+func (f *otpFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.key.Account, "totp", "", "generate a TOTP secret for this account, e.g. an email address, and print it with its otpauth:// URI")
+	fs.StringVar(&f.key.Issuer, "totp-issuer", "", "service name shown by authenticator apps for -totp, e.g. GitLab")
+	fs.StringVar(&f.key.Algorithm, "totp-algorithm", otp.SHA1, "HMAC algorithm of -totp codes: "+strings.Join(otp.Algorithms, ", ")+"; most apps only support SHA1")
+	fs.IntVar(&f.key.Digits, "totp-digits", otp.DefaultDigits, "number of digits of -totp codes (6-8)")
+	fs.IntVar(&f.key.Period, "totp-period", otp.DefaultPeriod, "seconds each -totp code is valid")
+}
+
+// enabled reports whether a TOTP secret was requested.
+func (f *otpFlags) enabled() bool {
+	return f.key.Account != ""
+}
+
+// run generates the secret and prints it and its URI; with -qr, the URI is
+// also written as a QR code. The secret is printed either way, as the
+// service needs it too.
+func (f *otpFlags) run(qrCode *qrFlags, stdout io.Writer) error {
+	if qrCode.ssid != "" {
+		return errors.New("-qr-wifi cannot be combined with -totp")
+	}
+	if err := f.key.NewSecret(); err != nil {
+		return err
+	}
+	if err := f.key.Validate(); err != nil {
+		return err
+	}
+	if qrCode.enabled() {
+		if err := qrCode.write([]string{f.key.URI()}); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(stdout, f.key.Secret)
+	fmt.Fprintln(stdout, f.key.URI())
+	return nil
+}
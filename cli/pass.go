@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/delivery"
+)
+
+// passFlags holds the options for inserting a password into a pass store.
+type passFlags struct {
+	target delivery.PassTarget
+}
+
+// register adds the pass flags to fs.
+func (f *passFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.target.Path, "pass-insert", "", "insert the generated password into the pass(1) store at this path, e.g. servers/db-01, instead of printing it")
+	fs.BoolVar(&f.target.Replace, "pass-replace", false, "overwrite an existing -pass-insert entry")
+}
+
+// enabled reports whether the password should be inserted into pass.
+func (f *passFlags) enabled() bool {
+	return f.target.Path != ""
+}
+
+// store inserts the single generated password.
+func (f *passFlags) store(passwords []string) error {
+	if len(passwords) != 1 {
+		return errors.New("-pass-insert requires -count 1")
+	}
+	return f.target.Deliver(context.Background(), delivery.Secret{Name: f.target.Path, Value: passwords[0], Generated: time.Now()})
+}
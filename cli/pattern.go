@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/controller"
+	"github.com/PaulBaker1/Password-Generator-GO/export"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// runPattern prints opts.Quantity passwords generated from pattern, with
+// their hashes if requested, and returns the exit code.
+func runPattern(ctrl *controller.GeneratorController, pattern string, opts passgen.PasswordOptions, verify bool, format string, hashes hashFlags, accounts accountFlags, lang string, stdout, stderr io.Writer) int {
+	passwords, err := ctrl.GeneratePatternPasswords(context.Background(), pattern, opts)
+	if err == nil && verify {
+		var parsed passgen.Pattern
+		if parsed, err = passgen.ParsePattern(pattern, opts); err == nil {
+			err = parsed.Verify(passwords)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", passgen.Localize(err, lang))
+		return 1
+	}
+	results := export.NewResults(passwords, opts, time.Now().UTC())
+	if parsed, err := passgen.ParsePattern(pattern, opts); err == nil {
+		for i := range results {
+			results[i].Entropy = math.Round(parsed.Entropy()*10) / 10
+		}
+	}
+	accounts.label(results)
+	if err := hashes.apply(results); err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	if err := printPasswords(results, opts, format, stdout, stderr); err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/policy"
+)
+
+// policyFlags holds the options for following a password policy.
+type policyFlags struct {
+	name   string
+	policy *policy.Policy
+}
+
+// register adds the policy flag to fs, defaulting to the policy of the
+// settings file.
+func (f *policyFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.name, "policy", f.name, "follow a password policy: nist-800-63b, pci-dss, ad-complexity or a .json/.yaml policy file")
+}
+
+// enforce refuses a policy other than the one required by the
+// organization, if any.
+func (f *policyFlags) enforce(managed config.Managed) error {
+	if managed.Policy != "" && f.name != managed.Policy {
+		return fmt.Errorf("-policy: your organization requires %s", managed.Policy)
+	}
+	return nil
+}
+
+// load reads the policy given with -policy, if any.
+func (f *policyFlags) load() error {
+	if f.name == "" {
+		return nil
+	}
+	p, err := policy.Lookup(f.name)
+	if err != nil {
+		return fmt.Errorf("-policy: %w", err)
+	}
+	f.policy = &p
+	return nil
+}
+
+// apply adjusts opts to the policy, noting it on stderr.
+func (f *policyFlags) apply(opts passgen.PasswordOptions, stderr io.Writer) passgen.PasswordOptions {
+	if f.policy == nil {
+		return opts
+	}
+	fmt.Fprintf(stderr, "Using the %s policy: %s\n", f.policy.Name, f.policy.Describe())
+	return f.policy.Apply(opts)
+}
+
+// constraints returns the constraint that skips passwords breaking the
+// policy, or none without one.
+func (f *policyFlags) constraints() []passgen.Constraint {
+	if f.policy == nil {
+		return nil
+	}
+	return []passgen.Constraint{f.policy.Constraint()}
+}
+
+// verify checks passwords against the policy, if any.
+func (f *policyFlags) verify(passwords []string) error {
+	if f.policy == nil {
+		return nil
+	}
+	return f.policy.Verify(passwords)
+}
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/keepass"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// presetFlags holds the options for importing, saving and using named
+// presets, and the audience whose defaults the options start from. profiles
+// are the presets of the settings file.
+type presetFlags struct {
+	profiles      config.Presets
+	name          string
+	save          string
+	keepassImport string
+	audience      string
+	diff          string
+}
+
+// register adds the preset flags to fs.
+func (f *presetFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.name, "preset", "", "start from the options of this saved preset; other flags override them")
+	fs.StringVar(&f.save, "preset-save", "", "save the options of this run, with -pattern and -audience, as a preset of this name and exit")
+	fs.StringVar(&f.keepassImport, "keepass-import", "", "import the generator profiles of this KeePass.config.xml as presets")
+	fs.StringVar(&f.diff, "preset-diff", "", "compare this presets file with the one given after it, e.g. -preset-diff old.json new.json, for review")
+	fs.StringVar(&f.audience, "audience", "", "start from the defaults for \"human\" (readable, memorable) or \"machine\" (long, no readability rules) secrets")
+}
+
+// applyAudience replaces *opts with the defaults of the audience, keeping
+// the quantity.
+func (f *presetFlags) applyAudience(opts *passgen.PasswordOptions) error {
+	defaults, err := config.AudienceOptions(config.Audience(f.audience))
+	if err != nil {
+		return err
+	}
+	defaults.Quantity = opts.Quantity
+	*opts = *defaults
+	return nil
+}
+
+// store saves opts and pattern as the preset named by -preset-save.
+func (f *presetFlags) store(opts passgen.PasswordOptions, pattern string) error {
+	if err := passgen.Validate(opts); err != nil {
+		return err
+	}
+	path, err := config.PresetsPath()
+	if err != nil {
+		return err
+	}
+	presets, err := config.LoadPresets(path)
+	if err != nil {
+		return err
+	}
+	opts.Quantity = 1
+	presets[f.save] = config.Preset{Options: opts, Pattern: pattern, Audience: config.Audience(f.audience)}
+	return config.SavePresets(path, presets)
+}
+
+// importKeePass saves the profiles of the KeePass file as presets and lists
+// them, with anything that could only be approximated, on stderr.
+func (f *presetFlags) importKeePass(base passgen.PasswordOptions, stderr io.Writer) error {
+	file, err := os.Open(f.keepassImport)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	imported, err := keepass.ReadProfiles(file, base)
+	if err != nil {
+		return err
+	}
+
+	path, err := config.PresetsPath()
+	if err != nil {
+		return err
+	}
+	presets, err := config.LoadPresets(path)
+	if err != nil {
+		return err
+	}
+	for _, profile := range imported.Profiles {
+		presets[profile.Name] = config.Preset{Options: profile.Options, Pattern: profile.Pattern}
+		fmt.Fprintln(stderr, "Imported preset", profile.Name)
+		for _, note := range profile.Notes {
+			fmt.Fprintln(stderr, "  Note:", note)
+		}
+	}
+	for _, skipped := range imported.Skipped {
+		fmt.Fprintln(stderr, "Skipped", skipped)
+	}
+	return config.SavePresets(path, presets)
+}
+
+// apply replaces *opts with the options of the preset, keeping the
+// quantity, and returns the preset's pattern. The preset's audience is
+// kept unless -audience was given.
+func (f *presetFlags) apply(opts *passgen.PasswordOptions) (string, error) {
+	path, err := config.PresetsPath()
+	if err != nil {
+		return "", err
+	}
+	saved, err := config.LoadPresets(path)
+	if err != nil {
+		return "", err
+	}
+	presets := saved.With(f.profiles)
+	preset, ok := presets[f.name]
+	if !ok {
+		return "", fmt.Errorf("no preset named %q; saved presets: %v", f.name, presets.Names())
+	}
+	preset.Options.Quantity = opts.Quantity
+	*opts = preset.Options
+	if f.audience == "" {
+		f.audience = string(preset.Audience)
+	}
+	return preset.Pattern, nil
+}
+
+// compare prints how the presets of the -preset-diff file differ from those
+// of newPath: one line per preset with its entropy, then the changed settings.
+func (f *presetFlags) compare(newPath string, stdout io.Writer) error {
+	if newPath == "" {
+		return errors.New("-preset-diff needs the new presets file after the old one")
+	}
+	from, err := readPresetsFile(f.diff)
+	if err != nil {
+		return err
+	}
+	to, err := readPresetsFile(newPath)
+	if err != nil {
+		return err
+	}
+	changes := config.DiffPresets(from, to)
+	if len(changes) == 0 {
+		fmt.Fprintln(stdout, "No differences")
+		return nil
+	}
+	for _, change := range changes {
+		fmt.Fprintln(stdout, change)
+		for _, field := range change.Fields {
+			switch change.Kind {
+			case config.PresetAdded:
+				fmt.Fprintf(stdout, "  %s: %s\n", field.Field, field.New)
+			case config.PresetRemoved:
+				fmt.Fprintf(stdout, "  %s: %s\n", field.Field, field.Old)
+			default:
+				fmt.Fprintf(stdout, "  %s: %s → %s\n", field.Field, field.Old, field.New)
+			}
+		}
+	}
+	return nil
+}
+
+// readPresetsFile reads a presets file that must exist.
+func readPresetsFile(path string) (config.Presets, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	presets, err := config.ReadPresets(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return presets, nil
+}
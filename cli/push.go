@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/delivery"
+)
+
+// pushSchemes maps the -push URL schemes to delivery target types.
+var pushSchemes = map[string]string{
+	"aws":     delivery.TypeAWSSecrets,
+	"aws-ssm": delivery.TypeAWSSSM,
+}
+
+// pushFlags holds the options for storing a password in a cloud secret store.
+type pushFlags struct {
+	to string
+}
+
+// register adds the push flag to fs.
+func (f *pushFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.to, "push", "", "store the generated password instead of printing it: aws://name creates or rotates an AWS Secrets Manager secret, aws-ssm://name an SSM SecureString parameter; add ?region= to override the configured region")
+}
+
+// enabled reports whether the password should be pushed.
+func (f *pushFlags) enabled() bool {
+	return f.to != ""
+}
+
+// target returns the delivery target -push names.
+func (f *pushFlags) target() (delivery.AWSTarget, error) {
+	scheme, rest, ok := strings.Cut(f.to, "://")
+	kind, known := pushSchemes[scheme]
+	if !ok || !known {
+		return delivery.AWSTarget{}, fmt.Errorf("-push must start with aws:// or aws-ssm://, not %q", f.to)
+	}
+	name, rawQuery, _ := strings.Cut(rest, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return delivery.AWSTarget{}, fmt.Errorf("-push: %w", err)
+	}
+	if name == "" {
+		return delivery.AWSTarget{}, errors.New("-push needs a secret name, e.g. aws://prod/db-password")
+	}
+	return delivery.AWSTarget{Type: kind, Name: name, Region: query.Get("region")}, nil
+}
+
+// push stores the single generated password and returns where it went.
+func (f *pushFlags) push(passwords []string) (delivery.Target, error) {
+	if len(passwords) != 1 {
+		return nil, errors.New("-push requires -count 1")
+	}
+	target, err := f.target()
+	if err != nil {
+		return nil, err
+	}
+	return target, target.Deliver(context.Background(), delivery.Secret{Name: target.Name, Value: passwords[0], Generated: time.Now()})
+}
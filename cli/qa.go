@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// qaFlags holds the options for the QA charset coverage matrix.
+type qaFlags struct {
+	matrix  bool
+	unicode bool
+}
+
+// register adds the QA matrix flags to fs.
+func (f *qaFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.matrix, "qa-matrix", false, "print a labeled test matrix placing each enabled symbol at the start, middle and end, plus the length limits")
+	fs.BoolVar(&f.unicode, "qa-unicode", false, "add Unicode edge cases to -qa-matrix")
+}
+
+// enabled reports whether the QA matrix should be printed.
+func (f *qaFlags) enabled() bool {
+	return f.matrix
+}
+
+// run prints one "label<TAB>password" line per case.
+func (f *qaFlags) run(opts passgen.PasswordOptions, stdout io.Writer) error {
+	cases, err := passgen.CoverageMatrix(opts, f.unicode)
+	if err != nil {
+		return err
+	}
+	for _, c := range cases {
+		fmt.Fprintf(stdout, "%s\t%s\n", c.Label, c.Password)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/PaulBaker1/Password-Generator-GO/qr"
+)
+
+// qrFlags holds the options for writing a password as a QR code image.
+type qrFlags struct {
+	out  string
+	ssid string
+}
+
+// register adds the QR code flags to fs.
+func (f *qrFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.out, "qr", "", "write the generated password as a QR code PNG to this file instead of printing it")
+	fs.StringVar(&f.ssid, "qr-wifi", "", "with -qr, encode a code that joins this WPA Wi-Fi network with the password")
+}
+
+// enabled reports whether a QR code should be written.
+func (f *qrFlags) enabled() bool {
+	return f.out != ""
+}
+
+// write writes the single generated password as a QR code.
+func (f *qrFlags) write(passwords []string) error {
+	if len(passwords) != 1 {
+		return errors.New("-qr requires -count 1")
+	}
+	text := passwords[0]
+	if f.ssid != "" {
+		text = qr.WiFi(f.ssid, text)
+	}
+	return qr.WriteFile(f.out, text, qr.DefaultSize)
+}
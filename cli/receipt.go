@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/export"
+)
+
+// receiptFlags holds the options for password receipts.
+type receiptFlags struct {
+	out    string
+	label  string
+	verify string
+}
+
+// register adds the receipt flags to fs.
+func (f *receiptFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.out, "receipt", "", "also write salted hashes of the passwords to this file, for verifying them later without keeping the plaintext")
+	fs.StringVar(&f.label, "receipt-label", "", "who or what the passwords are for, recorded in -receipt")
+	fs.StringVar(&f.verify, "verify-receipt", "", "check the password read from stdin against a file written with -receipt")
+}
+
+// write saves receipts for the batch, readable only by the current user.
+func (f *receiptFlags) write(passwords []string, stderr io.Writer) error {
+	receipts, err := export.NewReceipts(passwords, f.label, time.Now())
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(receipts, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(f.out, append(data, '\n'), 0600); err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "Wrote %d receipts to %s\n", len(receipts), f.out)
+	return nil
+}
+
+// check reports whether the password on stdin matches a receipt in the file
+// given with -verify-receipt.
+func (f *receiptFlags) check(stdin io.Reader, stdout io.Writer) error {
+	data, err := os.ReadFile(f.verify)
+	if err != nil {
+		return err
+	}
+	var receipts []export.Receipt
+	if err := json.Unmarshal(data, &receipts); err != nil {
+		return fmt.Errorf("%s: %w", f.verify, err)
+	}
+	password, err := readPassword(stdin, "-verify-receipt")
+	if err != nil {
+		return err
+	}
+	receipt, ok, err := export.MatchReceipt(receipts, password)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.verify, err)
+	}
+	if !ok {
+		return fmt.Errorf("%s: the password matches no receipt", f.verify)
+	}
+	line := "generated " + receipt.GeneratedAt.Local().Format("2006-01-02 15:04")
+	if receipt.Label != "" {
+		line += " for " + receipt.Label
+	}
+	fmt.Fprintf(stdout, "%s: match, %s\n", f.verify, line)
+	return nil
+}
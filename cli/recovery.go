@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/PaulBaker1/Password-Generator-GO/controller"
+	"github.com/PaulBaker1/Password-Generator-GO/export"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// recoveryFlags holds the options for sets of one-time recovery codes.
+type recoveryFlags struct {
+	opts passgen.RecoveryOptions
+}
+
+// register adds the recovery code flags to fs.
+func (f *recoveryFlags) register(fs *flag.FlagSet) {
+	d := passgen.DefaultRecoveryOptions()
+	fs.IntVar(&f.opts.Codes, "recovery-codes", 0, fmt.Sprintf("generate sets of this many one-time recovery codes (1-%d) instead of passwords; -count sets the number of sets", passgen.MaxRecoveryCodes))
+	fs.IntVar(&f.opts.Groups, "recovery-groups", d.Groups, fmt.Sprintf("groups of each -recovery-codes code (1-%d)", passgen.MaxRecoveryGroups))
+	fs.IntVar(&f.opts.GroupSize, "recovery-group-size", d.GroupSize, fmt.Sprintf("characters per group of -recovery-codes codes (%d-%d)", passgen.MinRecoveryGroupSize, passgen.MaxRecoveryGroupSize))
+	fs.StringVar(&f.opts.Separator, "recovery-separator", d.Separator, "separator between the groups of -recovery-codes codes")
+}
+
+// enabled reports whether recovery codes were requested.
+func (f *recoveryFlags) enabled() bool {
+	return f.opts.Codes != 0
+}
+
+// run prints sets recovery code sets, one code per line and a blank line
+// between sets. With -hash, every code is followed by a tab and the hash of
+// its normalized form (see passgen.NormalizeRecoveryCode), for the server to
+// store instead of the codes.
+func (f *recoveryFlags) run(ctx context.Context, ctrl *controller.GeneratorController, sets int, hashes *hashFlags, stdout io.Writer) error {
+	var hasher export.Hasher
+	if hashes.enabled() {
+		h, err := hashes.hasher()
+		if err != nil {
+			return err
+		}
+		hasher = h
+	}
+	for set := 0; set < sets; set++ {
+		codes, err := ctrl.GenerateRecoveryCodes(ctx, f.opts)
+		if err != nil {
+			return err
+		}
+		if set > 0 {
+			fmt.Fprintln(stdout)
+		}
+		for _, code := range codes {
+			if !hashes.enabled() {
+				fmt.Fprintln(stdout, code)
+				continue
+			}
+			hash, err := hasher.Hash(passgen.NormalizeRecoveryCode(code))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(stdout, "%s\t%s\n", code, hash)
+		}
+	}
+	return nil
+}
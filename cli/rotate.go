@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/policy"
+	"github.com/PaulBaker1/Password-Generator-GO/rotate"
+)
+
+// rotateFlags holds the options of the rotation daemon.
+type rotateFlags struct {
+	configFile string
+	once       bool
+}
+
+// register adds the rotation flags to fs.
+func (f *rotateFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.configFile, "rotate", "", "run as a daemon rotating the secrets configured in this JSON file")
+	fs.BoolVar(&f.once, "rotate-once", false, "with -rotate, rotate every secret once and exit")
+}
+
+// enabled reports whether the rotation daemon was requested.
+func (f *rotateFlags) enabled() bool {
+	return f.configFile != ""
+}
+
+// run rotates the configured secrets until interrupted, logging to stderr.
+// Every secret follows the managed settings and p, if any, and is generated
+// with g, which skips passwords failing the policy or the breach list.
+func (f *rotateFlags) run(managed config.Managed, p *policy.Policy, g *passgen.Generator, stderr io.Writer) error {
+	cfg, err := rotate.LoadConfig(f.configFile)
+	if err != nil {
+		return err
+	}
+	rotator, err := rotate.New(cfg, log.New(stderr, "rotate: ", log.LstdFlags),
+		rotate.WithManaged(managed), rotate.WithPolicy(p), rotate.WithGenerator(g))
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if f.once {
+		return rotator.RotateAll(ctx)
+	}
+	if err := rotator.Run(ctx); !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
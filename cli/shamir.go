@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/qr"
+	"github.com/PaulBaker1/Password-Generator-GO/shamir"
+)
+
+// shamirFlags holds the options for splitting a password into shares.
+type shamirFlags struct {
+	shares    int
+	threshold int
+	qrDir     string
+	combine   string
+}
+
+// register adds the secret sharing flags to fs.
+func (f *shamirFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&f.shares, "shamir-shares", 0, "split the generated password into this many shares")
+	fs.IntVar(&f.threshold, "shamir-threshold", 2, "number of shares needed to recover the password")
+	fs.StringVar(&f.qrDir, "shamir-qr", "", "also write each share as a QR code PNG into this directory")
+	fs.StringVar(&f.combine, "shamir-combine", "", "recover a password from the shares in this file (- for stdin), one per line")
+}
+
+// splitting reports whether the generated password should be split.
+func (f *shamirFlags) splitting() bool {
+	return f.shares > 0
+}
+
+// split divides the single generated password into shares and optionally
+// writes them as QR codes.
+func (f *shamirFlags) split(passwords []string) ([]shamir.Share, error) {
+	if len(passwords) != 1 {
+		return nil, errors.New("-shamir-shares requires -count 1")
+	}
+	shares, err := shamir.Split([]byte(passwords[0]), f.shares, f.threshold)
+	if err != nil {
+		return nil, err
+	}
+	if f.qrDir == "" {
+		return shares, nil
+	}
+	if err := os.MkdirAll(f.qrDir, 0700); err != nil {
+		return nil, err
+	}
+	for _, share := range shares {
+		path := filepath.Join(f.qrDir, fmt.Sprintf("share-%d.png", share.X))
+		if err := qr.WriteFile(path, share.String(), qr.DefaultSize); err != nil {
+			return nil, err
+		}
+	}
+	return shares, nil
+}
+
+// combineShares reads shares and prints the recovered password.
+func (f *shamirFlags) combineShares(stdout io.Writer) error {
+	in := io.Reader(os.Stdin)
+	if f.combine != "-" {
+		file, err := os.Open(f.combine)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	var shares []shamir.Share
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		share, err := shamir.ParseShare(line)
+		if err != nil {
+			return err
+		}
+		shares = append(shares, share)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	secret, err := shamir.Combine(shares)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, string(secret))
+	return nil
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/sharelink"
+)
+
+// shareServerCommand is the first argument that starts the built-in relay.
+const shareServerCommand = "share-server"
+
+// shareKeyEnv names the environment variable holding the API key for
+// -share-relay, kept out of the process list.
+const shareKeyEnv = "PASSGEN_SHARE_KEY"
+
+// shareFlags holds the options for sharing a password as a one-time link.
+type shareFlags struct {
+	relay string
+	ttl   time.Duration
+	open  string
+}
+
+// register adds the share link flags to fs.
+func (f *shareFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.relay, "share-relay", "", "encrypt the generated password and print a one-time link on this relay instead of the password; $"+shareKeyEnv+" holds the API key if the relay requires one")
+	fs.DurationVar(&f.ttl, "share-ttl", sharelink.DefaultTTL, "how long an unopened -share-relay link stays valid")
+	fs.StringVar(&f.open, "share-open", "", "print the secret behind a one-time link, which deletes it from the relay")
+}
+
+// enabled reports whether the password should be shared as a link.
+func (f *shareFlags) enabled() bool {
+	return f.relay != ""
+}
+
+// publish shares the single generated password and prints the link.
+func (f *shareFlags) publish(passwords []string, stdout io.Writer) error {
+	if len(passwords) != 1 {
+		return errors.New("-share-relay requires -count 1")
+	}
+	link, err := sharelink.Publish(context.Background(), nil, f.relay, os.Getenv(shareKeyEnv), passwords[0], f.ttl)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, link)
+	return nil
+}
+
+// fetch prints the secret behind the -share-open link.
+func (f *shareFlags) fetch(stdout io.Writer) error {
+	secret, err := sharelink.Fetch(context.Background(), nil, f.open)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, secret)
+	return nil
+}
+
+// runShareServer serves the built-in relay until interrupted.
+// Parameters:
+//   - args ([]string): Flags of the share-server command.
+//   - stderr (io.Writer): Destination for the log and diagnostics.
+//
+// Returns:
+//
+//	int: The process exit code.
+func runShareServer(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("password-generator-cli "+shareServerCommand, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", "127.0.0.1:8089", "address to listen on")
+	maxTTL := fs.Duration("max-ttl", sharelink.MaxTTL, "longest expiry accepted for a link")
+	certFile := fs.String("tls-cert", "", "TLS certificate file; required unless listening on localhost")
+	keyFile := fs.String("tls-key", "", "TLS private key file")
+	apiKeys := fs.String("api-keys", "", "file of API keys, one per line, of which clients must send one to store secrets")
+	rateLimit := fs.Float64("rate-limit", 60, "API requests a minute allowed per client address; 0 for no limit")
+	rateBurst := fs.Int("rate-burst", 10, "API requests a client may make at once before -rate-limit applies")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if (*certFile == "") != (*keyFile == "") {
+		fmt.Fprintln(stderr, "Error: -tls-cert and -tls-key must be given together")
+		return 1
+	}
+	if err := sharelink.CheckListenAddr(*addr, *certFile != ""); err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+
+	relay := sharelink.NewServer()
+	relay.MaxTTL = *maxTTL
+	if *apiKeys != "" {
+		keys, err := sharelink.LoadAPIKeys(*apiKeys)
+		if err != nil {
+			fmt.Fprintln(stderr, "Error:", err)
+			return 1
+		}
+		relay.APIKeys = keys
+	}
+	if *rateLimit > 0 {
+		relay.Limiter = sharelink.NewRateLimiter(*rateLimit, *rateBurst)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go relay.Sweep(ctx)
+
+	server := &http.Server{Addr: *addr, Handler: relay, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	logger := log.New(stderr, "", log.LstdFlags)
+	var err error
+	if *certFile != "" {
+		logger.Printf("Relay listening on https://%s", *addr)
+		err = server.ListenAndServeTLS(*certFile, *keyFile)
+	} else {
+		logger.Printf("Relay listening on http://%s", *addr)
+		err = server.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	return 0
+}
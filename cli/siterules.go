@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/siterules"
+)
+
+// siteFlags holds the options for applying a website's password rules.
+type siteFlags struct {
+	site      string
+	rulesFile string
+	forget    bool
+}
+
+// register adds the site rules flags to fs.
+func (f *siteFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.site, "site", "", "apply the known password rules of this site, e.g. apple.com")
+	fs.StringVar(&f.rulesFile, "site-rules", "", "password-rules.json file to use instead of the bundled rules")
+	fs.BoolVar(&f.forget, "site-forget", false, "ignore the options remembered for -site and use its rules again")
+}
+
+// enabled reports whether site rules should be applied.
+func (f *siteFlags) enabled() bool {
+	return f.site != ""
+}
+
+// apply looks up the site and adjusts opts to its rules, noting the matched
+// rules on stderr. Sites without known rules keep opts.
+func (f *siteFlags) apply(opts passgen.PasswordOptions, stderr io.Writer) (passgen.PasswordOptions, error) {
+	db, err := f.database()
+	if err != nil {
+		return opts, err
+	}
+	domain, rules, ok := db.Lookup(f.site)
+	if !ok {
+		fmt.Fprintf(stderr, "No password rules known for %s; the options of this run are remembered for it\n", siterules.NormalizeSite(f.site))
+		return opts, nil
+	}
+	fmt.Fprintf(stderr, "Using password rules for %s: %s\n", domain, rules.Describe())
+	return rules.Apply(opts), nil
+}
+
+// constraints returns the constraint of the site's rules, which enforces
+// their required groups and max-consecutive also with remembered options,
+// or none for a site without known rules.
+func (f *siteFlags) constraints() ([]passgen.Constraint, error) {
+	db, err := f.database()
+	if err != nil {
+		return nil, err
+	}
+	if _, rules, ok := db.Lookup(f.site); ok {
+		return []passgen.Constraint{rules.Constraint()}, nil
+	}
+	return nil, nil
+}
+
+// database loads the rules file given with -site-rules, or the bundled rules.
+func (f *siteFlags) database() (siterules.Database, error) {
+	if f.rulesFile == "" {
+		return siterules.Bundled()
+	}
+	file, err := os.Open(f.rulesFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return siterules.Load(file)
+}
+
+// recall replaces *opts with the options remembered for the site, keeping
+// the quantity, and reports whether there were any.
+func (f *siteFlags) recall(opts *passgen.PasswordOptions, stderr io.Writer) (bool, error) {
+	if f.forget {
+		return false, nil
+	}
+	sites, err := f.remembered()
+	if err != nil {
+		return false, err
+	}
+	remembered, ok := sites.Lookup(f.site)
+	if !ok {
+		return false, nil
+	}
+	remembered.Quantity = opts.Quantity
+	*opts = remembered
+	fmt.Fprintf(stderr, "Using the options last used for %s\n", siterules.NormalizeSite(f.site))
+	return true, nil
+}
+
+// remember saves opts as the options of the site for the next run.
+func (f *siteFlags) remember(opts passgen.PasswordOptions) error {
+	sites, err := f.remembered()
+	if err != nil {
+		return err
+	}
+	path, err := config.SiteOptionsPath()
+	if err != nil {
+		return err
+	}
+	sites.Remember(f.site, opts)
+	return config.SaveSiteOptions(path, sites)
+}
+
+// remembered loads the options remembered per site.
+func (f *siteFlags) remembered() (config.SiteOptions, error) {
+	path, err := config.SiteOptionsPath()
+	if err != nil {
+		return nil, err
+	}
+	return config.LoadSiteOptions(path)
+}
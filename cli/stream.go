@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/export"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// streamFlags holds the options for exporting a large batch to a file.
+type streamFlags struct {
+	out string
+}
+
+// register adds the export flags to fs.
+func (f *streamFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.out, "out", "", "stream the -count passwords into this file as they are generated: .jsonl for JSON lines, anything else for text; a .gz suffix compresses")
+}
+
+// enabled reports whether the batch should be streamed to a file.
+func (f *streamFlags) enabled() bool {
+	return f.out != ""
+}
+
+// run generates opts.Quantity passwords with g, or crypto/rand if nil, into
+// the output file, readable only by the current user. A failed export
+// removes the incomplete file.
+func (f *streamFlags) run(opts passgen.PasswordOptions, g *passgen.Generator) error {
+	so := export.StreamOptions{Format: export.FormatText, Generator: g}
+	name := f.out
+	if strings.HasSuffix(name, ".gz") {
+		so.Gzip = true
+		name = strings.TrimSuffix(name, ".gz")
+	}
+	if strings.HasSuffix(name, ".jsonl") {
+		so.Format = export.FormatJSONL
+	}
+
+	file, err := os.OpenFile(f.out, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	err = export.Stream(context.Background(), file, opts, opts.Quantity, so)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.out)
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// checkStrength rates the password read from stdin and prints the rating,
+// the crack times and the patterns found.
+func checkStrength(stdin io.Reader, stdout io.Writer) error {
+	password, err := readPassword(stdin, "-check-strength")
+	if err != nil {
+		return err
+	}
+	score := passgen.AnalyzeStrength(password)
+	fmt.Fprintf(stdout, "Strength: %s (%.0f bits)\n", score.Strength, score.Bits)
+	for _, attack := range passgen.Attacks {
+		fmt.Fprintf(stdout, "  %s: %s\n", attack.Name, score.CrackTime(attack))
+	}
+	for _, m := range score.Matches {
+		fmt.Fprintf(stdout, "Found %s %q\n", m.Kind, m.Token)
+	}
+	for _, advice := range score.Feedback() {
+		fmt.Fprintln(stdout, advice)
+	}
+	return nil
+}
+
+// readPassword returns the first line of stdin, for flags that must not
+// take a password on the command line where it would end up in the shell
+// history.
+func readPassword(stdin io.Reader, flagName string) (string, error) {
+	password, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	password = strings.TrimRight(password, "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("%s reads the password from stdin", flagName)
+	}
+	return password, nil
+}
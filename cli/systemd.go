@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/delivery"
+)
+
+// systemdFlags holds the options for writing a systemd encrypted credential.
+type systemdFlags struct {
+	target delivery.SystemdCredsTarget
+}
+
+// register adds the systemd-creds flags to fs.
+func (f *systemdFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.target.Path, "systemd-cred", "", "encrypt the generated password with systemd-creds into this file instead of printing it")
+	fs.StringVar(&f.target.Name, "systemd-cred-name", "", "credential name for -systemd-cred (default: the file name)")
+	fs.StringVar(&f.target.WithKey, "systemd-cred-key", "", "systemd-creds --with-key value, e.g. host, tpm2 or host+tpm2")
+}
+
+// enabled reports whether an encrypted credential should be written.
+func (f *systemdFlags) enabled() bool {
+	return f.target.Path != ""
+}
+
+// store encrypts the single generated password into the credential file.
+func (f *systemdFlags) store(passwords []string) error {
+	if len(passwords) != 1 {
+		return errors.New("-systemd-cred requires -count 1")
+	}
+	return f.target.Deliver(context.Background(), delivery.Secret{Name: f.target.Name, Value: passwords[0], Generated: time.Now()})
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/controller"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// usernameFlags holds the options for generated usernames.
+type usernameFlags struct {
+	on           bool
+	withPassword bool
+	opts         passgen.UsernameOptions
+}
+
+// register adds the username flags to fs.
+func (f *usernameFlags) register(fs *flag.FlagSet) {
+	d := passgen.DefaultUsernameOptions()
+	fs.BoolVar(&f.on, "username", false, "generate usernames such as brave_otter42 instead of passwords")
+	fs.IntVar(&f.opts.Adjectives, "username-adjectives", d.Adjectives, fmt.Sprintf("adjectives before the noun of -username (1-%d)", passgen.MaxUsernameAdjectives))
+	fs.IntVar(&f.opts.Digits, "username-digits", d.Digits, fmt.Sprintf("random digits at the end of -username (0-%d)", passgen.MaxUsernameDigits))
+	fs.StringVar(&f.opts.Separator, "username-separator", d.Separator, "separator between the words of -username: _, -, . or empty")
+	fs.BoolVar(&f.opts.Capitalize, "username-capitalize", false, "capitalize the words of -username, e.g. BraveOtter42")
+	fs.BoolVar(&f.withPassword, "username-password", false, "print a password of the other options after each -username, separated by a tab")
+}
+
+// enabled reports whether usernames were requested.
+func (f *usernameFlags) enabled() bool {
+	return f.on
+}
+
+// run prints quantity usernames, each with a password of opts if requested,
+// and notes on stderr how many can be generated before a repeat is likely.
+func (f *usernameFlags) run(ctx context.Context, ctrl *controller.GeneratorController, opts passgen.PasswordOptions, stdout, stderr io.Writer) error {
+	f.opts.Quantity = opts.Quantity
+	names, err := ctrl.GenerateUsernames(ctx, f.opts)
+	if err != nil {
+		return err
+	}
+	if f.withPassword {
+		passwords, err := ctrl.GeneratePasswords(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for i := range names {
+			names[i] += "\t" + passwords[i]
+		}
+	}
+	fmt.Fprintln(stdout, strings.Join(names, "\n"))
+	bits := passgen.UsernameEntropy(f.opts)
+	fmt.Fprintf(stderr, "About %.0f usernames of this format can be generated before a 1%% chance of a repeat; add -username-digits or -username-adjectives for more.\n", passgen.UsernamesBeforeCollision(bits, 0.01))
+	return nil
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/delivery"
+)
+
+// webhookKeyEnv names the environment variable holding the optional signing key.
+const webhookKeyEnv = "PASSGEN_WEBHOOK_KEY"
+
+// webhookFlags holds the options for sending a password to a webhook.
+type webhookFlags struct {
+	url  string
+	name string
+}
+
+// register adds the webhook flags to fs.
+func (f *webhookFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.url, "webhook", "", "POST the generated password as JSON to this https:// URL (signed if "+webhookKeyEnv+" is set)")
+	fs.StringVar(&f.name, "webhook-name", "password", "name sent along with the password to -webhook")
+}
+
+// enabled reports whether the password should be sent to a webhook.
+func (f *webhookFlags) enabled() bool {
+	return f.url != ""
+}
+
+// send posts the single generated password to the webhook.
+func (f *webhookFlags) send(passwords []string) error {
+	if len(passwords) != 1 {
+		return errors.New("-webhook requires -count 1")
+	}
+	target, err := delivery.NewWebhookTarget(f.url, []byte(os.Getenv(webhookKeyEnv)))
+	if err != nil {
+		return err
+	}
+	return target.Deliver(context.Background(), delivery.Secret{Name: f.name, Value: passwords[0], Generated: time.Now()})
+}
@@ -0,0 +1,22 @@
+/**
+ * Password Generator - CLI Entry Point
+ *
+ * This file is the entry point for the command line version of the password
+ * generator. All flag handling lives in the cli package.
+ */
+
+package main
+
+import (
+	"os"
+
+	"github.com/PaulBaker1/Password-Generator-GO/cli"
+)
+
+// main runs the command line interface and exits with its status code.
+// Example:
+//
+//	go run ./cmd/cli -length 20 -count 5
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,67 @@
+/**
+ * Password Generator - GUI Entry Point
+ *
+ * This file serves as the entry point for the password generator application,
+ * initializing the controller and launching the GUI. The main function
+ * sets up the default configurations from the settings file, with the
+ * settings an organization manages applied, and triggers the GUI layout, or the locked-down kiosk
+ * window when kiosk mode is enabled.
+ */
+
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/controller"
+	"github.com/PaulBaker1/Password-Generator-GO/view"
+)
+
+// main initializes the password generator's controller and launches the GUI.
+// Purpose:
+//
+//	Set up the password generator's configurations and start the application GUI.
+//	Kiosk mode is enabled with -kiosk or by "enabled": true in the kiosk file.
+//
+// Example:
+//
+//	Run the main function to start the application: go run ./cmd/gui
+func main() {
+	kioskPath, _ := config.KioskPath()
+	forceKiosk := flag.Bool("kiosk", false, "show only the preset with Generate and Copy buttons")
+	flag.StringVar(&kioskPath, "kiosk-config", kioskPath, "kiosk configuration file with the preset")
+	flag.Parse()
+
+	// Settings managed by the organization override the defaults; a broken
+	// managed file stops the app rather than leaving them unenforced
+	managed, err := config.LoadSystemManaged()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The settings file and its environment overrides replace the built-in
+	// defaults
+	settingsPath, _ := config.SettingsPath()
+	settings, err := config.LoadSettings(settingsPath)
+	if err != nil {
+		log.Fatalf("settings: %v", err)
+	}
+
+	// Initialize the controller with default options
+	ctrl := controller.NewGeneratorController()
+	*ctrl.Config = managed.Apply(settings.Defaults)
+
+	kiosk, err := config.LoadKiosk(kioskPath)
+	if err != nil {
+		log.Fatalf("kiosk configuration %s: %v", kioskPath, err)
+	}
+	if *forceKiosk || kiosk.Enabled {
+		view.StartKiosk(ctrl, managed.Apply(kiosk.Preset), managed)
+		return
+	}
+
+	// Start the GUI and pass the controller
+	view.StartGUI(ctrl, managed, settings)
+}
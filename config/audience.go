@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// Audience says who a secret is for, which decides sensible defaults.
+type Audience string
+
+// The audiences a secret can be generated for.
+const (
+	// AudienceHuman is for passwords people read, type and remember: a
+	// moderate length without look-alikes or runs.
+	AudienceHuman Audience = "human"
+	// AudienceMachine is for secrets only programs use, such as service
+	// account passwords: long, without readability constraints, and without
+	// symbols that need quoting in URLs, shells or configuration files.
+	AudienceMachine Audience = "machine"
+)
+
+// Audiences lists the audiences in the order front ends offer them.
+var Audiences = []Audience{AudienceHuman, AudienceMachine}
+
+// AudienceOptions returns the default options for audience.
+// Purpose:
+//
+//	Starts from GetDefaultOptions and switches length, character types and
+//	constraints to suit the audience. Length is set to DefaultLength. An
+//	empty audience returns the plain defaults.
+//
+// Parameters:
+//   - audience (Audience): One of Audiences, or empty.
+//
+// Returns:
+//
+//	*passgen.PasswordOptions: The defaults for the audience.
+//	error: An error if the audience is unknown.
+//
+// Example:
+//
+//	opts, err := config.AudienceOptions(config.AudienceMachine)
+func AudienceOptions(audience Audience) (*passgen.PasswordOptions, error) {
+	opts := GetDefaultOptions()
+	switch audience {
+	case "":
+	case AudienceHuman:
+		opts.DefaultLength = 14
+		opts.NoSimilar = true
+		opts.NoSequential = true
+	case AudienceMachine:
+		opts.DefaultLength = 48
+		opts.MaxLength = 128
+		opts.IncludeSymbols = false
+	default:
+		return nil, fmt.Errorf("unknown audience %q; use %q or %q", audience, AudienceHuman, AudienceMachine)
+	}
+	opts.Length = opts.DefaultLength
+	return opts, nil
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// TestAudienceOptions verifies that every audience gives valid options with
+// its own character of defaults.
+func TestAudienceOptions(t *testing.T) {
+	for _, audience := range Audiences {
+		opts, err := AudienceOptions(audience)
+		if err != nil {
+			t.Fatalf("Expected no error for %s, but got %v", audience, err)
+		}
+		if err := passgen.Validate(*opts); err != nil {
+			t.Errorf("Expected valid options for %s, but got %v", audience, err)
+		}
+	}
+
+	machine, _ := AudienceOptions(AudienceMachine)
+	if machine.Length < 40 || machine.NoSimilar || machine.IncludeSymbols {
+		t.Errorf("Expected long machine secrets without readability rules or symbols, but got %+v", machine)
+	}
+	human, _ := AudienceOptions(AudienceHuman)
+	if !human.NoSimilar || human.Length >= machine.Length {
+		t.Errorf("Expected shorter, readable human passwords, but got %+v", human)
+	}
+	if _, err := AudienceOptions("robot"); err == nil {
+		t.Errorf("Expected an error for an unknown audience, but got none")
+	}
+}
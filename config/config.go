@@ -1,10 +1,10 @@
 package config
 
-import "password-generator/model"
+import "github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
 
 // GetDefaultOptions initializes default password options.
-func GetDefaultOptions() *model.PasswordOptions {
-	return &model.PasswordOptions{
+func GetDefaultOptions() *passgen.PasswordOptions {
+	return &passgen.PasswordOptions{
 		MinLength:       6,
 		MaxLength:       32,
 		DefaultLength:   12,
@@ -19,3 +19,13 @@ func GetDefaultOptions() *model.PasswordOptions {
 		NoSequential:    false,
 	}
 }
+
+// GetDefaultPINOptions initializes default PIN options.
+func GetDefaultPINOptions() *passgen.PINOptions {
+	return &passgen.PINOptions{
+		MinLength:     passgen.MinPINLength,
+		MaxLength:     passgen.MaxPINLength,
+		DefaultLength: 6,
+		Quantity:      1,
+	}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// appDirName is the directory name used under the user's config directory.
+const appDirName = "password-generator"
+
+// Dir returns the per-user directory for application files, creating it if needed.
+func Dir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, appDirName)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// SigningKeyPath returns the location of the key that signs reproducibility
+// bundles.
+func SigningKeyPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bundle-signing.key"), nil
+}
+
+// SessionPath returns the location of the autosaved GUI session.
+func SessionPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "session.json"), nil
+}
+
+// VaultPath returns the location of the encrypted password history.
+func VaultPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "vault.json"), nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// kioskFileName is the file in Dir that turns on kiosk mode.
+const kioskFileName = "kiosk.json"
+
+// Kiosk configures the locked-down GUI for shared helpdesk or lab machines.
+// Fields:
+//   - Enabled (bool): Shows only the preset with Generate and Copy buttons.
+//   - Preset (passgen.PasswordOptions): The fixed options; unset fields keep
+//     the application defaults.
+type Kiosk struct {
+	Enabled bool                    `json:"enabled"`
+	Preset  passgen.PasswordOptions `json:"preset"`
+}
+
+// KioskPath returns the location of the kiosk configuration.
+func KioskPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, kioskFileName), nil
+}
+
+// LoadKiosk reads the kiosk configuration at path. A missing file disables
+// kiosk mode. The preset starts from GetDefaultOptions, and is validated.
+func LoadKiosk(path string) (Kiosk, error) {
+	k := Kiosk{Preset: *GetDefaultOptions()}
+	k.Preset.Length = k.Preset.DefaultLength
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return k, nil
+	}
+	if err != nil {
+		return k, err
+	}
+	if err := json.Unmarshal(data, &k); err != nil {
+		return k, err
+	}
+	k.Preset.Quantity = 1
+	return k, passgen.Validate(k.Preset)
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLoadKiosk verifies that a kiosk file overrides only the given preset fields.
+func TestLoadKiosk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), kioskFileName)
+	if err := os.WriteFile(path, []byte(`{"enabled": true, "preset": {"Length": 20, "IncludeSymbols": false}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	k, err := LoadKiosk(path)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !k.Enabled || k.Preset.Length != 20 || k.Preset.IncludeSymbols || !k.Preset.IncludeLower {
+		t.Errorf("Expected the preset to merge with the defaults, but got %+v", k)
+	}
+}
+
+// TestLoadKiosk_Invalid verifies that an unusable preset is rejected.
+func TestLoadKiosk_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), kioskFileName)
+	preset := `{"enabled": true, "preset": {"IncludeSymbols": false, "IncludeNumbers": false, "IncludeUpper": false, "IncludeLower": false}}`
+	if err := os.WriteFile(path, []byte(preset), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadKiosk(path); err == nil {
+		t.Errorf("Expected an error for a preset without character types, but got none")
+	}
+}
+
+// TestLoadKiosk_Missing verifies that a missing file leaves kiosk mode off.
+func TestLoadKiosk_Missing(t *testing.T) {
+	k, err := LoadKiosk(filepath.Join(t.TempDir(), kioskFileName))
+	if err != nil || k.Enabled {
+		t.Errorf("Expected kiosk mode off without error, but got %+v, %v", k, err)
+	}
+}
@@ -0,0 +1,14 @@
+package config
+
+import "os"
+
+// SystemLocale returns the user's message locale from the POSIX environment
+// (LC_ALL, LC_MESSAGES, then LANG), e.g. "de_DE.UTF-8", or "" if unset.
+func SystemLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale := os.Getenv(name); locale != "" && locale != "C" && locale != "POSIX" {
+			return locale
+		}
+	}
+	return ""
+}
@@ -0,0 +1,304 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// managedFileName is the file in the system-wide directory holding the
+// settings an organization enforces.
+const managedFileName = "managed.json"
+
+// managedFileNames are the files searched for in the system-wide directory,
+// in order; YAML and JSON use the same field names.
+var managedFileNames = []string{"managed.yaml", "managed.yml", managedFileName}
+
+// Managed holds settings an administrator enforces for every user of the
+// machine. Each field that is set overrides the user's own setting and
+// locks it; the GUI shows locked controls disabled and the CLI refuses
+// flags that contradict them.
+// Fields:
+//   - MinLength (int): The shortest password length allowed.
+//   - SafetyFloor (float64): The lowest safety floor in bits users may set.
+//   - BreachList (string): A breach list every generated password is
+//     checked against; generation fails if it cannot be opened.
+//   - IncludeSymbols, IncludeNumbers, IncludeUpper, IncludeLower (*bool):
+//     Fix a character type on or off.
+//   - RestoreResults (*bool): Fixes whether the GUI keeps un-copied results
+//     in the autosaved session.
+//   - Policy (string): A bundled policy or policy file every password must
+//     follow; generation fails if it cannot be loaded.
+type Managed struct {
+	MinLength      int     `json:"min_length,omitempty"`
+	SafetyFloor    float64 `json:"safety_floor,omitempty"`
+	BreachList     string  `json:"breach_list,omitempty"`
+	IncludeSymbols *bool   `json:"include_symbols,omitempty"`
+	IncludeNumbers *bool   `json:"include_numbers,omitempty"`
+	IncludeUpper   *bool   `json:"include_upper,omitempty"`
+	IncludeLower   *bool   `json:"include_lower,omitempty"`
+	RestoreResults *bool   `json:"restore_results,omitempty"`
+	Policy         string  `json:"policy,omitempty"`
+}
+
+// ManagedPath returns the location of the managed configuration, in a
+// system-wide directory that users cannot write to and that configuration
+// management or MDM tools deploy to: /etc/password-generator on Linux and
+// other Unix systems, /Library/Application Support/password-generator on
+// macOS and %ProgramData%\password-generator on Windows. The first of
+// managed.yaml, managed.yml and managed.json that exists is used.
+func ManagedPath() string {
+	dir := managedDir()
+	for _, name := range managedFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, managedFileName)
+}
+
+// managedDir returns the system-wide directory of the managed configuration.
+func managedDir() string {
+	switch runtime.GOOS {
+	case "windows":
+		dir := os.Getenv("ProgramData")
+		if dir == "" {
+			dir = `C:\ProgramData`
+		}
+		return filepath.Join(dir, appDirName)
+	case "darwin":
+		return filepath.Join("/Library/Application Support", appDirName)
+	default:
+		return filepath.Join("/etc", appDirName)
+	}
+}
+
+// LoadManaged reads the managed configuration at path, in YAML for a .yaml
+// or .yml file and in JSON otherwise. A missing file enforces nothing.
+// Unknown fields are rejected, so that a misspelt setting is not silently
+// left unenforced.
+func LoadManaged(path string) (Managed, error) {
+	var m Managed
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return m, err
+	}
+	format := SettingsJSON
+	if settingsFormat(path) == SettingsYAML {
+		format = SettingsYAML
+	}
+	if err := decodeStrict(data, format, &m); err != nil {
+		return Managed{}, err
+	}
+	return m, m.validate()
+}
+
+// LoadSystemManaged reads the managed configuration at ManagedPath and, on
+// Windows, the values Group Policy deploys under HKEY_LOCAL_MACHINE\
+// ManagedRegistryKey, which override the file. Errors name their source.
+func LoadSystemManaged() (Managed, error) {
+	path := ManagedPath()
+	m, err := LoadManaged(path)
+	if err != nil {
+		return Managed{}, fmt.Errorf("managed configuration %s: %w", path, err)
+	}
+	values, err := registryManaged()
+	if err != nil {
+		return Managed{}, fmt.Errorf("managed registry key: %w", err)
+	}
+	if len(values) == 0 {
+		return m, nil
+	}
+	data, err := json.Marshal(values)
+	if err == nil {
+		err = json.Unmarshal(data, &m)
+	}
+	if err == nil {
+		err = m.validate()
+	}
+	if err != nil {
+		return Managed{}, fmt.Errorf("managed registry key: %w", err)
+	}
+	return m, nil
+}
+
+// validate rejects settings that cannot be enforced.
+func (m Managed) validate() error {
+	if m.MinLength < 0 || m.SafetyFloor < 0 {
+		return errors.New("min_length and safety_floor must not be negative")
+	}
+	if max := GetDefaultOptions().MaxLength; m.MinLength > max {
+		return fmt.Errorf("min_length %d is above the maximum length of %d", m.MinLength, max)
+	}
+	return nil
+}
+
+// Active reports whether m enforces any setting.
+func (m Managed) Active() bool {
+	return m != Managed{}
+}
+
+// Apply returns opts with the managed settings in force: the character
+// types fixed, the minimum and default lengths and a set length raised to
+// MinLength, and the safety floor raised to SafetyFloor. A length below the
+// user's own minimum is left for passgen.Validate to report.
+// Purpose:
+//
+//	Overrides stored settings, such as defaults, presets and saved
+//	sessions, silently. Settings a user chooses explicitly are checked
+//	with Check instead, so that they are refused with an explanation.
+func (m Managed) Apply(opts passgen.PasswordOptions) passgen.PasswordOptions {
+	for _, lock := range m.classLocks(&opts) {
+		if lock.managed != nil {
+			*lock.field = *lock.managed
+		}
+	}
+	if opts.MinLength < m.MinLength {
+		opts.MinLength = m.MinLength
+	}
+	if opts.DefaultLength < opts.MinLength {
+		opts.DefaultLength = opts.MinLength
+	}
+	if m.MinLength > 0 && opts.Length != 0 && opts.Length < m.MinLength {
+		opts.Length = m.MinLength
+	}
+	if opts.MinEntropy < m.SafetyFloor {
+		opts.MinEntropy = m.SafetyFloor
+	}
+	return opts
+}
+
+// Check returns an error naming the first managed setting that opts
+// contradicts, or nil. Only settings the managed configuration sets are
+// checked; other invalid options are left to passgen.Validate, so that its
+// error does not blame the organization.
+func (m Managed) Check(opts passgen.PasswordOptions) error {
+	if m.MinLength > 0 && opts.Length < m.MinLength {
+		return fmt.Errorf("length %d is below the minimum of %d set by your organization", opts.Length, m.MinLength)
+	}
+	if m.SafetyFloor > 0 && opts.MinEntropy < m.SafetyFloor {
+		return fmt.Errorf("a safety floor of %.0f bits is below the %.0f bits set by your organization", opts.MinEntropy, m.SafetyFloor)
+	}
+	for _, lock := range m.classLocks(&opts) {
+		if lock.managed != nil && *lock.field != *lock.managed {
+			state := "off"
+			if *lock.managed {
+				state = "on"
+			}
+			return fmt.Errorf("%s are turned %s by your organization", lock.name, state)
+		}
+	}
+	return nil
+}
+
+// CheckPattern returns an error if passwords generated from p would be
+// shorter or weaker than the managed settings allow. Patterns fix their
+// own character types, which are not checked.
+func (m Managed) CheckPattern(p passgen.Pattern) error {
+	if len(p) < m.MinLength {
+		return fmt.Errorf("the pattern gives %d characters, below the minimum of %d set by your organization", len(p), m.MinLength)
+	}
+	if bits := p.Entropy(); bits < m.SafetyFloor {
+		return fmt.Errorf("the pattern gives about %.0f bits, below the safety floor of %.0f bits set by your organization", bits, m.SafetyFloor)
+	}
+	return nil
+}
+
+// CheckAcronym returns an error if a password derived from a sentence is
+// shorter than the managed minimum, or its random characters give less than
+// the safety floor; the sentence itself is not counted as random.
+func (m Managed) CheckAcronym(a passgen.Acronym) error {
+	if n := len([]rune(a.Password)); n < m.MinLength {
+		return fmt.Errorf("the acronym password has %d characters, below the minimum of %d set by your organization", n, m.MinLength)
+	}
+	if a.RandomBits < m.SafetyFloor {
+		return fmt.Errorf("the random characters give about %.0f bits, below the safety floor of %.0f bits set by your organization", a.RandomBits, m.SafetyFloor)
+	}
+	return nil
+}
+
+// ApplyProfile overrides the profile settings that m locks.
+func (m Managed) ApplyProfile(p *Profile) {
+	if p.SafetyFloor < m.SafetyFloor {
+		p.SafetyFloor = m.SafetyFloor
+	}
+	if m.BreachList != "" {
+		p.BreachList = m.BreachList
+	}
+	if m.RestoreResults != nil {
+		p.RestoreResults = *m.RestoreResults
+	}
+}
+
+// Describe returns one line per managed setting, saying what it enforces,
+// so that users can see why a control is locked.
+func (m Managed) Describe() []string {
+	var lines []string
+	if m.MinLength > 0 {
+		lines = append(lines, fmt.Sprintf("Passwords have at least %d characters.", m.MinLength))
+	}
+	if m.SafetyFloor > 0 {
+		lines = append(lines, fmt.Sprintf("The safety floor is at least %.0f bits.", m.SafetyFloor))
+	}
+	var opts passgen.PasswordOptions
+	for _, lock := range m.classLocks(&opts) {
+		if lock.managed == nil {
+			continue
+		}
+		state := "off"
+		if *lock.managed {
+			state = "on"
+		}
+		lines = append(lines, fmt.Sprintf("%s are always %s.", capitalize(lock.name), state))
+	}
+	if m.Policy != "" {
+		lines = append(lines, fmt.Sprintf("Passwords follow the %s policy.", m.Policy))
+	}
+	if m.BreachList != "" {
+		lines = append(lines, fmt.Sprintf("Passwords are checked against the breach list %s.", m.BreachList))
+	}
+	if m.RestoreResults != nil {
+		if *m.RestoreResults {
+			lines = append(lines, "Un-copied results are kept in the autosaved session.")
+		} else {
+			lines = append(lines, "Un-copied results are never kept in the autosaved session.")
+		}
+	}
+	return lines
+}
+
+// capitalize returns s with its first letter in upper case.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// classLock pairs a character type of the options with its managed value.
+type classLock struct {
+	name    string
+	field   *bool
+	managed *bool
+}
+
+// classLocks returns the character types of opts with their managed values.
+func (m Managed) classLocks(opts *passgen.PasswordOptions) []classLock {
+	return []classLock{
+		{"symbols", &opts.IncludeSymbols, m.IncludeSymbols},
+		{"numbers", &opts.IncludeNumbers, m.IncludeNumbers},
+		{"uppercase letters", &opts.IncludeUpper, m.IncludeUpper},
+		{"lowercase letters", &opts.IncludeLower, m.IncludeLower},
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package config
+
+// registryManaged returns nothing; only Windows has a policy registry.
+func registryManaged() (map[string]interface{}, error) {
+	return nil, nil
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// writeManaged writes a managed configuration and returns its path.
+func writeManaged(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), managedFileName)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestLoadManaged verifies that the given settings are read and that a
+// missing file enforces nothing.
+func TestLoadManaged(t *testing.T) {
+	m, err := LoadManaged(writeManaged(t, `{"min_length": 14, "include_symbols": true, "breach_list": "/srv/pwned.bloom"}`))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if m.MinLength != 14 || m.IncludeSymbols == nil || !*m.IncludeSymbols || m.IncludeLower != nil || m.BreachList != "/srv/pwned.bloom" {
+		t.Errorf("Expected the settings of the file, but got %+v", m)
+	}
+
+	m, err = LoadManaged(filepath.Join(t.TempDir(), managedFileName))
+	if err != nil || m.Active() {
+		t.Errorf("Expected nothing enforced without error, but got %+v, %v", m, err)
+	}
+}
+
+// TestLoadManaged_YAML verifies that a YAML file uses the field names of
+// managed.json and is as strict about misspelt ones.
+func TestLoadManaged_YAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "managed.yaml")
+	if err := os.WriteFile(path, []byte("min_length: 16\ninclude_numbers: true\npolicy: pci-dss\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m, err := LoadManaged(path)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if m.MinLength != 16 || m.IncludeNumbers == nil || !*m.IncludeNumbers || m.Policy != "pci-dss" {
+		t.Errorf("Expected the settings of the file, but got %+v", m)
+	}
+
+	if err := os.WriteFile(path, []byte("min_lenght: 16\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadManaged(path); err == nil {
+		t.Error("Expected an error for a misspelt setting, but got none")
+	}
+}
+
+// TestLoadManaged_Invalid verifies that misspelt and impossible settings are
+// rejected rather than left unenforced.
+func TestLoadManaged_Invalid(t *testing.T) {
+	for _, content := range []string{
+		`{"min_lenght": 14}`,
+		`{"min_length": -1}`,
+		`{"min_length": 100}`,
+		`{"min_length": "14"}`,
+	} {
+		if _, err := LoadManaged(writeManaged(t, content)); err == nil {
+			t.Errorf("Expected an error for %s, but got none", content)
+		}
+	}
+}
+
+// TestManaged_ApplyCheck verifies that Apply raises stored settings to the
+// managed ones and Check refuses explicit settings below them.
+func TestManaged_ApplyCheck(t *testing.T) {
+	on := true
+	m := Managed{MinLength: 16, SafetyFloor: 80, IncludeSymbols: &on}
+
+	opts := *GetDefaultOptions()
+	opts.Length = 8
+	opts.IncludeSymbols = false
+	if err := m.Check(opts); err == nil {
+		t.Errorf("Expected Check to refuse length 8, but got no error")
+	}
+	opts = m.Apply(opts)
+	if opts.Length != 16 || opts.MinLength != 16 || opts.DefaultLength != 16 || opts.MinEntropy != 80 || !opts.IncludeSymbols {
+		t.Errorf("Expected the managed settings to be applied, but got %+v", opts)
+	}
+	if err := m.Check(opts); err != nil {
+		t.Errorf("Expected the applied options to pass Check, but got %v", err)
+	}
+
+	opts.IncludeSymbols = false
+	if err := m.Check(opts); err == nil {
+		t.Errorf("Expected Check to refuse symbols turned off, but got no error")
+	}
+
+	short, _ := passgen.ParsePattern("Cvcvc-99", opts)
+	if err := m.CheckPattern(short); err == nil {
+		t.Errorf("Expected CheckPattern to refuse an 8-character pattern, but got no error")
+	}
+	if err := m.CheckAcronym(passgen.Acronym{Password: "Ilmdwm2019!42#", RandomBits: 12}); err == nil {
+		t.Errorf("Expected CheckAcronym to refuse a 14-character acronym, but got no error")
+	}
+	if err := m.CheckAcronym(passgen.Acronym{Password: "Ilmdwm2019!42#abcd", RandomBits: 90}); err != nil {
+		t.Errorf("Expected CheckAcronym to accept a long acronym above the floor, but got %v", err)
+	}
+
+	profile := Profile{SafetyFloor: 36}
+	m.ApplyProfile(&profile)
+	if profile.SafetyFloor != 80 {
+		t.Errorf("Expected the profile's safety floor to be raised to 80, but got %v", profile.SafetyFloor)
+	}
+}
+
+// TestManaged_CheckUnset verifies that settings without a managed value are
+// not checked, so invalid options are not blamed on the organization.
+func TestManaged_CheckUnset(t *testing.T) {
+	opts := *GetDefaultOptions()
+	opts.Length = -3
+	opts.MinEntropy = -1
+	if err := (Managed{}).Check(opts); err != nil {
+		t.Errorf("Expected no error without managed settings, but got %v", err)
+	}
+	on := true
+	if err := (Managed{IncludeSymbols: &on}).Check(opts); err != nil {
+		t.Errorf("Expected no error without a managed length or floor, but got %v", err)
+	}
+	if applied := (Managed{}).Apply(opts); applied.Length != -3 {
+		t.Errorf("Expected Apply to leave the length to Validate, but got %d", applied.Length)
+	}
+}
+
+// TestManaged_Describe verifies that every managed setting is explained.
+func TestManaged_Describe(t *testing.T) {
+	if lines := (Managed{}).Describe(); len(lines) != 0 {
+		t.Errorf("Expected no lines without managed settings, but got %v", lines)
+	}
+	off := false
+	m := Managed{MinLength: 16, IncludeSymbols: &off, Policy: "pci-dss", RestoreResults: &off}
+	want := []string{
+		"Passwords have at least 16 characters.",
+		"Symbols are always off.",
+		"Passwords follow the pci-dss policy.",
+		"Un-copied results are never kept in the autosaved session.",
+	}
+	if got := m.Describe(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %q, but got %q", want, got)
+	}
+}
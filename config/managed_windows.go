@@ -0,0 +1,127 @@
+//go:build windows
+
+package config
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// ManagedRegistryKey is the key under HKEY_LOCAL_MACHINE that Group Policy
+// deploys managed settings to. Its values are named like the fields of
+// managed.json: REG_DWORD for numbers and for on (1) or off (0), REG_SZ for
+// paths and policies.
+const ManagedRegistryKey = `SOFTWARE\Policies\PasswordGenerator`
+
+var (
+	advapi32         = syscall.NewLazyDLL("advapi32.dll")
+	procRegGetValueW = advapi32.NewProc("RegGetValueW")
+)
+
+// Constants of RegGetValueW.
+const (
+	hkeyLocalMachine  = 0x80000002
+	rrfRtRegSZ        = 0x00000002
+	rrfRtRegDWORD     = 0x00000010
+	errorFileNotFound = 2
+)
+
+// Registry values by their kind.
+var (
+	registryNumbers = []string{"min_length", "safety_floor"}
+	registryBools   = []string{"include_symbols", "include_numbers", "include_upper", "include_lower", "restore_results"}
+	registryStrings = []string{"breach_list", "policy"}
+)
+
+// registryManaged reads the values set under ManagedRegistryKey, by field
+// name; none if the key does not exist.
+func registryManaged() (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for _, name := range append(registryNumbers, registryBools...) {
+		value, ok, err := registryDWORD(name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		values[name] = value
+		if containsName(registryBools, name) {
+			values[name] = value != 0
+		}
+	}
+	for _, name := range registryStrings {
+		value, ok, err := registryString(name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			values[name] = value
+		}
+	}
+	return values, nil
+}
+
+// registryDWORD reads the REG_DWORD value name; ok is false if it is not set.
+func registryDWORD(name string) (value uint32, ok bool, err error) {
+	size := uint32(unsafe.Sizeof(value))
+	if err := registryGet(name, rrfRtRegDWORD, unsafe.Pointer(&value), &size); err != nil {
+		if err == syscall.Errno(errorFileNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("%s: %w", name, err)
+	}
+	return value, true, nil
+}
+
+// registryString reads the REG_SZ value name; ok is false if it is not set.
+func registryString(name string) (value string, ok bool, err error) {
+	var size uint32
+	if err := registryGet(name, rrfRtRegSZ, nil, &size); err != nil {
+		if err == syscall.Errno(errorFileNotFound) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("%s: %w", name, err)
+	}
+	buf := make([]uint16, size/2+1)
+	if err := registryGet(name, rrfRtRegSZ, unsafe.Pointer(&buf[0]), &size); err != nil {
+		return "", false, fmt.Errorf("%s: %w", name, err)
+	}
+	return syscall.UTF16ToString(buf), true, nil
+}
+
+// registryGet calls RegGetValueW for the value name of ManagedRegistryKey.
+func registryGet(name string, flags uint32, data unsafe.Pointer, size *uint32) error {
+	key, err := syscall.UTF16PtrFromString(ManagedRegistryKey)
+	if err != nil {
+		return err
+	}
+	value, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return err
+	}
+	status, _, _ := procRegGetValueW.Call(
+		hkeyLocalMachine,
+		uintptr(unsafe.Pointer(key)),
+		uintptr(unsafe.Pointer(value)),
+		uintptr(flags),
+		0,
+		uintptr(data),
+		uintptr(unsafe.Pointer(size)),
+	)
+	if status != 0 {
+		return syscall.Errno(status)
+	}
+	return nil
+}
+
+// containsName reports whether names contains name.
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// Kinds of PresetChange.
+const (
+	PresetAdded   = "added"
+	PresetRemoved = "removed"
+	PresetChanged = "changed"
+)
+
+// FieldChange is one setting that differs between two versions of a preset.
+// Fields:
+//   - Field (string): The setting, e.g. "Length" or "No similar".
+//   - Old, New (string): Its values; empty if the preset did not exist.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// PresetChange describes how one preset differs between two preset files.
+// Fields:
+//   - Name (string): The preset name.
+//   - Kind (string): PresetAdded, PresetRemoved or PresetChanged.
+//   - Fields ([]FieldChange): The settings that differ; for added and
+//     removed presets, every setting.
+//   - OldBits, NewBits (float64): Estimated entropy of the preset before and
+//     after; 0 where the preset does not exist or its options are invalid.
+type PresetChange struct {
+	Name    string
+	Kind    string
+	Fields  []FieldChange
+	OldBits float64
+	NewBits float64
+}
+
+// Weaker reports whether a changed preset now gives less entropy, which
+// reviewers should look at first.
+func (c PresetChange) Weaker() bool {
+	return c.Kind == PresetChanged && c.NewBits < c.OldBits
+}
+
+// presetFields lists the settings DiffPresets compares, in the order they
+// are reported: length, character classes, then constraints.
+var presetFields = []struct {
+	name  string
+	value func(p Preset) string
+}{
+	{"Length", func(p Preset) string { return strconv.Itoa(p.Options.Length) }},
+	{"Pattern", func(p Preset) string { return p.Pattern }},
+	{"Audience", func(p Preset) string { return string(p.Audience) }},
+	{"Symbols", func(p Preset) string { return strconv.FormatBool(p.Options.IncludeSymbols) }},
+	{"Numbers", func(p Preset) string { return strconv.FormatBool(p.Options.IncludeNumbers) }},
+	{"Uppercase", func(p Preset) string { return strconv.FormatBool(p.Options.IncludeUpper) }},
+	{"Lowercase", func(p Preset) string { return strconv.FormatBool(p.Options.IncludeLower) }},
+	{"Excluded characters", func(p Preset) string { return p.Options.ExcludeCharacters }},
+	{"Required characters", func(p Preset) string { return p.Options.MustInclude }},
+	{"Begin with letter", func(p Preset) string { return strconv.FormatBool(p.Options.BeginWithLetter) }},
+	{"No similar", func(p Preset) string { return strconv.FormatBool(p.Options.NoSimilar) }},
+	{"No duplicates", func(p Preset) string { return strconv.FormatBool(p.Options.NoDuplicates) }},
+	{"No sequential", func(p Preset) string { return strconv.FormatBool(p.Options.NoSequential) }},
+	{"Alternate hands", func(p Preset) string { return strconv.FormatBool(p.Options.AlternateHands) }},
+	{"Keyboard layout", func(p Preset) string { return p.Options.KeyboardLayout }},
+	{"Safety floor", func(p Preset) string { return strconv.FormatFloat(p.Options.MinEntropy, 'f', -1, 64) }},
+	{"Group size", func(p Preset) string { return strconv.Itoa(p.Options.GroupSize) }},
+	{"Group separator", func(p Preset) string { return p.Options.GroupSeparator }},
+}
+
+// DiffPresets compares two versions of a preset file.
+// Purpose:
+//
+//	Lets reviewers of shared team presets see what a change does: which
+//	presets were added or removed, which settings changed, and whether the
+//	estimated entropy went down.
+//
+// Parameters:
+//   - from, to (Presets): The presets before and after the change.
+//
+// Returns:
+//
+//	[]PresetChange: One entry per preset that differs, sorted by name.
+//
+// Example:
+//
+//	for _, change := range config.DiffPresets(from, to) {
+//		fmt.Println(change.Name, change.Kind, change.Weaker())
+//	}
+func DiffPresets(from, to Presets) []PresetChange {
+	names := make(map[string]bool)
+	for name := range from {
+		names[name] = true
+	}
+	for name := range to {
+		names[name] = true
+	}
+
+	var changes []PresetChange
+	for name := range names {
+		before, inOld := from[name]
+		after, inNew := to[name]
+		change := PresetChange{Name: name, Kind: PresetChanged}
+		switch {
+		case !inOld:
+			change.Kind = PresetAdded
+		case !inNew:
+			change.Kind = PresetRemoved
+		}
+		for _, field := range presetFields {
+			var oldValue, newValue string
+			if inOld {
+				oldValue = field.value(before)
+			}
+			if inNew {
+				newValue = field.value(after)
+			}
+			if oldValue != newValue {
+				change.Fields = append(change.Fields, FieldChange{Field: field.name, Old: oldValue, New: newValue})
+			}
+		}
+		if change.Kind == PresetChanged && len(change.Fields) == 0 {
+			continue
+		}
+		if inOld {
+			change.OldBits = presetEntropy(before)
+		}
+		if inNew {
+			change.NewBits = presetEntropy(after)
+		}
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// presetEntropy estimates the entropy of the passwords p generates, or 0 if
+// its options or pattern are invalid.
+func presetEntropy(p Preset) float64 {
+	if p.Pattern != "" {
+		pattern, err := passgen.ParsePattern(p.Pattern, p.Options)
+		if err != nil {
+			return 0
+		}
+		return pattern.Entropy()
+	}
+	if err := passgen.Validate(p.Options); err != nil {
+		return 0
+	}
+	return passgen.EstimateEntropy(p.Options)
+}
+
+// String renders the change as a short line, e.g.
+// "Work: changed, 78 → 52 bits (weaker)".
+func (c PresetChange) String() string {
+	switch c.Kind {
+	case PresetAdded:
+		return fmt.Sprintf("%s: added, %.0f bits", c.Name, c.NewBits)
+	case PresetRemoved:
+		return fmt.Sprintf("%s: removed", c.Name)
+	}
+	line := fmt.Sprintf("%s: changed, %.0f → %.0f bits", c.Name, c.OldBits, c.NewBits)
+	if c.Weaker() {
+		line += " (weaker)"
+	}
+	return line
+}
@@ -0,0 +1,37 @@
+package config
+
+import "testing"
+
+// TestDiffPresets verifies added, removed and changed presets, and that a
+// weakened preset is flagged.
+func TestDiffPresets(t *testing.T) {
+	work := *GetDefaultOptions()
+	work.Length = 20
+	weakened := work
+	weakened.Length = 10
+	weakened.IncludeSymbols = false
+
+	from := Presets{"Work": {Options: work}, "Old": {Options: work}, "Same": {Options: work}}
+	to := Presets{"Work": {Options: weakened}, "New": {Options: work, Pattern: "Cvcvc-99"}, "Same": {Options: work}}
+	changes := DiffPresets(from, to)
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 changes, but got %+v", changes)
+	}
+
+	kinds := map[string]string{}
+	for _, change := range changes {
+		kinds[change.Name] = change.Kind
+	}
+	if kinds["New"] != PresetAdded || kinds["Old"] != PresetRemoved || kinds["Work"] != PresetChanged {
+		t.Errorf("Expected New added, Old removed and Work changed, but got %v", kinds)
+	}
+
+	changed := changes[2]
+	if changed.Name != "Work" || !changed.Weaker() {
+		t.Fatalf("Expected Work to be weaker, but got %+v", changed)
+	}
+	want := []FieldChange{{"Length", "20", "10"}, {"Symbols", "true", "false"}}
+	if len(changed.Fields) != len(want) || changed.Fields[0] != want[0] || changed.Fields[1] != want[1] {
+		t.Errorf("Expected %v, but got %v", want, changed.Fields)
+	}
+}
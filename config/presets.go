@@ -0,0 +1,101 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// presetsFileName is the file in Dir holding the named presets.
+const presetsFileName = "presets.json"
+
+// Preset is a named set of options, such as a profile imported from KeePass.
+// Fields:
+//   - Options (passgen.PasswordOptions): The generation options.
+//   - Pattern (string): A pattern to generate from instead, if not empty.
+//   - Audience (Audience): Who the preset is for, if it was saved with one.
+type Preset struct {
+	Options  passgen.PasswordOptions `json:"options"`
+	Pattern  string                  `json:"pattern,omitempty"`
+	Audience Audience                `json:"audience,omitempty"`
+}
+
+// Presets maps preset names to presets.
+type Presets map[string]Preset
+
+// PresetsPath returns the location of the saved presets.
+func PresetsPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, presetsFileName), nil
+}
+
+// LoadPresets reads the presets at path. A missing file yields an empty map.
+func LoadPresets(path string) (Presets, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(Presets), nil
+	}
+	if err != nil {
+		return make(Presets), err
+	}
+	defer file.Close()
+	return ReadPresets(file)
+}
+
+// ReadPresets reads presets in the format of the presets file, e.g. a copy
+// shared by a team.
+func ReadPresets(r io.Reader) (Presets, error) {
+	presets := make(Presets)
+	err := json.NewDecoder(r).Decode(&presets)
+	return presets, err
+}
+
+// SavePresets writes presets to path, readable only by the current user.
+func SavePresets(path string, presets Presets) error {
+	data, err := json.MarshalIndent(presets, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Names returns the preset names in alphabetical order.
+func (p Presets) Names() []string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Rename moves the preset named from to the name to, which must not be
+// empty or taken by another preset.
+func (p Presets) Rename(from, to string) error {
+	preset, ok := p[from]
+	if !ok {
+		return fmt.Errorf("no preset named %q", from)
+	}
+	if to == "" {
+		return errors.New("the preset name is empty")
+	}
+	if to == from {
+		return nil
+	}
+	if _, taken := p[to]; taken {
+		return fmt.Errorf("a preset named %q already exists", to)
+	}
+	delete(p, from)
+	p[to] = preset
+	return nil
+}
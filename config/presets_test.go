@@ -0,0 +1,59 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestPresets_SaveAndLoad verifies that presets survive a save.
+func TestPresets_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), presetsFileName)
+	presets, err := LoadPresets(path)
+	if err != nil || len(presets) != 0 {
+		t.Fatalf("Expected no error and no presets, but got %v and %v", presets, err)
+	}
+	opts := *GetDefaultOptions()
+	opts.Length = 24
+	presets["Work"] = Preset{Options: opts, Audience: AudienceHuman}
+	presets["Voucher"] = Preset{Options: opts, Pattern: "AAAA-9999"}
+	if err := SavePresets(path, presets); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	loaded, err := LoadPresets(path)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !reflect.DeepEqual(loaded, presets) {
+		t.Errorf("Expected %+v, but got %+v", presets, loaded)
+	}
+	if names := loaded.Names(); !reflect.DeepEqual(names, []string{"Voucher", "Work"}) {
+		t.Errorf("Expected the names in order, but got %v", names)
+	}
+}
+
+// TestPresets_Rename verifies that renaming keeps the preset and refuses
+// missing, empty and taken names.
+func TestPresets_Rename(t *testing.T) {
+	opts := *GetDefaultOptions()
+	presets := Presets{"Work": {Options: opts}, "Router": {Options: opts, Pattern: "AAAA-9999"}}
+
+	if err := presets.Rename("Router", "Router WiFi"); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if names := presets.Names(); !reflect.DeepEqual(names, []string{"Router WiFi", "Work"}) {
+		t.Errorf("Expected the renamed preset, but got %v", names)
+	}
+	if presets["Router WiFi"].Pattern != "AAAA-9999" {
+		t.Errorf("Expected the pattern to be kept, but got %+v", presets["Router WiFi"])
+	}
+	for _, tc := range [][2]string{{"Missing", "Other"}, {"Work", ""}, {"Work", "Router WiFi"}} {
+		if err := presets.Rename(tc[0], tc[1]); err == nil {
+			t.Errorf("Expected an error renaming %q to %q, but got none", tc[0], tc[1])
+		}
+	}
+	if len(presets) != 2 {
+		t.Errorf("Expected 2 presets after failed renames, but got %v", presets.Names())
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// profileFileName is the file in Dir holding the personal profile.
+const profileFileName = "profile.json"
+
+// DefaultClipboardClear is how long a copied password stays on the
+// clipboard unless the profile says otherwise.
+const DefaultClipboardClear = 30 * time.Second
+
+// Themes that Profile.Theme can hold besides following the system.
+const (
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+)
+
+// DensityCompact is the layout density of a Profile with less padding and
+// smaller text, for small screens; empty is the normal density.
+const DensityCompact = "compact"
+
+// Profile holds personal settings that persist between runs.
+// Fields:
+//   - BrokenKeys (string): Characters on keys that are broken or missing on
+//     the user's keyboard; they are never used in generated passwords.
+//   - RestoreResults (bool): Whether the autosaved session includes results
+//     that were not copied yet, so they survive a crash.
+//   - ShareRelay (string): The relay last used for one-time share links.
+//   - Language (string): Locale of error messages, e.g. "de"; empty to
+//     follow the system.
+//   - SafetyFloor (float64): Minimum estimated entropy in bits below which
+//     the GUI refuses to generate; 0 disables it.
+//   - BreachList (string): A local breach list whose passwords are never
+//     generated; see package breach.
+//   - ClipboardClear (int): Seconds after which a copied password is
+//     cleared from the clipboard; 0 for DefaultClipboardClear, negative to
+//     never clear it.
+//   - Theme (string): ThemeLight or ThemeDark; empty to follow the system.
+//   - Density (string): DensityCompact, or empty for the normal layout.
+//   - WindowWidth, WindowHeight (float32): Size of the main window when it
+//     was last closed; 0 for the built-in size.
+//   - MaskPasswords (bool): Whether results are shown as bullets until
+//     revealed.
+//   - Hotkey (string): A system-wide combination such as "Ctrl+Alt+P" that
+//     generates a password with the options of the main window and copies
+//     it; empty to register none.
+type Profile struct {
+	BrokenKeys     string  `json:"broken_keys"`
+	RestoreResults bool    `json:"restore_results"`
+	ShareRelay     string  `json:"share_relay,omitempty"`
+	Language       string  `json:"language,omitempty"`
+	SafetyFloor    float64 `json:"safety_floor,omitempty"`
+	BreachList     string  `json:"breach_list,omitempty"`
+	ClipboardClear int     `json:"clipboard_clear,omitempty"`
+	Theme          string  `json:"theme,omitempty"`
+	Density        string  `json:"density,omitempty"`
+	WindowWidth    float32 `json:"window_width,omitempty"`
+	WindowHeight   float32 `json:"window_height,omitempty"`
+	MaskPasswords  bool    `json:"mask_passwords,omitempty"`
+	Hotkey         string  `json:"hotkey,omitempty"`
+}
+
+// ClipboardClearDelay returns how long a copied password stays on the
+// clipboard, or 0 if it is never cleared.
+func (p Profile) ClipboardClearDelay() time.Duration {
+	switch {
+	case p.ClipboardClear < 0:
+		return 0
+	case p.ClipboardClear == 0:
+		return DefaultClipboardClear
+	default:
+		return time.Duration(p.ClipboardClear) * time.Second
+	}
+}
+
+// ProfilePath returns the location of the personal profile.
+func ProfilePath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, profileFileName), nil
+}
+
+// LoadProfile reads the profile at path. A missing file yields an empty
+// profile, so a first run needs no setup.
+func LoadProfile(path string) (Profile, error) {
+	var p Profile
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return p, err
+	}
+	err = json.Unmarshal(data, &p)
+	return p, err
+}
+
+// SaveProfile writes p to path, readable only by the current user.
+func SaveProfile(path string, p Profile) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestProfile_SaveAndLoad verifies that a saved profile is read back unchanged.
+func TestProfile_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), profileFileName)
+	want := Profile{BrokenKeys: "2@qQ", Theme: ThemeDark, WindowWidth: 640, WindowHeight: 720}
+	if err := SaveProfile(path, want); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	got, err := LoadProfile(path)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if got != want {
+		t.Errorf("Expected %+v, but got %+v", want, got)
+	}
+}
+
+// TestLoadProfile_Missing verifies that a missing profile is empty rather than an error.
+func TestLoadProfile_Missing(t *testing.T) {
+	got, err := LoadProfile(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+	if got != (Profile{}) {
+		t.Errorf("Expected an empty profile, but got %+v", got)
+	}
+}
+
+// TestProfile_ClipboardClearDelay verifies the default, custom and disabled
+// clipboard timers.
+func TestProfile_ClipboardClearDelay(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    time.Duration
+	}{
+		{0, DefaultClipboardClear},
+		{10, 10 * time.Second},
+		{-1, 0},
+	}
+	for _, tt := range tests {
+		if got := (Profile{ClipboardClear: tt.seconds}).ClipboardClearDelay(); got != tt.want {
+			t.Errorf("%d: Expected %v, but got %v", tt.seconds, tt.want, got)
+		}
+	}
+}
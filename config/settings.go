@@ -0,0 +1,273 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of the settings file.
+const (
+	SettingsJSON = "json"
+	SettingsYAML = "yaml"
+	SettingsTOML = "toml"
+)
+
+// settingsEnvPrefix starts the environment variables read by Settings.
+const settingsEnvPrefix = "PASSGEN_"
+
+// SettingsEnv names the environment variable that points to the settings
+// file.
+const SettingsEnv = settingsEnvPrefix + "CONFIG"
+
+// settingsFileNames are the files in Dir searched for settings, in order.
+var settingsFileNames = []string{"config.yaml", "config.yml", "config.toml", "config.json"}
+
+// Settings is the declarative configuration of a deployment: the default
+// options, profiles to offer besides the user's presets, the policy to
+// follow and preferences of the GUI.
+// Fields:
+//   - Defaults (passgen.PasswordOptions): The options the CLI and GUI start
+//     from, with the field names of PasswordOptions; fields left out keep
+//     the application defaults, and DefaultLength sets the length.
+//   - Profiles (Presets): Named presets offered in addition to the saved
+//     ones; a saved preset of the same name wins.
+//   - Policy (string): A bundled policy or policy file to follow unless
+//     -policy or the GUI selects another.
+//   - UI (UISettings): Preferences the user's profile has not set.
+type Settings struct {
+	Defaults passgen.PasswordOptions `json:"defaults"`
+	Profiles Presets                 `json:"profiles,omitempty"`
+	Policy   string                  `json:"policy,omitempty"`
+	UI       UISettings              `json:"ui"`
+}
+
+// UISettings are preferences of the GUI, used where the profile is empty.
+// Fields:
+//   - Theme (string): ThemeLight or ThemeDark; empty to follow the system.
+//   - Density (string): DensityCompact, or empty for the normal layout.
+//   - Language (string): Locale of error messages, e.g. "de".
+//   - ClipboardClear (int): Seconds after which a copied password is
+//     cleared, as in Profile.
+type UISettings struct {
+	Theme          string `json:"theme,omitempty"`
+	Density        string `json:"density,omitempty"`
+	Language       string `json:"language,omitempty"`
+	ClipboardClear int    `json:"clipboard_clear,omitempty"`
+}
+
+// settingsOverrides are the environment variables that override a
+// setting, by the name after settingsEnvPrefix, e.g.
+// PASSGEN_LENGTH=20.
+var settingsOverrides = map[string]func(s *Settings, value string) error{
+	"LENGTH":   func(s *Settings, v string) error { return setInt(&s.Defaults.DefaultLength, v) },
+	"COUNT":    func(s *Settings, v string) error { return setInt(&s.Defaults.Quantity, v) },
+	"SYMBOLS":  func(s *Settings, v string) error { return setBool(&s.Defaults.IncludeSymbols, v) },
+	"NUMBERS":  func(s *Settings, v string) error { return setBool(&s.Defaults.IncludeNumbers, v) },
+	"UPPER":    func(s *Settings, v string) error { return setBool(&s.Defaults.IncludeUpper, v) },
+	"LOWER":    func(s *Settings, v string) error { return setBool(&s.Defaults.IncludeLower, v) },
+	"EXCLUDE":  func(s *Settings, v string) error { s.Defaults.ExcludeCharacters = v; return nil },
+	"POLICY":   func(s *Settings, v string) error { s.Policy = v; return nil },
+	"THEME":    func(s *Settings, v string) error { s.UI.Theme = v; return nil },
+	"DENSITY":  func(s *Settings, v string) error { s.UI.Density = v; return nil },
+	"LANGUAGE": func(s *Settings, v string) error { s.UI.Language = v; return nil },
+}
+
+// SettingsPath returns the settings file to read: the one SettingsEnv
+// names, else the first of config.yaml, config.yml, config.toml and
+// config.json in Dir that exists, else "".
+func SettingsPath() (string, error) {
+	if path := os.Getenv(SettingsEnv); path != "" {
+		return path, nil
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	for _, name := range settingsFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
+// DefaultSettings returns the settings used without a settings file.
+func DefaultSettings() Settings {
+	return Settings{Defaults: *GetDefaultOptions()}
+}
+
+// ParseSettings reads settings in the given format. Unknown fields are
+// refused, so that a typo does not silently keep a default.
+func ParseSettings(data []byte, format string) (Settings, error) {
+	s := DefaultSettings()
+	if err := decodeStrict(data, format, &s); err != nil {
+		return Settings{}, err
+	}
+	return s, nil
+}
+
+// decodeStrict decodes data in the given format into v, refusing unknown
+// fields. YAML and TOML are decoded through JSON, so that every format
+// accepts the same field names.
+func decodeStrict(data []byte, format string, v interface{}) error {
+	var tree interface{}
+	switch format {
+	case SettingsJSON:
+		tree = json.RawMessage(data)
+	case SettingsYAML:
+		if err := yaml.Unmarshal(data, &tree); err != nil {
+			return err
+		}
+	case SettingsTOML:
+		table := map[string]interface{}{}
+		if err := toml.Unmarshal(data, &table); err != nil {
+			return err
+		}
+		tree = table
+	default:
+		return fmt.Errorf("unknown settings format %q", format)
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// LoadSettings reads the settings file at path, in the format of its
+// extension, and applies the environment overrides. An empty path or a
+// missing file yields DefaultSettings with the overrides.
+func LoadSettings(path string) (Settings, error) {
+	s := DefaultSettings()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return s, err
+		default:
+			s, err = ParseSettings(data, settingsFormat(path))
+			if err != nil {
+				return DefaultSettings(), fmt.Errorf("%s: %w", path, err)
+			}
+		}
+	}
+	if err := s.ApplyEnv(os.LookupEnv); err != nil {
+		return s, err
+	}
+	return s, s.Validate()
+}
+
+// settingsFormat returns the format of a settings file by its extension;
+// files without a known one are read as JSON.
+func settingsFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return SettingsYAML
+	case ".toml":
+		return SettingsTOML
+	default:
+		return SettingsJSON
+	}
+}
+
+// ApplyEnv applies the overrides in settingsOverrides found by lookup,
+// such as os.LookupEnv.
+func (s *Settings) ApplyEnv(lookup func(string) (string, bool)) error {
+	for name, override := range settingsOverrides {
+		variable := settingsEnvPrefix + name
+		value, ok := lookup(variable)
+		if !ok {
+			continue
+		}
+		if err := override(s, value); err != nil {
+			return fmt.Errorf("%s: %w", variable, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the default options can generate passwords and the
+// theme and density are known.
+func (s Settings) Validate() error {
+	switch s.UI.Theme {
+	case "", ThemeLight, ThemeDark:
+	default:
+		return fmt.Errorf("unknown theme %q; use %s or %s", s.UI.Theme, ThemeLight, ThemeDark)
+	}
+	if s.UI.Density != "" && s.UI.Density != DensityCompact {
+		return fmt.Errorf("unknown density %q; use %s or leave it empty", s.UI.Density, DensityCompact)
+	}
+	opts := s.Defaults
+	opts.Length = opts.DefaultLength
+	if opts.Quantity < 1 {
+		return errors.New("defaults: Quantity must be at least 1")
+	}
+	if err := passgen.Validate(opts); err != nil {
+		return fmt.Errorf("defaults: %w", err)
+	}
+	return nil
+}
+
+// ApplyProfile fills the preferences p leaves empty from the settings.
+func (u UISettings) ApplyProfile(p *Profile) {
+	if p.Theme == "" {
+		p.Theme = u.Theme
+	}
+	if p.Density == "" {
+		p.Density = u.Density
+	}
+	if p.Language == "" {
+		p.Language = u.Language
+	}
+	if p.ClipboardClear == 0 {
+		p.ClipboardClear = u.ClipboardClear
+	}
+}
+
+// With returns the presets together with profiles, keeping p's preset
+// where both have the same name.
+func (p Presets) With(profiles Presets) Presets {
+	all := make(Presets, len(p)+len(profiles))
+	for name, preset := range profiles {
+		all[name] = preset
+	}
+	for name, preset := range p {
+		all[name] = preset
+	}
+	return all
+}
+
+// setInt parses value into *n.
+func setInt(n *int, value string) error {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", value)
+	}
+	*n = parsed
+	return nil
+}
+
+// setBool parses value, such as true, false, 1 or 0, into *b.
+func setBool(b *bool, value string) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("%q is not true or false", value)
+	}
+	*b = parsed
+	return nil
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestParseSettings_Formats verifies that JSON, YAML and TOML settings are
+// read with the same field names, and fields left out keep the defaults.
+func TestParseSettings_Formats(t *testing.T) {
+	files := map[string]string{
+		SettingsJSON: `{"defaults": {"DefaultLength": 20, "IncludeSymbols": false}, "policy": "pci-dss", "ui": {"theme": "dark"},
+			"profiles": {"Router WiFi": {"options": {"DefaultLength": 24, "Length": 24, "IncludeLower": true}}}}`,
+		SettingsYAML: `
+defaults:
+  DefaultLength: 20
+  IncludeSymbols: false
+policy: pci-dss
+ui:
+  theme: dark
+profiles:
+  Router WiFi:
+    options: {DefaultLength: 24, Length: 24, IncludeLower: true}
+`,
+		SettingsTOML: `
+policy = "pci-dss"
+
+[defaults]
+DefaultLength = 20
+IncludeSymbols = false
+
+[ui]
+theme = "dark"
+
+[profiles."Router WiFi".options]
+DefaultLength = 24
+Length = 24
+IncludeLower = true
+`,
+	}
+	for format, data := range files {
+		s, err := ParseSettings([]byte(data), format)
+		if err != nil {
+			t.Errorf("%s: Expected no error, but got %v", format, err)
+			continue
+		}
+		if s.Defaults.DefaultLength != 20 || s.Defaults.IncludeSymbols || !s.Defaults.IncludeNumbers {
+			t.Errorf("%s: Expected length 20 without symbols and the other defaults, but got %+v", format, s.Defaults)
+		}
+		if s.Policy != "pci-dss" || s.UI.Theme != ThemeDark {
+			t.Errorf("%s: Expected the policy and theme, but got %q and %q", format, s.Policy, s.UI.Theme)
+		}
+		if s.Profiles["Router WiFi"].Options.Length != 24 {
+			t.Errorf("%s: Expected the profile, but got %+v", format, s.Profiles)
+		}
+	}
+}
+
+// TestParseSettings_UnknownField verifies that a misspelt field is refused.
+func TestParseSettings_UnknownField(t *testing.T) {
+	if _, err := ParseSettings([]byte("defaults:\n  DefaultLenght: 20\n"), SettingsYAML); err == nil {
+		t.Error("Expected an error for an unknown field, but got none")
+	}
+}
+
+// TestSettings_ApplyEnv verifies that environment variables override the
+// file and that invalid values are reported with their variable.
+func TestSettings_ApplyEnv(t *testing.T) {
+	env := map[string]string{"PASSGEN_LENGTH": "18", "PASSGEN_SYMBOLS": "0", "PASSGEN_THEME": "light", "PASSGEN_DENSITY": "compact"}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+	s := DefaultSettings()
+	if err := s.ApplyEnv(lookup); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if s.Defaults.DefaultLength != 18 || s.Defaults.IncludeSymbols || s.UI.Theme != ThemeLight || s.UI.Density != DensityCompact {
+		t.Errorf("Expected the overrides, but got %+v and %+v", s.Defaults, s.UI)
+	}
+
+	env["PASSGEN_COUNT"] = "many"
+	if err := s.ApplyEnv(lookup); err == nil {
+		t.Error("Expected an error for a count that is not a number, but got none")
+	}
+}
+
+// TestLoadSettings_Validate verifies that a missing file yields the defaults
+// and that defaults which cannot generate passwords are refused.
+func TestLoadSettings_Validate(t *testing.T) {
+	dir := t.TempDir()
+	s, err := LoadSettings(filepath.Join(dir, "missing.yaml"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if s.Defaults != *GetDefaultOptions() {
+		t.Errorf("Expected the application defaults, but got %+v", s.Defaults)
+	}
+
+	path := filepath.Join(dir, "config.json")
+	data := `{"defaults": {"IncludeSymbols": false, "IncludeNumbers": false, "IncludeUpper": false, "IncludeLower": false}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSettings(path); err == nil {
+		t.Error("Expected an error for defaults without character types, but got none")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"ui": {"density": "tiny"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSettings(path); err == nil {
+		t.Error("Expected an error for an unknown density, but got none")
+	}
+}
+
+// TestPresets_With verifies that saved presets win over deployed profiles.
+func TestPresets_With(t *testing.T) {
+	opts := *GetDefaultOptions()
+	saved := Presets{"Work AD": {Options: opts, Pattern: "mine"}}
+	profiles := Presets{"Work AD": {Options: opts, Pattern: "deployed"}, "Throwaway": {Options: opts}}
+	all := saved.With(profiles)
+	if len(all) != 2 || all["Work AD"].Pattern != "mine" {
+		t.Errorf("Expected both presets with the saved Work AD, but got %+v", all)
+	}
+	if len(saved) != 1 {
+		t.Errorf("Expected the saved presets to be unchanged, but got %v", saved.Names())
+	}
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/siterules"
+)
+
+// sitesFileName is the file in Dir holding the options remembered per site.
+const sitesFileName = "sites.json"
+
+// SiteOptions maps a site, as normalized by siterules.NormalizeSite, to the
+// options last used to generate a password for it, so regenerating for the
+// same service meets its rules again.
+type SiteOptions map[string]passgen.PasswordOptions
+
+// SiteOptionsPath returns the location of the remembered site options.
+func SiteOptionsPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, sitesFileName), nil
+}
+
+// LoadSiteOptions reads the site options at path. A missing file yields an
+// empty map.
+func LoadSiteOptions(path string) (SiteOptions, error) {
+	sites := make(SiteOptions)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return sites, nil
+	}
+	if err != nil {
+		return sites, err
+	}
+	err = json.Unmarshal(data, &sites)
+	return sites, err
+}
+
+// SaveSiteOptions writes sites to path, readable only by the current user.
+func SaveSiteOptions(path string, sites SiteOptions) error {
+	data, err := json.MarshalIndent(sites, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Lookup returns the options remembered for site, given as a domain or URL.
+func (s SiteOptions) Lookup(site string) (passgen.PasswordOptions, bool) {
+	opts, ok := s[siterules.NormalizeSite(site)]
+	return opts, ok
+}
+
+// Remember records opts for site. The quantity is not remembered, as it is
+// a property of the request rather than of the site.
+func (s SiteOptions) Remember(site string, opts passgen.PasswordOptions) {
+	if domain := siterules.NormalizeSite(site); domain != "" {
+		opts.Quantity = 0
+		s[domain] = opts
+	}
+}
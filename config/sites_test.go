@@ -0,0 +1,37 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestSiteOptions_RememberAndLoad verifies that site options survive a save
+// and are found under any spelling of the site.
+func TestSiteOptions_RememberAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), sitesFileName)
+	sites, err := LoadSiteOptions(path)
+	if err != nil || len(sites) != 0 {
+		t.Fatalf("Expected no error and no sites, but got %v and %v", sites, err)
+	}
+	want := *GetDefaultOptions()
+	want.Length = 20
+	want.ExcludeCharacters = "<>"
+	want.Quantity = 5
+	sites.Remember("https://www.Example.com/login", want)
+	if err := SaveSiteOptions(path, sites); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	loaded, err := LoadSiteOptions(path)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	got, ok := loaded.Lookup("example.com")
+	want.Quantity = 0
+	if !ok || got != want {
+		t.Errorf("Expected %+v, but got %+v", want, got)
+	}
+	if _, ok := loaded.Lookup("example.org"); ok {
+		t.Errorf("Expected no options for another site")
+	}
+}
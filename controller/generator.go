@@ -9,8 +9,10 @@
 package controller
 
 import (
-	"password-generator/config"
-	"password-generator/model"
+	"context"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
 )
 
 // GeneratorController manages password generation requests.
@@ -18,8 +20,16 @@ import (
 //
 //	Manages and coordinates password generation requests from the view by
 //	interfacing with the password generation logic in the model.
+//
+// Generator, when set, generates everything the controller returns in place
+// of the package-level passgen functions, e.g. one built with
+// passgen.WithRand to draw from a hardware source. Its constraints, e.g. to
+// skip breached passwords, apply to passwords but not to patterns, PINs,
+// codes, usernames or keys.
 type GeneratorController struct {
-	Config *model.PasswordOptions
+	Config    *passgen.PasswordOptions
+	PINConfig *passgen.PINOptions
+	Generator *passgen.Generator
 }
 
 // NewGeneratorController initializes the controller with default options.
@@ -36,22 +46,220 @@ type GeneratorController struct {
 //	ctrl := NewGeneratorController()
 func NewGeneratorController() *GeneratorController {
 	return &GeneratorController{
-		Config: config.GetDefaultOptions(),
+		Config:    config.GetDefaultOptions(),
+		PINConfig: config.GetDefaultPINOptions(),
 	}
 }
 
 // GeneratePasswords generates a list of passwords based on the options provided.
 // Parameters:
-//   - opts (model.PasswordOptions): The settings used to customize password generation.
+//   - ctx (context.Context): Cancels the generation, e.g. from a Cancel button.
+//   - opts (passgen.PasswordOptions): The settings used to customize password generation.
 //
 // Returns:
 //
 //	[]string: A list of generated passwords based on the quantity specified in opts.
+//	error: A *ValidationError if Validate rejects opts, or an error if password
+//	generation fails or ctx is done.
+//
+// Example:
+//
+//	passwords, err := ctrl.GeneratePasswords(ctx, opts)
+func (gc *GeneratorController) GeneratePasswords(ctx context.Context, opts passgen.PasswordOptions) ([]string, error) {
+	if err := gc.Validate(opts); err != nil {
+		return nil, err
+	}
+	return gc.generator().GeneratePasswords(ctx, opts)
+}
+
+// generator returns Generator, or a Generator reading crypto/rand.Reader
+// when none is set.
+func (gc *GeneratorController) generator() *passgen.Generator {
+	if gc.Generator != nil {
+		return gc.Generator
+	}
+	return passgen.NewGenerator()
+}
+
+// SetConstraints makes the controller generate again every password that
+// fails one of the constraints. Every call replaces all constraints set
+// before, so callers pass every constraint in force together, e.g. those of
+// the breach list, the policy and the site; none drops them all. A random
+// source given to Generator with passgen.WithRand is kept.
+// Example:
+//
+//	ctrl.SetConstraints(list.Constraint(), policy.Constraint())
+func (gc *GeneratorController) SetConstraints(constraints ...passgen.Constraint) {
+	gc.Generator = gc.generator().With(passgen.WithoutConstraints(), passgen.WithConstraints(constraints...))
+}
+
+// GenerateStream delivers the passwords on a channel as they are generated,
+// for batches too large to hold in memory, once Validate accepts opts; see
+// passgen.GenerateStream.
+// Example:
+//
+//	results, err := ctrl.GenerateStream(ctx, opts)
+func (gc *GeneratorController) GenerateStream(ctx context.Context, opts passgen.PasswordOptions) (<-chan passgen.Result, error) {
+	if err := gc.Validate(opts); err != nil {
+		return nil, err
+	}
+	return gc.generator().GenerateStream(ctx, opts)
+}
+
+// Options returns the stored Config with overrides applied. The stored Config
+// is not modified, and Length falls back to DefaultLength when unset.
+func (gc *GeneratorController) Options(overrides Overrides) passgen.PasswordOptions {
+	opts := *gc.Config
+	if opts.Length == 0 {
+		opts.Length = opts.DefaultLength
+	}
+	return overrides.Apply(opts)
+}
+
+// GenerateWithDefaults generates passwords from the stored Config, changing
+// only the fields set in overrides.
+// Parameters:
+//   - ctx (context.Context): Cancels the generation.
+//   - overrides (Overrides): The fields to change for this request only.
+//
+// Returns:
+//
+//	[]string: The generated passwords.
 //	error: Returns an error if password generation fails due to invalid options.
 //
 // Example:
 //
-//	passwords, err := ctrl.GeneratePasswords(opts)
-func (gc *GeneratorController) GeneratePasswords(opts model.PasswordOptions) ([]string, error) {
-	return model.GeneratePasswords(opts)
+//	passwords, err := ctrl.GenerateWithDefaults(ctx, controller.Overrides{Length: controller.Int(20)})
+func (gc *GeneratorController) GenerateWithDefaults(ctx context.Context, overrides Overrides) ([]string, error) {
+	return gc.GeneratePasswords(ctx, gc.Options(overrides))
+}
+
+// GeneratePINs generates numeric PINs, skipping trivially weak ones.
+// Parameters:
+//   - ctx (context.Context): Cancels the generation.
+//   - opts (passgen.PINOptions): The length and quantity of the PINs.
+//
+// Returns:
+//
+//	[]string: The generated PINs.
+//	error: Returns an error if the length is out of range.
+//
+// Example:
+//
+//	pins, err := ctrl.GeneratePINs(ctx, passgen.PINOptions{Length: 6, Quantity: 1})
+func (gc *GeneratorController) GeneratePINs(ctx context.Context, opts passgen.PINOptions) ([]string, error) {
+	return gc.generator().GeneratePINs(ctx, opts)
+}
+
+// GenerateRecoveryCodes generates a set of one-time recovery codes.
+// Parameters:
+//   - ctx (context.Context): Cancels the generation.
+//   - opts (passgen.RecoveryOptions): The number and format of the codes.
+//
+// Returns:
+//
+//	[]string: The distinct codes of the set.
+//	error: Returns an error if the number or format is out of range.
+//
+// Example:
+//
+//	codes, err := ctrl.GenerateRecoveryCodes(ctx, passgen.DefaultRecoveryOptions())
+func (gc *GeneratorController) GenerateRecoveryCodes(ctx context.Context, opts passgen.RecoveryOptions) ([]string, error) {
+	return gc.generator().GenerateRecoveryCodes(ctx, opts)
+}
+
+// GenerateUsernames generates usernames from adjectives, nouns and digits.
+// Parameters:
+//   - ctx (context.Context): Cancels the generation.
+//   - opts (passgen.UsernameOptions): The format and quantity of the usernames.
+//
+// Returns:
+//
+//	[]string: The usernames, without repeats.
+//	error: Returns an error if an option is out of range.
+//
+// Example:
+//
+//	names, err := ctrl.GenerateUsernames(ctx, passgen.DefaultUsernameOptions())
+func (gc *GeneratorController) GenerateUsernames(ctx context.Context, opts passgen.UsernameOptions) ([]string, error) {
+	return gc.generator().GenerateUsernames(ctx, opts)
+}
+
+// GenerateTokens generates quantity random keys of size bytes each.
+// Parameters:
+//   - ctx (context.Context): Cancels the generation between two keys.
+//   - size (int): Number of random bytes per key.
+//   - encoding (string): One of passgen.Encodings.
+//   - quantity (int): Number of keys to generate.
+//
+// Returns:
+//
+//	[]string: The encoded keys.
+//	error: Returns an error if the size or encoding is invalid, or ctx is done.
+//
+// Example:
+//
+//	keys, err := ctrl.GenerateTokens(ctx, 32, passgen.EncodingHex, 1)
+func (gc *GeneratorController) GenerateTokens(ctx context.Context, size int, encoding string, quantity int) ([]string, error) {
+	g := gc.generator()
+	var keys []string
+	for i := 0; i < quantity; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		key, err := g.GenerateToken(size, encoding)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// GenerateAPIKeys generates quantity prefixed API keys.
+// Parameters:
+//   - ctx (context.Context): Cancels the generation between two keys.
+//   - opts (passgen.APIKeyOptions): The prefix, length and checksum of the keys.
+//   - quantity (int): Number of keys to generate.
+//
+// Returns:
+//
+//	[]string: The keys.
+//	error: Returns an error if the prefix or length is invalid, or ctx is done.
+//
+// Example:
+//
+//	keys, err := ctrl.GenerateAPIKeys(ctx, passgen.APIKeyOptions{Prefix: "sk_live_", Length: 32}, 1)
+func (gc *GeneratorController) GenerateAPIKeys(ctx context.Context, opts passgen.APIKeyOptions, quantity int) ([]string, error) {
+	g := gc.generator()
+	var keys []string
+	for i := 0; i < quantity; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		key, err := g.GenerateAPIKey(opts)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// GeneratePatternPasswords generates passwords from a pattern template.
+// Parameters:
+//   - ctx (context.Context): Cancels the generation.
+//   - pattern (string): The template, e.g. "Cvcvc-99-!!"; see passgen.PatternPlaceholders.
+//   - opts (passgen.PasswordOptions): Supplies the quantity and the exclusions.
+//
+// Returns:
+//
+//	[]string: The generated passwords.
+//	error: Returns an error if the pattern is invalid.
+//
+// Example:
+//
+//	passwords, err := ctrl.GeneratePatternPasswords(ctx, "Cvcvc-99-!!", opts)
+func (gc *GeneratorController) GeneratePatternPasswords(ctx context.Context, pattern string, opts passgen.PasswordOptions) ([]string, error) {
+	return gc.generator().GeneratePatternPasswords(ctx, pattern, opts)
 }
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// TestGenerateWithDefaults verifies that overrides change only the given fields.
+func TestGenerateWithDefaults(t *testing.T) {
+	ctrl := NewGeneratorController()
+	passwords, err := ctrl.GenerateWithDefaults(context.Background(), Overrides{
+		Length:         Int(20),
+		Quantity:       Int(3),
+		IncludeSymbols: Bool(false),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(passwords) != 3 {
+		t.Errorf("Expected 3 passwords, but got %d", len(passwords))
+	}
+	for _, password := range passwords {
+		if len(password) != 20 {
+			t.Errorf("Expected password length of 20, but got %d", len(password))
+		}
+		if strings.ContainsAny(password, "!@#$%^&*()-_=+[]{}|;:,.<>/?") {
+			t.Errorf("Expected no symbols, but got %s", password)
+		}
+	}
+	if !ctrl.Config.IncludeSymbols {
+		t.Errorf("Expected the stored Config to be unchanged")
+	}
+}
+
+// TestOptions_Defaults verifies that no overrides yield the stored defaults.
+func TestOptions_Defaults(t *testing.T) {
+	ctrl := NewGeneratorController()
+	opts := ctrl.Options(Overrides{})
+	if opts.Length != ctrl.Config.DefaultLength || opts.IncludeLower != ctrl.Config.IncludeLower {
+		t.Errorf("Expected the stored defaults, but got %+v", opts)
+	}
+}
+
+// countingReader counts the reads from crypto/rand.
+type countingReader struct{ reads int }
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	r.reads++
+	return rand.Read(p)
+}
+
+// TestSetConstraints verifies that constraints replace each other and keep
+// the random source of the Generator.
+func TestSetConstraints(t *testing.T) {
+	source := &countingReader{}
+	ctrl := NewGeneratorController()
+	ctrl.Generator = passgen.NewGenerator(passgen.WithRand(source))
+	ctrl.SetConstraints(passgen.MinDigits(2))
+	ctrl.SetConstraints(passgen.MinDigits(3))
+	if c := ctrl.Generator.Constraints(); len(c) != 1 {
+		t.Fatalf("Expected the constraints to be replaced, but got %d", len(c))
+	}
+	opts := passgen.PasswordOptions{Length: 8, Quantity: 5, IncludeLower: true, IncludeNumbers: true}
+	passwords, err := ctrl.GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, password := range passwords {
+		if !passgen.MinDigits(3).Check([]byte(password)) {
+			t.Errorf("Expected at least 3 digits, but got %s", password)
+		}
+	}
+
+	ctrl.SetConstraints()
+	reads := source.reads
+	if _, err := ctrl.GeneratePasswords(context.Background(), opts); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if reads == 0 || source.reads == reads {
+		t.Error("Expected the injected random source to be kept, but it was not read")
+	}
+	if c := ctrl.Generator.Constraints(); len(c) != 0 {
+		t.Errorf("Expected no constraints, but got %d", len(c))
+	}
+}
+
+// TestGenerator_AllModes verifies that every mode draws from the random
+// source of the Generator.
+func TestGenerator_AllModes(t *testing.T) {
+	ctx := context.Background()
+	modes := map[string]func(*GeneratorController) error{
+		"pins": func(c *GeneratorController) error {
+			_, err := c.GeneratePINs(ctx, passgen.PINOptions{Length: 6, Quantity: 1})
+			return err
+		},
+		"recovery codes": func(c *GeneratorController) error {
+			_, err := c.GenerateRecoveryCodes(ctx, passgen.DefaultRecoveryOptions())
+			return err
+		},
+		"usernames": func(c *GeneratorController) error {
+			_, err := c.GenerateUsernames(ctx, passgen.DefaultUsernameOptions())
+			return err
+		},
+		"tokens": func(c *GeneratorController) error {
+			_, err := c.GenerateTokens(ctx, 16, passgen.EncodingHex, 1)
+			return err
+		},
+		"api keys": func(c *GeneratorController) error {
+			_, err := c.GenerateAPIKeys(ctx, passgen.APIKeyOptions{Prefix: "sk_", Length: 32}, 1)
+			return err
+		},
+		"patterns": func(c *GeneratorController) error {
+			_, err := c.GeneratePatternPasswords(ctx, "Cvcvc-99", passgen.PasswordOptions{Quantity: 1})
+			return err
+		},
+	}
+	for name, generate := range modes {
+		source := &countingReader{}
+		ctrl := NewGeneratorController()
+		ctrl.Generator = passgen.NewGenerator(passgen.WithRand(source))
+		if err := generate(ctrl); err != nil {
+			t.Errorf("Expected no error for %s, but got %v", name, err)
+		}
+		if source.reads == 0 {
+			t.Errorf("Expected %s to read the injected source, but it was not read", name)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package controller
+
+import "github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+// Overrides holds partial changes to the controller's stored options. Nil
+// fields keep the stored value; use Int, Bool and String to set them inline.
+type Overrides struct {
+	Length            *int
+	Quantity          *int
+	IncludeSymbols    *bool
+	IncludeNumbers    *bool
+	IncludeUpper      *bool
+	IncludeLower      *bool
+	BeginWithLetter   *bool
+	NoSimilar         *bool
+	NoDuplicates      *bool
+	NoSequential      *bool
+	AlternateHands    *bool
+	KeyboardLayout    *string
+	ExcludeCharacters *string
+	MustInclude       *string
+}
+
+// Int returns a pointer to v, for setting an Overrides field.
+func Int(v int) *int { return &v }
+
+// Bool returns a pointer to v, for setting an Overrides field.
+func Bool(v bool) *bool { return &v }
+
+// String returns a pointer to v, for setting an Overrides field.
+func String(v string) *string { return &v }
+
+// Apply returns opts with every non-nil override set.
+func (o Overrides) Apply(opts passgen.PasswordOptions) passgen.PasswordOptions {
+	set(&opts.Length, o.Length)
+	set(&opts.Quantity, o.Quantity)
+	set(&opts.IncludeSymbols, o.IncludeSymbols)
+	set(&opts.IncludeNumbers, o.IncludeNumbers)
+	set(&opts.IncludeUpper, o.IncludeUpper)
+	set(&opts.IncludeLower, o.IncludeLower)
+	set(&opts.BeginWithLetter, o.BeginWithLetter)
+	set(&opts.NoSimilar, o.NoSimilar)
+	set(&opts.NoDuplicates, o.NoDuplicates)
+	set(&opts.NoSequential, o.NoSequential)
+	set(&opts.AlternateHands, o.AlternateHands)
+	set(&opts.KeyboardLayout, o.KeyboardLayout)
+	set(&opts.ExcludeCharacters, o.ExcludeCharacters)
+	set(&opts.MustInclude, o.MustInclude)
+	return opts
+}
+
+// set assigns *v to *dst when v is not nil.
+func set[T any](dst *T, v *T) {
+	if v != nil {
+		*dst = *v
+	}
+}
@@ -0,0 +1,69 @@
+package controller
+
+import "github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+// ValidationError is an option combination that Validate rejects.
+// Fields:
+//   - Option (string): The PasswordOptions field to correct, e.g. "Length"
+//     or "BeginWithLetter", so front ends can show the message next to
+//     its control.
+//   - Err (error): The *passgen.Error explaining the problem; Localize and
+//     errors.Is see it through the ValidationError.
+type ValidationError struct {
+	Option string
+	Err    error
+}
+
+func (e *ValidationError) Error() string { return e.Err.Error() }
+
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// Validate rejects options that cannot produce a password, before any
+// generation starts.
+// Purpose:
+//
+//	Checks opts against passgen.OptionRules, such as BeginWithLetter
+//	without letters or NoDuplicates with a length above the number of
+//	characters, and checks that Length lies within MinLength and MaxLength.
+//	Limits that opts leave at 0 are taken from the controller's Config.
+//
+// Parameters:
+//   - opts (passgen.PasswordOptions): The options to check.
+//
+// Returns:
+//
+//	error: A *ValidationError naming the option to correct, or nil.
+//
+// Example:
+//
+//	var invalid *controller.ValidationError
+//	if errors.As(ctrl.Validate(opts), &invalid) {
+//		showNextTo(invalid.Option, invalid.Err)
+//	}
+func (gc *GeneratorController) Validate(opts passgen.PasswordOptions) error {
+	for _, rule := range passgen.OptionRules {
+		if !rule.Violated(opts) {
+			continue
+		}
+		err := &passgen.Error{Code: rule.Code}
+		if rule.Args != nil {
+			err.Args = rule.Args(opts)
+		}
+		return &ValidationError{Option: rule.Option, Err: err}
+	}
+
+	min, max := opts.MinLength, opts.MaxLength
+	if min == 0 {
+		min = gc.Config.MinLength
+	}
+	if max == 0 {
+		max = gc.Config.MaxLength
+	}
+	if opts.Length < min || (max > 0 && opts.Length > max) {
+		return &ValidationError{
+			Option: "Length",
+			Err:    &passgen.Error{Code: passgen.CodeLengthOutOfRange, Args: []interface{}{min, max}},
+		}
+	}
+	return nil
+}
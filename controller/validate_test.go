@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// TestValidate verifies that impossible combinations name the option to fix.
+func TestValidate(t *testing.T) {
+	ctrl := NewGeneratorController()
+	tests := []struct {
+		name   string
+		opts   passgen.PasswordOptions
+		option string
+		code   passgen.Code
+	}{
+		{"no letters to begin with", passgen.PasswordOptions{Length: 8, IncludeNumbers: true, BeginWithLetter: true}, "BeginWithLetter", passgen.CodeBeginNeedsLetters},
+		{"longer than the digits", passgen.PasswordOptions{Length: 12, IncludeNumbers: true, NoDuplicates: true}, "Length", passgen.CodeLengthExceedsUnique},
+		{"above the maximum", passgen.PasswordOptions{Length: 40, IncludeLower: true}, "Length", passgen.CodeLengthOutOfRange},
+		{"below the minimum", passgen.PasswordOptions{Length: 4, IncludeLower: true}, "Length", passgen.CodeLengthOutOfRange},
+	}
+	for _, test := range tests {
+		var invalid *ValidationError
+		err := ctrl.Validate(test.opts)
+		if !errors.As(err, &invalid) {
+			t.Errorf("%s: Expected a *ValidationError, but got %v", test.name, err)
+			continue
+		}
+		if invalid.Option != test.option || passgen.ErrorCode(err) != test.code {
+			t.Errorf("%s: Expected %s on %s, but got %s on %s", test.name, test.code, test.option, passgen.ErrorCode(err), invalid.Option)
+		}
+	}
+
+	if err := ctrl.Validate(passgen.PasswordOptions{Length: 40, MaxLength: 128, IncludeLower: true}); err != nil {
+		t.Errorf("Expected the options' own MaxLength to apply, but got %v", err)
+	}
+}
+
+// TestGeneratePasswords_Validated verifies that generation is refused before
+// it starts when Validate fails.
+func TestGeneratePasswords_Validated(t *testing.T) {
+	ctrl := NewGeneratorController()
+	opts := passgen.PasswordOptions{Length: 8, Quantity: 1, IncludeNumbers: true, BeginWithLetter: true}
+	if _, err := ctrl.GeneratePasswords(context.Background(), opts); !errors.Is(err, passgen.ErrInfeasibleConstraints) {
+		t.Errorf("Expected %v, but got %v", passgen.ErrInfeasibleConstraints, err)
+	}
+}
@@ -0,0 +1,140 @@
+/**
+ * Password Generator - Crash Reports
+ *
+ * This file builds local crash reports when the application panics. A report
+ * carries the panic value, the stack trace, platform details, and the password
+ * options in effect. Generated passwords are never part of a report, so a
+ * report can be attached to a public issue without leaking secrets.
+ */
+
+package crashreport
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/version"
+)
+
+// IssueTrackerURL is the page used to open a new, pre-filled GitHub issue.
+const IssueTrackerURL = version.IssuesURL + "/new"
+
+// maxIssueStack caps how much of the stack trace is embedded in an issue URL,
+// keeping the link below the length browsers and GitHub accept.
+const maxIssueStack = 4000
+
+// Report describes a single crash.
+// Purpose:
+//
+//	Collects the non-secret context needed to diagnose a panic.
+//
+// Fields:
+//   - Time (time.Time): When the panic was recovered.
+//   - Panic (string): The recovered panic value.
+//   - Stack (string): The goroutine stack trace at the point of recovery.
+//   - Version (string): The application version and commit.
+//   - GoVersion, OS, Arch (string): Runtime and platform details.
+//   - Options (*passgen.PasswordOptions): The options in effect, if known.
+type Report struct {
+	Time      time.Time
+	Panic     string
+	Stack     string
+	Version   string
+	GoVersion string
+	OS        string
+	Arch      string
+	Options   *passgen.PasswordOptions
+}
+
+// New creates a report for a recovered panic.
+// Parameters:
+//   - recovered (any): The value returned by recover().
+//   - stack ([]byte): The stack trace, usually from debug.Stack().
+//   - opts (*passgen.PasswordOptions): The options in effect, or nil.
+//
+// Returns:
+//
+//	*Report: The populated crash report.
+//
+// Example:
+//
+//	report := crashreport.New(r, debug.Stack(), &opts)
+func New(recovered any, stack []byte, opts *passgen.PasswordOptions) *Report {
+	return &Report{
+		Time:      time.Now(),
+		Panic:     fmt.Sprint(recovered),
+		Stack:     string(stack),
+		Version:   version.String(),
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Options:   opts,
+	}
+}
+
+// String renders the report as plain text.
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Time: %s\n", r.Time.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Panic: %s\n", r.Panic)
+	fmt.Fprintf(&b, "Version: %s\n", r.Version)
+	fmt.Fprintf(&b, "Go: %s (%s/%s)\n", r.GoVersion, r.OS, r.Arch)
+	if r.Options != nil {
+		fmt.Fprintf(&b, "Options: %+v\n", *r.Options)
+	}
+	fmt.Fprintf(&b, "\n%s", r.Stack)
+	return b.String()
+}
+
+// Write stores the report as a text file inside dir.
+// Parameters:
+//   - dir (string): The directory that receives the report.
+//
+// Returns:
+//
+//	string: The path of the written report.
+//	error: An error if the directory or file could not be written.
+func (r *Report) Write(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("crash-%s.txt", r.Time.Format("20060102-150405"))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(r.String()), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// IssueURL returns a link that opens a new GitHub issue pre-filled with the report.
+// Purpose:
+//
+//	Lets the user decide to share the crash; nothing is sent automatically.
+//
+// Returns:
+//
+//	string: The issue URL with title and body query parameters.
+func (r *Report) IssueURL() string {
+	report := *r
+	if len(report.Stack) > maxIssueStack {
+		report.Stack = report.Stack[:maxIssueStack] + "\n... (truncated)"
+	}
+	query := url.Values{}
+	query.Set("title", "Crash: "+firstLine(r.Panic))
+	query.Set("body", "```\n"+report.String()+"\n```\n")
+	return IssueTrackerURL + "?" + query.Encode()
+}
+
+// firstLine returns s up to its first line break.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,46 @@
+package crashreport
+
+import (
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// TestReport_Write verifies that a report is written with the panic and options.
+func TestReport_Write(t *testing.T) {
+	opts := passgen.PasswordOptions{Length: 12, IncludeLower: true}
+	report := New("boom", []byte("goroutine 1 [running]:"), &opts)
+
+	path, err := report.Write(t.TempDir())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected report to be readable, but got %v", err)
+	}
+	for _, want := range []string{"Panic: boom", "Length:12", "goroutine 1"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected report to contain %q, but got:\n%s", want, data)
+		}
+	}
+}
+
+// TestReport_IssueURLTruncatesStack verifies that long stacks are cut from issue links.
+func TestReport_IssueURLTruncatesStack(t *testing.T) {
+	report := New("boom\nsecond line", []byte(strings.Repeat("x", maxIssueStack*2)), nil)
+
+	link, err := url.Parse(report.IssueURL())
+	if err != nil {
+		t.Fatalf("Expected a valid URL, but got %v", err)
+	}
+	if title := link.Query().Get("title"); title != "Crash: boom" {
+		t.Errorf("Expected title %q, but got %q", "Crash: boom", title)
+	}
+	if body := link.Query().Get("body"); !strings.Contains(body, "(truncated)") {
+		t.Errorf("Expected truncated stack in issue body")
+	}
+}
@@ -0,0 +1,158 @@
+/**
+ * Password Generator - Decoy Passwords
+ *
+ * This file generates honeytoken passwords for honeypot accounts and canary
+ * documents. Decoys look like typical human choices, a capitalised word, four
+ * digits and a symbol (e.g. "Sunshine4817!"), but the trailing digits are a
+ * keyed HMAC tag. Only someone holding the key can tell a decoy from a real
+ * password, so a decoy showing up in a login attempt or a leak reveals where
+ * it was planted.
+ */
+
+package decoy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+//go:embed words.txt
+var wordList []byte
+
+// words holds the bundled list of common password words.
+var words = func() []string {
+	var list []string
+	scanner := bufio.NewScanner(bytes.NewReader(wordList))
+	for scanner.Scan() {
+		if word := strings.TrimSpace(scanner.Text()); word != "" {
+			list = append(list, word)
+		}
+	}
+	return list
+}()
+
+// Symbols holds the trailing symbols people typically add to passwords.
+const Symbols = "!@#$%&*?"
+
+// digitCount is the number of digits in every decoy.
+const digitCount = 4
+
+// DefaultTagDigits is the number of tag digits used when Generator.TagDigits is 0.
+// With three tag digits a random password of the same shape is mistaken for a
+// decoy one time in a thousand.
+const DefaultTagDigits = 3
+
+// decoyPattern matches the shape of a decoy password.
+var decoyPattern = regexp.MustCompile(`^([A-Z][a-z]+)([0-9]{4})([` + regexp.QuoteMeta(Symbols) + `])$`)
+
+// Generator creates and recognises decoys tagged with a secret key.
+// Fields:
+//   - Key ([]byte): The defender's secret; at least 16 bytes.
+//   - TagDigits (int): How many of the four digits carry the tag (1-4).
+type Generator struct {
+	Key       []byte
+	TagDigits int
+}
+
+// Generate returns a new decoy password.
+// Purpose:
+//
+//	Picks a random word, symbol and untagged digits, then derives the tag
+//	digits from them with HMAC-SHA256 under the key.
+//
+// Returns:
+//
+//	string: The decoy password.
+//	error: An error if the key is too short or randomness fails.
+//
+// Example:
+//
+//	g := decoy.Generator{Key: key}
+//	password, err := g.Generate()
+func (g Generator) Generate() (string, error) {
+	tagDigits, err := g.validate()
+	if err != nil {
+		return "", err
+	}
+	word, err := randomElement(len(words))
+	if err != nil {
+		return "", err
+	}
+	symbol, err := randomElement(len(Symbols))
+	if err != nil {
+		return "", err
+	}
+	var salt strings.Builder
+	for i := 0; i < digitCount-tagDigits; i++ {
+		digit, err := randomElement(10)
+		if err != nil {
+			return "", err
+		}
+		salt.WriteByte(byte('0' + digit))
+	}
+
+	capitalised := strings.ToUpper(words[word][:1]) + words[word][1:]
+	sym := string(Symbols[symbol])
+	return capitalised + salt.String() + g.tag(capitalised, salt.String(), sym, tagDigits) + sym, nil
+}
+
+// IsDecoy reports whether password was generated with this key.
+func (g Generator) IsDecoy(password string) bool {
+	tagDigits, err := g.validate()
+	if err != nil {
+		return false
+	}
+	match := decoyPattern.FindStringSubmatch(password)
+	if match == nil {
+		return false
+	}
+	word, digits, symbol := match[1], match[2], match[3]
+	salt, tag := digits[:digitCount-tagDigits], digits[digitCount-tagDigits:]
+	return hmac.Equal([]byte(tag), []byte(g.tag(word, salt, symbol, tagDigits)))
+}
+
+// validate checks the key and returns the effective number of tag digits.
+func (g Generator) validate() (int, error) {
+	if len(g.Key) < 16 {
+		return 0, errors.New("decoy key must be at least 16 bytes")
+	}
+	tagDigits := g.TagDigits
+	if tagDigits == 0 {
+		tagDigits = DefaultTagDigits
+	}
+	if tagDigits < 1 || tagDigits > digitCount {
+		return 0, fmt.Errorf("tag digits must be between 1 and %d", digitCount)
+	}
+	return tagDigits, nil
+}
+
+// tag derives the tag digits for the visible parts of a decoy.
+func (g Generator) tag(word, salt, symbol string, tagDigits int) string {
+	mac := hmac.New(sha256.New, g.Key)
+	mac.Write([]byte(strings.ToLower(word) + "\x00" + salt + "\x00" + symbol))
+	sum := binary.BigEndian.Uint64(mac.Sum(nil))
+	modulus := uint64(1)
+	for i := 0; i < tagDigits; i++ {
+		modulus *= 10
+	}
+	return fmt.Sprintf("%0*d", tagDigits, sum%modulus)
+}
+
+// randomElement returns a uniformly random index below n.
+func randomElement(n int) (int, error) {
+	index, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, errors.New("failed to generate secure random index")
+	}
+	return int(index.Int64()), nil
+}
@@ -0,0 +1,53 @@
+package decoy
+
+import (
+	"strings"
+	"testing"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+// TestGenerate_RecognisedWithKey checks the shape of decoys and that they are
+// recognised with the right key only.
+func TestGenerate_RecognisedWithKey(t *testing.T) {
+	g := Generator{Key: testKey}
+	other := Generator{Key: []byte("fedcba9876543210fedcba9876543210")}
+
+	misses := 0
+	for i := 0; i < 50; i++ {
+		password, err := g.Generate()
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if !decoyPattern.MatchString(password) {
+			t.Errorf("Decoy %q does not have the expected shape", password)
+		}
+		if !g.IsDecoy(password) {
+			t.Errorf("Expected %q to be recognised as a decoy", password)
+		}
+		if other.IsDecoy(password) {
+			misses++
+		}
+	}
+	if misses > 5 {
+		t.Errorf("Expected a different key to reject nearly all decoys, but %d of 50 matched", misses)
+	}
+}
+
+// TestIsDecoy_RejectsAlteredPasswords ensures edits break the tag.
+func TestIsDecoy_RejectsAlteredPasswords(t *testing.T) {
+	g := Generator{Key: testKey, TagDigits: 4}
+	password, _ := g.Generate()
+	digit := password[len(password)-2]
+	altered := password[:len(password)-2] + string('0'+(digit-'0'+1)%10) + password[len(password)-1:]
+	if g.IsDecoy(altered) || g.IsDecoy(strings.ToLower(password)) {
+		t.Errorf("Expected altered passwords to be rejected")
+	}
+}
+
+// TestGenerate_ShortKey rejects keys that are too short to be secret.
+func TestGenerate_ShortKey(t *testing.T) {
+	if _, err := (Generator{Key: []byte("short")}).Generate(); err == nil {
+		t.Errorf("Expected an error for a short key, but got nil")
+	}
+}
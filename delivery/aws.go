@@ -0,0 +1,185 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// awsTimeout bounds a single AWS API call.
+const awsTimeout = 30 * time.Second
+
+// awsNotFound is the error type AWS returns for a missing secret.
+const awsNotFound = "ResourceNotFoundException"
+
+// AWSTarget creates or updates an AWS Secrets Manager secret, or an SSM
+// Parameter Store SecureString parameter, with the secret. Requests are
+// signed with Signature Version 4 using LoadAWSCredentials.
+// Fields:
+//   - Type (string): TypeAWSSecrets or TypeAWSSSM.
+//   - Name (string): The secret or parameter name; the Secret's name when empty.
+//   - Region (string): The AWS region; AWSRegion when empty.
+//   - Endpoint (string): Overrides https://<service>.<region>.amazonaws.com,
+//     e.g. for a VPC endpoint.
+//   - Credentials (*AWSCredentials): Used instead of LoadAWSCredentials
+//     when set.
+//   - Client (*http.Client): The HTTP client; http.DefaultClient when nil.
+type AWSTarget struct {
+	Type        string
+	Name        string
+	Region      string
+	Endpoint    string
+	Credentials *AWSCredentials
+	Client      *http.Client
+}
+
+// awsError is the error body of the AWS JSON protocol.
+type awsError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+	Upper   string `json:"Message"`
+}
+
+// Deliver stores the secret. A Secrets Manager secret gets a new version,
+// or is created if it does not exist yet; an SSM parameter is created or
+// overwritten.
+// Parameters:
+//   - ctx (context.Context): Cancels the requests.
+//   - secret (Secret): The secret to store.
+//
+// Returns:
+//
+//	error: An error if no credentials or region are found, or AWS refuses
+//	the request.
+func (t AWSTarget) Deliver(ctx context.Context, secret Secret) error {
+	name := t.Name
+	if name == "" {
+		name = secret.Name
+	}
+	if t.Type == TypeAWSSSM {
+		return t.call(ctx, "ssm", "AmazonSSM.PutParameter", map[string]interface{}{
+			"Name": name, "Value": secret.Value, "Type": "SecureString", "Overwrite": true,
+		})
+	}
+	token, err := requestToken()
+	if err != nil {
+		return err
+	}
+	err = t.call(ctx, "secretsmanager", "secretsmanager.PutSecretValue", map[string]interface{}{
+		"SecretId": name, "SecretString": secret.Value, "ClientRequestToken": token,
+	})
+	var apiErr *awsAPIError
+	if errors.As(err, &apiErr) && apiErr.Type == awsNotFound {
+		return t.call(ctx, "secretsmanager", "secretsmanager.CreateSecret", map[string]interface{}{
+			"Name": name, "SecretString": secret.Value, "ClientRequestToken": token,
+			"Description": "Generated by Password Generator",
+		})
+	}
+	return err
+}
+
+// String describes the target.
+func (t AWSTarget) String() string {
+	if t.Region != "" {
+		return t.Type + ":" + t.Name + " (" + t.Region + ")"
+	}
+	return t.Type + ":" + t.Name
+}
+
+// awsAPIError is an error answered by an AWS API.
+type awsAPIError struct {
+	Action  string
+	Type    string
+	Message string
+}
+
+// Error returns the action, type and message.
+func (e *awsAPIError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.Action, e.Type, e.Message)
+}
+
+// call makes one signed JSON request of action to service.
+func (t AWSTarget) call(ctx context.Context, service, action string, input interface{}) error {
+	region := t.Region
+	if region == "" {
+		var err error
+		if region, err = AWSRegion(); err != nil {
+			return err
+		}
+	}
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	creds := t.Credentials
+	if creds == nil {
+		loaded, err := LoadAWSCredentials(ctx, client)
+		if err != nil {
+			return err
+		}
+		creds = &loaded
+	}
+	endpoint := t.Endpoint
+	if endpoint == "" {
+		endpoint = "https://" + service + "." + region + ".amazonaws.com"
+		if strings.HasPrefix(region, "cn-") {
+			endpoint += ".cn"
+		}
+	}
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, awsTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	request.Header.Set("X-Amz-Target", action)
+	signAWS(request, body, *creds, region, service, time.Now())
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	answer, _ := io.ReadAll(io.LimitReader(response.Body, 1<<16))
+	if response.StatusCode >= 200 && response.StatusCode <= 299 {
+		return nil
+	}
+	var failure awsError
+	_ = json.Unmarshal(answer, &failure)
+	apiErr := &awsAPIError{Action: action, Type: failure.Type, Message: failure.Message}
+	if i := strings.LastIndex(apiErr.Type, "#"); i >= 0 {
+		apiErr.Type = apiErr.Type[i+1:]
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = failure.Upper
+	}
+	if apiErr.Type == "" {
+		apiErr.Type = response.Status
+	}
+	return apiErr
+}
+
+// requestToken returns a random UUID, the idempotency token Secrets Manager
+// requires of requests not made through an SDK.
+func requestToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,103 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSignAWS checks the signature against the GET ListUsers example of the
+// AWS Signature Version 4 documentation.
+func TestSignAWS(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	creds := AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signAWS(request, nil, creds, "us-east-1", "iam", time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC))
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+		"SignedHeaders=content-type;host;x-amz-date, " +
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if got := request.Header.Get("Authorization"); got != want {
+		t.Errorf("Expected %q, but got %q", want, got)
+	}
+}
+
+// TestLoadAWSCredentials reads the environment first, then the selected
+// profile of the shared credentials file.
+func TestLoadAWSCredentials(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "credentials")
+	data := "[default]\naws_access_key_id = AKIDDEFAULT\naws_secret_access_key = default-secret\n\n" +
+		"# rotation account\n[rotator]\naws_access_key_id = AKIDROTATOR\naws_secret_access_key = rotator-secret\naws_session_token = token\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", path)
+	t.Setenv("AWS_PROFILE", "rotator")
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	creds, err := LoadAWSCredentials(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if creds != (AWSCredentials{AccessKeyID: "AKIDROTATOR", SecretAccessKey: "rotator-secret", SessionToken: "token"}) {
+		t.Errorf("Expected the rotator profile, but got %+v", creds)
+	}
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDENV")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "env-secret")
+	if creds, _ := LoadAWSCredentials(context.Background(), nil); creds.AccessKeyID != "AKIDENV" {
+		t.Errorf("Expected the environment to win, but got %+v", creds)
+	}
+}
+
+// TestAWSTarget_CreatesMissingSecret puts a new value and creates the
+// secret when Secrets Manager does not know it yet.
+func TestAWSTarget_CreatesMissingSecret(t *testing.T) {
+	var actions []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action := r.Header.Get("X-Amz-Target")
+		actions = append(actions, action)
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			http.Error(w, "unsigned", http.StatusForbidden)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var input map[string]interface{}
+		_ = json.Unmarshal(body, &input)
+		if action == "secretsmanager.PutSecretValue" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException","Message":"Secrets Manager can't find the specified secret."}`))
+			return
+		}
+		if input["Name"] != "prod/db" || input["SecretString"] != "s3cret" || input["ClientRequestToken"] == "" {
+			http.Error(w, "bad input", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	target := AWSTarget{
+		Type: TypeAWSSecrets, Name: "prod/db", Region: "eu-west-1", Endpoint: server.URL,
+		Credentials: &AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}, Client: server.Client(),
+	}
+	if err := target.Deliver(context.Background(), Secret{Value: "s3cret", Generated: time.Now()}); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if strings.Join(actions, ",") != "secretsmanager.PutSecretValue,secretsmanager.CreateSecret" {
+		t.Errorf("Expected a put and then a create, but got %v", actions)
+	}
+
+	target.Type = TypeAWSSSM
+	err := target.Deliver(context.Background(), Secret{Value: "s3cret"})
+	if err == nil || !strings.Contains(err.Error(), "AmazonSSM.PutParameter") {
+		t.Errorf("Expected the refused parameter to be reported, but got %v", err)
+	}
+}
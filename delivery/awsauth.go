@@ -0,0 +1,313 @@
+package delivery
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AWSCredentials are the keys that sign AWS requests.
+// Fields:
+//   - AccessKeyID (string): The access key id, e.g. AKIA...
+//   - SecretAccessKey (string): The secret key; never logged.
+//   - SessionToken (string): Set for temporary credentials only.
+type AWSCredentials struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	SessionToken    string `json:"Token"`
+}
+
+// Endpoints of the container and instance credential providers; tests
+// replace them.
+var (
+	awsContainerEndpoint = "http://169.254.170.2"
+	awsInstanceEndpoint  = "http://169.254.169.254"
+)
+
+// awsMetadataTimeout bounds each request to a credential provider, so that
+// the chain fails fast outside of ECS and EC2.
+const awsMetadataTimeout = 2 * time.Second
+
+// LoadAWSCredentials finds credentials the way the AWS CLI and SDKs do, in
+// this order:
+//  1. AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.
+//  2. The AWS_PROFILE (or default) profile of the shared credentials file,
+//     AWS_SHARED_CREDENTIALS_FILE or ~/.aws/credentials.
+//  3. The ECS task role, through AWS_CONTAINER_CREDENTIALS_RELATIVE_URI or
+//     AWS_CONTAINER_CREDENTIALS_FULL_URI.
+//  4. The EC2 instance role, through IMDSv2, unless
+//     AWS_EC2_METADATA_DISABLED is true.
+//
+// SSO, assume-role and credential_process profiles are not supported; export
+// their credentials with "aws configure export-credentials" instead.
+func LoadAWSCredentials(ctx context.Context, client *http.Client) (AWSCredentials, error) {
+	if id, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"); id != "" && secret != "" {
+		return AWSCredentials{AccessKeyID: id, SecretAccessKey: secret, SessionToken: os.Getenv("AWS_SESSION_TOKEN")}, nil
+	}
+	if creds, ok, err := sharedAWSCredentials(); ok || err != nil {
+		return creds, err
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if creds, ok, err := containerAWSCredentials(ctx, client); ok || err != nil {
+		return creds, err
+	}
+	if !strings.EqualFold(os.Getenv("AWS_EC2_METADATA_DISABLED"), "true") {
+		if creds, err := instanceAWSCredentials(ctx, client); err == nil {
+			return creds, nil
+		}
+	}
+	return AWSCredentials{}, errors.New("no AWS credentials found: set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, configure a profile, or run with an ECS task or EC2 instance role")
+}
+
+// AWSRegion returns AWS_REGION, AWS_DEFAULT_REGION or the region of the
+// AWS_PROFILE (or default) profile in AWS_CONFIG_FILE or ~/.aws/config.
+func AWSRegion() (string, error) {
+	for _, name := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if region := os.Getenv(name); region != "" {
+			return region, nil
+		}
+	}
+	path := os.Getenv("AWS_CONFIG_FILE")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, ".aws", "config")
+	}
+	section := "default"
+	if profile := awsProfile(); profile != "default" {
+		section = "profile " + profile
+	}
+	values, _, err := readINISection(path, section)
+	if err != nil {
+		return "", err
+	}
+	if values["region"] == "" {
+		return "", errors.New("no AWS region configured: set AWS_REGION or a region in ~/.aws/config")
+	}
+	return values["region"], nil
+}
+
+// awsProfile returns the selected profile name.
+func awsProfile() string {
+	if profile := os.Getenv("AWS_PROFILE"); profile != "" {
+		return profile
+	}
+	return "default"
+}
+
+// sharedAWSCredentials reads the selected profile of the shared credentials
+// file; ok is false if the file or profile does not exist.
+func sharedAWSCredentials() (creds AWSCredentials, ok bool, err error) {
+	path := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return creds, false, nil
+		}
+		path = filepath.Join(home, ".aws", "credentials")
+	}
+	values, found, err := readINISection(path, awsProfile())
+	if err != nil || !found {
+		return creds, false, err
+	}
+	creds = AWSCredentials{
+		AccessKeyID:     values["aws_access_key_id"],
+		SecretAccessKey: values["aws_secret_access_key"],
+		SessionToken:    values["aws_session_token"],
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return creds, false, fmt.Errorf("%s: profile %q has no aws_access_key_id and aws_secret_access_key", path, awsProfile())
+	}
+	return creds, true, nil
+}
+
+// readINISection returns the keys of section in the INI file at path, and
+// whether the section exists. A missing file has no sections.
+func readINISection(path, section string) (map[string]string, bool, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	defer file.Close()
+	values := map[string]string{}
+	found, inside := false, false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			inside = strings.TrimSpace(line[1:len(line)-1]) == section
+			found = found || inside
+		case inside:
+			if key, value, ok := strings.Cut(line, "="); ok {
+				values[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+			}
+		}
+	}
+	return values, found, scanner.Err()
+}
+
+// containerAWSCredentials fetches the ECS task role credentials; ok is false
+// outside of ECS.
+func containerAWSCredentials(ctx context.Context, client *http.Client) (creds AWSCredentials, ok bool, err error) {
+	endpoint := os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI")
+	if relative := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"); relative != "" {
+		endpoint = awsContainerEndpoint + relative
+	}
+	if endpoint == "" {
+		return creds, false, nil
+	}
+	header := http.Header{}
+	if token := os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN"); token != "" {
+		header.Set("Authorization", token)
+	}
+	body, err := awsMetadata(ctx, client, http.MethodGet, endpoint, header)
+	if err != nil {
+		return creds, false, fmt.Errorf("container credentials: %w", err)
+	}
+	if err := json.Unmarshal(body, &creds); err != nil {
+		return creds, false, fmt.Errorf("container credentials: %w", err)
+	}
+	return creds, true, nil
+}
+
+// instanceAWSCredentials fetches the EC2 instance role credentials through
+// IMDSv2.
+func instanceAWSCredentials(ctx context.Context, client *http.Client) (AWSCredentials, error) {
+	var creds AWSCredentials
+	token, err := awsMetadata(ctx, client, http.MethodPut, awsInstanceEndpoint+"/latest/api/token",
+		http.Header{"X-Aws-Ec2-Metadata-Token-Ttl-Seconds": {"60"}})
+	if err != nil {
+		return creds, err
+	}
+	header := http.Header{"X-Aws-Ec2-Metadata-Token": {string(token)}}
+	base := awsInstanceEndpoint + "/latest/meta-data/iam/security-credentials/"
+	role, err := awsMetadata(ctx, client, http.MethodGet, base, header)
+	if err != nil {
+		return creds, err
+	}
+	name := strings.TrimSpace(strings.SplitN(string(role), "\n", 2)[0])
+	body, err := awsMetadata(ctx, client, http.MethodGet, base+url.PathEscape(name), header)
+	if err != nil {
+		return creds, err
+	}
+	return creds, json.Unmarshal(body, &creds)
+}
+
+// awsMetadata makes one request to a credential provider.
+func awsMetadata(ctx context.Context, client *http.Client, method, endpoint string, header http.Header) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, awsMetadataTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header = header
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<16))
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s answered %s", endpoint, response.Status)
+	}
+	return body, nil
+}
+
+// signAWS adds the AWS Signature Version 4 headers to request, whose body
+// is body, for service in region at time now. Every header already set is
+// signed.
+func signAWS(request *http.Request, body []byte, creds AWSCredentials, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	day := amzDate[:8]
+	request.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := request.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonical := strings.Join([]string{
+		request.Method,
+		path,
+		canonicalQuery(request.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := day + "/" + region + "/" + service + "/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonical))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+	key := []byte("AWS4" + creds.SecretAccessKey)
+	for _, part := range []string{day, region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	request.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+creds.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// canonicalQuery returns the query sorted by key and value, encoded as
+// SigV4 requires.
+func canonicalQuery(query url.Values) string {
+	escape := func(s string) string { return strings.ReplaceAll(url.QueryEscape(s), "+", "%20") }
+	var pairs []string
+	for key, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, escape(key)+"="+escape(value))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data under key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,73 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// execCommand creates engine processes; tests replace it.
+var execCommand = exec.CommandContext
+
+// ContainerSecretTarget stores the secret with "docker secret create" or
+// "podman secret create". The value is passed on stdin, so it never shows up
+// in a terminal, the process list or shell history.
+// Fields:
+//   - Engine (string): The container CLI to run, "docker" or "podman", or a path to it.
+//   - Name (string): The container secret name; the Secret's name when empty.
+//   - Replace (bool): Replace an existing secret of the same name. Docker
+//     refuses to remove secrets still used by a service.
+type ContainerSecretTarget struct {
+	Engine  string
+	Name    string
+	Replace bool
+}
+
+// Deliver creates the container secret.
+func (t ContainerSecretTarget) Deliver(ctx context.Context, secret Secret) error {
+	name := t.secretName(secret)
+	args := []string{"secret", "create"}
+	if t.Replace {
+		if t.isPodman() {
+			args = append(args, "--replace")
+		} else if err := t.run(ctx, "", "secret", "rm", name); err != nil && !strings.Contains(err.Error(), "not found") {
+			return err
+		}
+	}
+	return t.run(ctx, secret.Value, append(args, name, "-")...)
+}
+
+// String describes the target.
+func (t ContainerSecretTarget) String() string {
+	return t.Engine + "-secret:" + t.Name
+}
+
+// secretName returns the configured name or the name of the secret.
+func (t ContainerSecretTarget) secretName(secret Secret) string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return secret.Name
+}
+
+// isPodman reports whether the engine is podman, which supports --replace.
+func (t ContainerSecretTarget) isPodman() bool {
+	return strings.Contains(strings.ToLower(t.Engine), "podman")
+}
+
+// run executes the engine with stdin and returns its stderr as the error.
+func (t ContainerSecretTarget) run(ctx context.Context, stdin string, args ...string) error {
+	cmd := execCommand(ctx, t.Engine, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("%s %s: %s", t.Engine, strings.Join(args[:2], " "), message)
+		}
+		return fmt.Errorf("%s %s: %w", t.Engine, strings.Join(args[:2], " "), err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestHelperProcess stands in for the docker and podman executables when
+// GO_WANT_HELPER_PROCESS is set. It fails on purpose and reports its
+// arguments and stdin on stderr, which become the delivery error.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	stdin, _ := io.ReadAll(os.Stdin)
+	fmt.Fprintf(os.Stderr, "%s|%s", strings.Join(args[1:], " "), stdin)
+	os.Exit(1)
+}
+
+// fakeEngine routes engine invocations to TestHelperProcess.
+func fakeEngine(t *testing.T) {
+	t.Setenv("GO_WANT_HELPER_PROCESS", "1")
+	execCommand = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, os.Args[0], append([]string{"-test.run=TestHelperProcess", "--", name}, args...)...)
+	}
+	t.Cleanup(func() { execCommand = exec.CommandContext })
+}
+
+// TestContainerSecretTarget passes the secret on stdin, not in the arguments.
+func TestContainerSecretTarget(t *testing.T) {
+	fakeEngine(t)
+	target, _ := New(Config{Type: TypePodman, Name: "db_password", Replace: true})
+	err := target.Deliver(context.Background(), Secret{Name: "db", Value: "s3cret"})
+	if err == nil || !strings.HasSuffix(err.Error(), "podman secret create --replace db_password -|s3cret") {
+		t.Errorf("Expected the secret on stdin, but got %v", err)
+	}
+}
+
+// TestContainerSecretTarget_DefaultName falls back to the secret's name.
+func TestContainerSecretTarget_DefaultName(t *testing.T) {
+	fakeEngine(t)
+	err := ContainerSecretTarget{Engine: "docker"}.Deliver(context.Background(), Secret{Name: "db", Value: "x"})
+	if err == nil || !strings.HasSuffix(err.Error(), "docker secret create db -|x") {
+		t.Errorf("Expected the secret name db, but got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+/**
+ * Password Generator - Secret Delivery
+ *
+ * This file defines where generated secrets are sent. A Target receives a
+ * Secret and hands it on, e.g. by writing a file. Targets are described by a
+ * Config, so they can be listed in configuration files such as the rotation
+ * schedule. Targets must never log the secret value.
+ */
+
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Secret is a generated value together with its metadata.
+// Fields:
+//   - Name (string): The configured name of the secret, e.g. "db-password".
+//   - Value (string): The secret itself.
+//   - Generated (time.Time): When the value was generated.
+type Secret struct {
+	Name      string
+	Value     string
+	Generated time.Time
+}
+
+// Target delivers secrets to one destination.
+type Target interface {
+	// Deliver hands the secret to the destination.
+	Deliver(ctx context.Context, secret Secret) error
+	// String describes the destination without revealing credentials.
+	String() string
+}
+
+// Target types understood by New.
+const (
+	TypeFile       = "file"
+	TypeWebhook    = "webhook"
+	TypeDocker     = "docker"
+	TypePodman     = "podman"
+	TypeSystemd    = "systemd-creds"
+	TypeAWSSecrets = "aws-secretsmanager"
+	TypeAWSSSM     = "aws-ssm"
+	TypePass       = "pass"
+)
+
+// Config describes a target in a configuration file.
+// Fields:
+//   - Type (string): One of the Type constants.
+//   - Path (string): Destination file of TypeFile and TypeSystemd, entry of
+//     TypePass.
+//   - URL (string): Endpoint of TypeWebhook.
+//   - KeyEnv (string): Environment variable holding the webhook signing key,
+//     so the key itself stays out of configuration files.
+//   - Name (string): Secret name of TypeDocker and TypePodman, credential
+//     name of TypeSystemd, secret or parameter name of TypeAWSSecrets and
+//     TypeAWSSSM.
+//   - Replace (bool): Replace an existing TypeDocker or TypePodman secret,
+//     or TypePass entry.
+//   - WithKey (string): systemd-creds --with-key value of TypeSystemd.
+//   - Region (string): AWS region of TypeAWSSecrets and TypeAWSSSM; the
+//     configured one when empty.
+type Config struct {
+	Type    string `json:"type"`
+	Path    string `json:"path,omitempty"`
+	URL     string `json:"url,omitempty"`
+	KeyEnv  string `json:"keyEnv,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Replace bool   `json:"replace,omitempty"`
+	WithKey string `json:"withKey,omitempty"`
+	Region  string `json:"region,omitempty"`
+}
+
+// New creates the target described by cfg.
+// Parameters:
+//   - cfg (Config): The target description.
+//
+// Returns:
+//
+//	Target: The configured target.
+//	error: An error if the type is unknown or required settings are missing.
+//
+// Example:
+//
+//	target, err := delivery.New(delivery.Config{Type: delivery.TypeFile, Path: "/run/secrets/db"})
+func New(cfg Config) (Target, error) {
+	switch cfg.Type {
+	case TypeFile:
+		if cfg.Path == "" {
+			return nil, fmt.Errorf("%s target needs a path", cfg.Type)
+		}
+		return FileTarget{Path: cfg.Path}, nil
+	case TypeSystemd:
+		if cfg.Path == "" {
+			return nil, fmt.Errorf("%s target needs a path", cfg.Type)
+		}
+		return SystemdCredsTarget{Path: cfg.Path, Name: cfg.Name, WithKey: cfg.WithKey}, nil
+	case TypeWebhook:
+		var key []byte
+		if cfg.KeyEnv != "" {
+			if key = []byte(os.Getenv(cfg.KeyEnv)); len(key) == 0 {
+				return nil, fmt.Errorf("%s is empty", cfg.KeyEnv)
+			}
+		}
+		return NewWebhookTarget(cfg.URL, key)
+	case TypeDocker, TypePodman:
+		return ContainerSecretTarget{Engine: cfg.Type, Name: cfg.Name, Replace: cfg.Replace}, nil
+	case TypePass:
+		if cfg.Path == "" {
+			return nil, fmt.Errorf("%s target needs a path", cfg.Type)
+		}
+		return PassTarget{Path: cfg.Path, Replace: cfg.Replace}, nil
+	case TypeAWSSecrets, TypeAWSSSM:
+		return AWSTarget{Type: cfg.Type, Name: cfg.Name, Region: cfg.Region}, nil
+	default:
+		return nil, fmt.Errorf("unknown target type %q", cfg.Type)
+	}
+}
@@ -0,0 +1,40 @@
+package delivery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFileTarget writes and then replaces a secret file.
+func TestFileTarget(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	target, err := New(Config{Type: TypeFile, Path: path})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, value := range []string{"first", "second"} {
+		if err := target.Deliver(context.Background(), Secret{Name: "db", Value: value}); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "second" {
+		t.Errorf("Expected file content %q, but got %q (%v)", "second", data, err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected no temporary files to remain, but found %d entries", len(entries))
+	}
+}
+
+// TestNew_Invalid rejects unknown types and missing settings.
+func TestNew_Invalid(t *testing.T) {
+	for _, cfg := range []Config{{Type: "ftp"}, {Type: TypeFile}} {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("Expected an error for %+v, but got nil", cfg)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package delivery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// FileTarget writes the secret to a file readable only by the current user.
+// The file is replaced atomically, so readers never see a partial secret.
+type FileTarget struct {
+	Path string
+}
+
+// Deliver writes the secret value, without a trailing newline, to Path.
+func (t FileTarget) Deliver(_ context.Context, secret Secret) error {
+	temp, err := os.CreateTemp(filepath.Dir(t.Path), "."+filepath.Base(t.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if err := temp.Chmod(0600); err != nil {
+		temp.Close()
+		return err
+	}
+	if _, err := temp.WriteString(secret.Value); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), t.Path)
+}
+
+// String describes the target.
+func (t FileTarget) String() string {
+	return "file:" + t.Path
+}
@@ -0,0 +1,71 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PassTarget inserts the secret into a pass(1) password store with
+// "pass insert --multiline", so pass encrypts it for the store's GPG keys and
+// commits it if the store is a git repository. The value is passed on stdin.
+// Fields:
+//   - Path (string): The entry, e.g. "servers/db-01".
+//   - Replace (bool): Overwrite an existing entry; pass itself does so
+//     without asking when stdin is not a terminal, so the check is ours.
+//   - Store (string): The store directory; PASSWORD_STORE_DIR or
+//     ~/.password-store when empty, as pass uses.
+type PassTarget struct {
+	Path    string
+	Replace bool
+	Store   string
+}
+
+// Deliver inserts the entry.
+func (t PassTarget) Deliver(ctx context.Context, secret Secret) error {
+	path := t.Path
+	if path == "" {
+		path = secret.Name
+	}
+	if !t.Replace {
+		if _, err := os.Stat(filepath.Join(t.storeDir(), path+".gpg")); err == nil {
+			return fmt.Errorf("pass entry %s already exists; replace it explicitly", path)
+		}
+	}
+	// "--" keeps a path starting with "-" from being read as an option.
+	args := []string{"insert", "--multiline", "--force", "--", path}
+	cmd := execCommand(ctx, "pass", args...)
+	cmd.Stdin = strings.NewReader(secret.Value + "\n")
+	if t.Store != "" {
+		cmd.Env = append(os.Environ(), "PASSWORD_STORE_DIR="+t.Store)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("pass insert: %s", message)
+		}
+		return fmt.Errorf("pass insert: %w", err)
+	}
+	return nil
+}
+
+// String describes the target.
+func (t PassTarget) String() string {
+	return "pass:" + t.Path
+}
+
+// storeDir returns the password store directory pass uses.
+func (t PassTarget) storeDir() string {
+	if t.Store != "" {
+		return t.Store
+	}
+	if dir := os.Getenv("PASSWORD_STORE_DIR"); dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".password-store")
+}
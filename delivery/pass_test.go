@@ -0,0 +1,40 @@
+package delivery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestPassTarget passes the secret on stdin and refuses to overwrite an
+// existing entry unless asked to.
+func TestPassTarget(t *testing.T) {
+	fakeEngine(t)
+	store := t.TempDir()
+	target := PassTarget{Path: "servers/db-01", Store: store}
+	err := target.Deliver(context.Background(), Secret{Value: "s3cret"})
+	if err == nil || !strings.HasSuffix(err.Error(), "pass insert --multiline --force -- servers/db-01|s3cret") {
+		t.Errorf("Expected the secret on stdin, but got %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(store, "servers"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(store, "servers", "db-01.gpg"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := target.Deliver(context.Background(), Secret{Value: "s3cret"}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected an existing entry to be kept, but got %v", err)
+	}
+	target.Replace = true
+	if err := target.Deliver(context.Background(), Secret{Value: "s3cret"}); err == nil || !strings.Contains(err.Error(), "pass insert") {
+		t.Errorf("Expected Replace to run pass, but got %v", err)
+	}
+
+	target = PassTarget{Path: "--echo", Store: store}
+	if err := target.Deliver(context.Background(), Secret{Value: "s3cret"}); err == nil || !strings.HasSuffix(err.Error(), "pass insert --multiline --force -- --echo|s3cret") {
+		t.Errorf("Expected a path starting with - to follow --, but got %v", err)
+	}
+}
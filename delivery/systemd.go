@@ -0,0 +1,53 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// SystemdCredsTarget encrypts the secret with "systemd-creds encrypt" into a
+// file suitable for LoadCredentialEncrypted= in a service unit. The value is
+// passed on stdin.
+// Fields:
+//   - Path (string): The encrypted credential file to write.
+//   - Name (string): The credential name embedded in the file; by default the
+//     file name, which is what LoadCredentialEncrypted= expects.
+//   - WithKey (string): Optional --with-key value such as "host", "tpm2" or "host+tpm2".
+type SystemdCredsTarget struct {
+	Path    string
+	Name    string
+	WithKey string
+}
+
+// Deliver writes the encrypted credential file.
+func (t SystemdCredsTarget) Deliver(ctx context.Context, secret Secret) error {
+	name := t.Name
+	if name == "" {
+		name = filepath.Base(t.Path)
+	}
+	args := []string{"encrypt", "--name=" + name}
+	if t.WithKey != "" {
+		args = append(args, "--with-key="+t.WithKey)
+	}
+	args = append(args, "-", t.Path)
+
+	cmd := execCommand(ctx, "systemd-creds", args...)
+	cmd.Stdin = strings.NewReader(secret.Value)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("systemd-creds encrypt: %s", message)
+		}
+		return fmt.Errorf("systemd-creds encrypt: %w", err)
+	}
+	return nil
+}
+
+// String describes the target.
+func (t SystemdCredsTarget) String() string {
+	return "systemd-creds:" + t.Path
+}
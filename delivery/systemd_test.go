@@ -0,0 +1,17 @@
+package delivery
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestSystemdCredsTarget names the credential after the file by default.
+func TestSystemdCredsTarget(t *testing.T) {
+	fakeEngine(t)
+	target, _ := New(Config{Type: TypeSystemd, Path: "/etc/credstore.encrypted/db", WithKey: "tpm2"})
+	err := target.Deliver(context.Background(), Secret{Name: "ignored", Value: "s3cret"})
+	if err == nil || !strings.HasSuffix(err.Error(), "systemd-creds encrypt --name=db --with-key=tpm2 - /etc/credstore.encrypted/db|s3cret") {
+		t.Errorf("Unexpected invocation %v", err)
+	}
+}
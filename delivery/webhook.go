@@ -0,0 +1,121 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body, prefixed
+// with "sha256=", when the webhook has a signing key.
+const SignatureHeader = "X-Passgen-Signature"
+
+// webhookTimeout bounds a single delivery attempt.
+const webhookTimeout = 30 * time.Second
+
+// WebhookTarget POSTs secrets as JSON to an HTTPS endpoint.
+// Fields:
+//   - URL (string): The https:// endpoint.
+//   - Key ([]byte): Optional HMAC-SHA256 signing key shared with the receiver.
+//   - Client (*http.Client): The HTTP client; http.DefaultClient when nil.
+//     Redirects are never followed, whichever client is used.
+type WebhookTarget struct {
+	URL    string
+	Key    []byte
+	Client *http.Client
+}
+
+// webhookPayload is the JSON body sent to the webhook.
+type webhookPayload struct {
+	Name      string    `json:"name"`
+	Secret    string    `json:"secret"`
+	Generated time.Time `json:"generated"`
+}
+
+// NewWebhookTarget checks that url uses HTTPS and returns the target.
+func NewWebhookTarget(rawURL string, key []byte) (WebhookTarget, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return WebhookTarget{}, fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return WebhookTarget{}, errors.New("webhook URL must use https://")
+	}
+	return WebhookTarget{URL: rawURL, Key: key}, nil
+}
+
+// Deliver sends the secret and expects a 2xx response.
+// Purpose:
+//
+//	Posts {"name", "secret", "generated"} as JSON. When Key is set, the body
+//	is signed so the receiver can verify origin and integrity; the generated
+//	timestamp lets it reject replays.
+//
+// Parameters:
+//   - ctx (context.Context): Cancels the request.
+//   - secret (Secret): The secret to send.
+//
+// Returns:
+//
+//	error: An error if the request fails or the endpoint does not accept it.
+func (t WebhookTarget) Deliver(ctx context.Context, secret Secret) error {
+	body, err := json.Marshal(webhookPayload{Name: secret.Name, Secret: secret.Value, Generated: secret.Generated.UTC()})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if len(t.Key) > 0 {
+		request.Header.Set(SignatureHeader, Sign(t.Key, body))
+	}
+
+	// A followed 307 or 308 would send the body again, possibly to a plain
+	// http:// URL, so a redirect fails the delivery instead.
+	client := http.Client{}
+	if t.Client != nil {
+		client = *t.Client
+	}
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook answered %s", response.Status)
+	}
+	return nil
+}
+
+// String describes the target without query parameters, which may hold tokens.
+func (t WebhookTarget) String() string {
+	if u, err := url.Parse(t.URL); err == nil {
+		return "webhook:" + u.Scheme + "://" + u.Host + u.Path
+	}
+	return "webhook"
+}
+
+// Sign returns the SignatureHeader value for body, for use by receivers:
+//
+//	ok := hmac.Equal([]byte(r.Header.Get(delivery.SignatureHeader)), []byte(delivery.Sign(key, body)))
+func Sign(key, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
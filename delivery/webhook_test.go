@@ -0,0 +1,82 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestWebhookTarget posts a signed secret to a TLS test server.
+func TestWebhookTarget(t *testing.T) {
+	key := []byte("shared-key")
+	received := make(chan webhookPayload, 1)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(SignatureHeader) != Sign(key, body) {
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+		var payload webhookPayload
+		_ = json.Unmarshal(body, &payload)
+		received <- payload
+	}))
+	defer server.Close()
+
+	target, err := NewWebhookTarget(server.URL+"/hook?token=abc", key)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	target.Client = server.Client()
+	secret := Secret{Name: "db", Value: "s3cret", Generated: time.Now()}
+	if err := target.Deliver(context.Background(), secret); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if payload := <-received; payload.Name != "db" || payload.Secret != "s3cret" {
+		t.Errorf("Unexpected payload %+v", payload)
+	}
+	if got := target.String(); got != "webhook:"+server.URL+"/hook" {
+		t.Errorf("Expected the token to be hidden, but got %q", got)
+	}
+
+	target.Key = []byte("wrong-key")
+	if err := target.Deliver(context.Background(), secret); err == nil {
+		t.Errorf("Expected a rejected signature to fail delivery, but got nil")
+	}
+}
+
+// TestWebhookTarget_Redirect verifies that a redirect fails the delivery
+// and the secret is not sent to the redirect target.
+func TestWebhookTarget_Redirect(t *testing.T) {
+	leaked := make(chan struct{}, 1)
+	plain := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		leaked <- struct{}{}
+	}))
+	defer plain.Close()
+	server := httptest.NewTLSServer(http.RedirectHandler(plain.URL+"/hook", http.StatusPermanentRedirect))
+	defer server.Close()
+
+	target, err := NewWebhookTarget(server.URL+"/hook", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	target.Client = server.Client()
+	if err := target.Deliver(context.Background(), Secret{Name: "db", Value: "s3cret", Generated: time.Now()}); err == nil {
+		t.Error("Expected a redirect to fail delivery, but got nil")
+	}
+	select {
+	case <-leaked:
+		t.Error("Expected the redirect not to be followed, but the secret was sent over plain HTTP")
+	default:
+	}
+}
+
+// TestNewWebhookTarget_RequiresHTTPS refuses plain HTTP endpoints.
+func TestNewWebhookTarget_RequiresHTTPS(t *testing.T) {
+	if _, err := NewWebhookTarget("http://example.com/hook", nil); err == nil {
+		t.Errorf("Expected an error for http://, but got nil")
+	}
+}
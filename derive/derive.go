@@ -0,0 +1,198 @@
+/**
+ * Password Generator - Deterministic Per-Site Passwords
+ *
+ * This file derives site passwords from a master secret, in the style of
+ * LessPass: the same master secret, site, login and counter always give the
+ * same password, so nothing needs to be stored or synchronised. Argon2id
+ * stretches the master secret, and an HKDF stream turns the key into
+ * characters without modulo bias. Bumping the counter rotates a password.
+ *
+ * The master secret is taken as a byte slice so callers can wipe it with
+ * kdf.Wipe once done; the intermediate key is wiped before Password returns.
+ */
+
+package derive
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/kdf"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Limits of the derived password length.
+const (
+	MinLength     = 8
+	MaxLength     = 64
+	DefaultLength = 16
+)
+
+// version separates this derivation from any future one: changing it
+// changes every derived password.
+const version = "password-generator/derive/v1"
+
+// Profile describes one derived password. Everything except the master
+// secret is not secret and may be written down.
+// Fields:
+//   - Site (string): The site, e.g. "example.com"; compared case-insensitively.
+//   - Login (string): The user name or e-mail address on the site.
+//   - Counter (uint32): Starts at 1; increase it to rotate the password.
+//   - Length (int): Number of characters, MinLength to MaxLength.
+//   - Lowercase, Uppercase, Digits, Symbols (bool): The character classes;
+//     every enabled class appears at least once.
+type Profile struct {
+	Site      string `json:"site"`
+	Login     string `json:"login"`
+	Counter   uint32 `json:"counter"`
+	Length    int    `json:"length"`
+	Lowercase bool   `json:"lowercase"`
+	Uppercase bool   `json:"uppercase"`
+	Digits    bool   `json:"digits"`
+	Symbols   bool   `json:"symbols"`
+}
+
+// NewProfile returns a profile for site and login with the default length,
+// all character classes and counter 1.
+func NewProfile(site, login string) Profile {
+	return Profile{
+		Site:      site,
+		Login:     login,
+		Counter:   1,
+		Length:    DefaultLength,
+		Lowercase: true,
+		Uppercase: true,
+		Digits:    true,
+		Symbols:   true,
+	}
+}
+
+// classes returns the character sets enabled in p.
+func (p Profile) classes() []string {
+	var classes []string
+	if p.Lowercase {
+		classes = append(classes, passgen.Lowercase)
+	}
+	if p.Uppercase {
+		classes = append(classes, passgen.Uppercase)
+	}
+	if p.Digits {
+		classes = append(classes, passgen.Digits)
+	}
+	if p.Symbols {
+		classes = append(classes, passgen.Symbols)
+	}
+	return classes
+}
+
+// Password derives the password for p from master.
+// Purpose:
+//
+//	Stretches master with Argon2id, salted with the site, login and counter,
+//	then draws Length characters from an HKDF stream keyed with the result:
+//	one of each enabled class at derived positions, the rest from all
+//	enabled classes.
+//
+// Parameters:
+//   - master ([]byte): The master secret; not modified. Wipe it with
+//     kdf.Wipe when it is no longer needed.
+//   - p (Profile): The site, login, counter and password rules.
+//   - cost (kdf.Params): The Argon2id cost, normally kdf.Default. Every
+//     device must use the same parameters to derive the same passwords.
+//
+// Returns:
+//
+//	string: The derived password.
+//	error: An error if the master secret or site is empty, or the rules or
+//	cost are invalid.
+//
+// Example:
+//
+//	password, err := derive.Password(master, derive.NewProfile("example.com", "alice"), kdf.Default)
+func Password(master []byte, p Profile, cost kdf.Params) (string, error) {
+	if len(master) == 0 {
+		return "", errors.New("the master secret is empty")
+	}
+	site := strings.ToLower(strings.TrimSpace(p.Site))
+	if site == "" {
+		return "", errors.New("the site is empty")
+	}
+	if p.Length < MinLength || p.Length > MaxLength {
+		return "", fmt.Errorf("length must be between %d and %d", MinLength, MaxLength)
+	}
+	if p.Counter == 0 {
+		return "", errors.New("the counter starts at 1")
+	}
+	classes := p.classes()
+	if len(classes) == 0 {
+		return "", errors.New("at least one character type must be selected")
+	}
+
+	key, err := cost.Key(master, salt(site, p.Login, p.Counter), 32)
+	if err != nil {
+		return "", err
+	}
+	defer kdf.Wipe(key)
+	stream := hkdf.Expand(sha256.New, key, []byte(version))
+
+	all := strings.Join(classes, "")
+	password := make([]byte, 0, p.Length)
+	for len(password) < p.Length-len(classes) {
+		c, err := pick(stream, all)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+	for _, class := range classes {
+		c, err := pick(stream, class)
+		if err != nil {
+			return "", err
+		}
+		at, err := uniform(stream, len(password)+1)
+		if err != nil {
+			return "", err
+		}
+		password = append(password[:at], append([]byte{c}, password[at:]...)...)
+	}
+	return string(password), nil
+}
+
+// salt encodes the public inputs unambiguously: every field is length-prefixed.
+func salt(site, login string, counter uint32) []byte {
+	var b []byte
+	for _, field := range []string{version, site, login} {
+		b = binary.BigEndian.AppendUint32(b, uint32(len(field)))
+		b = append(b, field...)
+	}
+	return binary.BigEndian.AppendUint32(b, counter)
+}
+
+// pick returns a character of chars chosen by the stream.
+func pick(stream io.Reader, chars string) (byte, error) {
+	i, err := uniform(stream, len(chars))
+	if err != nil {
+		return 0, err
+	}
+	return chars[i], nil
+}
+
+// uniform returns a number in [0, n) from the stream, rejecting bytes that
+// would bias the result. n must be at most 256.
+func uniform(stream io.Reader, n int) (int, error) {
+	limit := 256 - 256%n
+	var b [1]byte
+	for {
+		if _, err := io.ReadFull(stream, b[:]); err != nil {
+			return 0, errors.New("derivation stream exhausted")
+		}
+		if int(b[0]) < limit {
+			return int(b[0]) % n, nil
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package derive
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/kdf"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// testKDF keeps the tests fast; real use needs kdf.Default.
+var testKDF = kdf.Params{Time: 1, Memory: 64, Threads: 1}
+
+// TestPassword_Deterministic checks that the same inputs give the same password
+// and that the site, login and counter each change it.
+func TestPassword_Deterministic(t *testing.T) {
+	master := []byte("correct horse battery staple")
+	p := NewProfile("example.com", "alice")
+	first, err := Password(master, p, testKDF)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	again, _ := Password(master, Profile{Site: " Example.COM ", Login: "alice", Counter: 1, Length: DefaultLength, Lowercase: true, Uppercase: true, Digits: true, Symbols: true}, testKDF)
+	if first != again {
+		t.Errorf("Expected the same password for the same inputs, but got %q and %q", first, again)
+	}
+
+	changes := []Profile{p, p, p}
+	changes[0].Site = "example.org"
+	changes[1].Login = "bob"
+	changes[2].Counter = 2
+	for _, changed := range changes {
+		if other, _ := Password(master, changed, testKDF); other == first {
+			t.Errorf("Expected a different password for %+v, but got the same", changed)
+		}
+	}
+	if other, _ := Password([]byte("another master"), p, testKDF); other == first {
+		t.Errorf("Expected a different password for another master secret")
+	}
+}
+
+// TestPassword_Rules checks the length and that every enabled class appears.
+func TestPassword_Rules(t *testing.T) {
+	p := NewProfile("example.com", "alice")
+	p.Length = MinLength
+	p.Symbols = false
+	for counter := uint32(1); counter <= 30; counter++ {
+		p.Counter = counter
+		password, err := Password([]byte("master"), p, testKDF)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if len(password) != MinLength {
+			t.Errorf("Expected length %d, but got %q", MinLength, password)
+		}
+		for _, class := range []string{passgen.Lowercase, passgen.Uppercase, passgen.Digits} {
+			if !strings.ContainsAny(password, class) {
+				t.Errorf("Expected %q to contain one of %q", password, class)
+			}
+		}
+		if strings.ContainsAny(password, passgen.Symbols) {
+			t.Errorf("Expected no symbols, but got %q", password)
+		}
+	}
+}
+
+// TestPassword_Invalid checks that invalid inputs are refused.
+func TestPassword_Invalid(t *testing.T) {
+	valid := NewProfile("example.com", "alice")
+	tests := map[string]struct {
+		master []byte
+		change func(*Profile)
+	}{
+		"empty master": {nil, func(*Profile) {}},
+		"empty site":   {[]byte("m"), func(p *Profile) { p.Site = " " }},
+		"too short":    {[]byte("m"), func(p *Profile) { p.Length = MinLength - 1 }},
+		"counter zero": {[]byte("m"), func(p *Profile) { p.Counter = 0 }},
+		"no classes":   {[]byte("m"), func(p *Profile) { *p = Profile{Site: "a", Counter: 1, Length: DefaultLength} }},
+	}
+	for name, tt := range tests {
+		p := valid
+		tt.change(&p)
+		if _, err := Password(tt.master, p, testKDF); err == nil {
+			t.Errorf("Expected an error for %s, but got none", name)
+		}
+	}
+	if _, err := Password([]byte("m"), valid, kdf.Params{Time: 1, Memory: 64}); err == nil {
+		t.Error("Expected an error for a cost without threads, but got none")
+	}
+}
@@ -0,0 +1,39 @@
+/**
+ * Password Generator - Windows Data Protection
+ *
+ * This file encrypts exports with the Windows Data Protection API (DPAPI) in
+ * the current user scope. Only the same Windows account, on the same machine
+ * or with a roaming profile, can decrypt the data; no password is needed.
+ * Other platforms report ErrUnsupported.
+ */
+
+package dpapi
+
+import "errors"
+
+// ErrUnsupported is returned on platforms without DPAPI.
+var ErrUnsupported = errors.New("DPAPI is only available on Windows")
+
+// description is stored unencrypted alongside the protected data.
+const description = "Password Generator export"
+
+// Protect encrypts data for the current Windows user.
+// Parameters:
+//   - data ([]byte): The plaintext, e.g. generated passwords.
+//
+// Returns:
+//
+//	[]byte: The protected blob.
+//	error: ErrUnsupported outside Windows, or the system error.
+//
+// Example:
+//
+//	blob, err := dpapi.Protect([]byte(password))
+func Protect(data []byte) ([]byte, error) {
+	return protect(data)
+}
+
+// Unprotect decrypts a blob created by Protect under the same user account.
+func Unprotect(blob []byte) ([]byte, error) {
+	return unprotect(blob)
+}
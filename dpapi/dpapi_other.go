@@ -0,0 +1,14 @@
+//go:build !windows
+
+package dpapi
+
+// Supported reports whether DPAPI is available on this platform.
+const Supported = false
+
+func protect([]byte) ([]byte, error) {
+	return nil, ErrUnsupported
+}
+
+func unprotect([]byte) ([]byte, error) {
+	return nil, ErrUnsupported
+}
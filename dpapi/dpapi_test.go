@@ -0,0 +1,29 @@
+package dpapi
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// TestProtectUnprotect round-trips data on Windows and reports
+// ErrUnsupported elsewhere.
+func TestProtectUnprotect(t *testing.T) {
+	blob, err := Protect([]byte("s3cret"))
+	if !Supported {
+		if !errors.Is(err, ErrUnsupported) {
+			t.Errorf("Expected ErrUnsupported, but got %v", err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if bytes.Contains(blob, []byte("s3cret")) {
+		t.Errorf("Expected the blob to be encrypted")
+	}
+	plain, err := Unprotect(blob)
+	if err != nil || string(plain) != "s3cret" {
+		t.Errorf("Expected %q, but got %q (%v)", "s3cret", plain, err)
+	}
+}
@@ -0,0 +1,77 @@
+//go:build windows
+
+package dpapi
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var (
+	crypt32                = syscall.NewLazyDLL("crypt32.dll")
+	kernel32               = syscall.NewLazyDLL("kernel32.dll")
+	procCryptProtectData   = crypt32.NewProc("CryptProtectData")
+	procCryptUnprotectData = crypt32.NewProc("CryptUnprotectData")
+	procLocalFree          = kernel32.NewProc("LocalFree")
+)
+
+// cryptProtectUIForbidden fails instead of showing a prompt.
+const cryptProtectUIForbidden = 0x1
+
+// Supported reports whether DPAPI is available on this platform.
+const Supported = true
+
+// dataBlob mirrors the Win32 DATA_BLOB structure.
+type dataBlob struct {
+	size uint32
+	data *byte
+}
+
+// newBlob points a DATA_BLOB at b.
+func newBlob(b []byte) *dataBlob {
+	if len(b) == 0 {
+		return &dataBlob{}
+	}
+	return &dataBlob{size: uint32(len(b)), data: &b[0]}
+}
+
+// bytes copies the blob contents and frees the system allocation.
+func (b *dataBlob) bytes() []byte {
+	defer procLocalFree.Call(uintptr(unsafe.Pointer(b.data)))
+	out := make([]byte, b.size)
+	copy(out, unsafe.Slice(b.data, b.size))
+	return out
+}
+
+func protect(data []byte) ([]byte, error) {
+	desc, err := syscall.UTF16PtrFromString(description)
+	if err != nil {
+		return nil, err
+	}
+	var out dataBlob
+	r, _, err := procCryptProtectData.Call(
+		uintptr(unsafe.Pointer(newBlob(data))),
+		uintptr(unsafe.Pointer(desc)),
+		0, 0, 0,
+		cryptProtectUIForbidden,
+		uintptr(unsafe.Pointer(&out)),
+	)
+	if r == 0 {
+		return nil, err
+	}
+	return out.bytes(), nil
+}
+
+func unprotect(blob []byte) ([]byte, error) {
+	var out dataBlob
+	r, _, err := procCryptUnprotectData.Call(
+		uintptr(unsafe.Pointer(newBlob(blob))),
+		0, 0, 0, 0,
+		cryptProtectUIForbidden,
+		uintptr(unsafe.Pointer(&out)),
+	)
+	if r == 0 {
+		return nil, err
+	}
+	return out.bytes(), nil
+}
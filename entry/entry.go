@@ -0,0 +1,213 @@
+/**
+ * Password Generator - Entry Templates
+ *
+ * This file defines typed entry templates: a login, a Wi-Fi network, a
+ * server and a database each have their own fields (SSID, hostname and port,
+ * connection string, ...) and a generator suited to where the secret is
+ * used, e.g. Wi-Fi keys that are easy to type on a TV or database passwords
+ * without characters that break connection strings. An entry bundles the
+ * generated secret with that context so it can be stored or handed on.
+ */
+
+package entry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// Kind identifies an entry template.
+type Kind string
+
+// Entry kinds, in the order they are offered.
+const (
+	KindLogin    Kind = "login"
+	KindWiFi     Kind = "wifi"
+	KindServer   Kind = "server"
+	KindDatabase Kind = "database"
+)
+
+// Field is one context field of a template.
+// Fields:
+//   - Name (string): The key in Entry.Fields.
+//   - Label (string): The label shown to users.
+//   - Hint (string): An example value.
+//   - Required (bool): The field must not be empty.
+//   - Port (bool): The value must be a TCP port number.
+//   - Default (string): The value used when the field is left empty.
+type Field struct {
+	Name     string
+	Label    string
+	Hint     string
+	Required bool
+	Port     bool
+	Default  string
+}
+
+// Template describes the fields of an entry kind and how its secret is generated.
+type Template struct {
+	Kind   Kind
+	Label  string
+	Fields []Field
+	// Options adapts the base options to where the secret is used.
+	Options func(base passgen.PasswordOptions) passgen.PasswordOptions
+}
+
+// Characters that have a special meaning in connection strings and URLs.
+const connectionStringCharacters = ":/?#[]@%&=+;,{}'\"\\"
+
+// Characters that are easily confused when read off a label.
+const lookAlikes = "iIl1Lo0O"
+
+// Characters that need quoting in shells and configuration files.
+const shellCharacters = "$&|;<>(){}[]'\"\\`!*?"
+
+// Templates lists the entry templates in the order they are offered.
+var Templates = []Template{
+	{
+		Kind:  KindLogin,
+		Label: "Website Login",
+		Fields: []Field{
+			{Name: "url", Label: "URL", Hint: "https://example.com", Required: true},
+			{Name: "username", Label: "Username", Hint: "alice@example.com"},
+		},
+		Options: func(base passgen.PasswordOptions) passgen.PasswordOptions { return base },
+	},
+	{
+		Kind:  KindWiFi,
+		Label: "Wi-Fi Network",
+		Fields: []Field{
+			{Name: "ssid", Label: "SSID", Hint: "Home", Required: true},
+			{Name: "security", Label: "Security", Hint: "WPA2 or WPA3", Default: "WPA2"},
+		},
+		// WPA keys are 8-63 characters and often typed on TVs and phones:
+		// letters and digits only, without look-alikes.
+		Options: func(base passgen.PasswordOptions) passgen.PasswordOptions {
+			base.Length = clamp(base.Length, 20, 63)
+			base.IncludeSymbols = false
+			base.IncludeNumbers = true
+			base.IncludeUpper = true
+			base.IncludeLower = true
+			base.ExcludeCharacters += lookAlikes
+			return base
+		},
+	},
+	{
+		Kind:  KindServer,
+		Label: "Server (SSH)",
+		Fields: []Field{
+			{Name: "hostname", Label: "Hostname", Hint: "db1.example.com", Required: true},
+			{Name: "port", Label: "Port", Hint: "22", Port: true, Default: "22"},
+			{Name: "username", Label: "Username", Hint: "root"},
+		},
+		// Server passwords end up in scripts and configuration files.
+		Options: func(base passgen.PasswordOptions) passgen.PasswordOptions {
+			base.Length = clamp(base.Length, 24, base.Length)
+			base.ExcludeCharacters += shellCharacters
+			return base
+		},
+	},
+	{
+		Kind:  KindDatabase,
+		Label: "Database",
+		Fields: []Field{
+			{Name: "connection", Label: "Connection string", Hint: "postgres://app@db1.example.com:5432/orders", Required: true},
+			{Name: "username", Label: "Username", Hint: "app"},
+		},
+		// Database passwords are embedded in connection strings.
+		Options: func(base passgen.PasswordOptions) passgen.PasswordOptions {
+			base.Length = clamp(base.Length, 32, base.Length)
+			base.ExcludeCharacters += connectionStringCharacters
+			return base
+		},
+	},
+}
+
+// clamp limits n to [low, high]; high below low means no upper limit.
+func clamp(n, low, high int) int {
+	if n < low {
+		return low
+	}
+	if high >= low && n > high {
+		return high
+	}
+	return n
+}
+
+// Lookup returns the template of kind.
+func Lookup(kind Kind) (Template, bool) {
+	for _, t := range Templates {
+		if t.Kind == kind {
+			return t, true
+		}
+	}
+	return Template{}, false
+}
+
+// Entry is a generated secret with the context of its template.
+// Fields:
+//   - Kind (Kind): The template the entry was created from.
+//   - Fields (map[string]string): The context, keyed by Field.Name.
+//   - Password (string): The generated secret.
+//   - Created (time.Time): When the secret was generated.
+type Entry struct {
+	Kind     Kind              `json:"kind"`
+	Fields   map[string]string `json:"fields"`
+	Password string            `json:"password"`
+	Created  time.Time         `json:"created"`
+}
+
+// New validates the context fields and generates the secret of an entry.
+// Parameters:
+//   - fields (map[string]string): The values of the template's fields.
+//   - base (passgen.PasswordOptions): The user's options, adapted by t.Options.
+//
+// Returns:
+//
+//	Entry: The entry with its generated secret.
+//	error: An error if a field is missing or invalid, or generation fails.
+//
+// Example:
+//
+//	t, _ := entry.Lookup(entry.KindWiFi)
+//	e, err := t.New(map[string]string{"ssid": "Home"}, opts)
+func (t Template) New(fields map[string]string, base passgen.PasswordOptions) (Entry, error) {
+	values := make(map[string]string)
+	for _, field := range t.Fields {
+		value := strings.TrimSpace(fields[field.Name])
+		if value == "" {
+			value = field.Default
+		}
+		if value == "" {
+			if field.Required {
+				return Entry{}, fmt.Errorf("%s is required", field.Label)
+			}
+			continue
+		}
+		if field.Port {
+			if port, err := strconv.Atoi(value); err != nil || port < 1 || port > 65535 {
+				return Entry{}, fmt.Errorf("%s must be a port number between 1 and 65535", field.Label)
+			}
+		}
+		values[field.Name] = value
+	}
+
+	opts := t.Options(base)
+	opts.Quantity = 1
+	passwords, err := passgen.GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		return Entry{}, err
+	}
+	return Entry{Kind: t.Kind, Fields: values, Password: passwords[0], Created: time.Now()}, nil
+}
+
+// JSON formats the entry for storage or for pasting into another tool.
+func (e Entry) JSON() ([]byte, error) {
+	return json.MarshalIndent(e, "", "  ")
+}
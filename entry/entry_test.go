@@ -0,0 +1,62 @@
+package entry
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+)
+
+// TestTemplates_New checks every template's fields and generator.
+func TestTemplates_New(t *testing.T) {
+	base := *config.GetDefaultOptions()
+	base.Length = 12
+	fields := map[string]string{
+		"url":        "https://example.com",
+		"ssid":       "Home",
+		"hostname":   "db1.example.com",
+		"connection": "postgres://app@db1.example.com:5432/orders",
+	}
+	for _, tmpl := range Templates {
+		e, err := tmpl.New(fields, base)
+		if err != nil {
+			t.Fatalf("Expected no error for %s, but got %v", tmpl.Kind, err)
+		}
+		if e.Kind != tmpl.Kind || e.Password == "" {
+			t.Errorf("Expected a %s entry with a password, but got %+v", tmpl.Kind, e)
+		}
+		switch tmpl.Kind {
+		case KindWiFi:
+			if len(e.Password) < 20 || strings.ContainsAny(e.Password, "!@#$%^&*()-_=+[]{}|;:,.<>/?iIl1Lo0O") {
+				t.Errorf("Expected a Wi-Fi key of 20+ letters and digits without look-alikes, but got %q", e.Password)
+			}
+			if e.Fields["security"] != "WPA2" {
+				t.Errorf("Expected the default security WPA2, but got %q", e.Fields["security"])
+			}
+		case KindServer:
+			if e.Fields["port"] != "22" || strings.ContainsAny(e.Password, shellCharacters) {
+				t.Errorf("Expected port 22 and no shell characters, but got %+v", e)
+			}
+		case KindDatabase:
+			if len(e.Password) < 32 || strings.ContainsAny(e.Password, connectionStringCharacters) {
+				t.Errorf("Expected 32+ characters safe in connection strings, but got %q", e.Password)
+			}
+		}
+	}
+}
+
+// TestTemplate_NewInvalid checks that missing and malformed fields are refused.
+func TestTemplate_NewInvalid(t *testing.T) {
+	base := *config.GetDefaultOptions()
+	base.Length = 16
+	server, _ := Lookup(KindServer)
+	if _, err := server.New(map[string]string{}, base); err == nil {
+		t.Errorf("Expected an error for a missing hostname, but got none")
+	}
+	if _, err := server.New(map[string]string{"hostname": "h", "port": "70000"}, base); err == nil {
+		t.Errorf("Expected an error for an invalid port, but got none")
+	}
+	if _, ok := Lookup("vpn"); ok {
+		t.Errorf("Expected no template for an unknown kind")
+	}
+}
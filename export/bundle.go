@@ -0,0 +1,224 @@
+package export
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"golang.org/x/crypto/chacha20"
+)
+
+// BundleFormat identifies the bundle layout; it changes if the signed
+// content ever does.
+const BundleFormat = "passgen-bundle-v1"
+
+// Bundle records a generation run for later audit and, for runs from a
+// SeededSource, reproduction.
+// Fields:
+//   - Format (string): Always BundleFormat.
+//   - GeneratorVersion (string): The version that generated the results.
+//   - Options (passgen.PasswordOptions): The options of the run.
+//   - Seed (string): The hex seed of the SeededSource the results were
+//     generated from, so Reproduce can generate them again; empty when they
+//     came from the system's secure random source and cannot be regenerated.
+//   - CreatedAt (time.Time): When the bundle was created.
+//   - Results ([]Result): The generated passwords with their metadata.
+//   - PublicKey, Signature ([]byte): The Ed25519 key and signature over all
+//     other fields, set by Sign.
+type Bundle struct {
+	Format           string                  `json:"format"`
+	GeneratorVersion string                  `json:"generator_version"`
+	Options          passgen.PasswordOptions `json:"options"`
+	Seed             string                  `json:"seed,omitempty"`
+	CreatedAt        time.Time               `json:"created_at"`
+	Results          []Result                `json:"results"`
+	PublicKey        []byte                  `json:"public_key,omitempty"`
+	Signature        []byte                  `json:"signature,omitempty"`
+}
+
+// NewBundle records a run that generated results with opts.
+func NewBundle(version string, opts passgen.PasswordOptions, seed string, results []Result, at time.Time) Bundle {
+	return Bundle{
+		Format:           BundleFormat,
+		GeneratorVersion: version,
+		Options:          opts,
+		Seed:             seed,
+		CreatedAt:        at,
+		Results:          results,
+	}
+}
+
+// signedContent returns the bytes covered by the signature: the bundle as
+// JSON without its signature.
+func (b Bundle) signedContent() ([]byte, error) {
+	b.Signature = nil
+	return json.Marshal(b)
+}
+
+// Sign stores key's public half in the bundle and signs it.
+func (b *Bundle) Sign(key ed25519.PrivateKey) error {
+	b.PublicKey = key.Public().(ed25519.PublicKey)
+	content, err := b.signedContent()
+	if err != nil {
+		return err
+	}
+	b.Signature = ed25519.Sign(key, content)
+	return nil
+}
+
+// Verify checks the bundle and its results.
+// Purpose:
+//
+//	Confirms the signature with the embedded public key and re-checks every
+//	result against the recorded options with passgen.Verify. Compare
+//	KeyFingerprint(b.PublicKey) with the signer's known fingerprint to confirm
+//	who created the bundle.
+//
+// Returns:
+//
+//	error: An error if the bundle is unsigned, altered, or a result breaks
+//	the recorded options.
+//
+// Example:
+//
+//	if err := bundle.Verify(); err != nil {
+//		log.Fatal(err)
+//	}
+func (b Bundle) Verify() error {
+	if b.Format != BundleFormat {
+		return fmt.Errorf("unsupported bundle format %q", b.Format)
+	}
+	if len(b.PublicKey) != ed25519.PublicKeySize || len(b.Signature) == 0 {
+		return errors.New("bundle is not signed")
+	}
+	content, err := b.signedContent()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(ed25519.PublicKey(b.PublicKey), content, b.Signature) {
+		return errors.New("bundle signature does not match; it was altered after signing")
+	}
+	passwords := make([]string, len(b.Results))
+	for i, result := range b.Results {
+		passwords[i] = result.Password
+	}
+	return passgen.VerifyPasswords(passwords, b.Options)
+}
+
+// Reproduce generates the results again from the recorded seed and options
+// and compares them with the recorded ones.
+// Returns:
+//
+//	error: An error if the bundle has no seed, or a result differs, e.g.
+//	because another generator version or constraints changed the draws.
+//
+// Example:
+//
+//	if bundle.Seed != "" {
+//		err = bundle.Reproduce()
+//	}
+func (b Bundle) Reproduce() error {
+	if b.Seed == "" {
+		return errors.New("the bundle has no seed; its results came from the secure random source and cannot be regenerated")
+	}
+	source, err := SeededSource(b.Seed)
+	if err != nil {
+		return err
+	}
+	opts := b.Options
+	opts.Quantity = len(b.Results)
+	passwords, err := passgen.NewGenerator(passgen.WithRand(source)).GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+	for i, result := range b.Results {
+		if passwords[i] != result.Password {
+			return fmt.Errorf("result %d differs from the one generated again from the seed", i+1)
+		}
+	}
+	return nil
+}
+
+// seedSize is the length of a bundle seed in bytes, the ChaCha20 key size.
+const seedSize = chacha20.KeySize
+
+// NewSeed returns a random hex seed for SeededSource.
+func NewSeed() (string, error) {
+	seed := make([]byte, seedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(seed), nil
+}
+
+// SeededSource returns the deterministic random source of a seed from
+// NewSeed: the ChaCha20 key stream keyed with the seed. The same seed always
+// gives the same bytes, so passwords drawn from it are only as secret as the
+// seed.
+// Example:
+//
+//	source, err := export.SeededSource(seed)
+//	g := passgen.NewGenerator(passgen.WithRand(source))
+func SeededSource(seed string) (io.Reader, error) {
+	key, err := hex.DecodeString(seed)
+	if err != nil || len(key) != seedSize {
+		return nil, fmt.Errorf("the seed must be %d hex-encoded bytes", seedSize)
+	}
+	cipher, err := chacha20.NewUnauthenticatedCipher(key, make([]byte, chacha20.NonceSize))
+	if err != nil {
+		return nil, err
+	}
+	return keyStream{cipher}, nil
+}
+
+// keyStream reads the key stream of a cipher.
+type keyStream struct {
+	cipher *chacha20.Cipher
+}
+
+func (k keyStream) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	k.cipher.XORKeyStream(p, p)
+	return len(p), nil
+}
+
+// KeyFingerprint returns a short hex fingerprint of a public key.
+func KeyFingerprint(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// LoadSigningKey reads the Ed25519 signing key stored at path, creating and
+// saving a new key, readable only by the current user, if none exists yet.
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if len(data) != ed25519.SeedSize {
+			return nil, fmt.Errorf("%s is not a signing key", path)
+		}
+		return ed25519.NewKeyFromSeed(data), nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, key.Seed(), 0o600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
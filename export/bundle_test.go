@@ -0,0 +1,103 @@
+package export
+
+import (
+	"context"
+	"crypto/ed25519"
+	"path/filepath"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// TestBundle_SignAndVerify verifies that a signed bundle verifies and that
+// any change afterwards is detected.
+func TestBundle_SignAndVerify(t *testing.T) {
+	key, err := LoadSigningKey(filepath.Join(t.TempDir(), "signing.key"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	opts := passgen.PasswordOptions{Length: 4, IncludeNumbers: true}
+	bundle := NewBundle("v1.0.0", opts, "", NewResults([]string{"1234", "5678"}, opts, testTime), testTime)
+
+	if err := bundle.Verify(); err == nil {
+		t.Errorf("Expected an error for an unsigned bundle, but got nil")
+	}
+	if err := bundle.Sign(key); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := bundle.Verify(); err != nil {
+		t.Errorf("Expected the signed bundle to verify, but got %v", err)
+	}
+
+	altered := bundle
+	altered.Results = append([]Result(nil), bundle.Results...)
+	altered.Results[1].Password = "0000"
+	if err := altered.Verify(); err == nil {
+		t.Errorf("Expected an error for altered results, but got nil")
+	}
+}
+
+// TestBundle_VerifyChecksResults verifies that results breaking the recorded
+// options fail even with a valid signature.
+func TestBundle_VerifyChecksResults(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(nil)
+	opts := passgen.PasswordOptions{Length: 4, IncludeNumbers: true}
+	bundle := NewBundle("v1.0.0", opts, "", NewResults([]string{"12ab"}, opts, testTime), testTime)
+	if err := bundle.Sign(key); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := bundle.Verify(); err == nil {
+		t.Errorf("Expected an error for a result outside the options, but got nil")
+	}
+}
+
+// TestLoadSigningKey_Persists verifies that the same key is returned on every load.
+func TestLoadSigningKey_Persists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signing.key")
+	first, err := LoadSigningKey(path)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	second, err := LoadSigningKey(path)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !first.Equal(second) {
+		t.Errorf("Expected the stored key to be reused")
+	}
+}
+
+// TestBundle_Reproduce verifies that a run from a seeded source is
+// generated again from the recorded seed, and that other results fail.
+func TestBundle_Reproduce(t *testing.T) {
+	seed, err := NewSeed()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	source, err := SeededSource(seed)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	opts := passgen.PasswordOptions{Length: 16, Quantity: 3, IncludeLower: true, IncludeNumbers: true}
+	passwords, err := passgen.NewGenerator(passgen.WithRand(source)).GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	bundle := NewBundle("v1.0.0", opts, seed, NewResults(passwords, opts, testTime), testTime)
+	if err := bundle.Reproduce(); err != nil {
+		t.Errorf("Expected the run to be reproduced, but got %v", err)
+	}
+
+	other, _ := NewSeed()
+	bundle.Seed = other
+	if err := bundle.Reproduce(); err == nil {
+		t.Errorf("Expected an error for another seed, but got nil")
+	}
+	bundle.Seed = ""
+	if err := bundle.Reproduce(); err == nil {
+		t.Errorf("Expected an error for a bundle without seed, but got nil")
+	}
+	if _, err := SeededSource("not hex"); err == nil {
+		t.Errorf("Expected an error for a malformed seed, but got nil")
+	}
+}
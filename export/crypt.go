@@ -0,0 +1,198 @@
+package export
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SHA-512-crypt parameters, from Ulrich Drepper's specification.
+const (
+	// DefaultCryptRounds is the rounds glibc uses without rounds= in the salt.
+	DefaultCryptRounds = 5000
+	minCryptRounds     = 1000
+	maxCryptRounds     = 999999999
+	maxCryptSalt       = 16
+)
+
+// cryptAlphabet is the base64 alphabet of crypt(3).
+const cryptAlphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// sha512CryptOrder lists the digest bytes in the order crypt(3) encodes
+// them, three at a time.
+var sha512CryptOrder = [][3]int{
+	{0, 21, 42}, {22, 43, 1}, {44, 2, 23}, {3, 24, 45}, {25, 46, 4}, {47, 5, 26}, {6, 27, 48},
+	{28, 49, 7}, {50, 8, 29}, {9, 30, 51}, {31, 52, 10}, {53, 11, 32}, {12, 33, 54}, {34, 55, 13},
+	{56, 14, 35}, {15, 36, 57}, {37, 58, 16}, {59, 17, 38}, {18, 39, 60}, {40, 61, 19}, {62, 20, 41},
+}
+
+// SHA512Crypt returns the $6$ crypt(3) hash of password, as /etc/shadow
+// and chpasswd -e take it, with a new random salt.
+// Parameters:
+//   - password (string): The password to hash.
+//   - rounds (int): 1000 to 999999999; DefaultCryptRounds is left out of
+//     the hash, as glibc does.
+//
+// Returns:
+//
+//	string: The hash, e.g. $6$salt$... or $6$rounds=N$salt$...
+//	error: If rounds is out of range or no salt could be drawn.
+func SHA512Crypt(password string, rounds int) (string, error) {
+	if rounds < minCryptRounds || rounds > maxCryptRounds {
+		return "", fmt.Errorf("SHA-512-crypt rounds must be between %d and %d, not %d", minCryptRounds, maxCryptRounds, rounds)
+	}
+	random := make([]byte, maxCryptSalt)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	salt := make([]byte, maxCryptSalt)
+	for i, b := range random {
+		salt[i] = cryptAlphabet[b&0x3f]
+	}
+	return sha512Crypt([]byte(password), salt, rounds), nil
+}
+
+// sha512Crypt computes SHA-512-crypt with the given salt, of at most
+// maxCryptSalt bytes.
+func sha512Crypt(password, salt []byte, rounds int) string {
+	// Digest B: password, salt, password
+	b := sha512.New()
+	b.Write(password)
+	b.Write(salt)
+	b.Write(password)
+	digestB := b.Sum(nil)
+
+	// Digest A: password, salt, B for every byte of the password, then B or
+	// the password for each bit of its length
+	a := sha512.New()
+	a.Write(password)
+	a.Write(salt)
+	a.Write(repeatTo(digestB, len(password)))
+	for n := len(password); n > 0; n >>= 1 {
+		if n&1 != 0 {
+			a.Write(digestB)
+		} else {
+			a.Write(password)
+		}
+	}
+	digest := a.Sum(nil)
+
+	// The sequences P and S: the password and salt, hashed and stretched
+	dp := sha512.New()
+	for range password {
+		dp.Write(password)
+	}
+	p := repeatTo(dp.Sum(nil), len(password))
+	ds := sha512.New()
+	for i := 0; i < 16+int(digest[0]); i++ {
+		ds.Write(salt)
+	}
+	s := repeatTo(ds.Sum(nil), len(salt))
+
+	for r := 0; r < rounds; r++ {
+		c := sha512.New()
+		if r&1 != 0 {
+			c.Write(p)
+		} else {
+			c.Write(digest)
+		}
+		if r%3 != 0 {
+			c.Write(s)
+		}
+		if r%7 != 0 {
+			c.Write(p)
+		}
+		if r&1 != 0 {
+			c.Write(digest)
+		} else {
+			c.Write(p)
+		}
+		digest = c.Sum(digest[:0])
+	}
+
+	var out strings.Builder
+	out.WriteString("$6$")
+	if rounds != DefaultCryptRounds {
+		out.WriteString("rounds=" + strconv.Itoa(rounds) + "$")
+	}
+	out.Write(salt)
+	out.WriteString("$")
+	for _, group := range sha512CryptOrder {
+		writeCrypt64(&out, uint(digest[group[0]])<<16|uint(digest[group[1]])<<8|uint(digest[group[2]]), 4)
+	}
+	writeCrypt64(&out, uint(digest[63]), 2)
+	return out.String()
+}
+
+// repeatTo returns data repeated to exactly n bytes.
+func repeatTo(data []byte, n int) []byte {
+	out := make([]byte, 0, n)
+	for len(out)+len(data) <= n {
+		out = append(out, data...)
+	}
+	return append(out, data[:n-len(out)]...)
+}
+
+// writeCrypt64 writes the low 6*n bits of w in the crypt(3) alphabet,
+// least significant first.
+func writeCrypt64(out *strings.Builder, w uint, n int) {
+	for ; n > 0; n-- {
+		out.WriteByte(cryptAlphabet[w&0x3f])
+		w >>= 6
+	}
+}
+
+// Shadow writes one /etc/shadow entry per result: the result's label as
+// the user name, its hash, the day of generation as the last change and
+// the usual aging fields, e.g.
+//
+//	alice:$6$...:20376:0:99999:7:::
+//
+// The first two fields are the user:hash lines chpasswd -e takes.
+func Shadow(w io.Writer, results []Result) error {
+	for _, result := range results {
+		if err := checkAccount(result); err != nil {
+			return err
+		}
+		lastChange := result.GeneratedAt.Unix() / (24 * 60 * 60)
+		if _, err := fmt.Fprintf(w, "%s:%s:%d:0:99999:7:::\n", result.Label, result.Hash, lastChange); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Htpasswd writes one Apache htpasswd line, user:hash, per result, with the
+// result's label as the user name. bcrypt hashes get the $2y$ prefix that
+// htpasswd -B writes; the algorithm is the same.
+func Htpasswd(w io.Writer, results []Result) error {
+	for _, result := range results {
+		if err := checkAccount(result); err != nil {
+			return err
+		}
+		hash := result.Hash
+		if rest, ok := strings.CutPrefix(hash, "$2a$"); ok {
+			hash = "$2y$" + rest
+		}
+		if _, err := fmt.Fprintf(w, "%s:%s\n", result.Label, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkAccount returns an error unless result has a hash and a label that
+// can be a user name in a colon-separated file.
+func checkAccount(result Result) error {
+	if result.Hash == "" {
+		return errors.New("the password has no hash")
+	}
+	if result.Label == "" || strings.ContainsAny(result.Label, ":\n\r\t ") {
+		return fmt.Errorf("%q is not a valid user name", result.Label)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSHA512Crypt checks the test vectors of the SHA-512-crypt
+// specification, with and without rounds=.
+func TestSHA512Crypt(t *testing.T) {
+	tests := []struct {
+		salt, password string
+		rounds         int
+		want           string
+	}{
+		{"saltstring", "Hello world!", DefaultCryptRounds,
+			"$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1"},
+		{"saltstringsaltst", "Hello world!", 10000,
+			"$6$rounds=10000$saltstringsaltst$OW1/O6BYHV6BcXZu8QVeXbDWra3Oeqh0sbHbbMCVNSnCM/UrjmM0Dp8vOuZeHBy/YTBmSK6H9qs/y3RnOaw5v."},
+	}
+	for _, tt := range tests {
+		if got := sha512Crypt([]byte(tt.password), []byte(tt.salt), tt.rounds); got != tt.want {
+			t.Errorf("Expected %s, but got %s", tt.want, got)
+		}
+	}
+}
+
+// TestSHA512Crypt_Salt verifies that every hash gets its own salt and that
+// rounds out of range are refused.
+func TestSHA512Crypt_Salt(t *testing.T) {
+	first, err := SHA512Crypt("pw", DefaultCryptRounds)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	second, _ := SHA512Crypt("pw", DefaultCryptRounds)
+	if first == second || !strings.HasPrefix(first, "$6$") || len(strings.Split(first, "$")[2]) != maxCryptSalt {
+		t.Errorf("Expected two differently salted $6$ hashes, but got %s and %s", first, second)
+	}
+	if _, err := SHA512Crypt("pw", 10); err == nil {
+		t.Error("Expected an error for 10 rounds, but got none")
+	}
+}
+
+// TestShadowAndHtpasswd checks the entries written for labelled, hashed
+// results and that unusable user names are refused.
+func TestShadowAndHtpasswd(t *testing.T) {
+	results := []Result{{Password: "pw", Label: "alice", Hash: "$2a$12$abc", GeneratedAt: testTime}}
+	var shadow, htpasswd strings.Builder
+	if err := Shadow(&shadow, results); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if want := "alice:$2a$12$abc:19844:0:99999:7:::\n"; shadow.String() != want {
+		t.Errorf("Expected %q, but got %q", want, shadow.String())
+	}
+	if err := Htpasswd(&htpasswd, results); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if want := "alice:$2y$12$abc\n"; htpasswd.String() != want {
+		t.Errorf("Expected %q, but got %q", want, htpasswd.String())
+	}
+
+	results[0].Label = "bob:x"
+	if err := Shadow(&shadow, results); err == nil {
+		t.Error("Expected an error for a user name with a colon, but got none")
+	}
+}
@@ -0,0 +1,242 @@
+/**
+ * Password Generator - Structured Results
+ *
+ * This file turns generated passwords into results carrying metadata, such as
+ * the entropy estimate and the generation time, and formats them, with the
+ * options used, as JSON, as CSV for bulk provisioning or
+ * through a user-supplied text/template for tools that expect more than the
+ * bare password.
+ */
+
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/policy"
+)
+
+// DefaultTemplate is offered when the user has not written a template yet.
+const DefaultTemplate = `{{.Password}}  # {{.Entropy}} bits, {{.GeneratedAt.Format "2006-01-02 15:04"}}`
+
+// Result is one generated password with its metadata.
+// Fields:
+//   - Password (string): The generated password.
+//   - Length (int): Number of characters.
+//   - Entropy (float64): Estimated entropy in bits, rounded to one decimal.
+//   - GeneratedAt (time.Time): When the batch was generated.
+//   - Label (string): What the password is for, e.g. a server name; optional.
+//   - Note (string): A free-form note; optional.
+//   - Hash (string): The bcrypt or Argon2id hash of the password, if
+//     requested; see Hasher.
+type Result struct {
+	Password    string    `json:"password"`
+	Length      int       `json:"length"`
+	Entropy     float64   `json:"entropy"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Label       string    `json:"label,omitempty"`
+	Note        string    `json:"note,omitempty"`
+	Hash        string    `json:"hash,omitempty"`
+}
+
+// NewResults wraps a batch generated with opts at time at.
+// Parameters:
+//   - passwords ([]string): The generated passwords.
+//   - opts (passgen.PasswordOptions): The options they were generated with.
+//   - at (time.Time): The generation time.
+//
+// Returns:
+//
+//	[]Result: One result per password.
+//
+// Example:
+//
+//	results := export.NewResults(passwords, opts, time.Now())
+func NewResults(passwords []string, opts passgen.PasswordOptions, at time.Time) []Result {
+	entropy := math.Round(passgen.EstimateEntropy(opts)*10) / 10
+	results := make([]Result, len(passwords))
+	for i, password := range passwords {
+		results[i] = Result{
+			Password:    password,
+			Length:      len([]rune(password)),
+			Entropy:     entropy,
+			GeneratedAt: at,
+		}
+	}
+	return results
+}
+
+// Batch is a generated batch with the options it was generated with, the
+// JSON output for automation pipelines.
+// Fields:
+//   - GeneratedAt (time.Time): When the batch was generated.
+//   - Options (passgen.PasswordOptions): The options used.
+//   - Results ([]Result): The passwords with their metadata.
+type Batch struct {
+	GeneratedAt time.Time
+	Options     passgen.PasswordOptions
+	Results     []Result
+}
+
+// NewBatch wraps results generated with opts at time at.
+func NewBatch(results []Result, opts passgen.PasswordOptions, at time.Time) Batch {
+	return Batch{GeneratedAt: at, Options: opts, Results: results}
+}
+
+// batchJSON is the JSON form of a Batch. The options get snake_case names
+// of their own, since passgen.PasswordOptions is stored elsewhere under its
+// field names, and the results leave out the time the batch already has.
+type batchJSON struct {
+	GeneratedAt time.Time         `json:"generated_at"`
+	Options     batchOptionsJSON  `json:"options"`
+	Results     []batchResultJSON `json:"results"`
+}
+
+// batchOptionsJSON is the JSON form of the options of a Batch.
+type batchOptionsJSON struct {
+	Length            int     `json:"length"`
+	Quantity          int     `json:"quantity"`
+	IncludeLower      bool    `json:"include_lower"`
+	IncludeUpper      bool    `json:"include_upper"`
+	IncludeNumbers    bool    `json:"include_numbers"`
+	IncludeSymbols    bool    `json:"include_symbols"`
+	BeginWithLetter   bool    `json:"begin_with_letter"`
+	NoSimilar         bool    `json:"no_similar"`
+	NoDuplicates      bool    `json:"no_duplicates"`
+	NoSequential      bool    `json:"no_sequential"`
+	AlternateHands    bool    `json:"alternate_hands"`
+	KeyboardLayout    string  `json:"keyboard_layout,omitempty"`
+	ExcludeCharacters string  `json:"exclude_characters,omitempty"`
+	MustInclude       string  `json:"must_include,omitempty"`
+	MinEntropy        float64 `json:"min_entropy,omitempty"`
+	GroupSize         int     `json:"group_size,omitempty"`
+	GroupSeparator    string  `json:"group_separator,omitempty"`
+}
+
+// batchResultJSON is the JSON form of a Result within a Batch.
+type batchResultJSON struct {
+	Password string  `json:"password"`
+	Length   int     `json:"length"`
+	Entropy  float64 `json:"entropy"`
+	Label    string  `json:"label,omitempty"`
+	Note     string  `json:"note,omitempty"`
+	Hash     string  `json:"hash,omitempty"`
+}
+
+// JSON formats the batch as an indented JSON object. Passwords are written
+// as generated, without escaping <, > and & for HTML.
+// Example:
+//
+//	data, err := export.NewBatch(export.NewResults(passwords, opts, now), opts, now).JSON()
+func (b Batch) JSON() ([]byte, error) {
+	opts := b.Options
+	out := batchJSON{
+		GeneratedAt: b.GeneratedAt,
+		Options: batchOptionsJSON{
+			Length:            opts.Length,
+			Quantity:          opts.Quantity,
+			IncludeLower:      opts.IncludeLower,
+			IncludeUpper:      opts.IncludeUpper,
+			IncludeNumbers:    opts.IncludeNumbers,
+			IncludeSymbols:    opts.IncludeSymbols,
+			BeginWithLetter:   opts.BeginWithLetter,
+			NoSimilar:         opts.NoSimilar,
+			NoDuplicates:      opts.NoDuplicates,
+			NoSequential:      opts.NoSequential,
+			AlternateHands:    opts.AlternateHands,
+			KeyboardLayout:    opts.KeyboardLayout,
+			ExcludeCharacters: opts.ExcludeCharacters,
+			MustInclude:       opts.MustInclude,
+			MinEntropy:        opts.MinEntropy,
+			GroupSize:         opts.GroupSize,
+			GroupSeparator:    opts.GroupSeparator,
+		},
+		Results: make([]batchResultJSON, len(b.Results)),
+	}
+	for i, result := range b.Results {
+		out.Results[i] = batchResultJSON{
+			Password: result.Password,
+			Length:   result.Length,
+			Entropy:  result.Entropy,
+			Label:    result.Label,
+			Note:     result.Note,
+			Hash:     result.Hash,
+		}
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(out); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// CSV writes the results as a CSV file for bulk provisioning, with a
+// header row and the columns index (from 1), password, length, entropy,
+// classes (which of lower, upper, digit and symbol the password uses),
+// generated_at, label and note, and hash if any result has one.
+// Example:
+//
+//	err := export.CSV(file, export.NewResults(passwords, opts, time.Now()))
+func CSV(w io.Writer, results []Result) error {
+	hashed := false
+	for _, result := range results {
+		hashed = hashed || result.Hash != ""
+	}
+	header := []string{"index", "password", "length", "entropy", "classes", "generated_at", "label", "note"}
+	if hashed {
+		header = append(header, "hash")
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for i, result := range results {
+		row := []string{
+			strconv.Itoa(i + 1),
+			result.Password,
+			strconv.Itoa(result.Length),
+			strconv.FormatFloat(result.Entropy, 'f', 1, 64),
+			strings.Join(policy.Classes(result.Password), " "),
+			result.GeneratedAt.Format(time.RFC3339),
+			result.Label,
+			result.Note,
+		}
+		if hashed {
+			row = append(row, result.Hash)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Template renders every result with text, one per line. The template sees
+// the fields of Result, e.g. {{.Password}} and {{.Entropy}}.
+func Template(text string, results []Result) (string, error) {
+	tmpl, err := template.New("result").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	for _, result := range results {
+		if err := tmpl.Execute(&out, result); err != nil {
+			return "", err
+		}
+		out.WriteString("\n")
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,71 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+var testTime = time.Date(2024, time.May, 1, 12, 30, 0, 0, time.UTC)
+
+// TestBatchJSON checks the JSON form of a batch with its options.
+func TestBatchJSON(t *testing.T) {
+	opts := passgen.PasswordOptions{Length: 4, IncludeNumbers: true}
+	data, err := NewBatch(NewResults([]string{"1234"}, opts, testTime), opts, testTime).JSON()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	want := "{\n      \"password\": \"1234\",\n      \"length\": 4,\n      \"entropy\": 13.3\n    }"
+	if !strings.Contains(string(data), want) {
+		t.Errorf("Expected %s in the results, but got %s", want, data)
+	}
+	if !strings.HasPrefix(string(data), "{\n  \"generated_at\": \"2024-05-01T12:30:00Z\",\n  \"options\": {") || !strings.Contains(string(data), "\"include_numbers\": true") {
+		t.Errorf("Expected the time and options first, but got %s", data)
+	}
+	if strings.Count(string(data), "generated_at") != 1 || strings.HasSuffix(string(data), "\n") {
+		t.Errorf("Expected the time once and no trailing newline, but got %s", data)
+	}
+	symbols := NewResults([]string{"a<b>&c"}, opts, testTime)
+	if data, _ := NewBatch(symbols, opts, testTime).JSON(); !strings.Contains(string(data), `"password": "a<b>&c"`) {
+		t.Errorf("Expected the password unescaped, but got %s", data)
+	}
+	tagged := NewResults([]string{"1234"}, opts, testTime)
+	tagged[0].Label, tagged[0].Note = "db-01", "rotate in May"
+	if data, _ := NewBatch(tagged, opts, testTime).JSON(); !strings.Contains(string(data), "\"label\": \"db-01\",\n      \"note\": \"rotate in May\"") {
+		t.Errorf("Expected the label and note, but got %s", data)
+	}
+}
+
+// TestCSV checks the header, the classes column and quoting.
+func TestCSV(t *testing.T) {
+	opts := passgen.PasswordOptions{Length: 6, IncludeLower: true, IncludeNumbers: true, IncludeSymbols: true}
+	results := NewResults([]string{"ab1,2!", "zzzzzz"}, opts, testTime)
+	results[1].Label = "db-01"
+	var out strings.Builder
+	if err := CSV(&out, results); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	want := "index,password,length,entropy,classes,generated_at,label,note\n" +
+		"1,\"ab1,2!\",6,35.1,lower digit symbol,2024-05-01T12:30:00Z,,\n" +
+		"2,zzzzzz,6,35.1,lower,2024-05-01T12:30:00Z,db-01,\n"
+	if out.String() != want {
+		t.Errorf("Expected %q, but got %q", want, out.String())
+	}
+}
+
+// TestTemplate renders one line per result and reports template errors.
+func TestTemplate(t *testing.T) {
+	results := NewResults([]string{"a", "b"}, passgen.PasswordOptions{Length: 1, IncludeLower: true}, testTime)
+	got, err := Template("{{.Password}}:{{.Length}}", results)
+	if err != nil || got != "a:1\nb:1\n" {
+		t.Errorf("Expected %q, but got %q (%v)", "a:1\nb:1\n", got, err)
+	}
+	if _, err := Template("{{.Missing}}", results); err == nil {
+		t.Errorf("Expected an error for an unknown field, but got nil")
+	}
+	if _, err := Template(DefaultTemplate, results); err != nil {
+		t.Errorf("Expected the default template to work, but got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package export
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/PaulBaker1/Password-Generator-GO/kdf"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Hash schemes of Hasher.
+const (
+	HashBcrypt      = "bcrypt"
+	HashArgon2id    = "argon2id"
+	HashSHA512Crypt = "sha512-crypt"
+)
+
+// Sizes of the Argon2id salt and hash in bytes.
+const (
+	argon2SaltSize = 16
+	argon2KeySize  = 32
+)
+
+// Hasher hashes passwords for seeding user databases and htpasswd-style
+// files. bcrypt hashes are in the $2a$ format, Argon2id hashes in the PHC
+// string format, $argon2id$v=19$m=...,t=...,p=...$salt$hash, which common
+// libraries verify, and SHA-512-crypt hashes in the $6$ format of
+// /etc/shadow.
+// Fields:
+//   - Scheme (string): HashBcrypt, HashArgon2id or HashSHA512Crypt.
+//   - Cost (int): The bcrypt cost, 4 to 31.
+//   - Rounds (int): The SHA-512-crypt rounds.
+//   - Params (kdf.Params): The Argon2id cost.
+type Hasher struct {
+	Scheme string
+	Cost   int
+	Rounds int
+	kdf.Params
+}
+
+// DefaultHasher hashes with Argon2id at kdf.Default, or with bcrypt at
+// cost 12, or SHA-512-crypt with the glibc default rounds.
+var DefaultHasher = Hasher{Scheme: HashArgon2id, Cost: 12, Rounds: DefaultCryptRounds, Params: kdf.Default}
+
+// Validate checks the scheme and its parameters.
+func (h Hasher) Validate() error {
+	switch h.Scheme {
+	case HashBcrypt:
+		if h.Cost < bcrypt.MinCost || h.Cost > bcrypt.MaxCost {
+			return fmt.Errorf("the bcrypt cost must be between %d and %d, not %d", bcrypt.MinCost, bcrypt.MaxCost, h.Cost)
+		}
+	case HashArgon2id:
+		return h.Params.Validate()
+	case HashSHA512Crypt:
+		if h.Rounds < minCryptRounds || h.Rounds > maxCryptRounds {
+			return fmt.Errorf("SHA-512-crypt rounds must be between %d and %d, not %d", minCryptRounds, maxCryptRounds, h.Rounds)
+		}
+	default:
+		return fmt.Errorf("unknown hash %q; use %s, %s or %s", h.Scheme, HashBcrypt, HashArgon2id, HashSHA512Crypt)
+	}
+	return nil
+}
+
+// Hash returns the hash of password, with a new random salt.
+// Example:
+//
+//	hash, err := export.Hasher{Scheme: export.HashBcrypt, Cost: 12}.Hash(password)
+func (h Hasher) Hash(password string) (string, error) {
+	if err := h.Validate(); err != nil {
+		return "", err
+	}
+	if h.Scheme == HashSHA512Crypt {
+		return SHA512Crypt(password, h.Rounds)
+	}
+	if h.Scheme == HashBcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+		if err != nil {
+			return "", fmt.Errorf("bcrypt: %w", err)
+		}
+		return string(hash), nil
+	}
+	salt := make([]byte, argon2SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := h.Key([]byte(password), salt, argon2KeySize)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.Memory, h.Time, h.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// AddHashes sets the Hash of every result.
+func AddHashes(results []Result, h Hasher) error {
+	for i := range results {
+		hash, err := h.Hash(results[i].Password)
+		if err != nil {
+			return err
+		}
+		results[i].Hash = hash
+	}
+	return nil
+}
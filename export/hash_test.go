@@ -0,0 +1,73 @@
+package export
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/kdf"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestHasher_Bcrypt verifies that bcrypt hashes check against the password
+// at the requested cost.
+func TestHasher_Bcrypt(t *testing.T) {
+	hash, err := Hasher{Scheme: HashBcrypt, Cost: bcrypt.MinCost}.Hash("s3cret-Pa55")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte("s3cret-Pa55")); err != nil {
+		t.Errorf("Expected the hash to match the password, but got %v", err)
+	}
+	if cost, _ := bcrypt.Cost([]byte(hash)); cost != bcrypt.MinCost {
+		t.Errorf("Expected cost %d, but got %d", bcrypt.MinCost, cost)
+	}
+}
+
+// TestHasher_Argon2id verifies the PHC string and that it can be recomputed
+// from its parameters and salt.
+func TestHasher_Argon2id(t *testing.T) {
+	h := Hasher{Scheme: HashArgon2id, Params: kdf.Params{Time: 1, Memory: 64, Threads: 2}}
+	hash, err := h.Hash("s3cret-Pa55")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" || parts[2] != fmt.Sprintf("v=%d", argon2.Version) || parts[3] != "m=64,t=1,p=2" {
+		t.Fatalf("Expected a PHC string with the parameters, but got %q", hash)
+	}
+	salt, _ := base64.RawStdEncoding.DecodeString(parts[4])
+	want := base64.RawStdEncoding.EncodeToString(argon2.IDKey([]byte("s3cret-Pa55"), salt, 1, 64, 2, argon2KeySize))
+	if parts[5] != want {
+		t.Errorf("Expected the hash %s, but got %s", want, parts[5])
+	}
+}
+
+// TestHasher_Validate verifies that unknown schemes and unusable parameters
+// are refused.
+func TestHasher_Validate(t *testing.T) {
+	for _, h := range []Hasher{{Scheme: "md5"}, {Scheme: HashBcrypt, Cost: 40}, {Scheme: HashArgon2id, Params: kdf.Params{Time: 1, Memory: 8, Threads: 4}}} {
+		if err := h.Validate(); err == nil {
+			t.Errorf("Expected an error for %+v, but got none", h)
+		}
+	}
+	if err := DefaultHasher.Validate(); err != nil {
+		t.Errorf("Expected the defaults to be valid, but got %v", err)
+	}
+}
+
+// TestCSV_Hash verifies the hash column is added when results are hashed.
+func TestCSV_Hash(t *testing.T) {
+	results := NewResults([]string{"abc"}, passgen.PasswordOptions{Length: 3, IncludeLower: true}, testTime)
+	results[0].Hash = "$2a$04$x"
+	var out strings.Builder
+	if err := CSV(&out, results); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "index,password,length,entropy,classes,generated_at,label,note,hash\n") || !strings.HasSuffix(out.String(), ",$2a$04$x\n") {
+		t.Errorf("Expected a hash column, but got %q", out.String())
+	}
+}
@@ -0,0 +1,86 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// Password managers whose CSV import layout ManagerCSV writes.
+const (
+	Bitwarden   = "bitwarden"
+	OnePassword = "1password"
+	LastPass    = "lastpass"
+)
+
+// Managers lists the password managers ManagerCSV supports, in the order
+// they are offered.
+var Managers = []string{Bitwarden, OnePassword, LastPass}
+
+// managerLayouts holds the header of each manager's import CSV and how a
+// result fills a row of it; name is the label, or a numbered name if the
+// result has none.
+var managerLayouts = map[string]struct {
+	header []string
+	row    func(name string, result Result) []string
+}{
+	// Bitwarden's vault import ("Bitwarden (csv)"), as login items
+	Bitwarden: {
+		header: []string{"folder", "favorite", "type", "name", "notes", "fields", "reprompt", "login_uri", "login_username", "login_password", "login_totp"},
+		row: func(name string, r Result) []string {
+			return []string{"", "", "login", name, r.Note, "", "", "", "", r.Password, ""}
+		},
+	},
+	// 1Password's CSV import, with the columns mapped by their header
+	OnePassword: {
+		header: []string{"Title", "Website", "Username", "Password", "Notes"},
+		row: func(name string, r Result) []string {
+			return []string{name, "", "", r.Password, r.Note}
+		},
+	},
+	// LastPass's "Generic CSV File" import
+	LastPass: {
+		header: []string{"url", "username", "password", "totp", "extra", "name", "grouping", "fav"},
+		row: func(name string, r Result) []string {
+			return []string{"", "", r.Password, "", r.Note, name, "", "0"}
+		},
+	},
+}
+
+// ManagerCSV writes the results as a CSV file the given password manager
+// imports, so that a provisioned batch is loaded in one step. Each result
+// becomes a login named after its label, or "Generated password 1" and so
+// on, with its note; username and website are left for the import to fill.
+// Parameters:
+//   - w (io.Writer): Receives the CSV.
+//   - manager (string): One of Managers.
+//   - results ([]Result): The results to write.
+//
+// Returns:
+//
+//	error: If manager is unknown or writing fails.
+//
+// Example:
+//
+//	err := export.ManagerCSV(file, export.Bitwarden, results)
+func ManagerCSV(w io.Writer, manager string, results []Result) error {
+	layout, ok := managerLayouts[manager]
+	if !ok {
+		return fmt.Errorf("unknown password manager %q", manager)
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(layout.header); err != nil {
+		return err
+	}
+	for i, result := range results {
+		name := result.Label
+		if name == "" {
+			name = fmt.Sprintf("Generated password %d", i+1)
+		}
+		if err := writer.Write(layout.row(name, result)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
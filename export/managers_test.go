@@ -0,0 +1,38 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// TestManagerCSV checks the header and row of each password manager layout
+// and that unknown managers are refused.
+func TestManagerCSV(t *testing.T) {
+	results := NewResults([]string{"ab1,2!", "zzzzzz"}, passgen.PasswordOptions{Length: 6, IncludeLower: true}, testTime)
+	results[0].Label, results[0].Note = "db-01", "rotate in May"
+	want := map[string]string{
+		Bitwarden: "folder,favorite,type,name,notes,fields,reprompt,login_uri,login_username,login_password,login_totp\n" +
+			",,login,db-01,rotate in May,,,,,\"ab1,2!\",\n" +
+			",,login,Generated password 2,,,,,,zzzzzz,\n",
+		OnePassword: "Title,Website,Username,Password,Notes\n" +
+			"db-01,,,\"ab1,2!\",rotate in May\n" +
+			"Generated password 2,,,zzzzzz,\n",
+		LastPass: "url,username,password,totp,extra,name,grouping,fav\n" +
+			",,\"ab1,2!\",,rotate in May,db-01,,0\n" +
+			",,zzzzzz,,,Generated password 2,,0\n",
+	}
+	for _, manager := range Managers {
+		var out strings.Builder
+		if err := ManagerCSV(&out, manager, results); err != nil {
+			t.Fatalf("%s: Expected no error, but got %v", manager, err)
+		}
+		if out.String() != want[manager] {
+			t.Errorf("%s: Expected %q, but got %q", manager, want[manager], out.String())
+		}
+	}
+	if err := ManagerCSV(&strings.Builder{}, "keychain", results); err == nil {
+		t.Error("Expected an error for an unknown password manager, but got nil")
+	}
+}
@@ -0,0 +1,138 @@
+package export
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/kdf"
+)
+
+// ReceiptFormat identifies the receipt layout and hash construction.
+const ReceiptFormat = "passgen-receipt-v1"
+
+// receiptSaltSize is the length of the random salt of each receipt.
+const receiptSaltSize = 16
+
+// Receipt proves which password was generated for whom and when, without
+// containing the password.
+// Fields:
+//   - Format (string): Always ReceiptFormat.
+//   - Label (string): Who or what the password was for; optional.
+//   - GeneratedAt (time.Time): When the password was generated.
+//   - KDF (kdf.Params): The Argon2id cost the hash was computed with; it
+//     is stored, so raising kdf.Default does not break older receipts.
+//   - Salt, Hash ([]byte): The random salt and the Argon2id hash of the
+//     password, label and time.
+type Receipt struct {
+	Format      string     `json:"format"`
+	Label       string     `json:"label,omitempty"`
+	GeneratedAt time.Time  `json:"generated_at"`
+	KDF         kdf.Params `json:"kdf"`
+	Salt        []byte     `json:"salt"`
+	Hash        []byte     `json:"hash"`
+}
+
+// NewReceipt records a salted hash of a password.
+// Purpose:
+//
+//	Lets a recipient later check "is this the password you generated for
+//	me on date X" while the generator keeps no plaintext. The label and
+//	time are hashed together with the password, so a receipt cannot be
+//	moved to another label or date. Argon2id makes guessing the password
+//	from a leaked receipt as slow as cracking a stored password hash.
+//
+// Parameters:
+//   - password (string): The generated password.
+//   - label (string): Who or what the password is for; may be empty.
+//   - at (time.Time): When the password was generated.
+//
+// Returns:
+//
+//	Receipt: The receipt, safe to store and share.
+//	error: An error if no random salt could be read.
+//
+// Example:
+//
+//	receipt, err := export.NewReceipt(password, "alice@example.com", time.Now())
+func NewReceipt(password, label string, at time.Time) (Receipt, error) {
+	salt := make([]byte, receiptSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return Receipt{}, err
+	}
+	r := Receipt{
+		Format:      ReceiptFormat,
+		Label:       label,
+		GeneratedAt: at.UTC(),
+		KDF:         kdf.Default,
+		Salt:        salt,
+	}
+	hash, err := r.hash(password)
+	if err != nil {
+		return Receipt{}, err
+	}
+	r.Hash = hash
+	return r, nil
+}
+
+// NewReceipts records a receipt for each of a batch of passwords.
+func NewReceipts(passwords []string, label string, at time.Time) ([]Receipt, error) {
+	receipts := make([]Receipt, len(passwords))
+	for i, password := range passwords {
+		receipt, err := NewReceipt(password, label, at)
+		if err != nil {
+			return nil, err
+		}
+		receipts[i] = receipt
+	}
+	return receipts, nil
+}
+
+// hash computes the Argon2id hash of password, bound to the label and time.
+func (r Receipt) hash(password string) ([]byte, error) {
+	salt := append([]byte(nil), r.Salt...)
+	salt = append(salt, r.GeneratedAt.UTC().Format(time.RFC3339Nano)...)
+	salt = append(salt, 0)
+	salt = append(salt, r.Label...)
+	return r.KDF.Key([]byte(password), salt, 32)
+}
+
+// Matches reports whether password is the one the receipt was made for.
+// Returns:
+//
+//	bool: true if the password, label and time all match.
+//	error: An error if the receipt is malformed, e.g. not a receipt at all.
+func (r Receipt) Matches(password string) (bool, error) {
+	if r.Format != ReceiptFormat {
+		return false, fmt.Errorf("unsupported receipt format %q", r.Format)
+	}
+	if len(r.Salt) == 0 || len(r.Hash) == 0 {
+		return false, errors.New("receipt is incomplete")
+	}
+	hash, err := r.hash(password)
+	if err != nil {
+		return false, fmt.Errorf("receipt: %w", err)
+	}
+	return subtle.ConstantTimeCompare(hash, r.Hash) == 1, nil
+}
+
+// MatchReceipt returns the receipt among receipts that password matches.
+// Returns:
+//
+//	Receipt: The matching receipt, with its label and time.
+//	bool: false if none matches.
+//	error: An error if a receipt is malformed.
+func MatchReceipt(receipts []Receipt, password string) (Receipt, bool, error) {
+	for _, receipt := range receipts {
+		ok, err := receipt.Matches(password)
+		if err != nil {
+			return Receipt{}, false, err
+		}
+		if ok {
+			return receipt, true, nil
+		}
+	}
+	return Receipt{}, false, nil
+}
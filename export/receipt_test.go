@@ -0,0 +1,68 @@
+package export
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestReceipt_Matches verifies that a receipt survives JSON and matches
+// only its own password, label and time.
+func TestReceipt_Matches(t *testing.T) {
+	receipt, err := NewReceipt("x7Kp93fQLmR2", "alice", testTime)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	data, err := json.Marshal(receipt)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if strings.Contains(string(data), "x7Kp93fQLmR2") {
+		t.Fatalf("Expected no plaintext in the receipt, but got %s", data)
+	}
+	var loaded Receipt
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if ok, err := loaded.Matches("x7Kp93fQLmR2"); !ok || err != nil {
+		t.Errorf("Expected the password to match, but got %v, %v", ok, err)
+	}
+	if ok, _ := loaded.Matches("x7Kp93fQLmR3"); ok {
+		t.Errorf("Expected another password not to match, but it did")
+	}
+
+	relabeled := loaded
+	relabeled.Label = "mallory"
+	if ok, _ := relabeled.Matches("x7Kp93fQLmR2"); ok {
+		t.Errorf("Expected a changed label not to match, but it did")
+	}
+	redated := loaded
+	redated.GeneratedAt = testTime.Add(24 * time.Hour)
+	if ok, _ := redated.Matches("x7Kp93fQLmR2"); ok {
+		t.Errorf("Expected a changed date not to match, but it did")
+	}
+}
+
+// TestMatchReceipt verifies that the matching receipt of a batch is found
+// and that malformed receipts are reported.
+func TestMatchReceipt(t *testing.T) {
+	receipts, err := NewReceipts([]string{"first", "second"}, "", testTime)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if receipt, ok, err := MatchReceipt(receipts, "second"); !ok || err != nil || string(receipt.Hash) != string(receipts[1].Hash) {
+		t.Errorf("Expected the second receipt, but got %v, %v", ok, err)
+	}
+	if _, ok, _ := MatchReceipt(receipts, "third"); ok {
+		t.Errorf("Expected no match, but got one")
+	}
+	if _, _, err := MatchReceipt([]Receipt{{Format: ReceiptFormat}}, "first"); err == nil {
+		t.Errorf("Expected an error for an incomplete receipt, but got nil")
+	}
+	damaged := receipts[0]
+	damaged.KDF.Threads = 0
+	if _, err := damaged.Matches("first"); err == nil {
+		t.Errorf("Expected an error for a receipt without threads, but got nil")
+	}
+}
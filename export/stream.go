@@ -0,0 +1,234 @@
+package export
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// Stream formats accepted in StreamOptions.Format.
+const (
+	FormatText  = "text"
+	FormatJSONL = "jsonl"
+)
+
+// DefaultChunkSize is how many passwords travel through the pipeline together.
+const DefaultChunkSize = 1024
+
+// StreamOptions configures Stream.
+// Fields:
+//   - Format (string): FormatText (one password per line) or FormatJSONL
+//     (one Result object per line).
+//   - Gzip (bool): Compresses the output.
+//   - Workers (int): Serialization goroutines; runtime.GOMAXPROCS(0) when 0.
+//     Generation always uses one goroutine per CPU.
+//   - ChunkSize (int): Passwords per chunk; DefaultChunkSize when 0.
+//   - Generator (*passgen.Generator): Generates the passwords; the
+//     package-level passgen.GenerateStream when nil.
+type StreamOptions struct {
+	Format    string
+	Gzip      bool
+	Workers   int
+	ChunkSize int
+	Generator *passgen.Generator
+}
+
+// chunk is a batch of passwords moving through the pipeline.
+type chunk struct {
+	passwords []string
+	data      []byte
+}
+
+// Stream generates count passwords and writes them to w as they are made.
+// Purpose:
+//
+//	Runs generation (passgen.GenerateStream), serialization and compression
+//	concurrently as a pipeline of goroutines joined by bounded channels, so that exports of
+//	millions of passwords neither wait for the whole batch nor hold it in
+//	memory. Chunks are written in the order they complete; as every password
+//	is independent, the order carries no meaning.
+//
+// Parameters:
+//   - ctx (context.Context): Cancels the export.
+//   - w (io.Writer): The destination.
+//   - opts (passgen.PasswordOptions): Generation options; Quantity is ignored.
+//   - count (int): Number of passwords to generate.
+//   - so (StreamOptions): Format, compression and concurrency.
+//
+// Returns:
+//
+//	error: The first error of any stage; the output is incomplete then.
+//
+// Example:
+//
+//	err := export.Stream(ctx, file, opts, 1000000, export.StreamOptions{Format: export.FormatJSONL, Gzip: true})
+func Stream(ctx context.Context, w io.Writer, opts passgen.PasswordOptions, count int, so StreamOptions) error {
+	if so.Format == "" {
+		so.Format = FormatText
+	}
+	if so.Format != FormatText && so.Format != FormatJSONL {
+		return fmt.Errorf("unknown export format %q", so.Format)
+	}
+	if err := passgen.Validate(opts); err != nil {
+		return err
+	}
+	if so.Workers <= 0 {
+		so.Workers = runtime.GOMAXPROCS(0)
+	}
+	if so.ChunkSize <= 0 {
+		so.ChunkSize = DefaultChunkSize
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var once sync.Once
+	var failed error
+	fail := func(err error) {
+		once.Do(func() {
+			failed = err
+			cancel()
+		})
+	}
+
+	// Stage 1: generate the passwords and gather them into chunks.
+	generated := make(chan chunk, so.Workers)
+	if count <= 0 {
+		close(generated)
+	} else {
+		streamOpts := opts
+		streamOpts.Quantity = count
+		generate := passgen.GenerateStream
+		if so.Generator != nil {
+			generate = so.Generator.GenerateStream
+		}
+		results, err := generate(ctx, streamOpts)
+		if err != nil {
+			return err
+		}
+		go func() {
+			defer close(generated)
+			send := func(passwords []string) bool {
+				select {
+				case generated <- chunk{passwords: passwords}:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+			passwords := make([]string, 0, so.ChunkSize)
+			for r := range results {
+				if r.Err != nil {
+					fail(r.Err)
+					return
+				}
+				passwords = append(passwords, r.Password)
+				if len(passwords) == so.ChunkSize {
+					if !send(passwords) {
+						return
+					}
+					passwords = make([]string, 0, so.ChunkSize)
+				}
+			}
+			if len(passwords) > 0 && ctx.Err() == nil {
+				send(passwords)
+			}
+		}()
+	}
+
+	// Stage 2: serialize each chunk.
+	serialized := make(chan chunk, so.Workers)
+	entropy := math.Round(passgen.EstimateEntropy(opts)*10) / 10
+	at := time.Now().UTC()
+	runStage(so.Workers, func() {
+		for c := range generated {
+			data, err := serializeChunk(c.passwords, so.Format, entropy, at)
+			if err != nil {
+				fail(err)
+				return
+			}
+			select {
+			case serialized <- chunk{data: data}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}, func() { close(serialized) })
+
+	// Stage 3: compress and write, in this goroutine.
+	if err := writeChunks(w, serialized, so.Gzip); err != nil {
+		fail(err)
+	}
+	for range serialized {
+		// Drain so that no stage blocks after a failure.
+	}
+	if failed != nil {
+		return failed
+	}
+	return ctx.Err()
+}
+
+// runStage starts workers goroutines running work and calls done once all
+// of them have returned.
+func runStage(workers int, work func(), done func()) {
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			work()
+		}()
+	}
+	go func() {
+		wg.Wait()
+		done()
+	}()
+}
+
+// serializeChunk formats passwords as text lines or JSON lines.
+func serializeChunk(passwords []string, format string, entropy float64, at time.Time) ([]byte, error) {
+	var out []byte
+	for _, password := range passwords {
+		if format == FormatJSONL {
+			line, err := json.Marshal(Result{Password: password, Length: len([]rune(password)), Entropy: entropy, GeneratedAt: at})
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, line...)
+		} else {
+			out = append(out, password...)
+		}
+		out = append(out, '\n')
+	}
+	return out, nil
+}
+
+// writeChunks writes every chunk to w, compressed if requested.
+func writeChunks(w io.Writer, chunks <-chan chunk, compress bool) error {
+	buffered := bufio.NewWriterSize(w, 1<<16)
+	out := io.Writer(buffered)
+	var zw *gzip.Writer
+	if compress {
+		zw = gzip.NewWriter(buffered)
+		out = zw
+	}
+	for c := range chunks {
+		if _, err := out.Write(c.data); err != nil {
+			return err
+		}
+	}
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			return err
+		}
+	}
+	return buffered.Flush()
+}
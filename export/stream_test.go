@@ -0,0 +1,94 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// streamOptions are the generation options used by the stream tests.
+var streamOptions = passgen.PasswordOptions{Length: 16, IncludeNumbers: true, IncludeUpper: true, IncludeLower: true}
+
+// TestStream_Text writes exactly count passwords, one per line.
+func TestStream_Text(t *testing.T) {
+	var out bytes.Buffer
+	if err := Stream(context.Background(), &out, streamOptions, 2500, StreamOptions{Workers: 3, ChunkSize: 100}); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2500 {
+		t.Fatalf("Expected 2500 lines, but got %d", len(lines))
+	}
+	for _, line := range lines {
+		if len(line) != 16 {
+			t.Errorf("Expected 16-character passwords, but got %q", line)
+		}
+	}
+}
+
+// TestStream_JSONLGzip writes compressed JSON lines that decode to results.
+func TestStream_JSONLGzip(t *testing.T) {
+	var out bytes.Buffer
+	if err := Stream(context.Background(), &out, streamOptions, 1000, StreamOptions{Format: FormatJSONL, Gzip: true}); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	zr, err := gzip.NewReader(&out)
+	if err != nil {
+		t.Fatalf("Expected gzip output, but got %v", err)
+	}
+	scanner := bufio.NewScanner(zr)
+	n := 0
+	for scanner.Scan() {
+		var result Result
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil || result.Length != 16 || result.Entropy == 0 {
+			t.Fatalf("Unexpected line %q: %v", scanner.Text(), err)
+		}
+		n++
+	}
+	if n != 1000 {
+		t.Errorf("Expected 1000 results, but got %d", n)
+	}
+}
+
+// TestStream_Errors reports invalid options and cancellation.
+func TestStream_Errors(t *testing.T) {
+	var out bytes.Buffer
+	if err := Stream(context.Background(), &out, passgen.PasswordOptions{Length: 8}, 10, StreamOptions{}); passgen.ErrorCode(err) != passgen.CodeNoCharacterTypes {
+		t.Errorf("Expected %s, but got %v", passgen.CodeNoCharacterTypes, err)
+	}
+	if err := Stream(context.Background(), &out, streamOptions, 10, StreamOptions{Format: "xml"}); err == nil {
+		t.Errorf("Expected an error for an unknown format, but got nil")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Stream(ctx, &out, streamOptions, 1000000, StreamOptions{}); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, but got %v", err)
+	}
+}
+
+// BenchmarkStream exports 100k passwords through the pipeline.
+func BenchmarkStream(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var out bytes.Buffer
+		if err := Stream(context.Background(), &out, streamOptions, 100000, StreamOptions{Format: FormatJSONL, Gzip: true}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkStream_Sequential exports the same batch one stage after another,
+// as a baseline for BenchmarkStream.
+func BenchmarkStream_Sequential(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var out bytes.Buffer
+		if err := Stream(context.Background(), &out, streamOptions, 100000, StreamOptions{Format: FormatJSONL, Gzip: true, Workers: 1, ChunkSize: 100000}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/**
+ * Password Generator - Git Credential Helper Protocol
+ *
+ * This file reads and writes the key=value format git uses to talk to
+ * credential helpers (see gitcredentials(7)). Git runs the helper with one of
+ * the operations get, store or erase and passes the credential on stdin; for
+ * get, the helper answers with the attributes it can fill in.
+ */
+
+package gitcred
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Helper operations invoked by git.
+const (
+	OpGet   = "get"
+	OpStore = "store"
+	OpErase = "erase"
+)
+
+// Credential holds the attributes exchanged with git.
+// Fields:
+//   - Protocol, Host, Path (string): Identify the remote, e.g. "https", "github.com".
+//   - Username, Password (string): The credential itself.
+type Credential struct {
+	Protocol string
+	Host     string
+	Path     string
+	Username string
+	Password string
+}
+
+// Read parses the attributes git writes to the helper's stdin, up to a blank
+// line or the end of input. Unknown attributes are ignored.
+func Read(r io.Reader) (Credential, error) {
+	var c Credential
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return Credential{}, fmt.Errorf("invalid credential line %q", line)
+		}
+		switch key {
+		case "protocol":
+			c.Protocol = value
+		case "host":
+			c.Host = value
+		case "path":
+			c.Path = value
+		case "username":
+			c.Username = value
+		case "password":
+			c.Password = value
+		}
+	}
+	return c, scanner.Err()
+}
+
+// Write sends the non-empty attributes of c back to git.
+func (c Credential) Write(w io.Writer) error {
+	for _, attribute := range [][2]string{
+		{"protocol", c.Protocol},
+		{"host", c.Host},
+		{"path", c.Path},
+		{"username", c.Username},
+		{"password", c.Password},
+	} {
+		if attribute[1] == "" {
+			continue
+		}
+		if strings.ContainsAny(attribute[1], "\n\x00") {
+			return fmt.Errorf("%s contains a newline or NUL", attribute[0])
+		}
+		if _, err := fmt.Fprintf(w, "%s=%s\n", attribute[0], attribute[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// URL returns the remote as a URL such as "https://github.com/org/repo".
+func (c Credential) URL() string {
+	url := c.Protocol + "://" + c.Host
+	if c.Path != "" {
+		url += "/" + c.Path
+	}
+	return url
+}
@@ -0,0 +1,46 @@
+package gitcred
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestReadWrite round-trips a credential in git's format.
+func TestReadWrite(t *testing.T) {
+	input := "protocol=https\nhost=example.com\npath=org/repo.git\nusername=alice\nwwwauth[]=Basic\n\nignored=1\n"
+	c, err := Read(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	want := Credential{Protocol: "https", Host: "example.com", Path: "org/repo.git", Username: "alice"}
+	if c != want {
+		t.Errorf("Expected %+v, but got %+v", want, c)
+	}
+	if c.URL() != "https://example.com/org/repo.git" {
+		t.Errorf("Unexpected URL %q", c.URL())
+	}
+
+	c.Password = "s3cret"
+	var out bytes.Buffer
+	if err := c.Write(&out); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !strings.HasSuffix(out.String(), "username=alice\npassword=s3cret\n") {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+}
+
+// TestWrite_RejectsNewlines prevents injecting extra attributes.
+func TestWrite_RejectsNewlines(t *testing.T) {
+	if err := (Credential{Password: "a\nhost=evil"}).Write(&bytes.Buffer{}); err == nil {
+		t.Errorf("Expected an error, but got nil")
+	}
+}
+
+// TestRead_Malformed rejects lines without "=".
+func TestRead_Malformed(t *testing.T) {
+	if _, err := Read(strings.NewReader("protocol\n")); err == nil {
+		t.Errorf("Expected an error, but got nil")
+	}
+}
@@ -0,0 +1,141 @@
+/**
+ * Password Generator - Global Hotkey
+ *
+ * This file registers a system-wide key combination such as Ctrl+Alt+P that
+ * works while another application has the focus, e.g. to generate a password
+ * straight into the clipboard from a sign-up form. Windows registers it with
+ * the system; Linux asks the desktop through the XDG GlobalShortcuts portal,
+ * which may show a confirmation and lets the user pick another combination.
+ * Other platforms report ErrUnsupported.
+ */
+
+package hotkey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupported is returned on platforms without global hotkeys.
+var ErrUnsupported = errors.New("global hotkeys are not supported on this platform")
+
+// Default is the combination suggested for generate-and-copy.
+const Default = "Ctrl+Alt+P"
+
+// Hotkey is a key combination.
+// Fields:
+//   - Ctrl, Alt, Shift, Super (bool): The modifiers held; Super is the
+//     Windows or Command key.
+//   - Key (string): An upper-case letter, a digit or F1 to F12.
+type Hotkey struct {
+	Ctrl  bool
+	Alt   bool
+	Shift bool
+	Super bool
+	Key   string
+}
+
+// Parse reads a combination such as "Ctrl+Alt+P", case-insensitively.
+// Parameters:
+//   - spec (string): Modifiers and a key joined by "+"; the modifiers are
+//     Ctrl (or Control), Alt, Shift and Super (or Win, Cmd).
+//
+// Returns:
+//
+//	Hotkey: The combination.
+//	error: An error if a part is unknown, the key is missing, or no
+//	modifier is given; a bare key would take it from every application.
+//
+// Example:
+//
+//	h, err := hotkey.Parse("Ctrl+Shift+F9")
+func Parse(spec string) (Hotkey, error) {
+	var h Hotkey
+	parts := strings.Split(spec, "+")
+	for i, part := range parts {
+		part = strings.ToUpper(strings.TrimSpace(part))
+		if i == len(parts)-1 {
+			if !validKey(part) {
+				return Hotkey{}, fmt.Errorf("unknown key %q in hotkey %q; use a letter, a digit or F1 to F12", part, spec)
+			}
+			h.Key = part
+			break
+		}
+		switch part {
+		case "CTRL", "CONTROL":
+			h.Ctrl = true
+		case "ALT":
+			h.Alt = true
+		case "SHIFT":
+			h.Shift = true
+		case "SUPER", "WIN", "CMD":
+			h.Super = true
+		default:
+			return Hotkey{}, fmt.Errorf("unknown modifier %q in hotkey %q; use Ctrl, Alt, Shift or Super", part, spec)
+		}
+	}
+	if !h.Ctrl && !h.Alt && !h.Super {
+		return Hotkey{}, fmt.Errorf("hotkey %q needs Ctrl, Alt or Super", spec)
+	}
+	return h, nil
+}
+
+// String returns the combination in the form Parse reads, e.g. "Ctrl+Alt+P".
+func (h Hotkey) String() string {
+	var parts []string
+	for _, modifier := range []struct {
+		held bool
+		name string
+	}{{h.Ctrl, "Ctrl"}, {h.Alt, "Alt"}, {h.Shift, "Shift"}, {h.Super, "Super"}} {
+		if modifier.held {
+			parts = append(parts, modifier.name)
+		}
+	}
+	return strings.Join(append(parts, h.Key), "+")
+}
+
+// Listen registers h and calls onPress, on its own goroutine, every time it
+// is pressed until stop is called.
+// Parameters:
+//   - ctx (context.Context): Gives up the registration, e.g. while the
+//     desktop still waits for the user to confirm it.
+//   - h (Hotkey): The combination.
+//   - description (string): Shown by desktops that list the shortcuts of
+//     an application.
+//   - onPress (func()): Called for every press.
+//
+// Returns:
+//
+//	func(): Unregisters the hotkey, and returns once it is unregistered.
+//	error: ErrUnsupported, ctx.Err(), or the system's error, e.g. when
+//	another application already uses the combination.
+//
+// Example:
+//
+//	stop, err := hotkey.Listen(ctx, h, "Generate a password", func() { ... })
+func Listen(ctx context.Context, h Hotkey, description string, onPress func()) (stop func(), err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return listen(ctx, h, description, onPress)
+}
+
+// validKey reports whether key, in upper case, is a letter, a digit or F1
+// to F12.
+func validKey(key string) bool {
+	if len(key) == 1 {
+		return key[0] >= 'A' && key[0] <= 'Z' || key[0] >= '0' && key[0] <= '9'
+	}
+	return functionKey(key) > 0
+}
+
+// functionKey returns n for the key "Fn" with n from 1 to 12, else 0.
+func functionKey(key string) int {
+	var n int
+	if _, err := fmt.Sscanf(key, "F%d", &n); err != nil || fmt.Sprintf("F%d", n) != key || n < 1 || n > 12 {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,150 @@
+//go:build linux
+
+package hotkey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// The XDG desktop portal and its GlobalShortcuts interface.
+const (
+	portalName      = "org.freedesktop.portal.Desktop"
+	portalPath      = "/org/freedesktop/portal/desktop"
+	globalShortcuts = "org.freedesktop.portal.GlobalShortcuts"
+	requestResponse = "org.freedesktop.portal.Request.Response"
+	sessionClose    = "org.freedesktop.portal.Session.Close"
+	shortcutID      = "generate"
+)
+
+// listen binds h as the preferred trigger of a portal shortcut and calls
+// onPress on its Activated signal. It waits until the desktop has answered,
+// which may take until the user confirms a dialog, or until ctx is done.
+func listen(ctx context.Context, h Hotkey, description string, onPress func()) (func(), error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	signals := make(chan *dbus.Signal, 16)
+	conn.Signal(signals)
+	for _, member := range []dbus.MatchOption{dbus.WithMatchMember("Response"), dbus.WithMatchMember("Activated")} {
+		if err := conn.AddMatchSignal(member); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	portal := conn.Object(portalName, portalPath)
+
+	results, err := request(ctx, conn, portal, signals, "CreateSession", "session", map[string]dbus.Variant{
+		"session_handle_token": dbus.MakeVariant("passgen"),
+	})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	var session dbus.ObjectPath
+	switch handle := results["session_handle"].Value().(type) {
+	case string:
+		session = dbus.ObjectPath(handle)
+	case dbus.ObjectPath:
+		session = handle
+	}
+	shortcuts := []struct {
+		ID      string
+		Options map[string]dbus.Variant
+	}{{shortcutID, map[string]dbus.Variant{
+		"description":       dbus.MakeVariant(description),
+		"preferred_trigger": dbus.MakeVariant(trigger(h)),
+	}}}
+	if _, err := request(ctx, conn, portal, signals, "BindShortcuts", "bind", session, shortcuts, "", map[string]dbus.Variant{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	go func() {
+		for s := range signals {
+			if s.Name == globalShortcuts+".Activated" && len(s.Body) >= 2 && s.Body[0] == session && s.Body[1] == shortcutID {
+				go onPress()
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			conn.Object(portalName, session).Call(sessionClose, 0)
+			conn.Close()
+		})
+	}, nil
+}
+
+// request calls a GlobalShortcuts method whose last argument is its options
+// and waits for the Response signal of the request object it creates.
+// Parameters:
+//   - ctx (context.Context): Stops waiting for the response.
+//   - token (string): The handle_token that names the request object.
+//
+// Returns:
+//
+//	map[string]dbus.Variant: The results of the response.
+//	error: ErrUnsupported if the desktop has no GlobalShortcuts portal,
+//	ctx.Err(), or an error if the user or the desktop declined.
+func request(ctx context.Context, conn *dbus.Conn, portal dbus.BusObject, signals <-chan *dbus.Signal, method, token string, args ...interface{}) (map[string]dbus.Variant, error) {
+	options := args[len(args)-1].(map[string]dbus.Variant)
+	options["handle_token"] = dbus.MakeVariant(token)
+	sender := strings.NewReplacer(".", "_", ":", "").Replace(conn.Names()[0])
+	path := dbus.ObjectPath(portalPath + "/request/" + sender + "/" + token)
+	if err := portal.CallWithContext(ctx, globalShortcuts+"."+method, 0, args...).Err; err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	for {
+		var s *dbus.Signal
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case s = <-signals:
+		}
+		if s == nil {
+			return nil, errors.New("the session bus closed")
+		}
+		if s.Name != requestResponse || s.Path != path || len(s.Body) < 2 {
+			continue
+		}
+		code, _ := s.Body[0].(uint32)
+		results, _ := s.Body[1].(map[string]dbus.Variant)
+		switch code {
+		case 0:
+			return results, nil
+		case 1:
+			return nil, errors.New("the hotkey was declined on the desktop")
+		default:
+			return nil, errors.New("the desktop could not register the hotkey")
+		}
+	}
+}
+
+// trigger returns h in the notation of the XDG shortcuts specification,
+// e.g. "CTRL+ALT+p".
+func trigger(h Hotkey) string {
+	var parts []string
+	for _, modifier := range []struct {
+		held bool
+		name string
+	}{{h.Ctrl, "CTRL"}, {h.Alt, "ALT"}, {h.Shift, "SHIFT"}, {h.Super, "LOGO"}} {
+		if modifier.held {
+			parts = append(parts, modifier.name)
+		}
+	}
+	key := h.Key
+	if len(key) == 1 {
+		key = strings.ToLower(key)
+	}
+	return strings.Join(append(parts, key), "+")
+}
@@ -0,0 +1,9 @@
+//go:build !windows && !linux
+
+package hotkey
+
+import "context"
+
+func listen(context.Context, Hotkey, string, func()) (func(), error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,32 @@
+package hotkey
+
+import "testing"
+
+// TestParse verifies that combinations are read case-insensitively and
+// written back in canonical form.
+func TestParse(t *testing.T) {
+	tests := map[string]string{
+		"Ctrl+Alt+P":        "Ctrl+Alt+P",
+		"alt + control + 7": "Ctrl+Alt+7",
+		"Shift+Win+f12":     "Shift+Super+F12",
+	}
+	for spec, expected := range tests {
+		h, err := Parse(spec)
+		if err != nil {
+			t.Errorf("Expected %q to parse, but got %v", spec, err)
+			continue
+		}
+		if h.String() != expected {
+			t.Errorf("Expected %q, but got %q", expected, h.String())
+		}
+	}
+}
+
+// TestParse_Invalid verifies that unknown parts and bare keys are rejected.
+func TestParse_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "P", "Shift+P", "Ctrl+Alt", "Ctrl+F13", "Ctrl+F01", "Hyper+P", "Ctrl+Enter"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Expected an error for %q, but got none", spec)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+//go:build windows
+
+package hotkey
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	user32                 = syscall.NewLazyDLL("user32.dll")
+	kernel32               = syscall.NewLazyDLL("kernel32.dll")
+	procRegisterHotKey     = user32.NewProc("RegisterHotKey")
+	procUnregisterHotKey   = user32.NewProc("UnregisterHotKey")
+	procGetMessageW        = user32.NewProc("GetMessageW")
+	procPostThreadMessageW = user32.NewProc("PostThreadMessageW")
+	procGetCurrentThreadId = kernel32.NewProc("GetCurrentThreadId")
+)
+
+// Win32 constants of RegisterHotKey and the message loop.
+const (
+	modAlt      = 0x1
+	modControl  = 0x2
+	modShift    = 0x4
+	modWin      = 0x8
+	modNoRepeat = 0x4000
+	vkF1        = 0x70
+	wmQuit      = 0x0012
+	wmHotkey    = 0x0312
+	hotkeyID    = 1
+)
+
+// msg mirrors the Win32 MSG structure.
+type msg struct {
+	hwnd    uintptr
+	message uint32
+	wParam  uintptr
+	lParam  uintptr
+	time    uint32
+	x, y    int32
+}
+
+// listen registers h on a locked thread, whose message queue receives the
+// WM_HOTKEY messages; stop posts WM_QUIT to that thread and waits until it
+// has unregistered the hotkey, so that it can be registered again at once.
+// Registration does not wait for anything, so ctx is not watched.
+func listen(_ context.Context, h Hotkey, _ string, onPress func()) (func(), error) {
+	registered := make(chan error)
+	done := make(chan struct{})
+	var thread uintptr
+	go func() {
+		defer close(done)
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		thread, _, _ = procGetCurrentThreadId.Call()
+		if ok, _, err := procRegisterHotKey.Call(0, hotkeyID, modifiers(h), virtualKey(h.Key)); ok == 0 {
+			registered <- err
+			return
+		}
+		defer procUnregisterHotKey.Call(0, hotkeyID)
+		registered <- nil
+		var m msg
+		for {
+			r, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0)
+			if int32(r) <= 0 {
+				return
+			}
+			if m.message == wmHotkey {
+				go onPress()
+			}
+		}
+	}()
+	if err := <-registered; err != nil {
+		return nil, err
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			procPostThreadMessageW.Call(thread, wmQuit, 0, 0)
+			<-done
+		})
+	}, nil
+}
+
+// modifiers returns the MOD_ flags of h; held keys do not repeat.
+func modifiers(h Hotkey) uintptr {
+	flags := uintptr(modNoRepeat)
+	if h.Alt {
+		flags |= modAlt
+	}
+	if h.Ctrl {
+		flags |= modControl
+	}
+	if h.Shift {
+		flags |= modShift
+	}
+	if h.Super {
+		flags |= modWin
+	}
+	return flags
+}
+
+// virtualKey returns the virtual-key code of key; letters and digits are
+// their ASCII codes.
+func virtualKey(key string) uintptr {
+	if n := functionKey(key); n > 0 {
+		return uintptr(vkF1 + n - 1)
+	}
+	return uintptr(key[0])
+}
@@ -0,0 +1,68 @@
+/**
+ * Password Generator - Argon2id Cost Parameters
+ *
+ * This file holds the Argon2id cost shared by everything that stretches a
+ * secret: the vault key, derived site passwords, generation receipts and
+ * exported password hashes. The parameters are stored with vaults, receipts
+ * and hashes, so they can be raised later without breaking older ones, and
+ * are validated before use because Argon2id panics on some of them.
+ */
+
+package kdf
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Params holds the Argon2id cost parameters.
+// Fields:
+//   - Time (uint32): Passes over the memory.
+//   - Memory (uint32): Memory in KiB, at least 8 per thread.
+//   - Threads (uint8): Degree of parallelism.
+type Params struct {
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+// Default follows the RFC 9106 recommendation for memory-constrained
+// environments: 3 passes over 64 MiB.
+var Default = Params{Time: 3, Memory: 64 * 1024, Threads: 4}
+
+// Validate reports parameters Argon2id cannot run with, e.g. from an edited
+// file or flag.
+func (p Params) Validate() error {
+	if p.Time < 1 || p.Threads < 1 {
+		return errors.New("Argon2id needs at least 1 pass and 1 thread")
+	}
+	if p.Memory < 8*uint32(p.Threads) {
+		return fmt.Errorf("Argon2id needs at least %d KiB of memory for %d threads", 8*uint32(p.Threads), p.Threads)
+	}
+	return nil
+}
+
+// Key stretches secret with salt into a key of size bytes.
+// Returns:
+//
+//	[]byte: The key; wipe it with Wipe when it is no longer needed.
+//	error: An error from Validate.
+//
+// Example:
+//
+//	key, err := kdf.Default.Key(master, salt, 32)
+func (p Params) Key(secret, salt []byte, size uint32) ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return argon2.IDKey(secret, salt, p.Time, p.Memory, p.Threads, size), nil
+}
+
+// Wipe overwrites b with zeros, e.g. a master secret or key after use.
+func Wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,50 @@
+package kdf
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestValidate verifies that parameters Argon2id cannot run with are rejected.
+func TestValidate(t *testing.T) {
+	if err := Default.Validate(); err != nil {
+		t.Errorf("Expected the default to be valid, but got %v", err)
+	}
+	for _, p := range []Params{
+		{Time: 0, Memory: 64, Threads: 1},
+		{Time: 1, Memory: 64, Threads: 0},
+		{Time: 1, Memory: 0, Threads: 1},
+		{Time: 1, Memory: 8, Threads: 4},
+	} {
+		if err := p.Validate(); err == nil {
+			t.Errorf("Expected an error for %+v, but got nil", p)
+		}
+		if _, err := p.Key([]byte("secret"), []byte("salt"), 32); err == nil {
+			t.Errorf("Expected Key to fail for %+v, but got nil", p)
+		}
+	}
+}
+
+// TestKey verifies that the key depends on the secret, salt and cost.
+func TestKey(t *testing.T) {
+	p := Params{Time: 1, Memory: 64, Threads: 1}
+	key, err := p.Key([]byte("secret"), []byte("salt"), 32)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(key) != 32 {
+		t.Errorf("Expected a 32-byte key, but got %d bytes", len(key))
+	}
+	again, _ := p.Key([]byte("secret"), []byte("salt"), 32)
+	if !bytes.Equal(key, again) {
+		t.Error("Expected the same key for the same inputs")
+	}
+	other, _ := Params{Time: 2, Memory: 64, Threads: 1}.Key([]byte("secret"), []byte("salt"), 32)
+	if bytes.Equal(key, other) {
+		t.Error("Expected another cost to give another key")
+	}
+	Wipe(key)
+	if !bytes.Equal(key, make([]byte, 32)) {
+		t.Errorf("Expected Wipe to zero the key, but got %x", key)
+	}
+}
@@ -0,0 +1,324 @@
+/**
+ * Password Generator - KeePass Generator Profiles
+ *
+ * This file converts the password generator profiles of KeePass 2, as stored
+ * in KeePass.config.xml, into password options and patterns, so that users
+ * migrating from KeePass can keep generating passwords the way they did.
+ */
+
+package keepass
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// Generator types of a KeePass profile.
+const (
+	GeneratorCharSet = "CharSet"
+	GeneratorPattern = "Pattern"
+	GeneratorCustom  = "Custom"
+)
+
+// KeePass character sets, as defined by its PwCharSet class.
+const (
+	special     = "!\"#$%&'*+,./:;=?@\\^`|~"
+	punctuation = ",.;:"
+	brackets    = "[]{}()<>"
+)
+
+// charSetRanges maps the letters of a profile's CharSetRanges to their
+// characters. High ANSI ('H') has no counterpart and is reported instead.
+var charSetRanges = map[rune]string{
+	'U': passgen.Uppercase,
+	'L': passgen.Lowercase,
+	'D': passgen.Digits,
+	'S': special,
+	'P': punctuation,
+	'm': "-",
+	'u': "_",
+	's': " ",
+	'B': brackets,
+}
+
+// patternPlaceholders maps KeePass pattern placeholders to the equivalent
+// placeholders of passgen patterns. Placeholders missing here, such as hex
+// digits or mixed-case sets, have no equivalent.
+var patternPlaceholders = map[rune]rune{
+	'c': 'c',
+	'z': 'C',
+	'v': 'v',
+	'Z': 'V',
+	'l': 'a',
+	'u': 'A',
+	'd': '9',
+	's': '!',
+	'S': '*',
+}
+
+// xmlProfile is a <Profile> element of KeePass.config.xml.
+type xmlProfile struct {
+	Name                   string `xml:"Name"`
+	GeneratorType          string `xml:"GeneratorType"`
+	Length                 int    `xml:"Length"`
+	CharSetRanges          string `xml:"CharSetRanges"`
+	CharSetAdditional      string `xml:"CharSetAdditional"`
+	Pattern                string `xml:"Pattern"`
+	PatternPermutePassword bool   `xml:"PatternPermutePassword"`
+	ExcludeLookAlike       bool   `xml:"ExcludeLookAlike"`
+	NoRepeatingCharacters  bool   `xml:"NoRepeatingCharacters"`
+	ExcludeCharacters      string `xml:"ExcludeCharacters"`
+}
+
+// Profile is a KeePass generator profile converted for this generator.
+// Fields:
+//   - Name (string): The profile name in KeePass.
+//   - Options (passgen.PasswordOptions): The equivalent options.
+//   - Pattern (string): A passgen pattern for pattern profiles, else empty.
+//   - Notes ([]string): Settings that could only be approximated.
+type Profile struct {
+	Name    string
+	Options passgen.PasswordOptions
+	Pattern string
+	Notes   []string
+}
+
+// Import is the result of ReadProfiles.
+// Fields:
+//   - Profiles ([]Profile): The converted profiles, in file order.
+//   - Skipped ([]string): One "name: reason" line per profile that could
+//     not be converted.
+type Import struct {
+	Profiles []Profile
+	Skipped  []string
+}
+
+// ReadProfiles reads the generator profiles from KeePass XML.
+// Purpose:
+//
+//	Accepts a whole KeePass.config.xml or any fragment holding <Profile>
+//	elements, and converts each named profile. Character set profiles map
+//	onto the character types, with characters outside the KeePass selection
+//	excluded; pattern profiles are translated into passgen patterns. The
+//	unnamed automatic and last-used profiles are ignored.
+//
+// Parameters:
+//   - r (io.Reader): The XML.
+//   - base (passgen.PasswordOptions): Options the profiles do not set, such
+//     as MaxLength and KeyboardLayout, are taken from base.
+//
+// Returns:
+//
+//	Import: The converted profiles and the skipped ones.
+//	error: An error if the XML is malformed or holds no profiles.
+//
+// Example:
+//
+//	imported, err := keepass.ReadProfiles(file, *config.GetDefaultOptions())
+func ReadProfiles(r io.Reader, base passgen.PasswordOptions) (Import, error) {
+	var imported Import
+	decoder := xml.NewDecoder(r)
+	found := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Import{}, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "Profile" {
+			continue
+		}
+		var raw xmlProfile
+		if err := decoder.DecodeElement(&raw, &start); err != nil {
+			return Import{}, err
+		}
+		found++
+		if raw.Name == "" {
+			continue
+		}
+		profile, err := convert(raw, base)
+		if err != nil {
+			imported.Skipped = append(imported.Skipped, fmt.Sprintf("%s: %v", raw.Name, err))
+			continue
+		}
+		imported.Profiles = append(imported.Profiles, profile)
+	}
+	if found == 0 {
+		return Import{}, errors.New("no KeePass generator profiles found")
+	}
+	return imported, nil
+}
+
+// convert maps one KeePass profile onto passgen options.
+func convert(raw xmlProfile, base passgen.PasswordOptions) (Profile, error) {
+	opts := base
+	opts.IncludeSymbols, opts.IncludeNumbers, opts.IncludeUpper, opts.IncludeLower = false, false, false, false
+	opts.BeginWithLetter, opts.NoSequential, opts.AlternateHands = false, false, false
+	opts.MustInclude, opts.GroupSize = "", 0
+	opts.NoSimilar = raw.ExcludeLookAlike
+	opts.NoDuplicates = raw.NoRepeatingCharacters
+	opts.ExcludeCharacters = raw.ExcludeCharacters
+	profile := Profile{Name: raw.Name}
+
+	switch raw.GeneratorType {
+	case GeneratorCharSet, "":
+		opts.Length = raw.Length
+		profile.Notes = applyCharSet(&opts, raw)
+		if err := passgen.Validate(opts); err != nil {
+			return Profile{}, err
+		}
+	case GeneratorPattern:
+		pattern, notes, err := convertPattern(raw.Pattern)
+		if err != nil {
+			return Profile{}, err
+		}
+		if raw.PatternPermutePassword {
+			notes = append(notes, "the characters are not shuffled after applying the pattern")
+		}
+		parsed, err := passgen.ParsePattern(pattern, opts)
+		if err != nil {
+			return Profile{}, err
+		}
+		// Pattern passwords ignore the character types; keep a valid set
+		// so the options can still be shown and edited.
+		opts.Length = len(parsed)
+		opts.IncludeLower, opts.IncludeUpper, opts.IncludeNumbers = true, true, true
+		profile.Pattern, profile.Notes = pattern, notes
+	case GeneratorCustom:
+		return Profile{}, errors.New("custom generator plugins are not supported")
+	default:
+		return Profile{}, fmt.Errorf("unknown generator type %q", raw.GeneratorType)
+	}
+	profile.Options = opts
+	return profile, nil
+}
+
+// applyCharSet enables every character type that shares characters with the
+// KeePass selection and excludes the rest of the type. It returns notes on
+// characters that no type covers.
+func applyCharSet(opts *passgen.PasswordOptions, raw xmlProfile) []string {
+	var allowed string
+	var notes []string
+	for _, r := range raw.CharSetRanges {
+		if r == 'H' {
+			notes = append(notes, "high ANSI characters are not supported")
+		}
+		allowed += charSetRanges[r]
+	}
+	allowed += raw.CharSetAdditional
+
+	types := []struct {
+		chars   string
+		include *bool
+	}{
+		{passgen.Uppercase, &opts.IncludeUpper},
+		{passgen.Lowercase, &opts.IncludeLower},
+		{passgen.Digits, &opts.IncludeNumbers},
+		{passgen.Symbols, &opts.IncludeSymbols},
+	}
+	covered := ""
+	for _, t := range types {
+		var excluded strings.Builder
+		for _, c := range t.chars {
+			if strings.ContainsRune(allowed, c) {
+				*t.include = true
+			} else {
+				excluded.WriteRune(c)
+			}
+		}
+		if *t.include {
+			opts.ExcludeCharacters += excluded.String()
+		}
+		covered += t.chars
+	}
+
+	var unsupported strings.Builder
+	for _, c := range allowed {
+		if !strings.ContainsRune(covered, c) && !strings.ContainsRune(unsupported.String(), c) && !strings.ContainsRune(raw.ExcludeCharacters, c) {
+			unsupported.WriteRune(c)
+		}
+	}
+	if unsupported.Len() > 0 {
+		notes = append(notes, fmt.Sprintf("characters %q are not supported and left out", unsupported.String()))
+	}
+	return notes
+}
+
+// convertPattern translates a KeePass pattern into a passgen pattern,
+// expanding repetitions such as d{4} and escaping literals that are
+// placeholders in passgen patterns.
+func convertPattern(keepass string) (string, []string, error) {
+	if keepass == "" {
+		return "", nil, errors.New("the pattern is empty")
+	}
+	var notes []string
+	var out strings.Builder
+	runes := []rune(keepass)
+	last := ""
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '{':
+			end := strings.IndexRune(string(runes[i:]), '}')
+			if end < 0 || last == "" {
+				return "", nil, fmt.Errorf("invalid repetition at position %d", i+1)
+			}
+			count, err := strconv.Atoi(string(runes[i+1 : i+end]))
+			if err != nil || count < 1 {
+				return "", nil, fmt.Errorf("invalid repetition at position %d", i+1)
+			}
+			out.WriteString(strings.Repeat(last, count-1))
+			i += end
+			continue
+		case r == '[':
+			return "", nil, errors.New("custom character sets in [ ] are not supported")
+		case r == '\\':
+			if i++; i == len(runes) {
+				return "", nil, errors.New("the pattern ends with an escape character")
+			}
+			last = literal(runes[i])
+		default:
+			placeholder, ok := patternPlaceholders[r]
+			switch {
+			case ok:
+				last = string(placeholder)
+				if r == 'S' {
+					notes = appendOnce(notes, "S uses this generator's symbols, not every printable character")
+				}
+			case strings.ContainsRune("aAUCLhHpbxV", r):
+				return "", nil, fmt.Errorf("the placeholder %c has no equivalent", r)
+			default:
+				last = literal(r)
+			}
+		}
+		out.WriteString(last)
+	}
+	return out.String(), notes, nil
+}
+
+// literal returns r as a literal of a passgen pattern.
+func literal(r rune) string {
+	if _, ok := passgen.PatternPlaceholders[r]; ok || r == '\\' {
+		return "\\" + string(r)
+	}
+	return string(r)
+}
+
+// appendOnce appends note to notes unless it is already there.
+func appendOnce(notes []string, note string) []string {
+	for _, n := range notes {
+		if n == note {
+			return notes
+		}
+	}
+	return append(notes, note)
+}
@@ -0,0 +1,111 @@
+package keepass
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// testConfig is an excerpt of a KeePass.config.xml with generator profiles.
+const testConfig = `<?xml version="1.0" encoding="utf-8"?>
+<Configuration>
+	<PasswordGenerator>
+		<AutoGeneratedPasswordsProfile>
+			<GeneratorType>CharSet</GeneratorType>
+			<Length>20</Length>
+			<CharSetRanges>ULD_______</CharSetRanges>
+		</AutoGeneratedPasswordsProfile>
+		<LastUsedProfile>
+			<Profile>
+				<GeneratorType>CharSet</GeneratorType>
+				<Length>12</Length>
+				<CharSetRanges>_L________</CharSetRanges>
+			</Profile>
+		</LastUsedProfile>
+		<UserProfiles>
+			<Profile>
+				<Name>Bank</Name>
+				<GeneratorType>CharSet</GeneratorType>
+				<Length>16</Length>
+				<CharSetRanges>ULD__m____</CharSetRanges>
+				<CharSetAdditional>#</CharSetAdditional>
+				<ExcludeLookAlike>true</ExcludeLookAlike>
+				<NoRepeatingCharacters>false</NoRepeatingCharacters>
+				<ExcludeCharacters>X</ExcludeCharacters>
+			</Profile>
+			<Profile>
+				<Name>Voucher</Name>
+				<GeneratorType>Pattern</GeneratorType>
+				<Pattern>uuu-d{4}\!</Pattern>
+				<PatternPermutePassword>true</PatternPermutePassword>
+			</Profile>
+			<Profile>
+				<Name>Hex</Name>
+				<GeneratorType>Pattern</GeneratorType>
+				<Pattern>h{32}</Pattern>
+			</Profile>
+			<Profile>
+				<Name>Plugin</Name>
+				<GeneratorType>Custom</GeneratorType>
+			</Profile>
+			<Profile>
+				<Name>Spaces</Name>
+				<GeneratorType>CharSet</GeneratorType>
+				<Length>20</Length>
+				<CharSetRanges>_L_____s_H</CharSetRanges>
+			</Profile>
+		</UserProfiles>
+	</PasswordGenerator>
+</Configuration>`
+
+// TestReadProfiles converts character set and pattern profiles and skips
+// the ones without an equivalent.
+func TestReadProfiles(t *testing.T) {
+	base := passgen.PasswordOptions{MaxLength: 64, KeyboardLayout: passgen.DefaultHandLayout}
+	imported, err := ReadProfiles(strings.NewReader(testConfig), base)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(imported.Profiles) != 3 || len(imported.Skipped) != 2 {
+		t.Fatalf("Expected 3 profiles and 2 skipped, but got %+v", imported)
+	}
+
+	bank := imported.Profiles[0].Options
+	if bank.Length != 16 || !bank.IncludeUpper || !bank.IncludeLower || !bank.IncludeNumbers || !bank.IncludeSymbols || !bank.NoSimilar || bank.NoDuplicates || bank.MaxLength != 64 {
+		t.Errorf("Expected the Bank options, but got %+v", bank)
+	}
+	bank.Quantity = 1
+	passwords, err := passgen.GeneratePasswords(context.Background(), bank)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, c := range passgen.Symbols + "X" {
+		if c != '-' && c != '#' && strings.ContainsRune(passwords[0], c) {
+			t.Errorf("Expected only - and # as symbols and no X, but got %q", passwords[0])
+		}
+	}
+
+	voucher := imported.Profiles[1]
+	if voucher.Pattern != `AAA-9999\!` || voucher.Options.Length != 9 || len(voucher.Notes) != 1 {
+		t.Errorf("Expected the pattern AAA-9999\\! with a note, but got %+v", voucher)
+	}
+
+	spaces := imported.Profiles[2]
+	if !spaces.Options.IncludeLower || spaces.Options.IncludeSymbols || len(spaces.Notes) != 2 {
+		t.Errorf("Expected lowercase only with notes on high ANSI and spaces, but got %+v", spaces)
+	}
+	if !strings.HasPrefix(imported.Skipped[0], "Hex:") || !strings.HasPrefix(imported.Skipped[1], "Plugin:") {
+		t.Errorf("Expected Hex and Plugin to be skipped, but got %v", imported.Skipped)
+	}
+}
+
+// TestReadProfiles_Errors rejects malformed XML and files without profiles.
+func TestReadProfiles_Errors(t *testing.T) {
+	for _, input := range []string{"<Configuration><Profile>", "<Configuration/>"} {
+		if _, err := ReadProfiles(strings.NewReader(input), passgen.PasswordOptions{}); err == nil {
+			t.Errorf("Expected an error for %q, but got none", input)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package keepassxc
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// LoadAssociation reads a stored association from path.
+func LoadAssociation(path string) (Association, error) {
+	var a Association
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return a, err
+	}
+	err = json.Unmarshal(data, &a)
+	return a, err
+}
+
+// SaveAssociation writes an association to path, readable only by the current user.
+func SaveAssociation(path string, a Association) error {
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
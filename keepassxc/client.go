@@ -0,0 +1,291 @@
+/**
+ * Password Generator - KeePassXC Browser Protocol Client
+ *
+ * This file implements the subset of the KeePassXC browser integration
+ * protocol needed to store a generated credential: public key exchange,
+ * association with the open database, and set-login. Messages are JSON; all
+ * but the key exchange are encrypted with NaCl box (X25519, XSalsa20-Poly1305).
+ * KeePassXC asks the user to approve every new association.
+ */
+
+package keepassxc
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+// nonceSize is the size of a NaCl box nonce.
+const nonceSize = 24
+
+// Association identifies this application to a KeePassXC database.
+// Fields:
+//   - ID (string): The name the user gave the association in KeePassXC.
+//   - IDKey ([]byte): The long-term identification public key.
+type Association struct {
+	ID    string `json:"id"`
+	IDKey []byte `json:"idKey"`
+}
+
+// Client talks to a running KeePassXC instance.
+type Client struct {
+	conn      io.ReadWriteCloser
+	decoder   *json.Decoder
+	clientID  string
+	publicKey *[32]byte
+	secretKey *[32]byte
+	serverKey *[32]byte
+}
+
+// envelope is the outer JSON message exchanged with KeePassXC.
+type envelope struct {
+	Action    string `json:"action"`
+	Message   string `json:"message,omitempty"`
+	Nonce     string `json:"nonce,omitempty"`
+	ClientID  string `json:"clientID,omitempty"`
+	PublicKey string `json:"publicKey,omitempty"`
+	Success   string `json:"success,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NewClient wraps an open connection and performs the public key exchange.
+// Purpose:
+//
+//	Generates a session key pair and exchanges public keys with KeePassXC so
+//	that subsequent messages can be encrypted.
+//
+// Parameters:
+//   - conn (io.ReadWriteCloser): A connection to the KeePassXC browser socket.
+//
+// Returns:
+//
+//	*Client: A client ready to associate and store logins.
+//	error: An error if the key exchange fails.
+//
+// Example:
+//
+//	conn, _ := keepassxc.Dial()
+//	client, err := keepassxc.NewClient(conn)
+func NewClient(conn io.ReadWriteCloser) (*Client, error) {
+	publicKey, secretKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	clientID := make([]byte, nonceSize)
+	if _, err := rand.Read(clientID); err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		conn:      conn,
+		decoder:   json.NewDecoder(conn),
+		clientID:  base64.StdEncoding.EncodeToString(clientID),
+		publicKey: publicKey,
+		secretKey: secretKey,
+	}
+	if err := c.exchangeKeys(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// exchangeKeys sends the session public key and stores the server's key.
+func (c *Client) exchangeKeys() error {
+	nonce, err := newNonce()
+	if err != nil {
+		return err
+	}
+	request := envelope{
+		Action:    "change-public-keys",
+		PublicKey: base64.StdEncoding.EncodeToString(c.publicKey[:]),
+		Nonce:     base64.StdEncoding.EncodeToString(nonce[:]),
+		ClientID:  c.clientID,
+	}
+	response, err := c.roundTrip(request)
+	if err != nil {
+		return err
+	}
+	serverKey, err := base64.StdEncoding.DecodeString(response.PublicKey)
+	if err != nil || len(serverKey) != 32 {
+		return errors.New("keepassxc: invalid server public key")
+	}
+	c.serverKey = new([32]byte)
+	copy(c.serverKey[:], serverKey)
+	return nil
+}
+
+// Associate asks KeePassXC to trust a new identification key.
+// Purpose:
+//
+//	Triggers the KeePassXC association prompt. The user names the connection,
+//	and the returned Association should be stored for later runs.
+//
+// Returns:
+//
+//	Association: The identification key and the name chosen by the user.
+//	error: An error if the user declines or the database is locked.
+func (c *Client) Associate() (Association, error) {
+	idKey, _, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return Association{}, err
+	}
+	var response struct {
+		ID string `json:"id"`
+	}
+	err = c.call("associate", map[string]any{
+		"action": "associate",
+		"key":    base64.StdEncoding.EncodeToString(c.publicKey[:]),
+		"idKey":  base64.StdEncoding.EncodeToString(idKey[:]),
+	}, &response)
+	if err != nil {
+		return Association{}, err
+	}
+	return Association{ID: response.ID, IDKey: idKey[:]}, nil
+}
+
+// TestAssociate checks that a stored association is still accepted.
+func (c *Client) TestAssociate(a Association) error {
+	return c.call("test-associate", map[string]any{
+		"action": "test-associate",
+		"id":     a.ID,
+		"key":    base64.StdEncoding.EncodeToString(a.IDKey),
+	}, nil)
+}
+
+// SetLogin stores a credential in the open KeePassXC database.
+// Parameters:
+//   - a (Association): A previously approved association.
+//   - url (string): The site URL the entry belongs to.
+//   - login, password (string): The credential to store.
+//
+// Returns:
+//
+//	error: An error if KeePassXC rejects the entry.
+func (c *Client) SetLogin(a Association, url, login, password string) error {
+	return c.call("set-login", map[string]any{
+		"action":    "set-login",
+		"url":       url,
+		"submitUrl": url,
+		"id":        a.ID,
+		"login":     login,
+		"password":  password,
+		"group":     "",
+		"groupUuid": "",
+		"uuid":      "",
+	}, nil)
+}
+
+// call encrypts and sends an action, then decrypts the reply into out.
+func (c *Client) call(action string, payload map[string]any, out any) error {
+	nonce, err := newNonce()
+	if err != nil {
+		return err
+	}
+	payload["nonce"] = base64.StdEncoding.EncodeToString(nonce[:])
+	plain, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	sealed := box.Seal(nil, plain, &nonce, c.serverKey, c.secretKey)
+
+	response, err := c.roundTrip(envelope{
+		Action:   action,
+		Message:  base64.StdEncoding.EncodeToString(sealed),
+		Nonce:    base64.StdEncoding.EncodeToString(nonce[:]),
+		ClientID: c.clientID,
+	})
+	if err != nil {
+		return err
+	}
+
+	replyNonce, err := decodeNonce(response.Nonce)
+	if err != nil {
+		return err
+	}
+	if replyNonce != incrementNonce(nonce) {
+		return errors.New("keepassxc: unexpected reply nonce")
+	}
+	message, err := base64.StdEncoding.DecodeString(response.Message)
+	if err != nil {
+		return fmt.Errorf("keepassxc: invalid %s reply: %w", action, err)
+	}
+	opened, ok := box.Open(nil, message, &replyNonce, c.serverKey, c.secretKey)
+	if !ok {
+		return errors.New("keepassxc: reply could not be decrypted")
+	}
+
+	var status struct {
+		Success string `json:"success"`
+	}
+	if err := json.Unmarshal(opened, &status); err != nil {
+		return err
+	}
+	if status.Success != "true" {
+		return fmt.Errorf("keepassxc: %s was rejected", action)
+	}
+	if out != nil {
+		return json.Unmarshal(opened, out)
+	}
+	return nil
+}
+
+// roundTrip writes a request and waits for the reply to the same action,
+// skipping unsolicited notifications such as database-locked.
+func (c *Client) roundTrip(request envelope) (envelope, error) {
+	if err := json.NewEncoder(c.conn).Encode(request); err != nil {
+		return envelope{}, err
+	}
+	for {
+		var response envelope
+		if err := c.decoder.Decode(&response); err != nil {
+			return envelope{}, err
+		}
+		if response.Action != request.Action {
+			continue
+		}
+		if response.Error != "" {
+			return envelope{}, fmt.Errorf("keepassxc: %s", response.Error)
+		}
+		return response, nil
+	}
+}
+
+// newNonce returns a random box nonce.
+func newNonce() ([nonceSize]byte, error) {
+	var nonce [nonceSize]byte
+	_, err := rand.Read(nonce[:])
+	return nonce, err
+}
+
+// decodeNonce parses a base64 nonce from a reply.
+func decodeNonce(encoded string) ([nonceSize]byte, error) {
+	var nonce [nonceSize]byte
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) != nonceSize {
+		return nonce, errors.New("keepassxc: invalid nonce")
+	}
+	copy(nonce[:], raw)
+	return nonce, nil
+}
+
+// incrementNonce adds one to a little-endian nonce, as KeePassXC does for replies.
+func incrementNonce(nonce [nonceSize]byte) [nonceSize]byte {
+	for i := range nonce {
+		nonce[i]++
+		if nonce[i] != 0 {
+			break
+		}
+	}
+	return nonce
+}
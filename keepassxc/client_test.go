@@ -0,0 +1,103 @@
+package keepassxc
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+// fakeServer answers the protocol like KeePassXC, approving every request and
+// recording the last decrypted message.
+func fakeServer(t *testing.T, conn net.Conn, received chan<- map[string]any) {
+	defer conn.Close()
+	serverPublic, serverSecret, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var clientKey [32]byte
+	decoder := json.NewDecoder(conn)
+	encoder := json.NewEncoder(conn)
+
+	for {
+		var request envelope
+		if err := decoder.Decode(&request); err != nil {
+			return
+		}
+		if request.Action == "change-public-keys" {
+			raw, _ := base64.StdEncoding.DecodeString(request.PublicKey)
+			copy(clientKey[:], raw)
+			_ = encoder.Encode(envelope{
+				Action:    request.Action,
+				PublicKey: base64.StdEncoding.EncodeToString(serverPublic[:]),
+				Success:   "true",
+			})
+			continue
+		}
+
+		nonce, _ := decodeNonce(request.Nonce)
+		sealed, _ := base64.StdEncoding.DecodeString(request.Message)
+		opened, ok := box.Open(nil, sealed, &nonce, &clientKey, serverSecret)
+		if !ok {
+			t.Error("server could not decrypt request")
+			return
+		}
+		var message map[string]any
+		_ = json.Unmarshal(opened, &message)
+		received <- message
+
+		reply, _ := json.Marshal(map[string]string{"success": "true", "id": "test-association"})
+		replyNonce := incrementNonce(nonce)
+		_ = encoder.Encode(envelope{
+			Action:  request.Action,
+			Message: base64.StdEncoding.EncodeToString(box.Seal(nil, reply, &replyNonce, &clientKey, serverSecret)),
+			Nonce:   base64.StdEncoding.EncodeToString(replyNonce[:]),
+		})
+	}
+}
+
+// TestClient_AssociateAndSetLogin runs a full exchange against a fake KeePassXC.
+func TestClient_AssociateAndSetLogin(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	received := make(chan map[string]any, 2)
+	go fakeServer(t, serverConn, received)
+
+	client, err := NewClient(clientConn)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	defer client.Close()
+
+	association, err := client.Associate()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if association.ID != "test-association" || len(association.IDKey) != 32 {
+		t.Errorf("Unexpected association %+v", association)
+	}
+	if message := <-received; message["action"] != "associate" {
+		t.Errorf("Expected associate message, but got %v", message)
+	}
+
+	if err := client.SetLogin(association, "https://example.com", "alice", "s3cret"); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	message := <-received
+	if message["login"] != "alice" || message["password"] != "s3cret" || message["id"] != "test-association" {
+		t.Errorf("Unexpected set-login message %v", message)
+	}
+}
+
+// TestIncrementNonce verifies little-endian carry propagation.
+func TestIncrementNonce(t *testing.T) {
+	var nonce [nonceSize]byte
+	nonce[0], nonce[1] = 0xff, 0x01
+	got := incrementNonce(nonce)
+	if got[0] != 0x00 || got[1] != 0x02 {
+		t.Errorf("Expected carry into the second byte, but got %v", got[:2])
+	}
+}
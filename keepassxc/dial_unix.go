@@ -0,0 +1,34 @@
+//go:build !windows
+
+package keepassxc
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// socketName is the name of the KeePassXC browser integration socket.
+const socketName = "org.keepassxc.KeePassXC.BrowserServer"
+
+// Dial connects to the browser integration socket of a running KeePassXC.
+// Browser integration must be enabled in the KeePassXC settings.
+func Dial() (io.ReadWriteCloser, error) {
+	var candidates []string
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		candidates = append(candidates,
+			filepath.Join(runtimeDir, "app", "org.keepassxc.KeePassXC", socketName),
+			filepath.Join(runtimeDir, socketName),
+		)
+	}
+	candidates = append(candidates, filepath.Join(os.TempDir(), socketName))
+
+	for _, path := range candidates {
+		if conn, err := net.Dial("unix", path); err == nil {
+			return conn, nil
+		}
+	}
+	return nil, errors.New("keepassxc: no running KeePassXC with browser integration found")
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package keepassxc
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// pipePrefix is the name of the KeePassXC browser integration pipe without the user suffix.
+const pipePrefix = `\\.\pipe\org.keepassxc.KeePassXC.BrowserServer_`
+
+// Dial connects to the browser integration pipe of a running KeePassXC.
+// Browser integration must be enabled in the KeePassXC settings.
+func Dial() (io.ReadWriteCloser, error) {
+	pipe, err := os.OpenFile(pipePrefix+os.Getenv("USERNAME"), os.O_RDWR, 0)
+	if err != nil {
+		return nil, errors.New("keepassxc: no running KeePassXC with browser integration found")
+	}
+	return pipe, nil
+}
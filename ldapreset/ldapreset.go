@@ -0,0 +1,135 @@
+/**
+ * Password Generator - LDAP / Active Directory Password Reset
+ *
+ * This file pushes a freshly generated password to a directory account. It
+ * binds over LDAPS with administrator credentials and either replaces the
+ * Active Directory unicodePwd attribute or issues an RFC 3062 Password Modify
+ * extended operation for other LDAP servers. Plain ldap:// URLs are refused so
+ * passwords never travel unencrypted.
+ */
+
+package ldapreset
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"unicode/utf16"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Directory modes supported by ResetPassword.
+const (
+	ModeActiveDirectory = "ad"
+	ModeLDAP            = "ldap"
+)
+
+// Config describes how to reach and authenticate against the directory.
+// Fields:
+//   - URL (string): The ldaps:// URL of the directory server.
+//   - BindDN, BindPassword (string): Administrator credentials used for the reset.
+//   - Mode (string): ModeActiveDirectory or ModeLDAP.
+//   - CAFile (string): Optional PEM bundle used to verify the server certificate.
+type Config struct {
+	URL          string
+	BindDN       string
+	BindPassword string
+	Mode         string
+	CAFile       string
+}
+
+// ResetPassword sets a new password on a directory account.
+// Purpose:
+//
+//	Binds with the administrator credentials from cfg and replaces the
+//	password of userDN with newPassword.
+//
+// Parameters:
+//   - cfg (Config): Connection and authentication settings.
+//   - userDN (string): Distinguished name of the account to reset.
+//   - newPassword (string): The password to set.
+//
+// Returns:
+//
+//	error: An error if the connection, bind, or modification fails.
+//
+// Example:
+//
+//	err := ldapreset.ResetPassword(cfg, "CN=Jane Doe,OU=Staff,DC=example,DC=com", password)
+func ResetPassword(cfg Config, userDN, newPassword string) error {
+	if userDN == "" {
+		return errors.New("user DN is required")
+	}
+	tlsConfig, err := tlsConfigFor(cfg)
+	if err != nil {
+		return err
+	}
+
+	conn, err := ldap.DialURL(cfg.URL, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return fmt.Errorf("connect to %s: %w", cfg.URL, err)
+	}
+	defer conn.Close()
+
+	if err := conn.Bind(cfg.BindDN, cfg.BindPassword); err != nil {
+		return fmt.Errorf("bind as %s: %w", cfg.BindDN, err)
+	}
+
+	switch cfg.Mode {
+	case ModeActiveDirectory, "":
+		request := ldap.NewModifyRequest(userDN, nil)
+		request.Replace("unicodePwd", []string{encodeADPassword(newPassword)})
+		if err := conn.Modify(request); err != nil {
+			return fmt.Errorf("reset password of %s: %w", userDN, err)
+		}
+	case ModeLDAP:
+		request := ldap.NewPasswordModifyRequest(userDN, "", newPassword)
+		if _, err := conn.PasswordModify(request); err != nil {
+			return fmt.Errorf("reset password of %s: %w", userDN, err)
+		}
+	default:
+		return fmt.Errorf("unknown directory mode %q", cfg.Mode)
+	}
+	return nil
+}
+
+// tlsConfigFor validates the URL scheme and builds the TLS configuration.
+func tlsConfigFor(cfg Config) (*tls.Config, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid directory URL: %w", err)
+	}
+	if u.Scheme != "ldaps" {
+		return nil, errors.New("directory URL must use ldaps://")
+	}
+
+	tlsConfig := &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// encodeADPassword quotes the password and encodes it as UTF-16LE, the format
+// Active Directory expects for the unicodePwd attribute.
+func encodeADPassword(password string) string {
+	quoted := utf16.Encode([]rune("\"" + password + "\""))
+	encoded := make([]byte, len(quoted)*2)
+	for i, unit := range quoted {
+		binary.LittleEndian.PutUint16(encoded[i*2:], unit)
+	}
+	return string(encoded)
+}
@@ -0,0 +1,103 @@
+package ldapreset
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestTLSConfigFor_RequiresLDAPS verifies that only ldaps:// URLs are accepted.
+func TestTLSConfigFor_RequiresLDAPS(t *testing.T) {
+	for _, url := range []string{"ldap://dc.example.com", "dc.example.com", "https://dc.example.com"} {
+		if _, err := tlsConfigFor(Config{URL: url}); err == nil {
+			t.Errorf("Expected an error for %q, but got nil", url)
+		}
+	}
+	tlsConfig, err := tlsConfigFor(Config{URL: "ldaps://dc.example.com:636"})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if tlsConfig.ServerName != "dc.example.com" {
+		t.Errorf("Expected server name dc.example.com, but got %q", tlsConfig.ServerName)
+	}
+	if tlsConfig.RootCAs != nil {
+		t.Error("Expected the system roots without a CA file, but got a pool")
+	}
+}
+
+// TestTLSConfigFor_CAFile verifies that a CA bundle is loaded, and that a
+// missing file or one without certificates is an error.
+func TestTLSConfigFor_CAFile(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(caFile, testCertificate(t), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tlsConfig, err := tlsConfigFor(Config{URL: "ldaps://dc.example.com", CAFile: caFile})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if tlsConfig.RootCAs == nil {
+		t.Error("Expected the CA file to set the root pool, but got nil")
+	}
+
+	if _, err := tlsConfigFor(Config{URL: "ldaps://dc.example.com", CAFile: filepath.Join(dir, "missing.pem")}); err == nil {
+		t.Error("Expected an error for a missing CA file, but got nil")
+	}
+	empty := filepath.Join(dir, "empty.pem")
+	if err := os.WriteFile(empty, []byte("not a certificate\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tlsConfigFor(Config{URL: "ldaps://dc.example.com", CAFile: empty}); err == nil {
+		t.Error("Expected an error for a CA file without certificates, but got nil")
+	}
+}
+
+// TestEncodeADPassword verifies the quoting and UTF-16LE encoding of unicodePwd.
+func TestEncodeADPassword(t *testing.T) {
+	tests := []struct {
+		password string
+		expected []byte
+	}{
+		{"", []byte{'"', 0, '"', 0}},
+		{"aB1", []byte{'"', 0, 'a', 0, 'B', 0, '1', 0, '"', 0}},
+		{"é", []byte{'"', 0, 0xe9, 0, '"', 0}},
+		{"😀", []byte{'"', 0, 0x3d, 0xd8, 0x00, 0xde, '"', 0}},
+	}
+	for _, tt := range tests {
+		if got := encodeADPassword(tt.password); got != string(tt.expected) {
+			t.Errorf("Expected %x for %q, but got %x", tt.expected, tt.password, got)
+		}
+	}
+}
+
+// testCertificate returns a self-signed certificate in PEM form.
+func testCertificate(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -0,0 +1,167 @@
+/**
+ * Password Generator - TOTP Secrets
+ *
+ * This file generates secrets for time-based one-time passwords (RFC 6238,
+ * built on the HOTP of RFC 4226) and the otpauth:// URI that authenticator
+ * apps read from a QR code, for provisioning 2FA on new services. It also
+ * computes the current code, so an enrolled app can be checked against it.
+ */
+
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HMAC algorithms of a key. Most authenticator apps only support SHA1.
+const (
+	SHA1   = "SHA1"
+	SHA256 = "SHA256"
+	SHA512 = "SHA512"
+)
+
+// Algorithms lists the algorithms in the order they are offered.
+var Algorithms = []string{SHA1, SHA256, SHA512}
+
+// Defaults understood by every authenticator app.
+const (
+	DefaultDigits = 6
+	DefaultPeriod = 30
+)
+
+// secretSizes is the secret length of each algorithm in bytes: the size of
+// its HMAC output, as RFC 4226 recommends at least 160 bits.
+var secretSizes = map[string]int{SHA1: 20, SHA256: 32, SHA512: 64}
+
+// encoding is unpadded base32, the secret format of otpauth URIs.
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Key is a TOTP secret with the details an authenticator app shows.
+// Fields:
+//   - Secret (string): The shared secret, base32 without padding.
+//   - Issuer (string): The service, e.g. "GitLab"; optional.
+//   - Account (string): The user at the service, e.g. an email address.
+//   - Algorithm (string): One of Algorithms.
+//   - Digits (int): Code length, 6 to 8.
+//   - Period (int): Seconds each code is valid.
+type Key struct {
+	Secret    string
+	Issuer    string
+	Account   string
+	Algorithm string
+	Digits    int
+	Period    int
+}
+
+// NewKey returns a key with a random secret and the default algorithm,
+// digits and period.
+// Example:
+//
+//	key, err := otp.NewKey("GitLab", "alice@example.com")
+func NewKey(issuer, account string) (Key, error) {
+	k := Key{Issuer: issuer, Account: account, Algorithm: SHA1, Digits: DefaultDigits, Period: DefaultPeriod}
+	return k, k.NewSecret()
+}
+
+// NewSecret replaces the secret with a random one of the size of the
+// algorithm's output.
+func (k *Key) NewSecret() error {
+	size, ok := secretSizes[k.Algorithm]
+	if !ok {
+		return fmt.Errorf("unknown algorithm %q; use %s", k.Algorithm, strings.Join(Algorithms, ", "))
+	}
+	secret := make([]byte, size)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	k.Secret = encoding.EncodeToString(secret)
+	return nil
+}
+
+// Validate checks the key's fields.
+func (k Key) Validate() error {
+	if _, ok := secretSizes[k.Algorithm]; !ok {
+		return fmt.Errorf("unknown algorithm %q; use %s", k.Algorithm, strings.Join(Algorithms, ", "))
+	}
+	if k.Digits < 6 || k.Digits > 8 {
+		return fmt.Errorf("codes must have 6 to 8 digits, not %d", k.Digits)
+	}
+	if k.Period < 1 {
+		return fmt.Errorf("the period must be at least 1 second, not %d", k.Period)
+	}
+	if strings.TrimSpace(k.Account) == "" {
+		return errors.New("the account name is empty")
+	}
+	if strings.Contains(k.Issuer, ":") || strings.Contains(k.Account, ":") {
+		return errors.New("the issuer and account cannot contain a colon")
+	}
+	if k.Secret == "" {
+		return errors.New("the secret is empty")
+	}
+	if _, err := encoding.DecodeString(k.Secret); err != nil {
+		return fmt.Errorf("the secret is not base32: %w", err)
+	}
+	return nil
+}
+
+// URI returns the otpauth:// URI of the key, as authenticator apps read it
+// from a QR code, e.g.
+//
+//	otpauth://totp/GitLab:alice%40example.com?secret=...&issuer=GitLab&algorithm=SHA1&digits=6&period=30
+func (k Key) URI() string {
+	label := url.PathEscape(k.Account)
+	query := url.Values{"secret": {k.Secret}}
+	if k.Issuer != "" {
+		label = url.PathEscape(k.Issuer) + ":" + label
+		query.Set("issuer", k.Issuer)
+	}
+	query.Set("algorithm", k.Algorithm)
+	query.Set("digits", strconv.Itoa(k.Digits))
+	query.Set("period", strconv.Itoa(k.Period))
+	return "otpauth://totp/" + label + "?" + strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+// Code returns the code valid at time t.
+func (k Key) Code(t time.Time) (string, error) {
+	if err := k.Validate(); err != nil {
+		return "", err
+	}
+	secret, _ := encoding.DecodeString(k.Secret)
+	return hotp(secret, uint64(t.Unix())/uint64(k.Period), k.Digits, k.Algorithm), nil
+}
+
+// Remaining returns how long the code of time t stays valid.
+func (k Key) Remaining(t time.Time) time.Duration {
+	period := int64(k.Period)
+	return time.Duration(period-t.Unix()%period) * time.Second
+}
+
+// hotp computes the RFC 4226 code of counter.
+func hotp(secret []byte, counter uint64, digits int, algorithm string) string {
+	newHash := map[string]func() hash.Hash{SHA1: sha1.New, SHA256: sha256.New, SHA512: sha512.New}[algorithm]
+	mac := hmac.New(newHash, secret)
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], counter)
+	mac.Write(message[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	modulo := uint32(1)
+	for i := 0; i < digits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%modulo)
+}
@@ -0,0 +1,78 @@
+package otp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestCode checks the test vectors of RFC 6238, appendix B.
+func TestCode(t *testing.T) {
+	secrets := map[string]string{
+		SHA1:   "12345678901234567890",
+		SHA256: "12345678901234567890123456789012",
+		SHA512: "1234567890123456789012345678901234567890123456789012345678901234",
+	}
+	tests := []struct {
+		at        int64
+		algorithm string
+		want      string
+	}{
+		{59, SHA1, "94287082"},
+		{59, SHA256, "46119246"},
+		{59, SHA512, "90693936"},
+		{1111111109, SHA1, "07081804"},
+		{1234567890, SHA256, "91819424"},
+		{20000000000, SHA512, "47863826"},
+	}
+	for _, tt := range tests {
+		k := Key{Secret: encoding.EncodeToString([]byte(secrets[tt.algorithm])), Account: "test", Algorithm: tt.algorithm, Digits: 8, Period: 30}
+		got, err := k.Code(time.Unix(tt.at, 0))
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("%s at %d: Expected %s, but got %s", tt.algorithm, tt.at, tt.want, got)
+		}
+	}
+}
+
+// TestNewKey verifies the secret size and the URI authenticator apps read.
+func TestNewKey(t *testing.T) {
+	k, err := NewKey("Example Co", "alice@example.com")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if secret, _ := encoding.DecodeString(k.Secret); len(secret) != 20 {
+		t.Errorf("Expected a 160-bit secret, but got %d bytes", len(secret))
+	}
+	u, err := url.Parse(k.URI())
+	if err != nil {
+		t.Fatalf("Expected a valid URI, but got %v", err)
+	}
+	query := u.Query()
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/Example Co:alice@example.com" {
+		t.Errorf("Expected the totp label, but got %s", k.URI())
+	}
+	if query.Get("secret") != k.Secret || query.Get("issuer") != "Example Co" || query.Get("digits") != "6" || query.Get("period") != "30" {
+		t.Errorf("Expected the secret and parameters, but got %s", k.URI())
+	}
+}
+
+// TestValidate verifies that unusable keys are refused.
+func TestValidate(t *testing.T) {
+	good, _ := NewKey("", "bob")
+	for _, change := range []func(k *Key){
+		func(k *Key) { k.Digits = 5 },
+		func(k *Key) { k.Algorithm = "MD5" },
+		func(k *Key) { k.Account = "" },
+		func(k *Key) { k.Issuer = "a:b" },
+		func(k *Key) { k.Secret = "not base32!" },
+	} {
+		k := good
+		change(&k)
+		if err := k.Validate(); err == nil {
+			t.Errorf("Expected an error for %+v, but got none", k)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+/**
+ * Acronym Passwords
+ *
+ * This file derives a password from a sentence the user remembers, the way
+ * many people already do by hand: the first letter of every word, numbers
+ * and punctuation kept, optional look-alike substitutions, and random digits
+ * and symbols appended. Only the appended characters are random, so the
+ * entropy reported counts nothing else.
+ */
+
+package passgen
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// AcronymOptions controls how a sentence becomes a password.
+// Fields:
+//   - Substitute (bool): Replaces letters with look-alikes from
+//     AcronymSubstitutions, e.g. o with 0.
+//   - RandomDigits (int): Random digits appended to the acronym.
+//   - RandomSymbols (int): Random symbols appended after the digits.
+type AcronymOptions struct {
+	Substitute    bool
+	RandomDigits  int
+	RandomSymbols int
+}
+
+// AcronymSubstitutions maps letters to the look-alikes Substitute uses.
+// They are well known to cracking tools and add no entropy.
+var AcronymSubstitutions = map[rune]rune{
+	'a': '@', 'A': '@',
+	'e': '3', 'E': '3',
+	'i': '!', 'I': '!',
+	'o': '0', 'O': '0',
+	's': '$', 'S': '$',
+}
+
+// AcronymWeakBits is the entropy of the random characters below which front
+// ends warn that the password rests mostly on the sentence staying secret.
+const AcronymWeakBits = 36
+
+// Acronym is a password derived from a sentence.
+// Fields:
+//   - Password (string): The acronym followed by the random characters.
+//   - RandomBits (float64): Entropy of the random characters. The sentence
+//     adds only as much as it is hard to guess, which cannot be measured,
+//     so it is not counted.
+type Acronym struct {
+	Password   string
+	RandomBits float64
+}
+
+// GenerateAcronym derives a password from sentence with crypto/rand; see
+// Generator.GenerateAcronym.
+func GenerateAcronym(sentence string, opts AcronymOptions) (Acronym, error) {
+	return defaultGenerator.GenerateAcronym(sentence, opts)
+}
+
+// GenerateAcronym derives a password from a sentence.
+// Purpose:
+//
+//	Takes the first letter of each word, keeping its case, whole numbers
+//	such as years, and punctuation within or after a word; then applies the
+//	substitutions and appends the random digits and symbols.
+//
+// Parameters:
+//   - sentence (string): A sentence the user can remember.
+//   - opts (AcronymOptions): The substitutions and random characters.
+//
+// Returns:
+//
+//	Acronym: The password and the entropy of its random characters.
+//	error: CodeAcronymEmpty if the sentence has no words, CodeAcronymSuffix
+//	if a count is negative, or CodeRandomFailure.
+//
+// Example:
+//
+//	acronym, err := g.GenerateAcronym("My cat Tom was born in 2015!", passgen.AcronymOptions{RandomDigits: 2})
+//	// acronym.Password: "McTwbi2015!" followed by two random digits
+func (g *Generator) GenerateAcronym(sentence string, opts AcronymOptions) (Acronym, error) {
+	if opts.RandomDigits < 0 || opts.RandomSymbols < 0 {
+		return Acronym{}, newError(CodeAcronymSuffix)
+	}
+	acronym := acronymOf(sentence)
+	if acronym == "" {
+		return Acronym{}, newError(CodeAcronymEmpty)
+	}
+	if opts.Substitute {
+		acronym = strings.Map(func(r rune) rune {
+			if s, ok := AcronymSubstitutions[r]; ok {
+				return s
+			}
+			return r
+		}, acronym)
+	}
+
+	suffix, err := g.randomString(Digits, opts.RandomDigits)
+	if err != nil {
+		return Acronym{}, err
+	}
+	symbols, err := g.randomString(Symbols, opts.RandomSymbols)
+	if err != nil {
+		return Acronym{}, err
+	}
+	return Acronym{
+		Password:   acronym + suffix + symbols,
+		RandomBits: AcronymEntropy(opts),
+	}, nil
+}
+
+// AcronymEntropy returns the entropy in bits of the random characters opts
+// append; the sentence itself is not counted.
+func AcronymEntropy(opts AcronymOptions) float64 {
+	bits := 0.0
+	if opts.RandomDigits > 0 {
+		bits += float64(opts.RandomDigits) * math.Log2(float64(len(Digits)))
+	}
+	if opts.RandomSymbols > 0 {
+		bits += float64(opts.RandomSymbols) * math.Log2(float64(len(Symbols)))
+	}
+	return bits
+}
+
+// acronymOf returns the first letter of every word of sentence, whole
+// numbers, and the punctuation of each word.
+func acronymOf(sentence string) string {
+	var acronym strings.Builder
+	for _, word := range strings.Fields(sentence) {
+		first, number := true, false
+		for _, r := range word {
+			switch {
+			case unicode.IsDigit(r):
+				if first || number {
+					acronym.WriteRune(r)
+					first, number = false, true
+				}
+			case unicode.IsLetter(r):
+				if first {
+					acronym.WriteRune(r)
+					first = false
+				}
+				number = false
+			case unicode.IsPunct(r) || unicode.IsSymbol(r):
+				acronym.WriteRune(r)
+				number = false
+			}
+		}
+	}
+	return acronym.String()
+}
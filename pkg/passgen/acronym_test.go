@@ -0,0 +1,57 @@
+package passgen
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestGenerateAcronym verifies the acronym, substitutions and random suffix.
+func TestGenerateAcronym(t *testing.T) {
+	tests := []struct {
+		sentence string
+		opts     AcronymOptions
+		acronym  string
+	}{
+		{"My cat Tom was born in 2015!", AcronymOptions{}, "McTwbi2015!"},
+		{"Hello, world. It's 9am", AcronymOptions{}, "H,w.I'9"},
+		{"one small step for a man", AcronymOptions{Substitute: true}, "0$$f@m"},
+		{"My cat Tom", AcronymOptions{RandomDigits: 2, RandomSymbols: 1}, "McT"},
+	}
+	for _, test := range tests {
+		acronym, err := GenerateAcronym(test.sentence, test.opts)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if !strings.HasPrefix(acronym.Password, test.acronym) {
+			t.Errorf("Expected %q to start with %q, but got %q", test.sentence, test.acronym, acronym.Password)
+			continue
+		}
+		suffix := acronym.Password[len(test.acronym):]
+		if len(suffix) != test.opts.RandomDigits+test.opts.RandomSymbols {
+			t.Errorf("Expected %d random characters, but got %q", test.opts.RandomDigits+test.opts.RandomSymbols, suffix)
+			continue
+		}
+		if strings.Trim(suffix[:test.opts.RandomDigits], Digits) != "" || strings.Trim(suffix[test.opts.RandomDigits:], Symbols) != "" {
+			t.Errorf("Expected digits then symbols, but got %q", suffix)
+		}
+	}
+}
+
+// TestGenerateAcronym_Errors verifies the errors for bad input.
+func TestGenerateAcronym_Errors(t *testing.T) {
+	if _, err := GenerateAcronym("", AcronymOptions{}); ErrorCode(err) != CodeAcronymEmpty {
+		t.Errorf("Expected %s, but got %v", CodeAcronymEmpty, err)
+	}
+	if _, err := GenerateAcronym("a b c", AcronymOptions{RandomDigits: -1}); ErrorCode(err) != CodeAcronymSuffix {
+		t.Errorf("Expected %s, but got %v", CodeAcronymSuffix, err)
+	}
+}
+
+// TestAcronymEntropy verifies that only the random characters are counted.
+func TestAcronymEntropy(t *testing.T) {
+	bits := AcronymEntropy(AcronymOptions{Substitute: true, RandomDigits: 3})
+	if want := 3 * math.Log2(10); math.Abs(bits-want) > 1e-9 {
+		t.Errorf("Expected %.2f bits, but got %.2f", want, bits)
+	}
+}
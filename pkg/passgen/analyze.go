@@ -0,0 +1,675 @@
+/**
+ * Realistic Strength Estimation
+ *
+ * This file estimates how many guesses an attacker needs for a password,
+ * in the style of zxcvbn: it looks for the patterns people use, such as
+ * common passwords and words (also reversed or with look-alike
+ * substitutions), keyboard walks, sequences, repeats and dates, and finds
+ * the cheapest way to build the password from them and from random
+ * characters. Unlike PasswordEntropy, which assumes every character is
+ * random, the result reflects how human-chosen passwords are cracked.
+ */
+
+package passgen
+
+import (
+	_ "embed"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+//go:embed common.txt
+var commonPasswordList string
+
+//go:embed words.txt
+var commonWordList string
+
+// MatchKind names a pattern found by AnalyzeStrength.
+type MatchKind string
+
+// Patterns found by AnalyzeStrength.
+const (
+	MatchDictionary MatchKind = "dictionary"
+	MatchKeyboard   MatchKind = "keyboard"
+	MatchSequence   MatchKind = "sequence"
+	MatchRepeat     MatchKind = "repeat"
+	MatchDate       MatchKind = "date"
+)
+
+// Match is a part of a password that follows a guessable pattern.
+// Fields:
+//   - Kind (MatchKind): The pattern.
+//   - Token (string): The matched characters.
+//   - Start, End (int): Rune positions of the token; End is exclusive.
+//   - Bits (float64): log2 of the guesses needed for the token.
+type Match struct {
+	Kind  MatchKind
+	Token string
+	Start int
+	End   int
+	Bits  float64
+}
+
+// Score is the result of AnalyzeStrength.
+// Fields:
+//   - Bits (float64): log2 of the guesses an attacker who knows common
+//     patterns needs; never more than PasswordEntropy.
+//   - Strength (Strength): Rating of Bits, see RateEntropy.
+//   - Matches ([]Match): The patterns the estimate is based on, in order.
+//     Characters outside any match are counted as random.
+type Score struct {
+	Bits     float64
+	Strength Strength
+	Matches  []Match
+}
+
+// matchBits is added to every match: the attacker also has to guess which
+// kind of pattern comes next.
+const matchBits = 1
+
+// minKeyboardRun and minSequenceRun are the shortest keyboard walks and
+// sequences matched; shorter ones occur by chance in random passwords.
+const (
+	minKeyboardRun = 4
+	minSequenceRun = 3
+	minRepeatSpan  = 3
+)
+
+// AnalyzeStrength estimates how hard password is to guess.
+// Purpose:
+//
+//	Rates passwords that were chosen by people, such as existing
+//	credentials, the way crackers attack them: common passwords and words
+//	first, then keyboard walks, sequences, repeats and dates, and only the
+//	rest by brute force. "P@ssw0rd2019" scores far lower than its length
+//	suggests.
+//
+// Parameters:
+//   - password (string): The password to rate.
+//
+// Returns:
+//
+//	Score: The estimate, its rating and the patterns found.
+//
+// Example:
+//
+//	score := passgen.AnalyzeStrength("Tr0ub4dor&3")
+//	fmt.Println(score.Strength, score.CrackTime(passgen.Attacks[3]))
+func AnalyzeStrength(password string) Score {
+	runes := []rune(password)
+	n := len(runes)
+	if n == 0 {
+		return Score{}
+	}
+	random := PasswordEntropy(password) / float64(n)
+
+	var candidates []Match
+	candidates = append(candidates, dictionaryMatches(runes)...)
+	candidates = append(candidates, keyboardMatches(runes)...)
+	candidates = append(candidates, sequenceMatches(runes)...)
+	candidates = append(candidates, repeatMatches(runes)...)
+	candidates = append(candidates, dateMatches(runes)...)
+	byEnd := make([][]int, n+1)
+	for i, m := range candidates {
+		byEnd[m.End] = append(byEnd[m.End], i)
+	}
+
+	// best[i] is the cheapest way to build runes[:i]; used[i] is the match
+	// ending at i that achieves it, or -1 for a random character.
+	best := make([]float64, n+1)
+	used := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		best[i], used[i] = best[i-1]+random, -1
+		for _, c := range byEnd[i] {
+			m := candidates[c]
+			if bits := best[m.Start] + m.Bits + matchBits; bits < best[i] {
+				best[i], used[i] = bits, c
+			}
+		}
+	}
+
+	var matches []Match
+	for i := n; i > 0; {
+		if used[i] < 0 {
+			i--
+			continue
+		}
+		m := candidates[used[i]]
+		matches = append([]Match{m}, matches...)
+		i = m.Start
+	}
+	bits := math.Min(best[n], PasswordEntropy(password))
+	return Score{Bits: bits, Strength: RateEntropy(bits), Matches: matches}
+}
+
+// CrackTime returns the average time attack needs to guess the password,
+// e.g. "3 days".
+func (s Score) CrackTime(attack Attack) string {
+	return FormatCrackTime(CrackSeconds(s.Bits, attack.GuessesPerSecond))
+}
+
+// matchFeedback holds the advice shown for each kind of pattern.
+var matchFeedback = map[MatchKind]string{
+	MatchDictionary: "Common passwords and words are guessed first, even with capitals, reversed or with look-alikes such as @ for a.",
+	MatchKeyboard:   "Keyboard walks such as qwerty or 1qaz2wsx are easy to guess.",
+	MatchSequence:   "Sequences such as abc or 6543 are easy to guess.",
+	MatchRepeat:     "Repeats such as aaa or abcabc add little strength.",
+	MatchDate:       "Dates and years are easy to guess, especially recent ones.",
+}
+
+// Feedback returns one piece of advice for each kind of pattern found, in
+// the order they first appear in the password.
+func (s Score) Feedback() []string {
+	var feedback []string
+	seen := make(map[MatchKind]bool)
+	for _, m := range s.Matches {
+		if !seen[m.Kind] {
+			seen[m.Kind] = true
+			feedback = append(feedback, matchFeedback[m.Kind])
+		}
+	}
+	return feedback
+}
+
+// IsCommonPassword reports whether password is on the bundled list of the
+// most frequently used passwords, ignoring case. These appear in every
+// breach.
+func IsCommonPassword(password string) bool {
+	_, ok := commonPasswords[strings.ToLower(password)]
+	return ok
+}
+
+// Ranked dictionaries: the position of each lowercase word, most frequent
+// first. maxWordLength bounds the tokens looked up.
+var (
+	commonPasswords = rankWords(commonPasswordList)
+	commonWords     = rankWords(commonWordList)
+	maxWordLength   = longestWord(commonPasswords, commonWords)
+)
+
+// rankWords numbers the words of a list, one per line, from 1.
+func rankWords(list string) map[string]int {
+	ranks := make(map[string]int)
+	for _, line := range strings.Split(list, "\n") {
+		word := strings.ToLower(strings.TrimSpace(line))
+		if _, ok := ranks[word]; word != "" && !ok {
+			ranks[word] = len(ranks) + 1
+		}
+	}
+	return ranks
+}
+
+// longestWord returns the length in runes of the longest dictionary word.
+func longestWord(dictionaries ...map[string]int) int {
+	longest := 0
+	for _, dictionary := range dictionaries {
+		for word := range dictionary {
+			if n := len([]rune(word)); n > longest {
+				longest = n
+			}
+		}
+	}
+	return longest
+}
+
+// wordPrefixes holds every start of a dictionary word.
+var wordPrefixes = func() map[string]bool {
+	prefixes := make(map[string]bool)
+	for _, dictionary := range []map[string]int{commonPasswords, commonWords} {
+		for word := range dictionary {
+			for k := 1; k <= len(word); k++ {
+				prefixes[word[:k]] = true
+			}
+		}
+	}
+	return prefixes
+}()
+
+// wordRank returns the best rank of word in any dictionary, or 0.
+func wordRank(word []byte) int {
+	rank := 0
+	for _, dictionary := range []map[string]int{commonPasswords, commonWords} {
+		if r, ok := dictionary[string(word)]; ok && (rank == 0 || r < rank) {
+			rank = r
+		}
+	}
+	return rank
+}
+
+// leetTables map look-alike characters back to letters. 1 stands for
+// both i and l, so there are two tables.
+var leetTables = []map[rune]rune{
+	{'4': 'a', '@': 'a', '3': 'e', '1': 'i', '!': 'i', '0': 'o', '$': 's', '5': 's', '7': 't', '+': 't'},
+	{'4': 'a', '@': 'a', '3': 'e', '1': 'l', '!': 'i', '0': 'o', '$': 's', '5': 's', '7': 't', '+': 't'},
+}
+
+// dictionaryMatches finds dictionary words, also reversed and with
+// look-alike substitutions.
+func dictionaryMatches(runes []rune) []Match {
+	matches := wordMatches(runes)
+	for _, m := range wordMatches(reverseRunes(runes)) {
+		m.Start, m.End = len(runes)-m.End, len(runes)-m.Start
+		m.Token = string(runes[m.Start:m.End])
+		m.Bits++
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+// wordMatches finds dictionary words written forwards. Words are extended
+// one character at a time while they are still the start of some word.
+func wordMatches(runes []rune) []Match {
+	var matches []Match
+	for i := range runes {
+		var lower []byte
+		unleet := make([][]byte, len(leetTables))
+		substituted := make([]int, len(leetTables))
+		for j := i; j < len(runes) && j-i < maxWordLength && runes[j] < unicode.MaxASCII; j++ {
+			c := byte(unicode.ToLower(runes[j]))
+			lower = append(lower, c)
+			prefix := wordPrefixes[string(lower)]
+			for t, table := range leetTables {
+				if letter, ok := table[rune(c)]; ok {
+					unleet[t] = append(unleet[t], byte(letter))
+					substituted[t]++
+				} else {
+					unleet[t] = append(unleet[t], c)
+				}
+				prefix = prefix || wordPrefixes[string(unleet[t])]
+			}
+			if !prefix {
+				break
+			}
+			if len(lower) < 3 {
+				continue
+			}
+
+			token := runes[i : j+1]
+			best, found := 0.0, false
+			if rank := wordRank(lower); rank > 0 {
+				best, found = math.Log2(float64(rank))+caseBits(token), true
+			}
+			for t := range leetTables {
+				if substituted[t] == 0 || substituted[t] == len(lower) {
+					continue
+				}
+				if rank := wordRank(unleet[t]); rank > 0 {
+					bits := math.Log2(float64(rank)) + caseBits(token) + float64(substituted[t])
+					if !found || bits < best {
+						best, found = bits, true
+					}
+				}
+			}
+			if found {
+				matches = append(matches, Match{Kind: MatchDictionary, Token: string(token), Start: i, End: j + 1, Bits: best})
+			}
+		}
+	}
+	return matches
+}
+
+// caseBits returns the bits added by the capitalisation of a word: none
+// for lowercase, one for a capital first letter or all capitals, and the
+// number of ways to place the capitals otherwise.
+func caseBits(token []rune) float64 {
+	upper, lower := 0, 0
+	for _, r := range token {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	switch {
+	case upper == 0:
+		return 0
+	case lower == 0, upper == 1 && unicode.IsUpper(token[0]):
+		return 1
+	}
+	ways := 0.0
+	for k := 1; k <= upper && k <= lower; k++ {
+		ways += binomial(upper+lower, k)
+	}
+	return math.Log2(ways)
+}
+
+// binomial returns n choose k.
+func binomial(n, k int) float64 {
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
+
+// reverseRunes returns a reversed copy of runes.
+func reverseRunes(runes []rune) []rune {
+	reversed := make([]rune, len(runes))
+	for i, r := range runes {
+		reversed[len(runes)-1-i] = r
+	}
+	return reversed
+}
+
+// keyboardRows holds the QWERTY rows, unshifted and shifted, and how far
+// each row is indented, in keys.
+var keyboardRows = []struct {
+	keys, shifted string
+	indent        float64
+}{
+	{"`1234567890-=", "~!@#$%^&*()_+", 0},
+	{"qwertyuiop[]\\", "QWERTYUIOP{}|", 0.5},
+	{"asdfghjkl;'", "ASDFGHJKL:\"", 0.75},
+	{"zxcvbnm,./", "ZXCVBNM<>?", 1.25},
+}
+
+// keyPosition is where a character is typed.
+type keyPosition struct {
+	row     int
+	x       float64
+	shifted bool
+}
+
+// keyPositions maps every character of keyboardRows to its key.
+var keyPositions = func() map[rune]keyPosition {
+	positions := make(map[rune]keyPosition)
+	for row, r := range keyboardRows {
+		for col, key := range r.keys {
+			positions[key] = keyPosition{row: row, x: float64(col) + r.indent}
+		}
+		for col, key := range r.shifted {
+			positions[key] = keyPosition{row: row, x: float64(col) + r.indent, shifted: true}
+		}
+	}
+	return positions
+}()
+
+// keyboardKeys and keyboardDegree are the number of keys and the average
+// number of neighbours of a key, used to count keyboard walks.
+const (
+	keyboardKeys   = 47
+	keyboardDegree = 4.6
+)
+
+// keyStep returns the direction from key a to a neighbouring key b, or
+// false if they are not neighbours.
+func keyStep(a, b rune) (int, bool) {
+	pa, okA := keyPositions[a]
+	pb, okB := keyPositions[b]
+	if !okA || !okB || a == b {
+		return 0, false
+	}
+	dx := pb.x - pa.x
+	switch pb.row - pa.row {
+	case 0:
+		if dx == 1 || dx == -1 {
+			return int(dx), true
+		}
+	case 1, -1:
+		if math.Abs(dx) <= 0.75 {
+			return (pb.row - pa.row) * 10 * int(math.Copysign(1, dx)), true
+		}
+	}
+	return 0, false
+}
+
+// keyboardMatches finds walks over neighbouring QWERTY keys, such as
+// qwerty, asdf or 1qaz2wsx.
+func keyboardMatches(runes []rune) []Match {
+	var matches []Match
+	for i := 0; i < len(runes)-1; {
+		j, turns, direction := i+1, 0, 0
+		for ; j < len(runes); j++ {
+			step, ok := keyStep(runes[j-1], runes[j])
+			if !ok {
+				break
+			}
+			if j > i+1 && step != direction {
+				turns++
+			}
+			direction = step
+		}
+		if j-i >= minKeyboardRun {
+			matches = append(matches, Match{Kind: MatchKeyboard, Token: string(runes[i:j]), Start: i, End: j, Bits: keyboardBits(runes[i:j], turns+1)})
+		}
+		if j-i > 1 {
+			i = j - 1
+		} else {
+			i++
+		}
+	}
+	return matches
+}
+
+// keyboardBits counts the walks of the same length with at most as many
+// turns, as zxcvbn does, plus the ways to use Shift.
+func keyboardBits(token []rune, turns int) float64 {
+	guesses := 0.0
+	for length := 2; length <= len(token); length++ {
+		for t := 1; t <= turns && t < length; t++ {
+			guesses += binomial(length-1, t-1) * keyboardKeys * math.Pow(keyboardDegree, float64(t))
+		}
+	}
+	shifted := 0
+	for _, r := range token {
+		if keyPositions[r].shifted {
+			shifted++
+		}
+	}
+	bits := math.Log2(guesses)
+	if shifted == len(token) {
+		bits++
+	} else if shifted > 0 {
+		bits += float64(shifted)
+	}
+	return bits
+}
+
+// sequenceMatches finds runs such as abc, 6543 or XYZ.
+func sequenceMatches(runes []rune) []Match {
+	var matches []Match
+	for i := 0; i < len(runes)-1; {
+		delta := runes[i+1] - runes[i]
+		j := i + 1
+		if delta == 1 || delta == -1 {
+			for j < len(runes) && runes[j]-runes[j-1] == delta && sameClass(runes[i], runes[j]) {
+				j++
+			}
+		}
+		if j-i >= minSequenceRun {
+			token := runes[i:j]
+			base := 26.0
+			switch {
+			case strings.ContainsRune("aAzZ019", token[0]):
+				base = 4
+			case unicode.IsDigit(token[0]):
+				base = 10
+			}
+			if delta < 0 {
+				base *= 2
+			}
+			matches = append(matches, Match{Kind: MatchSequence, Token: string(token), Start: i, End: j, Bits: math.Log2(base * float64(len(token)))})
+			i = j - 1
+		} else {
+			i++
+		}
+	}
+	return matches
+}
+
+// sameClass reports whether a and b are both lowercase, uppercase or digits.
+func sameClass(a, b rune) bool {
+	for _, class := range []string{Lowercase, Uppercase, Digits} {
+		if strings.ContainsRune(class, a) {
+			return strings.ContainsRune(class, b)
+		}
+	}
+	return false
+}
+
+// repeatMatches finds a part repeated right after itself, such as aaa or
+// abcabc. The part itself is rated with AnalyzeStrength.
+func repeatMatches(runes []rune) []Match {
+	var matches []Match
+	for i := 0; i < len(runes); i++ {
+		bestSpan, bestUnit := 0, 0
+		for unit := 1; i+2*unit <= len(runes); unit++ {
+			span := unit
+			for i+span+unit <= len(runes) && equalRunes(runes[i+span:i+span+unit], runes[i:i+unit]) {
+				span += unit
+			}
+			if span > unit && span > bestSpan {
+				bestSpan, bestUnit = span, unit
+			}
+		}
+		if bestSpan >= minRepeatSpan {
+			unit := AnalyzeStrength(string(runes[i : i+bestUnit]))
+			bits := unit.Bits + math.Log2(float64(bestSpan/bestUnit))
+			matches = append(matches, Match{Kind: MatchRepeat, Token: string(runes[i : i+bestSpan]), Start: i, End: i + bestSpan, Bits: bits})
+			// Repeats do not overlap: the next one starts after this one.
+			i += bestSpan - 1
+		}
+	}
+	return matches
+}
+
+// equalRunes reports whether a and b hold the same runes.
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// dateSeparators are accepted between the day, month and year of a date.
+const dateSeparators = "/-._ \\"
+
+// dateMatches finds years such as 1987 and dates such as 24.12.99 or
+// 20190501.
+func dateMatches(runes []rune) []Match {
+	var matches []Match
+	for i := range runes {
+		if !unicode.IsDigit(runes[i]) {
+			continue
+		}
+		for j := i + 4; j <= len(runes) && j-i <= 10; j++ {
+			if r := runes[j-1]; !unicode.IsDigit(r) && !strings.ContainsRune(dateSeparators, r) {
+				break
+			}
+			token := string(runes[i:j])
+			if bits, ok := dateBits(token); ok {
+				matches = append(matches, Match{Kind: MatchDate, Token: token, Start: i, End: j, Bits: bits})
+			}
+		}
+	}
+	return matches
+}
+
+// dateBits returns the bits of token as a year or a date: the days of the
+// year times the distance to the current year, at least 20 years.
+func dateBits(token string) (float64, bool) {
+	yearSpace := func(year int) float64 {
+		return math.Max(math.Abs(float64(year-time.Now().Year())), 20)
+	}
+	if len(token) == 4 {
+		if year, err := strconv.Atoi(token); err == nil && year >= 1900 && year <= 2099 {
+			return math.Log2(yearSpace(year)), true
+		}
+	}
+
+	digits, separator := token, ""
+	if k := strings.IndexAny(token, dateSeparators); k > 0 {
+		separator = token[k : k+1]
+		parts := strings.Split(token, separator)
+		if len(parts) != 3 {
+			return 0, false
+		}
+		year, ok := splitDate(parts)
+		if !ok {
+			return 0, false
+		}
+		return math.Log2(365*yearSpace(year)) + 2, true
+	}
+	if strings.Trim(digits, Digits) != "" {
+		return 0, false
+	}
+	best, found := 0.0, false
+	for _, parts := range dateSplits(digits) {
+		if year, ok := splitDate(parts); ok {
+			bits := math.Log2(365 * yearSpace(year))
+			if !found || bits < best {
+				best, found = bits, true
+			}
+		}
+	}
+	return best, found
+}
+
+// dateSplits returns the ways to split a run of 5 to 8 digits into year
+// and day and month, with the year first or last.
+func dateSplits(digits string) [][]string {
+	var splits [][]string
+	if len(digits) < 5 || len(digits) > 8 {
+		return nil
+	}
+	for _, yearLength := range []int{2, 4} {
+		rest := len(digits) - yearLength
+		if rest < 2 || rest > 4 {
+			continue
+		}
+		for first := 1; first <= 2; first++ {
+			second := rest - first
+			if second < 1 || second > 2 {
+				continue
+			}
+			last := digits[:len(digits)-yearLength]
+			splits = append(splits, []string{last[:first], last[first:], digits[len(digits)-yearLength:]})
+			tail := digits[yearLength:]
+			splits = append(splits, []string{digits[:yearLength], tail[:first], tail[first:]})
+		}
+	}
+	return splits
+}
+
+// splitDate checks that three parts are a day, month and year in any of
+// the common orders, and returns the year.
+func splitDate(parts []string) (int, bool) {
+	values := make([]int, 3)
+	for k, part := range parts {
+		if part == "" || len(part) > 4 || strings.Trim(part, Digits) != "" {
+			return 0, false
+		}
+		values[k], _ = strconv.Atoi(part)
+	}
+	for _, order := range [][3]int{{0, 1, 2}, {1, 0, 2}, {1, 2, 0}, {2, 1, 0}} {
+		day, month, year := values[order[0]], values[order[1]], values[order[2]]
+		yearText := parts[order[2]]
+		switch len(yearText) {
+		case 2:
+			if year > 50 {
+				year += 1900
+			} else {
+				year += 2000
+			}
+		case 4:
+			if year < 1900 || year > 2099 {
+				continue
+			}
+		default:
+			continue
+		}
+		if len(parts[order[0]]) <= 2 && len(parts[order[1]]) <= 2 && day >= 1 && day <= 31 && month >= 1 && month <= 12 {
+			return year, true
+		}
+	}
+	return 0, false
+}
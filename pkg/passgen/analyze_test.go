@@ -0,0 +1,75 @@
+package passgen
+
+import (
+	"math"
+	"testing"
+)
+
+// TestAnalyzeStrength_Patterns verifies that each kind of pattern is found
+// and rated well below the brute-force estimate.
+func TestAnalyzeStrength_Patterns(t *testing.T) {
+	tests := []struct {
+		password string
+		kind     MatchKind
+	}{
+		{"password", MatchDictionary},
+		{"P@ssw0rd", MatchDictionary},
+		{"drowssap", MatchDictionary},
+		{"Sunshine", MatchDictionary},
+		{"asdfghjk", MatchKeyboard},
+		{"abcdefg", MatchSequence},
+		{"98765", MatchSequence},
+		{"zzzzzzzz", MatchRepeat},
+		{"24.12.1999", MatchDate},
+		{"19870514", MatchDate},
+	}
+	for _, test := range tests {
+		score := AnalyzeStrength(test.password)
+		if len(score.Matches) == 0 || score.Matches[0].Kind != test.kind {
+			t.Errorf("Expected %s to match %s, but got %v", test.password, test.kind, score.Matches)
+			continue
+		}
+		if upper := PasswordEntropy(test.password) - 5; score.Bits > upper {
+			t.Errorf("Expected %s below %.1f bits, but got %.1f", test.password, upper, score.Bits)
+		}
+		if score.Strength != VeryWeak {
+			t.Errorf("Expected %s to be very weak, but got %s", test.password, score.Strength)
+		}
+	}
+}
+
+// TestAnalyzeStrength_Combined verifies that patterns are combined and that
+// the matches cover their tokens.
+func TestAnalyzeStrength_Combined(t *testing.T) {
+	score := AnalyzeStrength("Monkey2019!")
+	if len(score.Matches) != 2 || score.Matches[0].Token != "Monkey" || score.Matches[1].Token != "2019" {
+		t.Fatalf("Expected Monkey and 2019, but got %v", score.Matches)
+	}
+	if score.Matches[1].Start != 6 || score.Matches[1].End != 10 {
+		t.Errorf("Expected 2019 at 6 to 10, but got %d to %d", score.Matches[1].Start, score.Matches[1].End)
+	}
+	if len(score.Feedback()) != 2 {
+		t.Errorf("Expected advice for a word and a date, but got %v", score.Feedback())
+	}
+}
+
+// TestAnalyzeStrength_Random verifies that random passwords keep their
+// brute-force estimate.
+func TestAnalyzeStrength_Random(t *testing.T) {
+	for _, password := range []string{"x7Kp93fQLmR2vB4t", "Q9w!Zr4T#nE8uYb2"} {
+		score := AnalyzeStrength(password)
+		if want := PasswordEntropy(password); math.Abs(score.Bits-want) > 1e-9 {
+			t.Errorf("Expected %s to keep %.1f bits, but got %.1f (%v)", password, want, score.Bits, score.Matches)
+		}
+	}
+	if score := AnalyzeStrength(""); score.Bits != 0 || score.Strength != VeryWeak {
+		t.Errorf("Expected an empty password to score 0, but got %+v", score)
+	}
+}
+
+// TestIsCommonPassword verifies the lookup of the bundled list.
+func TestIsCommonPassword(t *testing.T) {
+	if !IsCommonPassword("QWERTY") || IsCommonPassword("x7Kp93fQLmR2") {
+		t.Errorf("Expected only QWERTY to be common")
+	}
+}
@@ -0,0 +1,63 @@
+package passgen
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGenerateAPIKey verifies the prefix, characters and checksum of API keys.
+func TestGenerateAPIKey(t *testing.T) {
+	key, err := GenerateAPIKey(APIKeyOptions{Prefix: "sk_live_", Length: DefaultAPIKeyLength})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !strings.HasPrefix(key, "sk_live_") || len(key) != len("sk_live_")+DefaultAPIKeyLength {
+		t.Errorf("Expected sk_live_ and %d characters, but got %s", DefaultAPIKeyLength, key)
+	}
+	if strings.Trim(key[len("sk_live_"):], Base62) != "" {
+		t.Errorf("Expected only base62 characters, but got %s", key)
+	}
+
+	key, err = GenerateAPIKey(APIKeyOptions{Prefix: "acme_", Length: 30, Checksum: true})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(key) != len("acme_")+30+APIKeyChecksumLength {
+		t.Errorf("Expected a %d-character checksum, but got %s", APIKeyChecksumLength, key)
+	}
+	if !CheckAPIKey(key, "acme_") {
+		t.Errorf("Expected %s to pass its checksum", key)
+	}
+	// Changing any character must break the checksum.
+	typo := []byte(key)
+	typo[10] = map[bool]byte{true: 'b', false: 'a'}[typo[10] == 'a']
+	if CheckAPIKey(string(typo), "acme_") {
+		t.Errorf("Expected %s to fail its checksum", typo)
+	}
+	if CheckAPIKey(key, "other_") {
+		t.Errorf("Expected %s to fail for another prefix", key)
+	}
+}
+
+// TestAPIKeyChecksum pins the checksum encoding, so keys issued by earlier
+// versions keep validating.
+func TestAPIKeyChecksum(t *testing.T) {
+	// CRC32("test") = 0xD87F7E0C = 3632233996
+	if got := apiKeyChecksum("test"); got != "3xoTzI" {
+		t.Errorf("Expected 3xoTzI, but got %s", got)
+	}
+}
+
+// TestGenerateAPIKey_Invalid verifies that bad lengths and prefixes are rejected.
+func TestGenerateAPIKey_Invalid(t *testing.T) {
+	for _, opts := range []APIKeyOptions{
+		{Length: MinAPIKeyLength - 1},
+		{Length: MaxAPIKeyLength + 1},
+		{Prefix: "sk-live-", Length: DefaultAPIKeyLength},
+		{Prefix: strings.Repeat("a", MaxAPIKeyPrefix+1), Length: DefaultAPIKeyLength},
+	} {
+		if _, err := GenerateAPIKey(opts); err == nil {
+			t.Errorf("Expected an error for %+v, but got none", opts)
+		}
+	}
+}
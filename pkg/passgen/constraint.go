@@ -0,0 +1,156 @@
+package passgen
+
+import "strings"
+
+// Constraint is a rule every generated password obeys.
+// Purpose:
+//
+//	Passwords are built one position at a time. Before a position is filled,
+//	Apply narrows the characters drawn from; once the password is complete,
+//	Check accepts or rejects it, and a rejected password is built again.
+//	A rule that can only be judged on the whole password, such as "does not
+//	contain the company name", returns pool unchanged from Apply and does
+//	all its work in Check.
+//
+// Methods:
+//   - Apply(pool, candidate, i): Returns the characters of pool allowed at
+//     position i of candidate. Zero bytes in candidate are positions not
+//     filled yet; the guaranteed characters of the options are placed first.
+//   - Check(candidate): Reports whether a complete password obeys the rule.
+type Constraint interface {
+	Apply(pool string, candidate []byte, i int) string
+	Check(candidate []byte) bool
+}
+
+// WithConstraints adds rules to every password the Generator builds, on
+// top of those the options select.
+// Purpose:
+//
+//	Lets other programs enforce their own policies without changing
+//	PasswordOptions. The constraints apply to GeneratePasswords and
+//	GenerateStream; patterns, PINs and keys ignore them. If no password
+//	meets them within a bounded number of attempts, generation fails with
+//	CodeConstraintsUnsatisfiable. Constraints may be called concurrently by
+//	GenerateStream and must not keep state between calls.
+//
+// Parameters:
+//   - constraints (...Constraint): The rules, applied in order after those
+//     of the options.
+//
+// Returns:
+//
+//	Option: The option for NewGenerator.
+//
+// Example:
+//
+//	g := passgen.NewGenerator(passgen.WithConstraints(passgen.MinDigits(3)))
+func WithConstraints(constraints ...Constraint) Option {
+	return func(g *Generator) {
+		g.constraints = append(g.constraints, constraints...)
+	}
+}
+
+// WithoutConstraints drops the constraints added so far, e.g. to replace
+// them on a copy made with Generator.With, which keeps the random source.
+// Example:
+//
+//	g = g.With(passgen.WithoutConstraints(), passgen.WithConstraints(list.Constraint()))
+func WithoutConstraints() Option {
+	return func(g *Generator) {
+		g.constraints = nil
+	}
+}
+
+// optionConstraints returns the constraints selected by opts.
+func optionConstraints(opts PasswordOptions) []Constraint {
+	var constraints []Constraint
+	if opts.NoSimilar {
+		constraints = append(constraints, NoSimilar())
+	}
+	if opts.NoDuplicates {
+		constraints = append(constraints, NoDuplicates())
+	}
+	if opts.NoSequential {
+		constraints = append(constraints, NoSequential())
+	}
+	return constraints
+}
+
+// noSimilar implements NoSimilar.
+type noSimilar struct{}
+
+// NoSimilar returns the constraint of the NoSimilar option: no visually
+// similar characters such as l, 1 and I.
+func NoSimilar() Constraint { return noSimilar{} }
+
+func (noSimilar) Apply(pool string, _ []byte, _ int) string {
+	return removeSimilarCharacters(pool)
+}
+
+func (noSimilar) Check(candidate []byte) bool {
+	return !strings.ContainsAny(string(candidate), similarCharacters)
+}
+
+// noDuplicates implements NoDuplicates.
+type noDuplicates struct{}
+
+// NoDuplicates returns the constraint of the NoDuplicates option: every
+// character at most once.
+func NoDuplicates() Constraint { return noDuplicates{} }
+
+func (noDuplicates) Apply(pool string, candidate []byte, _ int) string {
+	return removeCharacters(pool, string(candidate))
+}
+
+func (noDuplicates) Check(candidate []byte) bool {
+	return !hasRepeats(string(candidate))
+}
+
+// noSequential implements NoSequential.
+type noSequential struct{}
+
+// NoSequential returns the constraint of the NoSequential option: no
+// ascending or descending run of three, such as abc or 321.
+func NoSequential() Constraint { return noSequential{} }
+
+func (noSequential) Apply(pool string, candidate []byte, i int) string {
+	return nonSequentialCharacters(pool, candidate, i)
+}
+
+func (noSequential) Check(candidate []byte) bool {
+	return !hasSequence(candidate)
+}
+
+// minDigits implements MinDigits.
+type minDigits int
+
+// MinDigits returns a constraint requiring at least n digits. Once the free
+// positions left are only enough for the missing digits, it allows nothing
+// but digits there.
+func MinDigits(n int) Constraint { return minDigits(n) }
+
+func (m minDigits) Apply(pool string, candidate []byte, i int) string {
+	missing, free := int(m), 0
+	for j, c := range candidate {
+		switch {
+		case strings.IndexByte(Digits, c) >= 0:
+			missing--
+		case c == 0 && j >= i:
+			free++
+		}
+	}
+	if missing > 0 && free <= missing {
+		return intersectCharacters(pool, Digits)
+	}
+	return pool
+}
+
+func (m minDigits) Check(candidate []byte) bool {
+	count := 0
+	for _, c := range candidate {
+		if strings.IndexByte(Digits, c) >= 0 {
+			count++
+		}
+	}
+	return count >= int(m)
+}
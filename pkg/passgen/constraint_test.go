@@ -0,0 +1,117 @@
+package passgen
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// noSubstring is a custom constraint rejecting passwords that contain word.
+type noSubstring string
+
+func (noSubstring) Apply(pool string, _ []byte, _ int) string { return pool }
+
+func (w noSubstring) Check(candidate []byte) bool {
+	return !strings.Contains(strings.ToLower(string(candidate)), string(w))
+}
+
+// TestWithConstraints_Custom verifies that a custom constraint is enforced.
+func TestWithConstraints_Custom(t *testing.T) {
+	g := NewGenerator(WithConstraints(noSubstring("ab")))
+	opts := PasswordOptions{Length: 12, Quantity: 200, IncludeLower: true}
+	opts.ExcludeCharacters = Lowercase[3:] // only a, b and c are left
+	passwords, err := g.GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, password := range passwords {
+		if strings.Contains(password, "ab") {
+			t.Errorf("Expected no \"ab\", but got %s", password)
+		}
+	}
+}
+
+// TestGenerator_With verifies that With adds constraints to a copy and
+// keeps those of the original.
+func TestGenerator_With(t *testing.T) {
+	g := NewGenerator(WithConstraints(noSubstring("ab")))
+	site := g.With(WithConstraints(noSubstring("ba")))
+	if len(g.constraints) != 1 || len(site.constraints) != 2 {
+		t.Fatalf("Expected 1 and 2 constraints, but got %d and %d", len(g.constraints), len(site.constraints))
+	}
+	opts := PasswordOptions{Length: 12, Quantity: 100, IncludeLower: true, ExcludeCharacters: Lowercase[3:]}
+	passwords, err := site.GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, password := range passwords {
+		if strings.Contains(password, "ab") || strings.Contains(password, "ba") {
+			t.Errorf("Expected neither \"ab\" nor \"ba\", but got %s", password)
+		}
+	}
+}
+
+// TestWithoutConstraints verifies that WithoutConstraints replaces the
+// constraints of a copy, which keeps the random source.
+func TestWithoutConstraints(t *testing.T) {
+	g := NewGenerator(WithRand(strings.NewReader("")), WithConstraints(noSubstring("ab")))
+	replaced := g.With(WithoutConstraints(), WithConstraints(noSubstring("ba")))
+	if c := replaced.Constraints(); len(c) != 1 || c[0] != noSubstring("ba") {
+		t.Errorf("Expected only the new constraint, but got %v", c)
+	}
+	if replaced.rand != g.rand {
+		t.Error("Expected the random source to be kept")
+	}
+	if len(g.Constraints()) != 1 {
+		t.Errorf("Expected the original to keep its constraint, but got %v", g.Constraints())
+	}
+}
+
+// TestMinDigits verifies that MinDigits places enough digits even when
+// digits are rare in the pool.
+func TestMinDigits(t *testing.T) {
+	g := NewGenerator(WithConstraints(MinDigits(4)))
+	opts := PasswordOptions{Length: 6, Quantity: 100, IncludeNumbers: true, IncludeUpper: true, IncludeLower: true, NoDuplicates: true}
+	passwords, err := g.GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, password := range passwords {
+		if !MinDigits(4).Check([]byte(password)) {
+			t.Errorf("Expected at least 4 digits, but got %s", password)
+		}
+		if err := VerifyPasswords([]string{password}, opts); err != nil {
+			t.Errorf("Expected the options to hold, but got %v", err)
+		}
+	}
+}
+
+// TestWithConstraints_Unsatisfiable verifies the error when no password can
+// meet the constraints.
+func TestWithConstraints_Unsatisfiable(t *testing.T) {
+	g := NewGenerator(WithConstraints(MinDigits(3)))
+	opts := PasswordOptions{Length: 8, Quantity: 1, IncludeLower: true}
+	if _, err := g.GeneratePasswords(context.Background(), opts); ErrorCode(err) != CodeConstraintsUnsatisfiable {
+		t.Errorf("Expected %s, but got %v", CodeConstraintsUnsatisfiable, err)
+	}
+}
+
+// TestOptionConstraints verifies the checks of the built-in constraints.
+func TestOptionConstraints(t *testing.T) {
+	tests := []struct {
+		constraint Constraint
+		good, bad  string
+	}{
+		{NoSimilar(), "abcXYZ", "abc1XY"},
+		{NoDuplicates(), "abcdef", "abcdea"},
+		{NoSequential(), "acegik", "xabcyz"},
+	}
+	for _, test := range tests {
+		if !test.constraint.Check([]byte(test.good)) {
+			t.Errorf("Expected %T to accept %s, but it was rejected", test.constraint, test.good)
+		}
+		if test.constraint.Check([]byte(test.bad)) {
+			t.Errorf("Expected %T to reject %s, but it was accepted", test.constraint, test.bad)
+		}
+	}
+}
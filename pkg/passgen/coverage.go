@@ -0,0 +1,159 @@
+/**
+ * Charset Coverage Matrix
+ *
+ * This file builds labeled test passwords for QA engineers. Instead of
+ * random passwords, it places every enabled symbol at the start, in the
+ * middle and at the end of a password, adds the length limits and, on
+ * request, Unicode edge cases, so a target application's handling of each
+ * character can be tested one case at a time. The filler around each edge
+ * character is still random.
+ */
+
+package passgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CoverageCase is one labeled test password of a coverage matrix.
+type CoverageCase struct {
+	Label    string
+	Password string
+}
+
+// unicodeSamples are the Unicode edge cases added by CoverageMatrix, from
+// two-byte Latin up to emoji, combining marks and right-to-left text.
+var unicodeSamples = []struct {
+	label string
+	value string
+}{
+	{"Latin-1 letter", "é"},
+	{"sharp s", "ß"},
+	{"euro sign", "€"},
+	{"Greek letter", "Ω"},
+	{"Cyrillic letter", "я"},
+	{"CJK ideograph", "中"},
+	{"emoji outside the BMP", "😀"},
+	{"combining accent", "e\u0301"},
+	{"right-to-left letter", "א"},
+	{"non-breaking space", "\u00a0"},
+}
+
+// CoverageMatrix returns labeled passwords that exercise edge characters.
+// Purpose:
+//
+//	Builds one case per enabled symbol at the start, middle and end of an
+//	opts.Length password, one password holding every symbol, the minimum and
+//	maximum lengths, and, when withUnicode is set, each Unicode sample at
+//	the three positions. Lengths count characters, not bytes.
+//
+// Parameters:
+//   - opts (PasswordOptions): The character classes, exclusions and lengths.
+//   - withUnicode (bool): Adds non-ASCII cases, which the generator itself
+//     never produces.
+//
+// Returns:
+//
+//	[]CoverageCase: The labeled test passwords.
+//	error: An error if no character class is enabled.
+//
+// Example:
+//
+//	cases, err := g.CoverageMatrix(opts, true)
+func (g *Generator) CoverageMatrix(opts PasswordOptions, withUnicode bool) ([]CoverageCase, error) {
+	chars := buildCharacterSet(opts)
+	if chars == "" {
+		return nil, newError(CodeNoCharacterTypes)
+	}
+	filler := removeCharacters(chars, Symbols)
+	if filler == "" {
+		filler = chars
+	}
+	length := opts.Length
+	if length < 3 {
+		length = 3
+	}
+
+	var cases []CoverageCase
+	add := func(label, edge string, position string) error {
+		password, err := g.placeCharacter(edge, position, length, filler)
+		if err != nil {
+			return err
+		}
+		cases = append(cases, CoverageCase{Label: label + " " + position, Password: password})
+		return nil
+	}
+
+	symbols := removeCharacters(Symbols, opts.ExcludeCharacters)
+	if opts.IncludeSymbols {
+		for _, symbol := range symbols {
+			for _, position := range []string{"at start", "in middle", "at end"} {
+				if err := add(fmt.Sprintf("symbol %q", symbol), string(symbol), position); err != nil {
+					return nil, err
+				}
+			}
+		}
+		cases = append(cases, CoverageCase{Label: "all symbols", Password: symbols})
+	}
+
+	for _, limit := range []struct {
+		label  string
+		length int
+	}{{"minimum length", opts.MinLength}, {"maximum length", opts.MaxLength}} {
+		if limit.length < 1 {
+			continue
+		}
+		password, err := g.randomString(chars, limit.length)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, CoverageCase{Label: fmt.Sprintf("%s (%d)", limit.label, limit.length), Password: password})
+	}
+
+	if withUnicode {
+		for _, sample := range unicodeSamples {
+			for _, position := range []string{"at start", "in middle", "at end"} {
+				if err := add(sample.label, sample.value, position); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return cases, nil
+}
+
+// placeCharacter returns a password of length characters with edge at the
+// given position and random filler characters around it.
+func (g *Generator) placeCharacter(edge, position string, length int, filler string) (string, error) {
+	edgeLength := len([]rune(edge))
+	before := 0
+	switch position {
+	case "in middle":
+		before = (length - edgeLength) / 2
+	case "at end":
+		before = length - edgeLength
+	}
+	head, err := g.randomString(filler, before)
+	if err != nil {
+		return "", err
+	}
+	tail, err := g.randomString(filler, length-edgeLength-before)
+	if err != nil {
+		return "", err
+	}
+	return head + edge + tail, nil
+}
+
+// randomString returns n random characters of chars.
+func (g *Generator) randomString(chars string, n int) (string, error) {
+	var out strings.Builder
+	for i := 0; i < n; i++ {
+		char, err := g.randomChar(chars)
+		if err != nil {
+			return "", err
+		}
+		out.WriteByte(char)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,60 @@
+package passgen
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestCoverageMatrix places every symbol at the start, middle and end.
+func TestCoverageMatrix(t *testing.T) {
+	opts := PasswordOptions{MinLength: 4, MaxLength: 64, Length: 12, IncludeSymbols: true, IncludeLower: true}
+	cases, err := CoverageMatrix(opts, false)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if want := 3*len(Symbols) + 3; len(cases) != want {
+		t.Fatalf("Expected %d cases, but got %d", want, len(cases))
+	}
+	for _, c := range cases[:3*len(Symbols)] {
+		if len(c.Password) != 12 {
+			t.Errorf("Expected length 12 for %s, but got %q", c.Label, c.Password)
+		}
+	}
+	if first := cases[0]; first.Label != `symbol '!' at start` || first.Password[0] != '!' {
+		t.Errorf("Unexpected first case %+v", first)
+	}
+	if mid := cases[1]; mid.Password[5] != '!' {
+		t.Errorf("Expected '!' in the middle, but got %q", mid.Password)
+	}
+	if end := cases[2]; !strings.HasSuffix(end.Password, "!") {
+		t.Errorf("Expected '!' at the end, but got %q", end.Password)
+	}
+	if last := cases[len(cases)-1]; len(last.Password) != 64 {
+		t.Errorf("Expected a maximum length case of 64, but got %+v", last)
+	}
+}
+
+// TestCoverageMatrix_Unicode adds non-ASCII cases counted in characters.
+func TestCoverageMatrix_Unicode(t *testing.T) {
+	opts := PasswordOptions{Length: 8, IncludeNumbers: true, ExcludeCharacters: "0"}
+	cases, err := CoverageMatrix(opts, true)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(cases) != 3*len(unicodeSamples) {
+		t.Fatalf("Expected only Unicode cases, but got %d", len(cases))
+	}
+	for _, c := range cases {
+		if utf8.RuneCountInString(c.Password) != 8 {
+			t.Errorf("Expected 8 characters for %s, but got %q", c.Label, c.Password)
+		}
+		if strings.Contains(c.Password, "0") {
+			t.Errorf("Expected excluded characters to stay out, but got %q", c.Password)
+		}
+	}
+
+	if _, err := CoverageMatrix(PasswordOptions{Length: 8}, true); err == nil {
+		t.Errorf("Expected an error without character classes, but got nil")
+	}
+}
@@ -0,0 +1,21 @@
+// Package passgen generates secure passwords from a set of PasswordOptions.
+//
+// It is the model behind the password generator GUI and CLI and can be used
+// on its own by other Go programs:
+//
+//	opts := passgen.PasswordOptions{
+//		Length:         16,
+//		Quantity:       3,
+//		IncludeNumbers: true,
+//		IncludeUpper:   true,
+//		IncludeLower:   true,
+//	}
+//	passwords, err := passgen.GeneratePasswords(ctx, opts)
+//
+// All randomness comes from crypto/rand, unless a Generator created with
+// WithRand is used to draw it from another source. WithConstraints adds
+// custom rules, written as Constraint values, to the built-in ones.
+//
+// AnalyzeStrength rates passwords chosen elsewhere, finding the common
+// words, keyboard walks, sequences and dates crackers try first.
+package passgen
@@ -0,0 +1,191 @@
+/**
+ * Entropy Estimation
+ *
+ * This file estimates how many bits of entropy a password generated with a
+ * given set of options carries, based on the size of the character pool
+ * available at every position.
+ */
+
+package passgen
+
+import (
+	"math"
+	"strings"
+)
+
+// EstimateEntropy returns the estimated entropy of one password in bits.
+// Purpose:
+//
+//	Models the output the options produce rather than a uniform draw from
+//	the character set: the pool of each position after the first-letter
+//	rule, similar-character removal and alternating hands, fewer choices at
+//	every position without duplicates, and only the passwords that contain
+//	one character of each enabled class and every MustInclude character.
+//	No Sequential Characters is not modelled; it removes few passwords.
+//
+// Parameters:
+//   - opts (PasswordOptions): The options the password would be generated with.
+//
+// Returns:
+//
+//	float64: The estimated entropy in bits, or 0 if the options are unusable.
+//
+// Example:
+//
+//	bits := EstimateEntropy(opts)
+func EstimateEntropy(opts PasswordOptions) float64 {
+	chars := buildCharacterSet(opts)
+	if chars == "" || opts.Length <= 0 {
+		return 0
+	}
+
+	if opts.AlternateHands {
+		hands, err := handCharacterSets(opts, chars)
+		if err != nil {
+			return 0
+		}
+		// Either hand may start, so average both orders and add one bit for the choice.
+		leftFirst := constrainedEntropy(opts, positionPools(opts, hands))
+		rightFirst := constrainedEntropy(opts, positionPools(opts, [2]string{hands[1], hands[0]}))
+		return 1 + (leftFirst+rightFirst)/2
+	}
+
+	if opts.NoSimilar {
+		chars = removeSimilarCharacters(chars)
+	}
+	return constrainedEntropy(opts, positionPools(opts, [2]string{chars, chars}))
+}
+
+// maxFloorLength bounds LengthForEntropy when the options set no MaxLength.
+const maxFloorLength = 128
+
+// LengthForEntropy returns the shortest length at which opts reach bits of
+// estimated entropy, searching up to opts.MaxLength. It reports false if no
+// allowed length does, e.g. because too few character types are enabled.
+func LengthForEntropy(opts PasswordOptions, bits float64) (int, bool) {
+	limit := opts.MaxLength
+	if limit <= 0 {
+		limit = maxFloorLength
+	}
+	for length := 1; length <= limit; length++ {
+		opts.Length = length
+		if EstimateEntropy(opts) >= bits {
+			return length, true
+		}
+	}
+	return 0, false
+}
+
+// belowFloor reports whether opts are under their MinEntropy safety floor.
+func belowFloor(opts PasswordOptions) bool {
+	return opts.MinEntropy > 0 && EstimateEntropy(opts) < opts.MinEntropy
+}
+
+// positionPools returns the pool of every position, alternating between the
+// two pools and applying the first-letter rule.
+func positionPools(opts PasswordOptions, pools [2]string) []string {
+	positions := make([]string, opts.Length)
+	for i := range positions {
+		positions[i] = pools[i%2]
+		if i == 0 && opts.BeginWithLetter {
+			positions[i] = intersectCharacters(positions[i], letterCharacters(opts))
+		}
+	}
+	return positions
+}
+
+// maxRequirements bounds the requirements constrainedEntropy counts exactly;
+// its inclusion-exclusion sum has 2^n terms.
+const maxRequirements = 12
+
+// constrainedEntropy returns log2 of the number of passwords that positions
+// allow under the NoDuplicates, class and MustInclude constraints.
+// Purpose:
+//
+//	Without duplicates, a position has as many fewer choices as earlier
+//	positions drew from an overlapping pool. The class and MustInclude
+//	requirements keep the fraction of those passwords that contain each of
+//	them, counted by inclusion-exclusion over the requirements a password
+//	could miss. MustInclude characters outside every pool are placed over
+//	random characters, so their positions are not counted.
+func constrainedEntropy(opts PasswordOptions, positions []string) float64 {
+	var requirements []string
+	for _, class := range enabledClasses(opts) {
+		requirements = append(requirements, class.chars)
+	}
+	all := strings.Join(positions, "")
+	for _, r := range removeDuplicateCharacters(opts.MustInclude) {
+		if strings.ContainsRune(all, r) {
+			requirements = append(requirements, string(r))
+		} else if len(positions) > 0 {
+			positions = positions[:len(positions)-1]
+		}
+	}
+	if len(requirements) > maxRequirements {
+		requirements = requirements[:maxRequirements]
+	}
+	if len(positions) == 0 {
+		return 0
+	}
+
+	// taken[i] counts earlier positions that may have used a character of
+	// position i's pool.
+	taken := make([]int, len(positions))
+	if opts.NoDuplicates {
+		overlaps := make(map[[2]string]bool)
+		for i := range positions {
+			for j := 0; j < i; j++ {
+				key := [2]string{positions[i], positions[j]}
+				overlap, ok := overlaps[key]
+				if !ok {
+					overlap = strings.ContainsAny(positions[i], positions[j])
+					overlaps[key] = overlap
+				}
+				if overlap {
+					taken[i]++
+				}
+			}
+		}
+	}
+
+	bits := 0.0
+	for i, pool := range positions {
+		choices := len(pool) - taken[i]
+		if choices <= 0 {
+			return 0
+		}
+		bits += math.Log2(float64(choices))
+	}
+
+	// share is the fraction of the passwords above that meet every requirement.
+	share := 0.0
+	for subset := 0; subset < 1<<len(requirements); subset++ {
+		var missed strings.Builder
+		sign := 1.0
+		for k, requirement := range requirements {
+			if subset&(1<<k) != 0 {
+				missed.WriteString(requirement)
+				sign = -sign
+			}
+		}
+		term := 1.0
+		avoided := make(map[string]int)
+		for i, pool := range positions {
+			left, ok := avoided[pool]
+			if !ok {
+				left = len(removeCharacters(pool, missed.String()))
+				avoided[pool] = left
+			}
+			if left-taken[i] <= 0 {
+				term = 0
+				break
+			}
+			term *= float64(left-taken[i]) / float64(len(pool)-taken[i])
+		}
+		share += sign * term
+	}
+	if share <= 0 {
+		return 0
+	}
+	return bits + math.Log2(share)
+}
@@ -0,0 +1,101 @@
+package passgen
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestEstimateEntropy_Uniform verifies the estimate for a plain character set.
+func TestEstimateEntropy_Uniform(t *testing.T) {
+	opts := PasswordOptions{Length: 10, IncludeLower: true}
+	want := 10 * math.Log2(26)
+	if got := EstimateEntropy(opts); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Expected %.2f bits, but got %.2f", want, got)
+	}
+}
+
+// TestEstimateEntropy_EmptyCharset verifies that unusable options yield zero.
+func TestEstimateEntropy_EmptyCharset(t *testing.T) {
+	if got := EstimateEntropy(PasswordOptions{Length: 10}); got != 0 {
+		t.Errorf("Expected 0 bits, but got %.2f", got)
+	}
+}
+
+// TestEstimateEntropy_Constraints compares the estimate with a count of every
+// password small options allow.
+func TestEstimateEntropy_Constraints(t *testing.T) {
+	base := PasswordOptions{IncludeNumbers: true, IncludeLower: true, ExcludeCharacters: "03456789cdefghijklmnopqrstuvwxyz"}
+	tests := []func(*PasswordOptions){
+		func(o *PasswordOptions) { o.Length = 3 },
+		func(o *PasswordOptions) { o.Length = 4; o.NoDuplicates = true },
+		func(o *PasswordOptions) { o.Length = 3; o.MustInclude = "a" },
+		func(o *PasswordOptions) { o.Length = 4; o.MustInclude = "b1"; o.NoDuplicates = true },
+		func(o *PasswordOptions) { o.Length = 5; o.NoSimilar = true },
+	}
+	for _, change := range tests {
+		opts := base
+		change(&opts)
+		want := math.Log2(float64(countPasswords(opts)))
+		if got := EstimateEntropy(opts); math.Abs(got-want) > 1e-9 {
+			t.Errorf("Expected %.3f bits for %+v, but got %.3f", want, opts, got)
+		}
+	}
+
+	// The class guarantee excludes the passwords that miss a class, so the
+	// estimate falls below a uniform draw from the character set.
+	opts := PasswordOptions{Length: 8, IncludeLower: true, IncludeSymbols: true, IncludeNumbers: true, IncludeUpper: true}
+	if uniform := 8 * math.Log2(float64(len(buildCharacterSet(opts)))); EstimateEntropy(opts) >= uniform {
+		t.Errorf("Expected the class guarantee to lower the estimate below %.2f bits", uniform)
+	}
+}
+
+// countPasswords counts the passwords of opts.Length over the character set
+// that contain every class and MustInclude character, without similar
+// characters and, with NoDuplicates, without repeats.
+func countPasswords(opts PasswordOptions) int {
+	chars := buildCharacterSet(opts)
+	if opts.NoSimilar {
+		chars = removeSimilarCharacters(chars)
+	}
+	count := 0
+	var walk func(prefix string)
+	walk = func(prefix string) {
+		if len(prefix) == opts.Length {
+			if meetsRequirements(prefix, opts) {
+				count++
+			}
+			return
+		}
+		for _, c := range chars {
+			if !opts.NoDuplicates || !strings.ContainsRune(prefix, c) {
+				walk(prefix + string(c))
+			}
+		}
+	}
+	walk("")
+	return count
+}
+
+// TestSafetyFloor verifies that options below MinEntropy are refused with advice.
+func TestSafetyFloor(t *testing.T) {
+	opts := PasswordOptions{MaxLength: 32, Length: 6, Quantity: 1, IncludeLower: true, MinEntropy: 60}
+	_, err := GeneratePasswords(context.Background(), opts)
+	if ErrorCode(err) != CodeBelowEntropyFloor {
+		t.Fatalf("Expected %s, but got %v", CodeBelowEntropyFloor, err)
+	}
+	if want := "a length of 13 or more meets it"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected the message to suggest %q, but got %q", want, err)
+	}
+
+	opts.Length = 13
+	if _, err := GeneratePasswords(context.Background(), opts); err != nil {
+		t.Errorf("Expected options at the floor to generate, but got %v", err)
+	}
+
+	opts.Length, opts.MaxLength, opts.IncludeLower, opts.IncludeNumbers = 8, 12, false, true
+	if _, err := GeneratePasswords(context.Background(), opts); ErrorCode(err) != CodeBelowEntropyFloorClasses {
+		t.Errorf("Expected %s when no allowed length reaches the floor, but got %v", CodeBelowEntropyFloorClasses, err)
+	}
+}
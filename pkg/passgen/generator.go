@@ -0,0 +1,149 @@
+package passgen
+
+import (
+	"context"
+	"crypto/rand"
+	"io"
+	"math/big"
+	"sync"
+)
+
+// Generator generates passwords, PINs and keys from a source of randomness.
+// The package-level functions use a Generator reading crypto/rand.Reader.
+type Generator struct {
+	rand        io.Reader
+	constraints []Constraint
+}
+
+// Option configures a Generator created by NewGenerator.
+type Option func(*Generator)
+
+// WithRand makes the Generator draw its randomness from r instead of
+// crypto/rand.Reader.
+// Purpose:
+//
+//	Lets tests inject deterministic randomness and lets advanced users plug
+//	in a hardware random number generator. The output is only as
+//	unpredictable as r; never use a deterministic reader for real secrets.
+//	Reads from r are serialized, so r need not be safe for concurrent use;
+//	GenerateStream still delivers in no fixed order.
+//
+// Parameters:
+//   - r (io.Reader): The source of random bytes.
+//
+// Returns:
+//
+//	Option: The option for NewGenerator.
+//
+// Example:
+//
+//	g := passgen.NewGenerator(passgen.WithRand(hardwareRNG))
+func WithRand(r io.Reader) Option {
+	return func(g *Generator) {
+		g.rand = &lockedReader{r: r}
+	}
+}
+
+// lockedReader serializes the reads from r for concurrent generation.
+type lockedReader struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+func (l *lockedReader) Read(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Read(p)
+}
+
+// NewGenerator returns a Generator reading crypto/rand.Reader unless an
+// option says otherwise.
+func NewGenerator(options ...Option) *Generator {
+	g := &Generator{rand: rand.Reader}
+	for _, option := range options {
+		option(g)
+	}
+	return g
+}
+
+// With returns a copy of g with further options, e.g. the constraints of
+// one site on top of those g already has; g itself is not changed.
+// Example:
+//
+//	site := g.With(passgen.WithConstraints(rules.Constraint()))
+func (g *Generator) With(options ...Option) *Generator {
+	c := &Generator{rand: g.rand, constraints: append([]Constraint(nil), g.constraints...)}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Constraints returns the constraints added with WithConstraints, in order.
+func (g *Generator) Constraints() []Constraint {
+	return append([]Constraint(nil), g.constraints...)
+}
+
+// defaultGenerator backs the package-level functions.
+var defaultGenerator = NewGenerator()
+
+// GeneratePasswords generates passwords with crypto/rand; see
+// Generator.GeneratePasswords.
+func GeneratePasswords(ctx context.Context, opts PasswordOptions) ([]string, error) {
+	return defaultGenerator.GeneratePasswords(ctx, opts)
+}
+
+// GenerateStream streams passwords generated with crypto/rand; see
+// Generator.GenerateStream.
+func GenerateStream(ctx context.Context, opts PasswordOptions) (<-chan Result, error) {
+	return defaultGenerator.GenerateStream(ctx, opts)
+}
+
+// GeneratePatternPasswords generates pattern passwords with crypto/rand; see
+// Generator.GeneratePatternPasswords.
+func GeneratePatternPasswords(ctx context.Context, template string, opts PasswordOptions) ([]string, error) {
+	return defaultGenerator.GeneratePatternPasswords(ctx, template, opts)
+}
+
+// GeneratePINs generates PINs with crypto/rand; see Generator.GeneratePINs.
+func GeneratePINs(ctx context.Context, opts PINOptions) ([]string, error) {
+	return defaultGenerator.GeneratePINs(ctx, opts)
+}
+
+// GenerateRecoveryCodes generates recovery codes with crypto/rand; see
+// Generator.GenerateRecoveryCodes.
+func GenerateRecoveryCodes(ctx context.Context, opts RecoveryOptions) ([]string, error) {
+	return defaultGenerator.GenerateRecoveryCodes(ctx, opts)
+}
+
+// GenerateUsernames generates usernames with crypto/rand; see
+// Generator.GenerateUsernames.
+func GenerateUsernames(ctx context.Context, opts UsernameOptions) ([]string, error) {
+	return defaultGenerator.GenerateUsernames(ctx, opts)
+}
+
+// GenerateToken generates a key with crypto/rand; see Generator.GenerateToken.
+func GenerateToken(size int, encoding string) (string, error) {
+	return defaultGenerator.GenerateToken(size, encoding)
+}
+
+// GenerateAPIKey generates an API key with crypto/rand; see
+// Generator.GenerateAPIKey.
+func GenerateAPIKey(opts APIKeyOptions) (string, error) {
+	return defaultGenerator.GenerateAPIKey(opts)
+}
+
+// CoverageMatrix builds the QA passwords with crypto/rand; see
+// Generator.CoverageMatrix.
+func CoverageMatrix(opts PasswordOptions, withUnicode bool) ([]CoverageCase, error) {
+	return defaultGenerator.CoverageMatrix(opts, withUnicode)
+}
+
+// intn returns a uniformly distributed random number in [0, n).
+func (g *Generator) intn(n int) (int, error) {
+	index, err := rand.Int(g.rand, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, newError(CodeRandomFailure)
+	}
+	return int(index.Int64()), nil
+}
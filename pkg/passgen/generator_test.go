@@ -0,0 +1,55 @@
+package passgen
+
+import (
+	"context"
+	"errors"
+	mathrand "math/rand"
+	"reflect"
+	"testing"
+)
+
+// failingReader is a random source that always fails.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("no entropy") }
+
+// TestWithRand verifies that a deterministic source yields repeatable output.
+func TestWithRand(t *testing.T) {
+	opts := PasswordOptions{Length: 16, Quantity: 5, IncludeSymbols: true, IncludeNumbers: true, IncludeUpper: true, IncludeLower: true, MustInclude: "#"}
+	generate := func() ([]string, []string, string) {
+		g := NewGenerator(WithRand(mathrand.New(mathrand.NewSource(42))))
+		passwords, err := g.GeneratePasswords(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		pins, err := g.GeneratePINs(context.Background(), PINOptions{Length: 6, Quantity: 3})
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		token, err := g.GenerateToken(16, EncodingHex)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		return passwords, pins, token
+	}
+	passwords1, pins1, token1 := generate()
+	passwords2, pins2, token2 := generate()
+	if !reflect.DeepEqual(passwords1, passwords2) || !reflect.DeepEqual(pins1, pins2) || token1 != token2 {
+		t.Errorf("Expected the same output from the same seed, but got %v %v %s and %v %v %s", passwords1, pins1, token1, passwords2, pins2, token2)
+	}
+	if err := VerifyPasswords(passwords1, opts); err != nil {
+		t.Errorf("Expected valid passwords, but got %v", err)
+	}
+}
+
+// TestWithRand_Failure verifies that a failing source is reported.
+func TestWithRand_Failure(t *testing.T) {
+	g := NewGenerator(WithRand(failingReader{}))
+	opts := PasswordOptions{Length: 8, Quantity: 1, IncludeLower: true}
+	if _, err := g.GeneratePasswords(context.Background(), opts); ErrorCode(err) != CodeRandomFailure {
+		t.Errorf("Expected %s, but got %v", CodeRandomFailure, err)
+	}
+	if _, err := g.GenerateToken(16, EncodingHex); ErrorCode(err) != CodeRandomFailure {
+		t.Errorf("Expected %s, but got %v", CodeRandomFailure, err)
+	}
+}
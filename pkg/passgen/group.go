@@ -0,0 +1,62 @@
+package passgen
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultGroupSeparator separates groups when GroupSeparator is empty.
+const DefaultGroupSeparator = "-"
+
+// groupSeparator returns the separator used for opts.
+func groupSeparator(opts PasswordOptions) string {
+	if opts.GroupSeparator == "" {
+		return DefaultGroupSeparator
+	}
+	return opts.GroupSeparator
+}
+
+// Group splits password into groups of opts.GroupSize characters joined by
+// the separator, e.g. "x7Kp-93fQ-LmR2". It returns password unchanged when
+// grouping is off.
+func Group(password string, opts PasswordOptions) string {
+	if opts.GroupSize <= 0 {
+		return password
+	}
+	separator := groupSeparator(opts)
+	var grouped strings.Builder
+	for i, r := range []rune(password) {
+		if i > 0 && i%opts.GroupSize == 0 {
+			grouped.WriteString(separator)
+		}
+		grouped.WriteRune(r)
+	}
+	return grouped.String()
+}
+
+// Ungroup removes the separators Group inserted, so that checks and entropy
+// estimates see only the generated characters. Separators are recognised by
+// position, so a separator that is also a password character is kept where
+// it was generated.
+func Ungroup(password string, opts PasswordOptions) string {
+	if opts.GroupSize <= 0 {
+		return password
+	}
+	separator, _ := utf8.DecodeRuneInString(groupSeparator(opts))
+	var ungrouped strings.Builder
+	for i, r := range []rune(password) {
+		if (i+1)%(opts.GroupSize+1) == 0 && r == separator {
+			continue
+		}
+		ungrouped.WriteRune(r)
+	}
+	return ungrouped.String()
+}
+
+// invalidGroupSeparator reports whether the separator is not a single
+// printable character.
+func invalidGroupSeparator(opts PasswordOptions) bool {
+	separator := groupSeparator(opts)
+	r, size := utf8.DecodeRuneInString(separator)
+	return size != len(separator) || r == utf8.RuneError || r < ' ' || r == 0x7f
+}
@@ -0,0 +1,58 @@
+package passgen
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestGeneratePasswords_Grouped checks that separators are added between
+// groups without counting toward the length or the class checks.
+func TestGeneratePasswords_Grouped(t *testing.T) {
+	opts := PasswordOptions{
+		Length:         12,
+		Quantity:       20,
+		IncludeNumbers: true,
+		IncludeUpper:   true,
+		IncludeLower:   true,
+		GroupSize:      4,
+	}
+	passwords, err := GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, password := range passwords {
+		if len(password) != 14 || password[4] != '-' || password[9] != '-' {
+			t.Errorf("Expected three groups of 4 separated by '-', but got %q", password)
+		}
+		if v := Verify(password, opts); len(v) != 0 {
+			t.Errorf("Expected %q to verify, but got %v", password, v)
+		}
+	}
+	if v := Verify("abcdABCD1234", opts); len(v) == 0 || v[0].Constraint != "groups" {
+		t.Errorf("Expected a groups violation for an ungrouped password, but got %v", v)
+	}
+}
+
+// TestUngroup checks that only separators at group positions are removed.
+func TestUngroup(t *testing.T) {
+	opts := PasswordOptions{GroupSize: 3, GroupSeparator: "-"}
+	if got := Group("a-cdefg", opts); got != "a-c-def-g" {
+		t.Errorf("Expected %q, but got %q", "a-c-def-g", got)
+	}
+	if got := Ungroup("a-c-def-g", opts); got != "a-cdefg" {
+		t.Errorf("Expected %q, but got %q", "a-cdefg", got)
+	}
+	if got := EstimateEntropy(PasswordOptions{Length: 8, IncludeLower: true, GroupSize: 2}); got != EstimateEntropy(PasswordOptions{Length: 8, IncludeLower: true}) {
+		t.Errorf("Expected grouping not to change the entropy estimate, but got %.2f", got)
+	}
+	for _, separator := range []string{"--", "\t"} {
+		err := Validate(PasswordOptions{Length: 8, IncludeLower: true, GroupSize: 2, GroupSeparator: separator})
+		if ErrorCode(err) != CodeGroupSeparator {
+			t.Errorf("Expected %s for separator %q, but got %v", CodeGroupSeparator, separator, err)
+		}
+	}
+	if !strings.Contains(Group("abcdef", PasswordOptions{GroupSize: 2, GroupSeparator: " "}), " ") {
+		t.Errorf("Expected a space to be accepted as separator")
+	}
+}
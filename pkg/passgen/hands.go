@@ -0,0 +1,104 @@
+/**
+ * Alternating-Hand Typing
+ *
+ * This file defines keyboard layouts split into left- and right-hand keys.
+ * With AlternateHands enabled, generated passwords switch hands on every
+ * character, which makes them considerably faster to type at the cost of a
+ * smaller character pool per position (see EstimateEntropy).
+ */
+
+package passgen
+
+import (
+	"strings"
+)
+
+// HandLayout lists the characters typed by each hand on a keyboard layout.
+// Both unshifted and shifted characters belong to the hand that presses the key.
+type HandLayout struct {
+	Left  string
+	Right string
+}
+
+// DefaultHandLayout is used when PasswordOptions.KeyboardLayout is empty.
+const DefaultHandLayout = "qwerty"
+
+// HandLayouts maps layout names to their hand split. Callers may register
+// additional layouts before generating passwords.
+var HandLayouts = map[string]HandLayout{
+	"qwerty": {
+		Left:  "`~1!2@3#4$5%qwertQWERTasdfgASDFGzxcvbZXCVB",
+		Right: "6^7&8*9(0)-_=+yuiop[]{}\\|YUIOPhjkl;:'\"HJKLnm,<.>/?NM",
+	},
+	"qwertz": {
+		Left:  "^°1!2\"3§4$5%qwertQWERTasdfgASDFGyxcvbYXCVB<>",
+		Right: "6&7/8(9)0=ß?zuiopüZUIOPÜ+*#'hjklöäHJKLÖÄnm,;.:-_NM",
+	},
+	"azerty": {
+		Left:  "²&1é2\"3'4(5azertAZERTqsdfgQSDFGwxcvbWXCVB<>",
+		Right: "-6è7_8ç9à0)°=+yuiopYUIOP^¨$£hjklmHJKLMù%*µn,?;.:/!N",
+	},
+	"dvorak": {
+		Left:  "`~1!2@3#4$5%'\",<.>pyPYaoeuiAOEUI;:qjkxQJKX",
+		Right: "6^7&8*9(0)[]{}fgcrlFGCRL/?=+\\|dhtnsDHTNS-_bmwvzBMWVZ",
+	},
+}
+
+// handCharacterSets splits the allowed characters between the two hands.
+// Purpose:
+//
+//	Intersects the enabled character set with each hand of the selected
+//	keyboard layout, removing similar characters when NoSimilar is set.
+//
+// Parameters:
+//   - opts (PasswordOptions): Selects the layout and the NoSimilar behaviour.
+//   - chars (string): The character set built from the enabled classes.
+//
+// Returns:
+//
+//	[2]string: The left-hand and right-hand character sets.
+//	error: An error if the layout is unknown or a hand has no usable keys.
+func handCharacterSets(opts PasswordOptions, chars string) ([2]string, error) {
+	name := opts.KeyboardLayout
+	if name == "" {
+		name = DefaultHandLayout
+	}
+	layout, ok := HandLayouts[name]
+	if !ok {
+		return [2]string{}, newError(CodeUnknownLayout, name)
+	}
+
+	left := intersectCharacters(chars, layout.Left)
+	right := intersectCharacters(chars, layout.Right)
+	if opts.NoSimilar {
+		left = removeSimilarCharacters(left)
+		right = removeSimilarCharacters(right)
+	}
+	if left == "" || right == "" {
+		return [2]string{}, newError(CodeNoKeysForBothHands)
+	}
+	return [2]string{left, right}, nil
+}
+
+// shuffleHands randomly decides which hand types the first character.
+func (g *Generator) shuffleHands(hands [2]string) ([2]string, error) {
+	coin, err := g.intn(2)
+	if err != nil {
+		return hands, err
+	}
+	if coin == 1 {
+		hands[0], hands[1] = hands[1], hands[0]
+	}
+	return hands, nil
+}
+
+// intersectCharacters returns the characters of chars that also appear in allowed.
+func intersectCharacters(chars, allowed string) string {
+	var result strings.Builder
+	for _, char := range chars {
+		if strings.ContainsRune(allowed, char) {
+			result.WriteRune(char)
+		}
+	}
+	return result.String()
+}
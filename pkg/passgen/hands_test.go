@@ -0,0 +1,71 @@
+package passgen
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestGeneratePasswords_AlternateHands verifies that consecutive characters use different hands.
+func TestGeneratePasswords_AlternateHands(t *testing.T) {
+	opts := PasswordOptions{
+		Length:         20,
+		Quantity:       20,
+		IncludeSymbols: true,
+		IncludeNumbers: true,
+		IncludeUpper:   true,
+		IncludeLower:   true,
+		AlternateHands: true,
+	}
+	layout := HandLayouts[DefaultHandLayout]
+
+	passwords, err := GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, password := range passwords {
+		if len(password) != opts.Length {
+			t.Errorf("Expected password length of %d, but got %d", opts.Length, len(password))
+		}
+		for i := 1; i < len(password); i++ {
+			prevLeft := strings.ContainsRune(layout.Left, rune(password[i-1]))
+			currLeft := strings.ContainsRune(layout.Left, rune(password[i]))
+			if prevLeft == currLeft {
+				t.Errorf("Password %s uses the same hand at positions %d and %d", password, i-1, i)
+				break
+			}
+		}
+	}
+}
+
+// TestGeneratePasswords_UnknownLayout verifies that an unknown keyboard layout is rejected.
+func TestGeneratePasswords_UnknownLayout(t *testing.T) {
+	opts := PasswordOptions{
+		Length:         10,
+		Quantity:       1,
+		IncludeLower:   true,
+		AlternateHands: true,
+		KeyboardLayout: "no-such-layout",
+	}
+	if _, err := GeneratePasswords(context.Background(), opts); err == nil {
+		t.Error("Expected an error for an unknown keyboard layout, but got none")
+	}
+}
+
+// TestEstimateEntropy_AlternateHandsCost verifies that alternating hands lowers the estimate.
+func TestEstimateEntropy_AlternateHandsCost(t *testing.T) {
+	opts := PasswordOptions{
+		Length:         16,
+		IncludeSymbols: true,
+		IncludeNumbers: true,
+		IncludeUpper:   true,
+		IncludeLower:   true,
+	}
+	uniform := EstimateEntropy(opts)
+	opts.AlternateHands = true
+	alternating := EstimateEntropy(opts)
+
+	if alternating <= 0 || alternating >= uniform {
+		t.Errorf("Expected 0 < alternating (%.1f) < uniform (%.1f)", alternating, uniform)
+	}
+}
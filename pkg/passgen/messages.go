@@ -0,0 +1,283 @@
+/**
+ * Error Codes and Message Catalog
+ *
+ * This file separates the errors of the model from their wording. Every
+ * error the generator returns carries a stable Code and its arguments; the
+ * text is rendered from a per-locale catalog, so the GUI, the CLI and other
+ * front ends can show messages in the user's language and react to specific
+ * errors without parsing strings.
+ */
+
+package passgen
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Code identifies an error of the model independently of its wording.
+type Code string
+
+// Error codes returned by the model.
+const (
+	CodeNoCharacterTypes         Code = "no_character_types"
+	CodeLengthTooShort           Code = "length_too_short"
+	CodeBeginNeedsLetters        Code = "begin_needs_letters"
+	CodeLengthExceedsUnique      Code = "length_exceeds_unique"
+	CodeUnknownLayout            Code = "unknown_keyboard_layout"
+	CodeNoKeysForBothHands       Code = "no_keys_for_both_hands"
+	CodeRandomFailure            Code = "random_failure"
+	CodePINLength                Code = "pin_length"
+	CodeNoStrongPIN              Code = "no_strong_pin"
+	CodeTokenSize                Code = "key_size"
+	CodeUnknownEncoding          Code = "unknown_key_encoding"
+	CodeVerificationFailed       Code = "verification_failed"
+	CodeMustIncludeInvalid       Code = "must_include_invalid"
+	CodeMustIncludeSimilar       Code = "must_include_similar"
+	CodeMustIncludeRepeats       Code = "must_include_repeats"
+	CodeMustIncludeTooLong       Code = "must_include_too_long"
+	CodeMustIncludeUnplaceable   Code = "must_include_unplaceable"
+	CodeLengthBelowClasses       Code = "length_below_classes"
+	CodeClassesUnplaceable       Code = "classes_unplaceable"
+	CodeBelowEntropyFloor        Code = "below_entropy_floor"
+	CodeBelowEntropyFloorClasses Code = "below_entropy_floor_classes"
+	CodePatternEmpty             Code = "pattern_empty"
+	CodePatternTrailingEscape    Code = "pattern_trailing_escape"
+	CodePatternNoCharacters      Code = "pattern_no_characters"
+	CodeGroupSize                Code = "group_size"
+	CodeGroupSeparator           Code = "group_separator"
+	CodeConstraintsUnsatisfiable Code = "constraints_unsatisfiable"
+	CodeAcronymEmpty             Code = "acronym_empty"
+	CodeAcronymSuffix            Code = "acronym_suffix"
+	CodeLengthOutOfRange         Code = "length_out_of_range"
+	CodeRecoveryCount            Code = "recovery_count"
+	CodeRecoveryFormat           Code = "recovery_format"
+	CodeAPIKeyLength             Code = "api_key_length"
+	CodeAPIKeyPrefix             Code = "api_key_prefix"
+	CodeUsernameAdjectives       Code = "username_adjectives"
+	CodeUsernameDigits           Code = "username_digits"
+	CodeUsernameSeparator        Code = "username_separator"
+	CodeUsernameQuantity         Code = "username_quantity"
+)
+
+// DefaultLocale is the locale used by Error.Error and for missing messages.
+const DefaultLocale = "en"
+
+// Catalogs maps a locale to the message template of each code. Templates
+// are fmt formats applied to Error.Args. Front ends may add locales.
+var Catalogs = map[string]map[Code]string{
+	"en": {
+		CodeNoCharacterTypes:         "at least one character type must be selected",
+		CodeLengthTooShort:           "length must be at least 1",
+		CodeBeginNeedsLetters:        "beginning with a letter requires uppercase or lowercase letters",
+		CodeLengthExceedsUnique:      "without duplicates, the length cannot exceed the number of available characters",
+		CodeUnknownLayout:            "unknown keyboard layout %q",
+		CodeNoKeysForBothHands:       "selected characters must include keys for both hands",
+		CodeRandomFailure:            "failed to generate secure random data",
+		CodePINLength:                "PIN length must be between %d and %d",
+		CodeNoStrongPIN:              "failed to generate a PIN that is not weak",
+		CodeTokenSize:                "key size must be between %d and %d bytes",
+		CodeUnknownEncoding:          "unknown key encoding %q",
+		CodeVerificationFailed:       "verification failed:\n  %s",
+		CodeMustIncludeInvalid:       "required character %q cannot be used: it is excluded or not printable ASCII",
+		CodeMustIncludeSimilar:       "the required characters include similar characters, which No Similar removes",
+		CodeMustIncludeRepeats:       "without duplicates, each required character can be listed only once",
+		CodeMustIncludeTooLong:       "%d required characters do not fit in a password of length %d",
+		CodeMustIncludeUnplaceable:   "the required characters %q cannot be placed with the selected options",
+		CodeLengthBelowClasses:       "a length of %d cannot hold one character of each selected type and the required characters; at least %d is needed",
+		CodeClassesUnplaceable:       "one character of each selected type cannot be placed with the selected options",
+		CodeBelowEntropyFloor:        "these options give about %.0f bits of entropy, below the safety floor of %.0f bits; a length of %d or more meets it",
+		CodeBelowEntropyFloorClasses: "these options give about %.0f bits of entropy, below the safety floor of %.0f bits; enable more character types",
+		CodePatternEmpty:             "the pattern is empty",
+		CodePatternTrailingEscape:    "the pattern ends in a backslash; write \\\\ for a literal backslash",
+		CodePatternNoCharacters:      "placeholder %q at position %d has no characters left after the exclusions",
+		CodeGroupSize:                "the group size cannot be negative",
+		CodeGroupSeparator:           "the group separator must be a single printable character",
+		CodeConstraintsUnsatisfiable: "no password meets the constraints with the selected options",
+		CodeAcronymEmpty:             "the sentence has no words to take letters from",
+		CodeAcronymSuffix:            "the number of random digits and symbols cannot be negative",
+		CodeLengthOutOfRange:         "length must be between %d and %d",
+		CodeRecoveryCount:            "a set must have between 1 and %d recovery codes",
+		CodeRecoveryFormat:           "recovery codes need 1 to %d groups of %d to %d characters",
+		CodeAPIKeyLength:             "API keys must have between %d and %d random characters",
+		CodeAPIKeyPrefix:             "the API key prefix can have up to %d letters, digits and underscores",
+		CodeUsernameAdjectives:       "usernames can have 1 to %d adjectives",
+		CodeUsernameDigits:           "usernames can have 0 to %d digits",
+		CodeUsernameSeparator:        "unknown username separator %q; use _, -, . or none",
+		CodeUsernameQuantity:         "only about %.0f distinct usernames exist with these options, fewer than the %d requested, and a repeat gets likely after about %.0f; add digits or adjectives",
+	},
+	"de": {
+		CodeNoCharacterTypes:         "mindestens eine Zeichenart muss ausgewählt sein",
+		CodeLengthTooShort:           "die Länge muss mindestens 1 betragen",
+		CodeBeginNeedsLetters:        "für einen Buchstaben am Anfang müssen Groß- oder Kleinbuchstaben ausgewählt sein",
+		CodeLengthExceedsUnique:      "ohne Wiederholungen darf die Länge die Zahl der verfügbaren Zeichen nicht überschreiten",
+		CodeUnknownLayout:            "unbekanntes Tastaturlayout %q",
+		CodeNoKeysForBothHands:       "die ausgewählten Zeichen müssen Tasten für beide Hände enthalten",
+		CodeRandomFailure:            "sichere Zufallsdaten konnten nicht erzeugt werden",
+		CodePINLength:                "die PIN-Länge muss zwischen %d und %d liegen",
+		CodeNoStrongPIN:              "es konnte keine PIN erzeugt werden, die nicht schwach ist",
+		CodeTokenSize:                "die Schlüsselgröße muss zwischen %d und %d Bytes liegen",
+		CodeUnknownEncoding:          "unbekannte Schlüsselkodierung %q",
+		CodeVerificationFailed:       "Prüfung fehlgeschlagen:\n  %s",
+		CodeMustIncludeInvalid:       "das geforderte Zeichen %q ist nicht verwendbar: es ist ausgeschlossen oder kein druckbares ASCII",
+		CodeMustIncludeSimilar:       "die geforderten Zeichen enthalten ähnliche Zeichen, die ohne ähnliche Zeichen entfernt werden",
+		CodeMustIncludeRepeats:       "ohne Wiederholungen darf jedes geforderte Zeichen nur einmal vorkommen",
+		CodeMustIncludeTooLong:       "%d geforderte Zeichen passen nicht in ein Passwort der Länge %d",
+		CodeMustIncludeUnplaceable:   "die geforderten Zeichen %q lassen sich mit den gewählten Optionen nicht unterbringen",
+		CodeLengthBelowClasses:       "eine Länge von %d reicht nicht für ein Zeichen jeder gewählten Zeichenart und die geforderten Zeichen; mindestens %d ist nötig",
+		CodeClassesUnplaceable:       "ein Zeichen jeder gewählten Zeichenart lässt sich mit den gewählten Optionen nicht unterbringen",
+		CodeBelowEntropyFloor:        "diese Optionen ergeben etwa %.0f Bit Entropie, weniger als die Sicherheitsuntergrenze von %.0f Bit; ab einer Länge von %d wird sie erreicht",
+		CodeBelowEntropyFloorClasses: "diese Optionen ergeben etwa %.0f Bit Entropie, weniger als die Sicherheitsuntergrenze von %.0f Bit; aktivieren Sie weitere Zeichenarten",
+		CodePatternEmpty:             "das Muster ist leer",
+		CodePatternTrailingEscape:    "das Muster endet mit einem Backslash; schreiben Sie \\\\ für einen Backslash",
+		CodePatternNoCharacters:      "für den Platzhalter %q an Position %d bleiben nach den Ausschlüssen keine Zeichen übrig",
+		CodeGroupSize:                "die Gruppengröße darf nicht negativ sein",
+		CodeGroupSeparator:           "das Gruppentrennzeichen muss ein einzelnes druckbares Zeichen sein",
+		CodeConstraintsUnsatisfiable: "mit den gewählten Optionen erfüllt kein Passwort die Regeln",
+		CodeAcronymEmpty:             "der Satz enthält keine Wörter, aus denen Buchstaben genommen werden können",
+		CodeAcronymSuffix:            "die Zahl der zufälligen Ziffern und Sonderzeichen darf nicht negativ sein",
+		CodeLengthOutOfRange:         "die Länge muss zwischen %d und %d liegen",
+		CodeRecoveryCount:            "ein Satz muss zwischen 1 und %d Wiederherstellungscodes enthalten",
+		CodeRecoveryFormat:           "Wiederherstellungscodes brauchen 1 bis %d Gruppen mit %d bis %d Zeichen",
+		CodeAPIKeyLength:             "API-Schlüssel müssen zwischen %d und %d zufällige Zeichen haben",
+		CodeAPIKeyPrefix:             "das Präfix eines API-Schlüssels darf bis zu %d Buchstaben, Ziffern und Unterstriche enthalten",
+		CodeUsernameAdjectives:       "Benutzernamen können 1 bis %d Adjektive haben",
+		CodeUsernameDigits:           "Benutzernamen können 0 bis %d Ziffern haben",
+		CodeUsernameSeparator:        "unbekanntes Trennzeichen %q für Benutzernamen; verwenden Sie _, -, . oder keines",
+		CodeUsernameQuantity:         "mit diesen Optionen gibt es nur etwa %.0f verschiedene Benutzernamen, weniger als die %d gewünschten, und ab etwa %.0f wird eine Wiederholung wahrscheinlich; fügen Sie Ziffern oder Adjektive hinzu",
+	},
+}
+
+// Kinds of errors, for callers that react to the kind of failure rather than
+// its exact Code. errors.Is(err, ErrEmptyCharset) reports whether err is an
+// *Error whose code is of that kind; codes such as CodeRandomFailure belong
+// to no kind.
+var (
+	// ErrEmptyCharset: the options leave no characters to draw from.
+	ErrEmptyCharset = errors.New("no characters to generate from")
+	// ErrLengthOutOfRange: the length does not fit the options or limits.
+	ErrLengthOutOfRange = errors.New("length out of range")
+	// ErrInfeasibleConstraints: the options are each fine, but no secret
+	// meets all of them together.
+	ErrInfeasibleConstraints = errors.New("constraints cannot be met")
+)
+
+// errorKinds maps codes to their kind.
+var errorKinds = map[Code]error{
+	CodeNoCharacterTypes:         ErrEmptyCharset,
+	CodePatternNoCharacters:      ErrEmptyCharset,
+	CodeLengthTooShort:           ErrLengthOutOfRange,
+	CodeLengthOutOfRange:         ErrLengthOutOfRange,
+	CodeLengthExceedsUnique:      ErrLengthOutOfRange,
+	CodeMustIncludeTooLong:       ErrLengthOutOfRange,
+	CodeLengthBelowClasses:       ErrLengthOutOfRange,
+	CodePINLength:                ErrLengthOutOfRange,
+	CodeTokenSize:                ErrLengthOutOfRange,
+	CodeRecoveryCount:            ErrLengthOutOfRange,
+	CodeRecoveryFormat:           ErrLengthOutOfRange,
+	CodeAPIKeyLength:             ErrLengthOutOfRange,
+	CodeUsernameAdjectives:       ErrLengthOutOfRange,
+	CodeUsernameDigits:           ErrLengthOutOfRange,
+	CodeBeginNeedsLetters:        ErrInfeasibleConstraints,
+	CodeNoKeysForBothHands:       ErrInfeasibleConstraints,
+	CodeNoStrongPIN:              ErrInfeasibleConstraints,
+	CodeMustIncludeSimilar:       ErrInfeasibleConstraints,
+	CodeMustIncludeRepeats:       ErrInfeasibleConstraints,
+	CodeMustIncludeUnplaceable:   ErrInfeasibleConstraints,
+	CodeClassesUnplaceable:       ErrInfeasibleConstraints,
+	CodeConstraintsUnsatisfiable: ErrInfeasibleConstraints,
+	CodeUsernameQuantity:         ErrInfeasibleConstraints,
+	CodeBelowEntropyFloor:        ErrInfeasibleConstraints,
+	CodeBelowEntropyFloorClasses: ErrInfeasibleConstraints,
+}
+
+// Error is an error of the model with a code and the arguments of its message.
+type Error struct {
+	Code Code
+	Args []interface{}
+}
+
+// Is reports whether target is the kind of e's code, for errors.Is.
+func (e *Error) Is(target error) bool {
+	kind, ok := errorKinds[e.Code]
+	return ok && kind == target
+}
+
+// newError returns an *Error for code with the given message arguments.
+func newError(code Code, args ...interface{}) *Error {
+	return &Error{Code: code, Args: args}
+}
+
+// Error renders the message in DefaultLocale.
+func (e *Error) Error() string {
+	return e.Message(DefaultLocale)
+}
+
+// Message renders the message in locale, falling back to DefaultLocale.
+func (e *Error) Message(locale string) string {
+	template, ok := Catalogs[locale][e.Code]
+	if !ok {
+		if template, ok = Catalogs[DefaultLocale][e.Code]; !ok {
+			return string(e.Code)
+		}
+	}
+	if len(e.Args) == 0 {
+		return template
+	}
+	return fmt.Sprintf(template, e.Args...)
+}
+
+// ErrorCode returns the code of the first *Error in err's chain, or "" if
+// err did not come from the model.
+func ErrorCode(err error) Code {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return ""
+}
+
+// Localize renders err in locale if it came from the model, and returns
+// err.Error() otherwise, or "" for a nil err. Locales such as "de_DE.UTF-8"
+// or "de-AT" fall back to their language.
+// Example:
+//
+//	fmt.Fprintln(stderr, "Error:", passgen.Localize(err, "de"))
+func Localize(err error, locale string) string {
+	if err == nil {
+		return ""
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		return err.Error()
+	}
+	return e.Message(NormalizeLocale(locale))
+}
+
+// NormalizeLocale maps a locale such as "de_DE.UTF-8" to a catalog key,
+// or DefaultLocale if there is no catalog for it.
+func NormalizeLocale(locale string) string {
+	if _, ok := Catalogs[locale]; ok {
+		return locale
+	}
+	language := strings.ToLower(locale)
+	if i := strings.IndexAny(language, "_-."); i >= 0 {
+		language = language[:i]
+	}
+	if _, ok := Catalogs[language]; ok {
+		return language
+	}
+	return DefaultLocale
+}
+
+// Locales returns the locales with a catalog, sorted.
+func Locales() []string {
+	locales := make([]string, 0, len(Catalogs))
+	for locale := range Catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
@@ -0,0 +1,79 @@
+package passgen
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestErrorCode returns stable codes for model errors.
+func TestErrorCode(t *testing.T) {
+	_, err := GeneratePasswords(context.Background(), PasswordOptions{Length: 8, Quantity: 1})
+	if code := ErrorCode(err); code != CodeNoCharacterTypes {
+		t.Errorf("Expected %s, but got %q", CodeNoCharacterTypes, code)
+	}
+	if code := ErrorCode(errors.New("other")); code != "" {
+		t.Errorf("Expected no code for other errors, but got %q", code)
+	}
+}
+
+// TestLocalize renders messages from the catalog of the requested locale.
+func TestLocalize(t *testing.T) {
+	_, err := GeneratePINs(context.Background(), PINOptions{Length: 2, Quantity: 1})
+	if got := err.Error(); got != "PIN length must be between 4 and 12" {
+		t.Errorf("Unexpected default message %q", got)
+	}
+	if got := Localize(err, "de_DE.UTF-8"); got != "die PIN-Länge muss zwischen 4 und 12 liegen" {
+		t.Errorf("Unexpected German message %q", got)
+	}
+	if got := Localize(err, "xx"); got != err.Error() {
+		t.Errorf("Expected unknown locales to fall back, but got %q", got)
+	}
+	if got := Localize(errors.New("other"), "de"); got != "other" {
+		t.Errorf("Expected other errors unchanged, but got %q", got)
+	}
+	if got := Localize(nil, "de"); got != "" {
+		t.Errorf("Expected no message for a nil error, but got %q", got)
+	}
+
+	opts := PasswordOptions{Length: 8, Quantity: 1, IncludeLower: true, AlternateHands: true, KeyboardLayout: "colemak"}
+	if got := Localize(Validate(opts), "de"); got != `unbekanntes Tastaturlayout "colemak"` {
+		t.Errorf("Unexpected message with arguments %q", got)
+	}
+}
+
+// TestCatalogs_Complete checks every locale translates every code.
+func TestCatalogs_Complete(t *testing.T) {
+	for _, locale := range Locales() {
+		for code := range Catalogs[DefaultLocale] {
+			if _, ok := Catalogs[locale][code]; !ok {
+				t.Errorf("Expected locale %s to have a message for %s", locale, code)
+			}
+		}
+	}
+}
+
+// TestErrorKinds verifies that errors.Is matches model errors by kind.
+func TestErrorKinds(t *testing.T) {
+	tests := []struct {
+		opts PasswordOptions
+		kind error
+	}{
+		{PasswordOptions{Length: 8, Quantity: 1}, ErrEmptyCharset},
+		{PasswordOptions{Length: 0, Quantity: 1, IncludeLower: true}, ErrLengthOutOfRange},
+		{PasswordOptions{Length: 30, Quantity: 1, IncludeNumbers: true, NoDuplicates: true}, ErrLengthOutOfRange},
+		{PasswordOptions{Length: 8, Quantity: 1, IncludeNumbers: true, BeginWithLetter: true}, ErrInfeasibleConstraints},
+	}
+	kinds := []error{ErrEmptyCharset, ErrLengthOutOfRange, ErrInfeasibleConstraints}
+	for _, test := range tests {
+		_, err := GeneratePasswords(context.Background(), test.opts)
+		for _, kind := range kinds {
+			if errors.Is(err, kind) != (kind == test.kind) {
+				t.Errorf("Expected errors.Is(%v, %v) to be %t", err, kind, kind == test.kind)
+			}
+		}
+	}
+	if errors.Is(newError(CodeRandomFailure), ErrInfeasibleConstraints) {
+		t.Errorf("Expected random failures to have no kind, but got %v", ErrInfeasibleConstraints)
+	}
+}
@@ -0,0 +1,110 @@
+/**
+ * Passphrase Entropy and Crack Time
+ *
+ * This file estimates the entropy of passphrases built from random words and
+ * turns entropy estimates into expected crack times for typical attackers.
+ * It supports writing passphrase policies before any passphrase exists: the
+ * estimate depends only on the wordlist size, the word count and the
+ * separators, as every word and separator is assumed to be drawn at random.
+ */
+
+package passgen
+
+import (
+	"fmt"
+	"math"
+)
+
+// PassphraseOptions describes how passphrases are built.
+// Fields:
+//   - WordlistSize (int): Number of words the words are drawn from, e.g.
+//     7776 for the EFF large wordlist.
+//   - Words (int): Number of words per passphrase.
+//   - Separators (string): Characters one of which is drawn for each gap
+//     between words; a single character adds no entropy.
+//   - RandomCase (bool): Each word is capitalised at random.
+type PassphraseOptions struct {
+	WordlistSize int
+	Words        int
+	Separators   string
+	RandomCase   bool
+}
+
+// PassphraseEntropy returns the entropy of one passphrase in bits, or 0 if
+// the wordlist or word count is empty.
+// Example:
+//
+//	bits := PassphraseEntropy(PassphraseOptions{WordlistSize: 7776, Words: 6, Separators: "-"}) // ~77.5
+func PassphraseEntropy(opts PassphraseOptions) float64 {
+	if opts.WordlistSize < 1 || opts.Words < 1 {
+		return 0
+	}
+	bits := float64(opts.Words) * math.Log2(float64(opts.WordlistSize))
+	if separators := len([]rune(removeDuplicateCharacters(opts.Separators))); separators > 1 {
+		bits += float64(opts.Words-1) * math.Log2(float64(separators))
+	}
+	if opts.RandomCase {
+		bits += float64(opts.Words)
+	}
+	return bits
+}
+
+// Attack is a typical attacker with the number of guesses it makes per second.
+type Attack struct {
+	Name             string
+	GuessesPerSecond float64
+}
+
+// Attacks lists the attackers crack times are shown for, from slowest to fastest.
+var Attacks = []Attack{
+	{"Online, rate-limited (100/hour)", 100.0 / 3600},
+	{"Online, unthrottled (1,000/s)", 1e3},
+	{"Offline, slow hash such as bcrypt (10,000/s)", 1e4},
+	{"Offline, fast hash such as SHA-256 on GPUs (10 billion/s)", 1e10},
+}
+
+// CrackSeconds returns the expected time in seconds to guess a secret of
+// bits entropy at guessesPerSecond: on average half of the space is searched.
+func CrackSeconds(bits, guessesPerSecond float64) float64 {
+	return math.Pow(2, bits-1) / guessesPerSecond
+}
+
+// FormatCrackTime renders seconds as a rough human duration, e.g. "3 days"
+// or "2.1 million years".
+func FormatCrackTime(seconds float64) string {
+	const year = 365.25 * 24 * 3600
+	years := seconds / year
+	switch {
+	case years >= 1e15:
+		return "more than a quadrillion years"
+	case years >= 1e12:
+		return fmt.Sprintf("%.1f trillion years", years/1e12)
+	case years >= 1e9:
+		return fmt.Sprintf("%.1f billion years", years/1e9)
+	case years >= 1e6:
+		return fmt.Sprintf("%.1f million years", years/1e6)
+	case years >= 1e3:
+		return fmt.Sprintf("%.1f thousand years", years/1e3)
+	}
+	for _, unit := range crackTimeUnits {
+		if n := math.Floor(seconds / unit.seconds); n >= 1 {
+			if n == 1 {
+				return "1 " + unit.name
+			}
+			return fmt.Sprintf("%.0f %ss", n, unit.name)
+		}
+	}
+	return "less than a second"
+}
+
+// crackTimeUnits lists the units FormatCrackTime uses below a thousand years.
+var crackTimeUnits = []struct {
+	name    string
+	seconds float64
+}{
+	{"year", 365.25 * 24 * 3600},
+	{"day", 24 * 3600},
+	{"hour", 3600},
+	{"minute", 60},
+	{"second", 1},
+}
@@ -0,0 +1,45 @@
+package passgen
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPassphraseEntropy checks the entropy of word, separator and case choices.
+func TestPassphraseEntropy(t *testing.T) {
+	tests := []struct {
+		opts PassphraseOptions
+		want float64
+	}{
+		{PassphraseOptions{WordlistSize: 7776, Words: 6, Separators: "-"}, 6 * math.Log2(7776)},
+		{PassphraseOptions{WordlistSize: 2048, Words: 4, Separators: "-_.,"}, 4*11 + 3*2},
+		{PassphraseOptions{WordlistSize: 2048, Words: 4, Separators: "--"}, 4 * 11},
+		{PassphraseOptions{WordlistSize: 1024, Words: 3, RandomCase: true}, 3*10 + 3},
+		{PassphraseOptions{WordlistSize: 0, Words: 3}, 0},
+	}
+	for _, tt := range tests {
+		if got := PassphraseEntropy(tt.opts); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Expected %.2f bits for %+v, but got %.2f", tt.want, tt.opts, got)
+		}
+	}
+}
+
+// TestFormatCrackTime checks the expected crack time and its rendering.
+func TestFormatCrackTime(t *testing.T) {
+	if got := CrackSeconds(11, 1024); got != 1 {
+		t.Errorf("Expected 1 second for 11 bits at 1024 guesses/s, but got %v", got)
+	}
+	tests := map[float64]string{
+		0.5:              "less than a second",
+		1:                "1 second",
+		7200:             "2 hours",
+		3 * 24 * 3600:    "3 days",
+		2.1e6 * 31557600: "2.1 million years",
+		1e300:            "more than a quadrillion years",
+	}
+	for seconds, want := range tests {
+		if got := FormatCrackTime(seconds); got != want {
+			t.Errorf("Expected %q for %v seconds, but got %q", want, seconds, got)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+/**
+ * Password Generation Logic
+ *
+ * This file contains the core password generation logic, structured around the
+ * PasswordOptions struct, which holds all customizable parameters. The functions
+ * here generate secure passwords based on user-defined options, ensuring that
+ * specific criteria are met, such as the exclusion of similar, duplicate, or
+ * sequential characters.
+ */
+
+package passgen
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// PasswordOptions holds user-selected settings for password customization.
+// Purpose:
+//
+//	Used to define various aspects of password generation, such as the inclusion
+//	of symbols, length of the password, and the exclusion of certain characters.
+//
+// Fields:
+//   - MinLength, MaxLength, DefaultLength (int): Controls length limits and defaults.
+//   - Quantity (int): Specifies the number of passwords to generate.
+//   - IncludeSymbols, IncludeNumbers, IncludeUpper, IncludeLower (bool): Defines
+//     character types to include.
+//   - BeginWithLetter, NoSimilar, NoDuplicates, NoSequential (bool): Additional
+//     customization options for password structure.
+//   - Length (int): Desired length for each password.
+//   - AlternateHands (bool): Alternates characters between left- and right-hand
+//     keys of KeyboardLayout so passwords are faster to type.
+//   - KeyboardLayout (string): Name of the HandLayouts entry used by
+//     AlternateHands; DefaultHandLayout when empty.
+//   - ExcludeCharacters (string): Characters never used, whatever the classes.
+//   - MustInclude (string): Printable ASCII characters that appear at least
+//     once in every password, at random positions, whatever the classes.
+//   - MinEntropy (float64): Safety floor in bits; options whose
+//     EstimateEntropy is lower are refused. 0 disables the floor.
+//   - GroupSize (int): Splits each password into groups of this many
+//     characters, e.g. "x7Kp-93fQ-LmR2"; 0 disables grouping. Separators do
+//     not count toward Length, entropy or class checks.
+//   - GroupSeparator (string): The single character between groups;
+//     DefaultGroupSeparator when empty.
+type PasswordOptions struct {
+	MinLength         int
+	MaxLength         int
+	DefaultLength     int
+	Quantity          int
+	IncludeSymbols    bool
+	IncludeNumbers    bool
+	IncludeUpper      bool
+	IncludeLower      bool
+	BeginWithLetter   bool
+	NoSimilar         bool
+	NoDuplicates      bool
+	NoSequential      bool
+	Length            int
+	AlternateHands    bool
+	KeyboardLayout    string
+	ExcludeCharacters string
+	MustInclude       string
+	MinEntropy        float64
+	GroupSize         int
+	GroupSeparator    string
+}
+
+// Character classes that can be enabled in PasswordOptions.
+const (
+	Symbols   = "!@#$%^&*()-_=+[]{}|;:,.<>/?"
+	Digits    = "0123456789"
+	Uppercase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Lowercase = "abcdefghijklmnopqrstuvwxyz"
+)
+
+// similarCharacters holds a string of visually similar characters
+// (e.g., "i" and "l") that should be excluded from generated passwords if
+// the NoSimilar option is enabled.
+var similarCharacters = "iIl1Lo0O"
+
+// requiredAttempts bounds how often a password is rebuilt when a position
+// had no character left under the constraints.
+const requiredAttempts = 100
+
+// GeneratePasswords generates a list of passwords based on the provided options.
+// Purpose:
+//
+//	Generates multiple passwords using the GeneratePassword function, iterating
+//	based on the Quantity field in PasswordOptions.
+//
+// Parameters:
+//   - ctx (context.Context): Cancels a long batch between two passwords.
+//   - opts (PasswordOptions): Settings used to customize the passwords generated.
+//
+// Returns:
+//
+//	[]string: A list of generated passwords.
+//	error: Returns an error if the options break one of the OptionRules,
+//	password generation fails, or ctx is done (ctx.Err()).
+//
+// Example:
+//
+//	passwords, err := g.GeneratePasswords(ctx, opts)
+func (g *Generator) GeneratePasswords(ctx context.Context, opts PasswordOptions) ([]string, error) {
+	if err := Validate(opts); err != nil {
+		return nil, err
+	}
+	var passwords []string
+	for i := 0; i < opts.Quantity; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		password, err := g.generatePassword(opts)
+		if err != nil {
+			return nil, err
+		}
+		passwords = append(passwords, Group(password, opts))
+	}
+	return passwords, nil
+}
+
+// generatePassword creates a single password based on the options provided.
+// Purpose:
+//
+//	Builds a password character set and assembles the password according to user
+//	specifications, ensuring that specific structural requirements are met.
+//
+// Parameters:
+//   - opts (PasswordOptions): Settings for password length, character types, and restrictions.
+//
+// Returns:
+//
+//	string: A generated password.
+//	error: An error if no valid character types are selected.
+//
+// Example:
+//
+//	password, err := g.generatePassword(opts)
+func (g *Generator) generatePassword(opts PasswordOptions) (string, error) {
+	for attempt := 0; attempt < requiredAttempts; attempt++ {
+		password, err := g.assemblePassword(opts)
+		if errors.Is(err, errUnplaced) {
+			continue
+		}
+		if err != nil || meetsRequirements(password, opts) {
+			return password, err
+		}
+	}
+	if len(g.constraints) > 0 {
+		return "", newError(CodeConstraintsUnsatisfiable)
+	}
+	if opts.MustInclude != "" {
+		return "", newError(CodeMustIncludeUnplaceable, opts.MustInclude)
+	}
+	return "", newError(CodeClassesUnplaceable)
+}
+
+// assemblePassword builds one candidate password of exactly opts.Length
+// characters.
+// Purpose:
+//
+//	Places one character of every enabled class and the MustInclude
+//	characters at random positions first, then fills the other positions
+//	in order. Every Constraint, those of the options followed by those of
+//	the Generator, narrows the pool of each position and then checks the
+//	result: similar characters are never in the pool, NoDuplicates draws
+//	without replacement, and NoSequential leaves out the characters that
+//	would complete a run with their neighbours. It returns errUnplaced if a
+//	position has no character left or a check fails, so that
+//	generatePassword starts over.
+func (g *Generator) assemblePassword(opts PasswordOptions) (string, error) {
+	chars := buildCharacterSet(opts)
+	if chars == "" {
+		return "", newError(CodeNoCharacterTypes)
+	}
+	if opts.NoSimilar {
+		chars = removeSimilarCharacters(chars)
+	}
+	constraints := append(optionConstraints(opts), g.constraints...)
+
+	var hands [2]string
+	if opts.AlternateHands {
+		var err error
+		if hands, err = handCharacterSets(opts, chars); err != nil {
+			return "", err
+		}
+		if hands, err = g.shuffleHands(hands); err != nil {
+			return "", err
+		}
+	}
+
+	required := opts.MustInclude
+	for _, class := range missingClasses(opts) {
+		pool := class.chars
+		if opts.NoDuplicates {
+			pool = removeCharacters(pool, required)
+		}
+		if pool == "" {
+			return "", errUnplaced
+		}
+		c, err := g.randomChar(pool)
+		if err != nil {
+			return "", err
+		}
+		required += string(c)
+	}
+	// Zero bytes mark the positions that are still free.
+	password := make([]byte, opts.Length)
+	if err := g.placeRequired(password, opts, hands, required); err != nil {
+		return "", err
+	}
+
+	for i := range password {
+		if password[i] != 0 {
+			continue
+		}
+		pool := positionCharacters(opts, chars, hands, i)
+		for _, constraint := range constraints {
+			pool = constraint.Apply(pool, password, i)
+		}
+		if pool == "" {
+			return "", errUnplaced
+		}
+		c, err := g.randomChar(pool)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+	// Required characters placed side by side may still form a run, and
+	// some constraints can only judge the whole password.
+	for _, constraint := range constraints {
+		if !constraint.Check(password) {
+			return "", errUnplaced
+		}
+	}
+	return string(password), nil
+}
+
+// positionCharacters returns the characters allowed at position i: the hand
+// due there with AlternateHands, and only letters first with BeginWithLetter.
+func positionCharacters(opts PasswordOptions, chars string, hands [2]string, i int) string {
+	pool := chars
+	if opts.AlternateHands {
+		pool = hands[i%2]
+	}
+	if i == 0 && opts.BeginWithLetter {
+		pool = intersectCharacters(pool, letterCharacters(opts))
+	}
+	return pool
+}
+
+// buildCharacterSet compiles a set of allowed characters based on options.
+// Purpose:
+//
+//	Builds a character set according to user-specified options for symbols,
+//	numbers, uppercase, and lowercase letters, minus any excluded characters.
+//
+// Parameters:
+//   - opts (PasswordOptions): Settings that determine the characters included.
+//
+// Returns:
+//
+//	string: A string containing the allowed characters for password generation.
+func buildCharacterSet(opts PasswordOptions) string {
+	var chars string
+	if opts.IncludeSymbols {
+		chars += Symbols
+	}
+	if opts.IncludeNumbers {
+		chars += Digits
+	}
+	if opts.IncludeUpper {
+		chars += Uppercase
+	}
+	if opts.IncludeLower {
+		chars += Lowercase
+	}
+	return removeCharacters(chars, opts.ExcludeCharacters)
+}
+
+// letterCharacters returns the uppercase and/or lowercase letters enabled in opts.
+func letterCharacters(opts PasswordOptions) string {
+	letters := ""
+	if opts.IncludeUpper {
+		letters += Uppercase
+	}
+	if opts.IncludeLower {
+		letters += Lowercase
+	}
+	return removeCharacters(letters, opts.ExcludeCharacters)
+}
+
+// removeCharacters returns chars without any character listed in excluded.
+func removeCharacters(chars, excluded string) string {
+	if excluded == "" {
+		return chars
+	}
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(excluded, r) {
+			return -1
+		}
+		return r
+	}, chars)
+}
+
+// randomChar returns a random character from a given character set.
+// Purpose:
+//
+//	Selects a character without bias using the randomness of the Generator.
+//
+// Parameters:
+//   - chars (string): The set of characters to choose from.
+//
+// Returns:
+//
+//	byte: A securely generated random character.
+//	error: An error if secure random generation fails.
+func (g *Generator) randomChar(chars string) (byte, error) {
+	index, err := g.intn(len(chars))
+	if err != nil {
+		return 0, err
+	}
+	return chars[index], nil
+}
+
+// removeSimilarCharacters removes visually similar characters from a
+// character set.
+// Purpose:
+//
+//	Enhances readability by leaving similar characters out of the pool if
+//	NoSimilar is enabled.
+//
+// Parameters:
+//   - chars (string): The original characters.
+//
+// Returns:
+//
+//	string: The characters without similar ones.
+func removeSimilarCharacters(chars string) string {
+	return removeCharacters(chars, similarCharacters)
+}
+
+// removeDuplicateCharacters keeps the first occurrence of every character.
+// Purpose:
+//
+//	Reduces a character set, or a list such as MustInclude, to its distinct
+//	characters.
+//
+// Parameters:
+//   - chars (string): The original characters.
+//
+// Returns:
+//
+//	string: The characters with duplicates removed.
+func removeDuplicateCharacters(chars string) string {
+	seen := make(map[rune]bool)
+	result := strings.Builder{}
+	for _, char := range chars {
+		if !seen[char] {
+			seen[char] = true
+			result.WriteRune(char)
+		}
+	}
+	return result.String()
+}
+
+// nonSequentialCharacters returns the characters of pool that complete no
+// ascending or descending run of three with the characters already placed
+// around position i of password; zero bytes are free positions.
+func nonSequentialCharacters(pool string, password []byte, i int) string {
+	at := func(j int) rune {
+		if j < 0 || j >= len(password) {
+			return 0
+		}
+		return rune(password[j])
+	}
+	var allowed strings.Builder
+	for _, c := range []byte(pool) {
+		r := rune(c)
+		switch {
+		case at(i-2) != 0 && at(i-1) != 0 && isSequential(at(i-2), at(i-1), r):
+		case at(i-1) != 0 && at(i+1) != 0 && isSequential(at(i-1), r, at(i+1)):
+		case at(i+1) != 0 && at(i+2) != 0 && isSequential(r, at(i+1), at(i+2)):
+		default:
+			allowed.WriteByte(c)
+		}
+	}
+	return allowed.String()
+}
+
+// hasSequence reports whether password contains a run of three such as abc or 321.
+func hasSequence(password []byte) bool {
+	for i := 0; i+2 < len(password); i++ {
+		if isSequential(rune(password[i]), rune(password[i+1]), rune(password[i+2])) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSequential checks if three characters form a sequence.
+// Purpose:
+//
+//	Determines if three characters form an ascending or descending sequence.
+//
+// Parameters:
+//   - a, b, c (rune): Three consecutive characters from the password.
+//
+// Returns:
+//
+//	bool: True if the characters are sequential; otherwise, false.
+func isSequential(a, b, c rune) bool {
+	return (b == a+1 && c == b+1) || (b == a-1 && c == b-1)
+}
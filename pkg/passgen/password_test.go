@@ -1,6 +1,7 @@
-package model
+package passgen
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"unicode"
@@ -17,7 +18,7 @@ func TestGeneratePasswords_MinMaxLength(t *testing.T) {
 		IncludeUpper:   true,
 		IncludeLower:   true,
 	}
-	passwords, err := GeneratePasswords(opts)
+	passwords, err := GeneratePasswords(context.Background(), opts)
 	if err != nil {
 		t.Errorf("Expected no error, but got %v", err)
 	}
@@ -29,7 +30,7 @@ func TestGeneratePasswords_MinMaxLength(t *testing.T) {
 
 	// Test maximum length
 	opts.Length = 32
-	passwords, err = GeneratePasswords(opts)
+	passwords, err = GeneratePasswords(context.Background(), opts)
 	if err != nil {
 		t.Errorf("Expected no error, but got %v", err)
 	}
@@ -51,7 +52,7 @@ func TestGeneratePasswords_OptionCombinations(t *testing.T) {
 	}
 
 	for i, opts := range combinations {
-		passwords, err := GeneratePasswords(opts)
+		passwords, err := GeneratePasswords(context.Background(), opts)
 		if err != nil {
 			t.Errorf("Combination %d: Expected no error, but got %v", i+1, err)
 			continue
@@ -112,7 +113,7 @@ func TestGeneratePasswords_NoDuplicate(t *testing.T) {
 		NoDuplicates: true,
 	}
 
-	passwords, err := GeneratePasswords(opts)
+	passwords, err := GeneratePasswords(context.Background(), opts)
 	if err != nil {
 		t.Errorf("Expected no error, but got %v", err)
 	}
@@ -137,7 +138,7 @@ func TestGeneratePasswords_NoSimilarCharacters(t *testing.T) {
 		NoSimilar:    true,
 	}
 
-	passwords, err := GeneratePasswords(opts)
+	passwords, err := GeneratePasswords(context.Background(), opts)
 	if err != nil {
 		t.Errorf("Expected no error, but got %v", err)
 	}
@@ -161,7 +162,7 @@ func TestGeneratePasswords_NoSequentialCharacters(t *testing.T) {
 		NoSequential:   true,
 	}
 
-	passwords, err := GeneratePasswords(opts)
+	passwords, err := GeneratePasswords(context.Background(), opts)
 	if err != nil {
 		t.Errorf("Expected no error, but got %v", err)
 	}
@@ -188,7 +189,7 @@ func TestPerformance_Consistency(t *testing.T) {
 	}
 
 	for i := 0; i < 10; i++ { // Run 10 iterations to check for consistency
-		passwords, err := GeneratePasswords(opts)
+		passwords, err := GeneratePasswords(context.Background(), opts)
 		if err != nil {
 			t.Errorf("Iteration %d: Expected no error, but got %v", i+1, err)
 		}
@@ -198,20 +199,113 @@ func TestPerformance_Consistency(t *testing.T) {
 	}
 }
 
-// TestGetRandomLetter_OnlyLetters tests getRandomLetter to confirm it only generates letters.
-func TestGetRandomLetter_OnlyLetters(t *testing.T) {
+// TestPositionCharacters_OnlyLetters confirms that the first position only
+// allows letters with BeginWithLetter.
+func TestPositionCharacters_OnlyLetters(t *testing.T) {
 	opts := PasswordOptions{
-		IncludeUpper: true,
-		IncludeLower: true,
+		IncludeNumbers:  true,
+		IncludeUpper:    true,
+		IncludeLower:    true,
+		BeginWithLetter: true,
 	}
 
-	for i := 0; i < 20; i++ { // 20 iterations for better coverage
-		char, err := getRandomLetter(opts)
+	pool := positionCharacters(opts, buildCharacterSet(opts), [2]string{}, 0)
+	if pool == "" {
+		t.Fatalf("Expected letters, but got none")
+	}
+	for _, char := range pool {
+		if !unicode.IsLetter(char) {
+			t.Errorf("Expected a letter, but got %c", char)
+		}
+	}
+	if pool := positionCharacters(opts, buildCharacterSet(opts), [2]string{}, 1); !strings.ContainsAny(pool, Digits) {
+		t.Errorf("Expected digits after the first position, but got %q", pool)
+	}
+}
+
+// TestGeneratePasswords_EveryClass verifies that each enabled class appears
+// in every password, even when the password is as short as the class count.
+func TestGeneratePasswords_EveryClass(t *testing.T) {
+	tests := []struct {
+		name string
+		opts PasswordOptions
+	}{
+		{"one per class", PasswordOptions{Length: 4, IncludeSymbols: true, IncludeNumbers: true, IncludeUpper: true, IncludeLower: true}},
+		{"begin with letter", PasswordOptions{Length: 4, IncludeSymbols: true, IncludeNumbers: true, IncludeUpper: true, BeginWithLetter: true}},
+		{"single digit left", PasswordOptions{Length: 8, IncludeNumbers: true, IncludeLower: true, ExcludeCharacters: "012345689"}},
+		{"alternate hands", PasswordOptions{Length: 6, IncludeSymbols: true, IncludeNumbers: true, IncludeUpper: true, IncludeLower: true, AlternateHands: true, BeginWithLetter: true}},
+		{"no similar", PasswordOptions{Length: 5, IncludeNumbers: true, IncludeUpper: true, IncludeLower: true, NoSimilar: true}},
+	}
+	for _, tt := range tests {
+		tt.opts.Quantity = 300
+		passwords, err := GeneratePasswords(context.Background(), tt.opts)
 		if err != nil {
-			t.Errorf("Expected no error, but got %v", err)
+			t.Fatalf("%s: Expected no error, but got %v", tt.name, err)
 		}
-		if !unicode.IsLetter(rune(char)) {
-			t.Errorf("Expected a letter, but got %c", char)
+		for _, password := range passwords {
+			for _, class := range enabledClasses(tt.opts) {
+				if !strings.ContainsAny(password, class.chars) {
+					t.Errorf("%s: Expected %q to contain %s", tt.name, password, class.name)
+				}
+			}
+			if violations := Verify(password, tt.opts); len(violations) > 0 {
+				t.Errorf("%s: Expected %q to pass verification, but got %v", tt.name, password, violations)
+			}
+		}
+	}
+}
+
+// TestGeneratePasswords_TooShortForClasses verifies the error when the
+// length cannot hold one character of each class.
+func TestGeneratePasswords_TooShortForClasses(t *testing.T) {
+	opts := PasswordOptions{Length: 3, Quantity: 1, IncludeSymbols: true, IncludeNumbers: true, IncludeUpper: true, IncludeLower: true}
+	if _, err := GeneratePasswords(context.Background(), opts); ErrorCode(err) != CodeLengthBelowClasses {
+		t.Errorf("Expected %s, but got %v", CodeLengthBelowClasses, err)
+	}
+	opts.MustInclude = "#"
+	opts.Length = 4
+	if _, err := GeneratePasswords(context.Background(), opts); err != nil {
+		t.Errorf("Expected a required symbol to count for its class, but got %v", err)
+	}
+}
+
+// TestGeneratePasswords_Cancelled verifies that a done context stops the batch.
+func TestGeneratePasswords_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	opts := PasswordOptions{Length: 12, Quantity: 1000, IncludeLower: true}
+	if passwords, err := GeneratePasswords(ctx, opts); err != context.Canceled || passwords != nil {
+		t.Errorf("Expected context.Canceled and no passwords, but got %v and %d passwords", err, len(passwords))
+	}
+	if _, err := GeneratePINs(ctx, PINOptions{Length: 6, Quantity: 10}); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, but got %v", err)
+	}
+	if _, err := GeneratePatternPasswords(ctx, "Cvcvc", opts); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, but got %v", err)
+	}
+}
+
+// TestGeneratePasswords_ExactLength verifies that the no-similar,
+// no-duplicate and no-sequential options keep the requested length.
+func TestGeneratePasswords_ExactLength(t *testing.T) {
+	tests := []struct {
+		name string
+		opts PasswordOptions
+	}{
+		{"all three", PasswordOptions{Length: 20, IncludeNumbers: true, IncludeLower: true, NoSimilar: true, NoDuplicates: true, NoSequential: true}},
+		{"every distinct digit", PasswordOptions{Length: 10, IncludeNumbers: true, NoDuplicates: true, NoSequential: true}},
+		{"begin with letter", PasswordOptions{Length: 16, IncludeSymbols: true, IncludeNumbers: true, IncludeUpper: true, IncludeLower: true, BeginWithLetter: true, NoSimilar: true, NoDuplicates: true, NoSequential: true}},
+		{"must include", PasswordOptions{Length: 12, IncludeNumbers: true, IncludeLower: true, MustInclude: "#x7", NoSimilar: true, NoDuplicates: true, NoSequential: true}},
+		{"alternate hands", PasswordOptions{Length: 14, IncludeNumbers: true, IncludeUpper: true, IncludeLower: true, AlternateHands: true, NoSimilar: true, NoDuplicates: true, NoSequential: true}},
+	}
+	for _, tt := range tests {
+		tt.opts.Quantity = 200
+		passwords, err := GeneratePasswords(context.Background(), tt.opts)
+		if err != nil {
+			t.Fatalf("%s: Expected no error, but got %v", tt.name, err)
+		}
+		if err := VerifyPasswords(passwords, tt.opts); err != nil {
+			t.Errorf("%s: Expected every password to verify, but got %v", tt.name, err)
 		}
 	}
 }
@@ -0,0 +1,201 @@
+/**
+ * Pattern-Based Generation
+ *
+ * This file generates passwords from pwgen/KeePass-style pattern templates
+ * such as "Cvcvc-99-!!", so a password can satisfy a site-specific format.
+ * Each placeholder is replaced by a random character of its class; any other
+ * character is copied literally, and a backslash copies the next character
+ * literally even if it is a placeholder.
+ */
+
+package passgen
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Letter classes used by the pattern placeholders.
+const (
+	Vowels     = "aeiou"
+	Consonants = "bcdfghjklmnpqrstvwxyz"
+)
+
+// PatternPlaceholders maps each placeholder of a pattern to its characters.
+var PatternPlaceholders = map[rune]string{
+	'c': Consonants,
+	'C': strings.ToUpper(Consonants),
+	'v': Vowels,
+	'V': strings.ToUpper(Vowels),
+	'a': Lowercase,
+	'A': Uppercase,
+	'9': Digits,
+	'!': Symbols,
+	'*': Lowercase + Uppercase + Digits + Symbols,
+}
+
+// PatternPresets lists common patterns with a short description, in the
+// order they are offered.
+var PatternPresets = []struct {
+	Name    string
+	Pattern string
+}{
+	{"Pronounceable with digits and symbols", "Cvcvc-99-!!"},
+	{"Two syllable words and a number", "Cvcvcv.Cvcvcv.999"},
+	{"Word, digits, symbol", "Cvccvc9999!"},
+	{"Letters and digits in groups", "AAAA-9999-aaaa"},
+	{"License-key style groups", "AAAAA-AAAAA-AAAAA-AAAAA"},
+}
+
+// Pattern is a parsed pattern template: one character set per position.
+// Literal characters are sets of one character.
+type Pattern []string
+
+// ParsePattern parses a pattern template, dropping the characters in opts.ExcludeCharacters
+// and, with opts.NoSimilar, the similar characters from every placeholder.
+// Parameters:
+//   - template (string): The pattern, e.g. "Cvcvc-99-!!"; see PatternPlaceholders.
+//   - opts (PasswordOptions): Supplies ExcludeCharacters and NoSimilar.
+//
+// Returns:
+//
+//	Pattern: The character set of each position.
+//	error: An error if the pattern is empty, ends in a lone backslash, or a
+//	placeholder has no characters left after the exclusions.
+//
+// Example:
+//
+//	pattern, err := ParsePattern("Cvcvc-99-!!", opts)
+func ParsePattern(template string, opts PasswordOptions) (Pattern, error) {
+	if template == "" {
+		return nil, newError(CodePatternEmpty)
+	}
+	excluded := opts.ExcludeCharacters
+	if opts.NoSimilar {
+		excluded += similarCharacters
+	}
+
+	var pattern Pattern
+	runes := []rune(template)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\\' {
+			if i++; i == len(runes) {
+				return nil, newError(CodePatternTrailingEscape)
+			}
+			pattern = append(pattern, string(runes[i]))
+			continue
+		}
+		chars, ok := PatternPlaceholders[r]
+		if !ok {
+			pattern = append(pattern, string(r))
+			continue
+		}
+		if chars = removeCharacters(chars, excluded); chars == "" {
+			return nil, newError(CodePatternNoCharacters, string(r), len(pattern)+1)
+		}
+		pattern = append(pattern, chars)
+	}
+	return pattern, nil
+}
+
+// Generate returns one random password that follows the pattern, drawn
+// with crypto/rand.
+func (p Pattern) Generate() (string, error) {
+	return defaultGenerator.generatePattern(p)
+}
+
+// generatePattern returns one random password that follows p.
+func (g *Generator) generatePattern(p Pattern) (string, error) {
+	var password strings.Builder
+	for _, chars := range p {
+		if len([]rune(chars)) == 1 {
+			password.WriteString(chars)
+			continue
+		}
+		c, err := g.randomChar(chars)
+		if err != nil {
+			return "", err
+		}
+		password.WriteByte(c)
+	}
+	return password.String(), nil
+}
+
+// Entropy returns the entropy of a password generated from the pattern in bits.
+func (p Pattern) Entropy() float64 {
+	bits := 0.0
+	for _, chars := range p {
+		bits += math.Log2(float64(len([]rune(chars))))
+	}
+	return bits
+}
+
+// Matches reports whether password could have been generated from the pattern.
+func (p Pattern) Matches(password string) bool {
+	runes := []rune(password)
+	if len(runes) != len(p) {
+		return false
+	}
+	for i, chars := range p {
+		if !strings.ContainsRune(chars, runes[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify re-checks passwords generated from the pattern, like VerifyPasswords.
+func (p Pattern) Verify(passwords []string) error {
+	var failures []string
+	for i, password := range passwords {
+		if !p.Matches(password) {
+			failures = append(failures, fmt.Sprintf("password %d: pattern: does not follow the pattern", i+1))
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return newError(CodeVerificationFailed, strings.Join(failures, "\n  "))
+}
+
+// GeneratePatternPasswords generates opts.Quantity passwords from template.
+// Purpose:
+//
+//	Only Quantity, ExcludeCharacters and NoSimilar of opts apply: the
+//	pattern itself fixes the length and the class of every position.
+//
+// Parameters:
+//   - ctx (context.Context): Cancels a long batch between two passwords.
+//   - template (string): The pattern, e.g. "Cvcvc-99-!!".
+//   - opts (PasswordOptions): Supplies Quantity and the exclusions.
+//
+// Returns:
+//
+//	[]string: The generated passwords.
+//	error: An error if the pattern is invalid, randomness fails, or ctx is
+//	done (ctx.Err()).
+//
+// Example:
+//
+//	passwords, err := g.GeneratePatternPasswords(ctx, "Cvcvc-99-!!", opts)
+func (g *Generator) GeneratePatternPasswords(ctx context.Context, template string, opts PasswordOptions) ([]string, error) {
+	pattern, err := ParsePattern(template, opts)
+	if err != nil {
+		return nil, err
+	}
+	var passwords []string
+	for i := 0; i < opts.Quantity; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		password, err := g.generatePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		passwords = append(passwords, password)
+	}
+	return passwords, nil
+}
@@ -0,0 +1,59 @@
+package passgen
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestGeneratePatternPasswords checks that every position follows its placeholder.
+func TestGeneratePatternPasswords(t *testing.T) {
+	passwords, err := GeneratePatternPasswords(context.Background(), `Cvcvc-99-!!\9`, PasswordOptions{Quantity: 50})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(passwords) != 50 {
+		t.Fatalf("Expected 50 passwords, but got %d", len(passwords))
+	}
+	classes := []string{strings.ToUpper(Consonants), Vowels, Consonants, Vowels, Consonants, "-", Digits, Digits, "-", Symbols, Symbols, "9"}
+	for _, password := range passwords {
+		if len(password) != len(classes) {
+			t.Fatalf("Expected length %d, but got %q", len(classes), password)
+		}
+		for i, chars := range classes {
+			if !strings.ContainsRune(chars, rune(password[i])) {
+				t.Errorf("Expected position %d of %q to be one of %q", i+1, password, chars)
+			}
+		}
+	}
+}
+
+// TestParsePattern_Exclusions checks exclusions, entropy, matching and errors.
+func TestParsePattern_Exclusions(t *testing.T) {
+	pattern, err := ParsePattern("99a", PasswordOptions{ExcludeCharacters: "0123", NoSimilar: true})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if pattern[0] != "456789" || strings.ContainsAny(pattern[2], "ilo") {
+		t.Errorf("Expected exclusions and similar characters removed, but got %q", pattern)
+	}
+	if want := 2*math.Log2(6) + math.Log2(23); math.Abs(pattern.Entropy()-want) > 1e-9 {
+		t.Errorf("Expected entropy %.2f, but got %.2f", want, pattern.Entropy())
+	}
+	if !pattern.Matches("45x") || pattern.Matches("40x") || pattern.Matches("45") {
+		t.Errorf("Expected Matches to accept only passwords that follow the pattern")
+	}
+
+	tests := map[string]Code{
+		"":    CodePatternEmpty,
+		`ab\`: CodePatternTrailingEscape,
+		"v9":  CodePatternNoCharacters,
+	}
+	for template, want := range tests {
+		_, err := ParsePattern(template, PasswordOptions{ExcludeCharacters: Vowels})
+		if got := ErrorCode(err); got != want {
+			t.Errorf("Expected %s for %q, but got %v", want, template, err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+/**
+ * PIN Generation
+ *
+ * This file generates numeric PINs and rejects the ones people guess first:
+ * repeated digits, runs such as 1234, repeated patterns, common PINs, and
+ * numbers that look like a year or a date.
+ */
+
+package passgen
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits of the PIN length.
+const (
+	MinPINLength = 4
+	MaxPINLength = 12
+)
+
+// maxPINAttempts bounds the retries when a generated PIN is weak.
+const maxPINAttempts = 1000
+
+// PINOptions holds the settings for PIN generation.
+// Fields:
+//   - MinLength, MaxLength, DefaultLength (int): Length limits and default.
+//   - Length (int): Number of digits of each PIN.
+//   - Quantity (int): Number of PINs to generate.
+type PINOptions struct {
+	MinLength     int
+	MaxLength     int
+	DefaultLength int
+	Length        int
+	Quantity      int
+}
+
+// commonPINs lists PINs that are weak without matching any pattern below.
+var commonPINs = map[string]bool{
+	"1004": true, "2580": true, "0852": true, "1379": true, "1397": true,
+	"6969": true, "1010": true, "5683": true, "2468": true, "8520": true,
+	"147258": true, "159753": true, "112233": true, "121212": true,
+}
+
+// GeneratePINs generates PINs that pass WeakPIN.
+// Parameters:
+//   - ctx (context.Context): Cancels a long batch between two PINs.
+//   - opts (PINOptions): Length and quantity of the PINs.
+//
+// Returns:
+//
+//	[]string: The generated PINs.
+//	error: An error if the length is outside MinPINLength-MaxPINLength, or
+//	ctx.Err() once ctx is done.
+//
+// Example:
+//
+//	pins, err := g.GeneratePINs(ctx, PINOptions{Length: 6, Quantity: 1})
+func (g *Generator) GeneratePINs(ctx context.Context, opts PINOptions) ([]string, error) {
+	if opts.Length < MinPINLength || opts.Length > MaxPINLength {
+		return nil, newError(CodePINLength, MinPINLength, MaxPINLength)
+	}
+	var pins []string
+	for i := 0; i < opts.Quantity; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		pin, err := g.generatePIN(opts.Length)
+		if err != nil {
+			return nil, err
+		}
+		pins = append(pins, pin)
+	}
+	return pins, nil
+}
+
+// generatePIN draws random PINs until one is not weak.
+func (g *Generator) generatePIN(length int) (string, error) {
+	pin := make([]byte, length)
+	for attempt := 0; attempt < maxPINAttempts; attempt++ {
+		for i := range pin {
+			digit, err := g.randomChar(Digits)
+			if err != nil {
+				return "", err
+			}
+			pin[i] = digit
+		}
+		if WeakPIN(string(pin)) == "" {
+			return string(pin), nil
+		}
+	}
+	return "", newError(CodeNoStrongPIN)
+}
+
+// WeakPIN reports why a PIN is easy to guess.
+// Parameters:
+//   - pin (string): The PIN to check.
+//
+// Returns:
+//
+//	string: The reason, e.g. "sequence" or "looks like a date", "empty" or
+//	"not only digits" for input that is no PIN, or "" if the PIN shows no
+//	known weakness.
+//
+// Example:
+//
+//	if reason := WeakPIN("1984"); reason != "" {
+//		fmt.Println("weak PIN:", reason)
+//	}
+func WeakPIN(pin string) string {
+	switch {
+	case pin == "":
+		return "empty"
+	case strings.Trim(pin, Digits) != "":
+		return "not only digits"
+	case strings.Count(pin, pin[:1]) == len(pin):
+		return "repeated digit"
+	case isDigitSequence(pin):
+		return "sequence"
+	case isRepeatedPattern(pin):
+		return "repeated pattern"
+	case commonPINs[pin]:
+		return "common PIN"
+	case len(pin) == 4 && looksLikeYear(pin):
+		return "looks like a year"
+	case looksLikeDate(pin):
+		return "looks like a date"
+	}
+	return ""
+}
+
+// isDigitSequence reports whether every digit is one more, or one less, than
+// the previous one, wrapping around, as in 7890 or 3210.
+func isDigitSequence(pin string) bool {
+	for _, step := range []int{1, 9} {
+		sequence := true
+		for i := 1; i < len(pin); i++ {
+			if (int(pin[i]-'0')-int(pin[i-1]-'0')+10)%10 != step {
+				sequence = false
+				break
+			}
+		}
+		if sequence {
+			return true
+		}
+	}
+	return false
+}
+
+// isRepeatedPattern reports whether the PIN repeats a shorter block, as in
+// 1212 or 123123.
+func isRepeatedPattern(pin string) bool {
+	for size := 1; size <= len(pin)/2; size++ {
+		if len(pin)%size == 0 && strings.Repeat(pin[:size], len(pin)/size) == pin {
+			return true
+		}
+	}
+	return false
+}
+
+// looksLikeYear reports whether a four-digit PIN is a plausible birth year.
+func looksLikeYear(pin string) bool {
+	year, _ := strconv.Atoi(pin)
+	return year >= 1900 && year <= time.Now().Year()
+}
+
+// dateLayouts lists the date formats a PIN is compared against, by length.
+var dateLayouts = map[int][]string{
+	4: {"0201", "0102"},
+	6: {"020106", "010206", "060102"},
+	8: {"02012006", "01022006", "20060102"},
+}
+
+// looksLikeDate reports whether the PIN reads as a valid calendar date, such
+// as a birthday in DDMM, MMDD, DDMMYY or YYYYMMDD form.
+func looksLikeDate(pin string) bool {
+	for _, layout := range dateLayouts[len(pin)] {
+		if _, err := time.Parse(layout, pin); err == nil {
+			return true
+		}
+	}
+	return false
+}
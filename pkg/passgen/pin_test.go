@@ -0,0 +1,65 @@
+package passgen
+
+import (
+	"context"
+	"testing"
+)
+
+// TestGeneratePINs verifies the length, digits and strength of generated PINs.
+func TestGeneratePINs(t *testing.T) {
+	for _, length := range []int{MinPINLength, 6, 8, MaxPINLength} {
+		pins, err := GeneratePINs(context.Background(), PINOptions{Length: length, Quantity: 20})
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		for _, pin := range pins {
+			if len(pin) != length {
+				t.Errorf("Expected a PIN of length %d, but got %s", length, pin)
+			}
+			for _, r := range pin {
+				if r < '0' || r > '9' {
+					t.Errorf("Expected only digits, but got %s", pin)
+					break
+				}
+			}
+			if reason := WeakPIN(pin); reason != "" {
+				t.Errorf("Expected a strong PIN, but %s is weak: %s", pin, reason)
+			}
+		}
+	}
+}
+
+// TestGeneratePINs_Length verifies that lengths outside the limits are rejected.
+func TestGeneratePINs_Length(t *testing.T) {
+	for _, length := range []int{MinPINLength - 1, MaxPINLength + 1} {
+		if _, err := GeneratePINs(context.Background(), PINOptions{Length: length, Quantity: 1}); err == nil {
+			t.Errorf("Expected an error for length %d, but got none", length)
+		}
+	}
+}
+
+// TestWeakPIN verifies each class of weak PIN.
+func TestWeakPIN(t *testing.T) {
+	tests := map[string]string{
+		"0000":     "repeated digit",
+		"1234":     "sequence",
+		"7890":     "sequence",
+		"987654":   "sequence",
+		"1212":     "repeated pattern",
+		"123123":   "repeated pattern",
+		"2580":     "common PIN",
+		"1987":     "looks like a year",
+		"2512":     "looks like a date",
+		"19840229": "looks like a date",
+		"4829":     "",
+		"730561":   "",
+		"":         "empty",
+		"12a4":     "not only digits",
+		"١٢٣٤":     "not only digits",
+	}
+	for pin, want := range tests {
+		if got := WeakPIN(pin); got != want {
+			t.Errorf("Expected %q for %s, but got %q", want, pin, got)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+/**
+ * Recovery Code Generation
+ *
+ * This file generates sets of one-time recovery codes, the backup codes a
+ * service hands out when two-factor login is enabled. Codes are groups of
+ * uppercase letters and digits without look-alikes, such as 7KQP-M3XD, so
+ * they can be read off paper and typed without confusion, and no code
+ * repeats within a set.
+ */
+
+package passgen
+
+import (
+	"context"
+	"strings"
+)
+
+// RecoveryCharset holds the characters of recovery codes: digits and
+// uppercase letters without 0, 1, I, L and O.
+const RecoveryCharset = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// Limits and defaults of recovery code sets.
+const (
+	MaxRecoveryCodes         = 100
+	MaxRecoveryGroups        = 8
+	MinRecoveryGroupSize     = 2
+	MaxRecoveryGroupSize     = 8
+	DefaultRecoveryCodes     = 10
+	DefaultRecoveryGroups    = 2
+	DefaultRecoveryGroupSize = 4
+)
+
+// RecoveryOptions holds the settings of a set of recovery codes.
+// Fields:
+//   - Codes (int): Number of codes in the set.
+//   - Groups (int): Number of groups of each code.
+//   - GroupSize (int): Characters per group.
+//   - Separator (string): Placed between groups, e.g. "-".
+type RecoveryOptions struct {
+	Codes     int
+	Groups    int
+	GroupSize int
+	Separator string
+}
+
+// DefaultRecoveryOptions returns ten codes of the form XXXX-XXXX.
+func DefaultRecoveryOptions() RecoveryOptions {
+	return RecoveryOptions{Codes: DefaultRecoveryCodes, Groups: DefaultRecoveryGroups, GroupSize: DefaultRecoveryGroupSize, Separator: "-"}
+}
+
+// GenerateRecoveryCodes generates a set of distinct recovery codes.
+// Parameters:
+//   - ctx (context.Context): Cancels a long set between two codes.
+//   - opts (RecoveryOptions): Number and format of the codes.
+//
+// Returns:
+//
+//	[]string: The codes, formatted with the separator.
+//	error: An error if the number or format is outside the limits, or
+//	ctx.Err() once ctx is done.
+//
+// Example:
+//
+//	codes, err := g.GenerateRecoveryCodes(ctx, DefaultRecoveryOptions())
+func (g *Generator) GenerateRecoveryCodes(ctx context.Context, opts RecoveryOptions) ([]string, error) {
+	if opts.Codes < 1 || opts.Codes > MaxRecoveryCodes {
+		return nil, newError(CodeRecoveryCount, MaxRecoveryCodes)
+	}
+	if opts.Groups < 1 || opts.Groups > MaxRecoveryGroups || opts.GroupSize < MinRecoveryGroupSize || opts.GroupSize > MaxRecoveryGroupSize {
+		return nil, newError(CodeRecoveryFormat, MaxRecoveryGroups, MinRecoveryGroupSize, MaxRecoveryGroupSize)
+	}
+	seen := make(map[string]bool, opts.Codes)
+	codes := make([]string, 0, opts.Codes)
+	for len(codes) < opts.Codes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		groups := make([]string, opts.Groups)
+		for i := range groups {
+			group := make([]byte, opts.GroupSize)
+			for j := range group {
+				c, err := g.randomChar(RecoveryCharset)
+				if err != nil {
+					return nil, err
+				}
+				group[j] = c
+			}
+			groups[i] = string(group)
+		}
+		// Even the smallest format has 31^2 codes; a repeat is drawn again.
+		if key := strings.Join(groups, ""); !seen[key] {
+			seen[key] = true
+			codes = append(codes, strings.Join(groups, opts.Separator))
+		}
+	}
+	return codes, nil
+}
+
+// NormalizeRecoveryCode returns a code as typed by a user in the form it is
+// hashed and compared in: in uppercase, without separators or spaces.
+// Example:
+//
+//	NormalizeRecoveryCode("7kqp-m3xd") // "7KQPM3XD"
+func NormalizeRecoveryCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return -1
+	}, strings.ToUpper(code))
+}
@@ -0,0 +1,73 @@
+package passgen
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestGenerateRecoveryCodes verifies the format, characters and uniqueness
+// of a set of recovery codes.
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(context.Background(), DefaultRecoveryOptions())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(codes) != DefaultRecoveryCodes {
+		t.Fatalf("Expected %d codes, but got %d", DefaultRecoveryCodes, len(codes))
+	}
+	seen := map[string]bool{}
+	for _, code := range codes {
+		groups := strings.Split(code, "-")
+		if len(groups) != 2 || len(groups[0]) != 4 || len(groups[1]) != 4 {
+			t.Errorf("Expected a code of the form XXXX-XXXX, but got %s", code)
+		}
+		for _, r := range NormalizeRecoveryCode(code) {
+			if !strings.ContainsRune(RecoveryCharset, r) {
+				t.Errorf("Expected no look-alike characters, but got %s", code)
+			}
+		}
+		if seen[code] {
+			t.Errorf("Expected distinct codes, but got %s twice", code)
+		}
+		seen[code] = true
+	}
+
+	// The smallest format cannot hold duplicates without being redrawn.
+	codes, err = GenerateRecoveryCodes(context.Background(), RecoveryOptions{Codes: MaxRecoveryCodes, Groups: 1, GroupSize: MinRecoveryGroupSize})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	seen = map[string]bool{}
+	for _, code := range codes {
+		if seen[code] {
+			t.Errorf("Expected distinct codes, but got %s twice", code)
+		}
+		seen[code] = true
+	}
+}
+
+// TestGenerateRecoveryCodes_Limits verifies that counts and formats outside
+// the limits are rejected.
+func TestGenerateRecoveryCodes_Limits(t *testing.T) {
+	for _, opts := range []RecoveryOptions{
+		{Codes: 0, Groups: 2, GroupSize: 4},
+		{Codes: MaxRecoveryCodes + 1, Groups: 2, GroupSize: 4},
+		{Codes: 10, Groups: 0, GroupSize: 4},
+		{Codes: 10, Groups: 2, GroupSize: MinRecoveryGroupSize - 1},
+		{Codes: 10, Groups: 2, GroupSize: MaxRecoveryGroupSize + 1},
+	} {
+		if _, err := GenerateRecoveryCodes(context.Background(), opts); err == nil {
+			t.Errorf("Expected an error for %+v, but got none", opts)
+		}
+	}
+}
+
+// TestNormalizeRecoveryCode verifies that typed variants of a code match.
+func TestNormalizeRecoveryCode(t *testing.T) {
+	for _, typed := range []string{"7KQP-M3XD", "7kqp m3xd", " 7KQPM3XD\n"} {
+		if got := NormalizeRecoveryCode(typed); got != "7KQPM3XD" {
+			t.Errorf("Expected 7KQPM3XD for %q, but got %s", typed, got)
+		}
+	}
+}
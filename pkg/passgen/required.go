@@ -0,0 +1,153 @@
+package passgen
+
+import (
+	"errors"
+	"strings"
+)
+
+// errUnplaced makes generatePassword retry with fresh random characters when
+// the required characters found no valid positions.
+var errUnplaced = errors.New("required characters could not be placed")
+
+// charClass is one enabled character class with its usable characters.
+type charClass struct {
+	name  string
+	chars string
+}
+
+// enabledClasses returns the enabled classes, without excluded characters
+// and, with NoSimilar, without similar ones. Classes left empty are omitted.
+func enabledClasses(opts PasswordOptions) []charClass {
+	var classes []charClass
+	for _, class := range []struct {
+		enabled bool
+		charClass
+	}{
+		{opts.IncludeSymbols, charClass{"symbols", Symbols}},
+		{opts.IncludeNumbers, charClass{"digits", Digits}},
+		{opts.IncludeUpper, charClass{"uppercase", Uppercase}},
+		{opts.IncludeLower, charClass{"lowercase", Lowercase}},
+	} {
+		if !class.enabled {
+			continue
+		}
+		chars := removeCharacters(class.chars, opts.ExcludeCharacters)
+		if opts.NoSimilar {
+			chars = removeSimilarCharacters(chars)
+		}
+		if chars != "" {
+			classes = append(classes, charClass{class.name, chars})
+		}
+	}
+	return classes
+}
+
+// missingClasses returns the enabled classes that MustInclude does not cover
+// already; each needs one guaranteed character.
+func missingClasses(opts PasswordOptions) []charClass {
+	var missing []charClass
+	for _, class := range enabledClasses(opts) {
+		if !strings.ContainsAny(opts.MustInclude, class.chars) {
+			missing = append(missing, class)
+		}
+	}
+	return missing
+}
+
+// requiredCount returns how many positions the guaranteed characters take.
+func requiredCount(opts PasswordOptions) int {
+	return len(opts.MustInclude) + len(missingClasses(opts))
+}
+
+// meetsRequirements reports whether password contains every MustInclude
+// character and a character of every enabled class.
+func meetsRequirements(password string, opts PasswordOptions) bool {
+	if !containsAll(password, opts.MustInclude) {
+		return false
+	}
+	for _, class := range enabledClasses(opts) {
+		if !strings.ContainsAny(password, class.chars) {
+			return false
+		}
+	}
+	return true
+}
+
+// placeRequired writes the required characters over distinct positions of
+// password drawn at random, which shuffles them securely among the
+// random characters. Positions keep the other options intact: the first
+// character stays an enabled letter with BeginWithLetter, and with
+// AlternateHands a character only goes where its hand is due. It returns
+// errUnplaced if a character has no position left.
+func (g *Generator) placeRequired(password []byte, opts PasswordOptions, hands [2]string, required string) error {
+	letters := letterCharacters(opts)
+	var halves [2]string
+	if opts.AlternateHands {
+		halves = handHalves(opts, hands)
+	}
+	used := make([]bool, len(password))
+	for i := 0; i < len(required); i++ {
+		c := required[i]
+		var candidates []int
+		for p := range password {
+			switch {
+			case used[p]:
+			case p == 0 && opts.BeginWithLetter && strings.IndexByte(letters, c) < 0:
+			case opts.AlternateHands && !strings.ContainsRune(halves[p%2], rune(c)):
+			default:
+				candidates = append(candidates, p)
+			}
+		}
+		if len(candidates) == 0 {
+			return errUnplaced
+		}
+		index, err := g.intn(len(candidates))
+		if err != nil {
+			return err
+		}
+		p := candidates[index]
+		password[p] = c
+		used[p] = true
+	}
+	return nil
+}
+
+// handHalves returns the layout keys typed at even and at odd positions,
+// given the shuffled hand sets of a password.
+func handHalves(opts PasswordOptions, hands [2]string) [2]string {
+	name := opts.KeyboardLayout
+	if name == "" {
+		name = DefaultHandLayout
+	}
+	layout := HandLayouts[name]
+	if hands[0] != "" && strings.ContainsRune(layout.Left, rune(hands[0][0])) {
+		return [2]string{layout.Left, layout.Right}
+	}
+	return [2]string{layout.Right, layout.Left}
+}
+
+// containsAll reports whether password contains every character of required.
+func containsAll(password, required string) bool {
+	for _, r := range required {
+		if !strings.ContainsRune(password, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// invalidRequired returns the first MustInclude character that can never be
+// used: one outside printable ASCII or listed in ExcludeCharacters.
+func invalidRequired(opts PasswordOptions) (rune, bool) {
+	for _, r := range opts.MustInclude {
+		if r < '!' || r > '~' || strings.ContainsRune(opts.ExcludeCharacters, r) {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+// hasRepeats reports whether s lists a character more than once.
+func hasRepeats(s string) bool {
+	return len(removeDuplicateCharacters(s)) != len(s)
+}
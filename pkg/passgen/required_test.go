@@ -0,0 +1,66 @@
+package passgen
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestMustInclude verifies that every required character appears in every password.
+func TestMustInclude(t *testing.T) {
+	opts := PasswordOptions{Length: 8, Quantity: 200, IncludeLower: true, BeginWithLetter: true, MustInclude: "#7Q"}
+	passwords, err := GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, password := range passwords {
+		if !containsAll(password, opts.MustInclude) {
+			t.Errorf("Expected %q to contain %q", password, opts.MustInclude)
+		}
+		if strings.ContainsAny(password[:1], "#7") {
+			t.Errorf("Expected %q to begin with a letter", password)
+		}
+		if violations := Verify(password, opts); len(violations) > 0 {
+			t.Errorf("Expected %q to pass verification, but got %v", password, violations)
+		}
+	}
+}
+
+// TestMustInclude_AlternateHands places required characters where their hand is due.
+func TestMustInclude_AlternateHands(t *testing.T) {
+	opts := PasswordOptions{Length: 10, Quantity: 100, IncludeLower: true, AlternateHands: true, MustInclude: "aK"}
+	passwords, err := GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, password := range passwords {
+		if violations := Verify(password, opts); len(violations) > 0 {
+			t.Errorf("Expected %q to pass verification, but got %v", password, violations)
+		}
+	}
+
+	opts.Length, opts.MustInclude = 3, "asd"
+	if _, err := GeneratePasswords(context.Background(), opts); ErrorCode(err) != CodeMustIncludeUnplaceable {
+		t.Errorf("Expected %s for three left-hand keys in three positions, but got %v", CodeMustIncludeUnplaceable, err)
+	}
+}
+
+// TestMustInclude_Conflicts verifies that impossible requirements fail with a clear code.
+func TestMustInclude_Conflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		opts PasswordOptions
+		code Code
+	}{
+		{"longer than the password", PasswordOptions{Length: 2, IncludeLower: true, MustInclude: "abc"}, CodeMustIncludeTooLong},
+		{"repeats without duplicates", PasswordOptions{Length: 8, IncludeLower: true, NoDuplicates: true, MustInclude: "aa"}, CodeMustIncludeRepeats},
+		{"excluded", PasswordOptions{Length: 8, IncludeLower: true, ExcludeCharacters: "#", MustInclude: "#"}, CodeMustIncludeInvalid},
+		{"not ASCII", PasswordOptions{Length: 8, IncludeLower: true, MustInclude: "é"}, CodeMustIncludeInvalid},
+		{"similar", PasswordOptions{Length: 8, IncludeLower: true, NoSimilar: true, MustInclude: "l"}, CodeMustIncludeSimilar},
+	}
+	for _, tt := range tests {
+		if code := ErrorCode(Validate(tt.opts)); code != tt.code {
+			t.Errorf("%s: Expected %s, but got %q", tt.name, tt.code, code)
+		}
+	}
+}
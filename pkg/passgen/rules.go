@@ -0,0 +1,181 @@
+/**
+ * Option Rules
+ *
+ * This file holds the dependencies between password options as a table. The
+ * same table validates options before generation and drives the GUI, which
+ * disables or adjusts dependent controls instead of letting users pick
+ * combinations that cannot work.
+ */
+
+package passgen
+
+import "strings"
+
+// OptionRule describes one dependency between password options.
+// Fields:
+//   - Option (string): The PasswordOptions field the rule constrains, e.g.
+//     "BeginWithLetter" or "Length".
+//   - Code (Code): Identifies the dependency; Validate returns it as an
+//     *Error, whose message explains it in the user's locale.
+//   - Args (func): Returns the arguments of the message; nil if it has none.
+//   - Violated (func): Reports whether opts break the rule.
+//   - Adjust (func): Changes Option so that opts meet the rule; nil if the
+//     user has to decide, e.g. which character class to enable.
+type OptionRule struct {
+	Option   string
+	Code     Code
+	Args     func(opts PasswordOptions) []interface{}
+	Violated func(opts PasswordOptions) bool
+	Adjust   func(opts *PasswordOptions)
+}
+
+// OptionRules lists the dependencies checked by Validate, in order.
+var OptionRules = []OptionRule{
+	{
+		Option:   "IncludeLower",
+		Code:     CodeNoCharacterTypes,
+		Violated: func(opts PasswordOptions) bool { return buildCharacterSet(opts) == "" },
+	},
+	{
+		Option:   "Length",
+		Code:     CodeLengthTooShort,
+		Violated: func(opts PasswordOptions) bool { return opts.Length < 1 },
+		Adjust:   func(opts *PasswordOptions) { opts.Length = 1 },
+	},
+	{
+		Option:   "BeginWithLetter",
+		Code:     CodeBeginNeedsLetters,
+		Violated: func(opts PasswordOptions) bool { return opts.BeginWithLetter && letterCharacters(opts) == "" },
+		Adjust:   func(opts *PasswordOptions) { opts.BeginWithLetter = false },
+	},
+	{
+		Option: "Length",
+		Code:   CodeLengthExceedsUnique,
+		Violated: func(opts PasswordOptions) bool {
+			return opts.NoDuplicates && opts.Length > UniqueCharacterCount(opts)
+		},
+		Adjust: func(opts *PasswordOptions) { opts.Length = UniqueCharacterCount(*opts) },
+	},
+	{
+		Option: "KeyboardLayout",
+		Code:   CodeUnknownLayout,
+		Args:   func(opts PasswordOptions) []interface{} { return []interface{}{opts.KeyboardLayout} },
+		Violated: func(opts PasswordOptions) bool {
+			_, ok := HandLayouts[opts.KeyboardLayout]
+			return opts.AlternateHands && opts.KeyboardLayout != "" && !ok
+		},
+		Adjust: func(opts *PasswordOptions) { opts.KeyboardLayout = DefaultHandLayout },
+	},
+	{
+		Option: "MustInclude",
+		Code:   CodeMustIncludeInvalid,
+		Args: func(opts PasswordOptions) []interface{} {
+			r, _ := invalidRequired(opts)
+			return []interface{}{r}
+		},
+		Violated: func(opts PasswordOptions) bool {
+			_, invalid := invalidRequired(opts)
+			return invalid
+		},
+	},
+	{
+		Option: "NoSimilar",
+		Code:   CodeMustIncludeSimilar,
+		Violated: func(opts PasswordOptions) bool {
+			return opts.NoSimilar && strings.ContainsAny(opts.MustInclude, similarCharacters)
+		},
+		Adjust: func(opts *PasswordOptions) { opts.NoSimilar = false },
+	},
+	{
+		Option:   "NoDuplicates",
+		Code:     CodeMustIncludeRepeats,
+		Violated: func(opts PasswordOptions) bool { return opts.NoDuplicates && hasRepeats(opts.MustInclude) },
+		Adjust:   func(opts *PasswordOptions) { opts.NoDuplicates = false },
+	},
+	{
+		Option: "Length",
+		Code:   CodeMustIncludeTooLong,
+		Args: func(opts PasswordOptions) []interface{} {
+			return []interface{}{len(opts.MustInclude), opts.Length}
+		},
+		Violated: func(opts PasswordOptions) bool { return len(opts.MustInclude) > opts.Length },
+		Adjust:   func(opts *PasswordOptions) { opts.Length = len(opts.MustInclude) },
+	},
+	{
+		Option: "Length",
+		Code:   CodeLengthBelowClasses,
+		Args: func(opts PasswordOptions) []interface{} {
+			return []interface{}{opts.Length, requiredCount(opts)}
+		},
+		Violated: func(opts PasswordOptions) bool { return opts.Length < requiredCount(opts) },
+		Adjust:   func(opts *PasswordOptions) { opts.Length = requiredCount(*opts) },
+	},
+	{
+		Option:   "GroupSize",
+		Code:     CodeGroupSize,
+		Violated: func(opts PasswordOptions) bool { return opts.GroupSize < 0 },
+		Adjust:   func(opts *PasswordOptions) { opts.GroupSize = 0 },
+	},
+	{
+		Option:   "GroupSeparator",
+		Code:     CodeGroupSeparator,
+		Violated: invalidGroupSeparator,
+		Adjust:   func(opts *PasswordOptions) { opts.GroupSeparator = DefaultGroupSeparator },
+	},
+	{
+		Option: "MinEntropy",
+		Code:   CodeBelowEntropyFloor,
+		Args: func(opts PasswordOptions) []interface{} {
+			length, _ := LengthForEntropy(opts, opts.MinEntropy)
+			return []interface{}{EstimateEntropy(opts), opts.MinEntropy, length}
+		},
+		Violated: func(opts PasswordOptions) bool {
+			_, reachable := LengthForEntropy(opts, opts.MinEntropy)
+			return belowFloor(opts) && reachable
+		},
+	},
+	{
+		Option: "MinEntropy",
+		Code:   CodeBelowEntropyFloorClasses,
+		Args: func(opts PasswordOptions) []interface{} {
+			return []interface{}{EstimateEntropy(opts), opts.MinEntropy}
+		},
+		Violated: func(opts PasswordOptions) bool {
+			_, reachable := LengthForEntropy(opts, opts.MinEntropy)
+			return belowFloor(opts) && !reachable
+		},
+	},
+}
+
+// UniqueCharacterCount returns how many different characters the options
+// allow, which bounds the length of a password without duplicates.
+func UniqueCharacterCount(opts PasswordOptions) int {
+	chars := removeDuplicateCharacters(buildCharacterSet(opts) + opts.MustInclude)
+	if opts.NoSimilar {
+		chars = removeSimilarCharacters(chars)
+	}
+	return len(chars)
+}
+
+// Validate checks opts against OptionRules.
+// Returns:
+//
+//	error: An *Error with the code of the first rule opts break, or nil.
+//
+// Example:
+//
+//	if err := passgen.Validate(opts); err != nil {
+//		return err
+//	}
+func Validate(opts PasswordOptions) error {
+	for _, rule := range OptionRules {
+		if rule.Violated(opts) {
+			err := &Error{Code: rule.Code}
+			if rule.Args != nil {
+				err.Args = rule.Args(opts)
+			}
+			return err
+		}
+	}
+	return nil
+}
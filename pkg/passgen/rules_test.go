@@ -0,0 +1,49 @@
+package passgen
+
+import (
+	"testing"
+)
+
+// TestValidate verifies that each dependency between options is reported.
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  PasswordOptions
+		valid bool
+	}{
+		{"valid", PasswordOptions{Length: 12, IncludeLower: true, BeginWithLetter: true}, true},
+		{"no character types", PasswordOptions{Length: 12}, false},
+		{"zero length", PasswordOptions{Length: 0, IncludeLower: true}, false},
+		{"begin with letter without letters", PasswordOptions{Length: 12, IncludeNumbers: true, BeginWithLetter: true}, false},
+		{"no duplicates within pool", PasswordOptions{Length: 10, IncludeNumbers: true, NoDuplicates: true}, true},
+		{"no duplicates beyond pool", PasswordOptions{Length: 11, IncludeNumbers: true, NoDuplicates: true}, false},
+		{"no duplicates beyond pool without similar", PasswordOptions{Length: 10, IncludeNumbers: true, NoDuplicates: true, NoSimilar: true}, false},
+		{"unknown layout", PasswordOptions{Length: 12, IncludeLower: true, AlternateHands: true, KeyboardLayout: "nope"}, false},
+	}
+	for _, tt := range tests {
+		err := Validate(tt.opts)
+		if tt.valid && err != nil {
+			t.Errorf("%s: Expected no error, but got %v", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: Expected an error, but got none", tt.name)
+		}
+	}
+}
+
+// TestOptionRules_Adjust verifies that every adjustable rule is met after adjusting.
+func TestOptionRules_Adjust(t *testing.T) {
+	opts := PasswordOptions{Length: 40, IncludeNumbers: true, BeginWithLetter: true, NoDuplicates: true, AlternateHands: true, KeyboardLayout: "nope"}
+	for _, rule := range OptionRules {
+		if rule.Adjust == nil || !rule.Violated(opts) {
+			continue
+		}
+		rule.Adjust(&opts)
+		if rule.Violated(opts) {
+			t.Errorf("Expected the %s rule to be met after adjusting, but got %+v", rule.Option, opts)
+		}
+	}
+	if err := Validate(opts); err != nil {
+		t.Errorf("Expected adjusted options to validate, but got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package passgen
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Result is one password from GenerateStream, or the error that ended the stream.
+type Result struct {
+	Password string
+	Err      error
+}
+
+// GenerateStream generates opts.Quantity passwords concurrently and delivers
+// them on a channel as they are made.
+// Purpose:
+//
+//	Lets callers consume millions of passwords without holding them all in
+//	memory, unlike GeneratePasswords. One goroutine per CPU generates; the
+//	order of the passwords carries no meaning. The channel is closed after
+//	the last password, after a Result with Err set, or once ctx is done;
+//	callers that stop reading early must cancel ctx.
+//
+// Parameters:
+//   - ctx (context.Context): Stops the generation when done.
+//   - opts (PasswordOptions): The generation options, including Quantity.
+//
+// Returns:
+//
+//	<-chan Result: The passwords, as they are generated.
+//	error: An error if the options break one of the OptionRules.
+//
+// Example:
+//
+//	results, err := g.GenerateStream(ctx, opts)
+//	for r := range results {
+//		if r.Err != nil {
+//			return r.Err
+//		}
+//		fmt.Println(r.Password)
+//	}
+func (g *Generator) GenerateStream(ctx context.Context, opts PasswordOptions) (<-chan Result, error) {
+	if err := Validate(opts); err != nil {
+		return nil, err
+	}
+	workers := runtime.GOMAXPROCS(0)
+	out := make(chan Result, workers*64)
+	remaining := int64(opts.Quantity)
+	var failed atomic.Bool
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil && !failed.Load() && atomic.AddInt64(&remaining, -1) >= 0 {
+				password, err := g.generatePassword(opts)
+				r := Result{Password: Group(password, opts), Err: err}
+				if err != nil {
+					// Only the first error is delivered; the stream ends with it.
+					if !failed.CompareAndSwap(false, true) {
+						return
+					}
+					r.Password = ""
+				}
+				select {
+				case out <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out, nil
+}
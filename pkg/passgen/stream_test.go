@@ -0,0 +1,50 @@
+package passgen
+
+import (
+	"context"
+	"testing"
+)
+
+// TestGenerateStream delivers exactly Quantity valid passwords.
+func TestGenerateStream(t *testing.T) {
+	opts := PasswordOptions{Length: 12, Quantity: 5000, IncludeNumbers: true, IncludeLower: true, GroupSize: 4}
+	results, err := GenerateStream(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	n := 0
+	for r := range results {
+		if r.Err != nil {
+			t.Fatalf("Expected no error, but got %v", r.Err)
+		}
+		if v := Verify(r.Password, opts); len(v) != 0 {
+			t.Errorf("Expected %q to verify, but got %v", r.Password, v)
+		}
+		n++
+	}
+	if n != opts.Quantity {
+		t.Errorf("Expected %d passwords, but got %d", opts.Quantity, n)
+	}
+}
+
+// TestGenerateStream_Stops checks invalid options and cancellation.
+func TestGenerateStream_Stops(t *testing.T) {
+	if _, err := GenerateStream(context.Background(), PasswordOptions{Length: 8, Quantity: 1}); ErrorCode(err) != CodeNoCharacterTypes {
+		t.Errorf("Expected %s, but got %v", CodeNoCharacterTypes, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results, err := GenerateStream(ctx, PasswordOptions{Length: 8, Quantity: 1 << 30, IncludeLower: true})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	<-results
+	cancel()
+	n := 0
+	for range results {
+		n++
+	}
+	if n >= 1<<20 {
+		t.Errorf("Expected the stream to stop after cancellation, but got %d more passwords", n)
+	}
+}
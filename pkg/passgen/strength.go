@@ -0,0 +1,110 @@
+/**
+ * Strength Rating
+ *
+ * This file rates passwords that were not necessarily generated here, such as
+ * existing credentials being audited. The estimate is based on the character
+ * classes a password uses and its length, and is mapped onto a five-step scale.
+ */
+
+package passgen
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Strength is a coarse rating of a password's entropy.
+type Strength int
+
+// Strength ratings, from weakest to strongest.
+const (
+	VeryWeak Strength = iota
+	Weak
+	Fair
+	Strong
+	VeryStrong
+)
+
+// String returns the display name of the rating.
+func (s Strength) String() string {
+	switch s {
+	case VeryWeak:
+		return "very weak"
+	case Weak:
+		return "weak"
+	case Fair:
+		return "fair"
+	case Strong:
+		return "strong"
+	default:
+		return "very strong"
+	}
+}
+
+// RateEntropy maps an entropy estimate in bits onto a Strength.
+func RateEntropy(bits float64) Strength {
+	switch {
+	case bits < 28:
+		return VeryWeak
+	case bits < 36:
+		return Weak
+	case bits < 60:
+		return Fair
+	case bits < 128:
+		return Strong
+	default:
+		return VeryStrong
+	}
+}
+
+// PasswordEntropy estimates the entropy of an existing password in bits.
+// Purpose:
+//
+//	Assumes every character was drawn at random from the union of the
+//	character classes the password uses. Human-chosen passwords are far more
+//	predictable, so the result is an upper bound.
+//
+// Parameters:
+//   - password (string): The password to rate.
+//
+// Returns:
+//
+//	float64: The estimated entropy in bits.
+//
+// Example:
+//
+//	strength := RateEntropy(PasswordEntropy("correct horse"))
+func PasswordEntropy(password string) float64 {
+	var lower, upper, digit, symbol, other bool
+	length := 0
+	for _, char := range password {
+		length++
+		switch {
+		case strings.ContainsRune(Lowercase, char):
+			lower = true
+		case strings.ContainsRune(Uppercase, char):
+			upper = true
+		case strings.ContainsRune(Digits, char):
+			digit = true
+		case char < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	pool := 0
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.used {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+	return float64(length) * math.Log2(float64(pool))
+}
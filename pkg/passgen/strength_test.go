@@ -0,0 +1,27 @@
+package passgen
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPasswordEntropy checks the pool size for mixed character classes.
+func TestPasswordEntropy(t *testing.T) {
+	want := 8 * math.Log2(26+10)
+	if got := PasswordEntropy("abcd1234"); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Expected %.2f bits, but got %.2f", want, got)
+	}
+	if got := PasswordEntropy(""); got != 0 {
+		t.Errorf("Expected 0 bits for an empty password, but got %.2f", got)
+	}
+}
+
+// TestRateEntropy verifies the rating boundaries.
+func TestRateEntropy(t *testing.T) {
+	tests := map[float64]Strength{0: VeryWeak, 30: Weak, 50: Fair, 80: Strong, 128: VeryStrong}
+	for bits, want := range tests {
+		if got := RateEntropy(bits); got != want {
+			t.Errorf("Expected %v for %.0f bits, but got %v", want, bits, got)
+		}
+	}
+}
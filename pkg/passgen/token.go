@@ -0,0 +1,71 @@
+/**
+ * Raw Key Generation
+ *
+ * This file generates cryptographically random keys of a given number of
+ * bytes, encoded as text for use as API secrets, session keys or signing
+ * keys. Unlike passwords, keys are not built from character classes: every
+ * byte carries 8 bits of entropy.
+ */
+
+package passgen
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+)
+
+// Key encodings accepted by GenerateToken.
+const (
+	EncodingHex       = "hex"
+	EncodingBase64URL = "base64url"
+	EncodingBase32    = "base32"
+)
+
+// Encodings lists the key encodings in the order they are offered.
+var Encodings = []string{EncodingHex, EncodingBase64URL, EncodingBase32}
+
+// Limits and default of the key size in bytes.
+const (
+	MinTokenBytes     = 8
+	MaxTokenBytes     = 1024
+	DefaultTokenBytes = 32
+)
+
+// GenerateToken returns a random key of size bytes in the given encoding.
+// Purpose:
+//
+//	Reads size bytes of randomness and encodes them as lowercase hex,
+//	unpadded URL-safe base64, or unpadded base32.
+//
+// Parameters:
+//   - size (int): Number of random bytes, MinTokenBytes to MaxTokenBytes.
+//   - encoding (string): One of Encodings.
+//
+// Returns:
+//
+//	string: The encoded key.
+//	error: An error if the size or encoding is invalid or randomness fails.
+//
+// Example:
+//
+//	key, err := g.GenerateToken(32, EncodingBase64URL)
+func (g *Generator) GenerateToken(size int, encoding string) (string, error) {
+	if size < MinTokenBytes || size > MaxTokenBytes {
+		return "", newError(CodeTokenSize, MinTokenBytes, MaxTokenBytes)
+	}
+	key := make([]byte, size)
+	if _, err := io.ReadFull(g.rand, key); err != nil {
+		return "", newError(CodeRandomFailure)
+	}
+	switch encoding {
+	case EncodingHex:
+		return hex.EncodeToString(key), nil
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.EncodeToString(key), nil
+	case EncodingBase32:
+		return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(key), nil
+	}
+	return "", newError(CodeUnknownEncoding, encoding)
+}
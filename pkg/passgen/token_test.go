@@ -0,0 +1,46 @@
+package passgen
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+)
+
+// TestGenerateToken verifies that each encoding decodes back to the requested size.
+func TestGenerateToken(t *testing.T) {
+	decoders := map[string]func(string) ([]byte, error){
+		EncodingHex:       hex.DecodeString,
+		EncodingBase64URL: base64.RawURLEncoding.DecodeString,
+		EncodingBase32:    base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString,
+	}
+	for _, encoding := range Encodings {
+		key, err := GenerateToken(DefaultTokenBytes, encoding)
+		if err != nil {
+			t.Fatalf("%s: Expected no error, but got %v", encoding, err)
+		}
+		raw, err := decoders[encoding](key)
+		if err != nil || len(raw) != DefaultTokenBytes {
+			t.Errorf("%s: Expected %d bytes, but got %d (%v)", encoding, DefaultTokenBytes, len(raw), err)
+		}
+	}
+
+	first, _ := GenerateToken(16, EncodingHex)
+	second, _ := GenerateToken(16, EncodingHex)
+	if first == second {
+		t.Errorf("Expected different keys, but got %s twice", first)
+	}
+}
+
+// TestGenerateToken_Invalid verifies that bad sizes and encodings are rejected.
+func TestGenerateToken_Invalid(t *testing.T) {
+	if _, err := GenerateToken(MinTokenBytes-1, EncodingHex); err == nil {
+		t.Errorf("Expected an error for a short key, but got none")
+	}
+	if _, err := GenerateToken(MaxTokenBytes+1, EncodingHex); err == nil {
+		t.Errorf("Expected an error for a long key, but got none")
+	}
+	if _, err := GenerateToken(DefaultTokenBytes, "rot13"); err == nil {
+		t.Errorf("Expected an error for an unknown encoding, but got none")
+	}
+}
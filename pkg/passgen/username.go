@@ -0,0 +1,170 @@
+/**
+ * Username Generation
+ *
+ * This file generates usernames such as brave_otter42 or SwiftAmberFalcon
+ * from built-in adjective and noun lists, for throwaway accounts created
+ * together with a generated password. Handles are meant to be unique rather
+ * than secret: more words and digits make it less likely that two generated
+ * handles, or a handle and an existing account, are the same.
+ */
+
+package passgen
+
+import (
+	"context"
+	_ "embed"
+	"math"
+	"strings"
+)
+
+//go:embed adjectives.txt
+var adjectiveList string
+
+//go:embed nouns.txt
+var nounList string
+
+// Adjectives and Nouns are the words usernames are built from.
+var (
+	Adjectives = strings.Fields(adjectiveList)
+	Nouns      = strings.Fields(nounList)
+)
+
+// Limits and defaults of usernames.
+const (
+	MaxUsernameAdjectives = 3
+	MaxUsernameDigits     = 8
+	DefaultUsernameDigits = 2
+)
+
+// UsernameSeparators lists the separators offered between the words.
+var UsernameSeparators = []string{"", "_", "-", "."}
+
+// UsernameOptions holds the settings for username generation.
+// Fields:
+//   - Adjectives (int): Adjectives before the noun, 1 to MaxUsernameAdjectives.
+//   - Digits (int): Random digits appended, 0 to MaxUsernameDigits.
+//   - Separator (string): Placed between the words; one of UsernameSeparators.
+//   - Capitalize (bool): Capitalizes every word, e.g. BraveOtter.
+//   - Quantity (int): Number of usernames to generate.
+type UsernameOptions struct {
+	Adjectives int
+	Digits     int
+	Separator  string
+	Capitalize bool
+	Quantity   int
+}
+
+// DefaultUsernameOptions returns usernames such as brave_otter42.
+func DefaultUsernameOptions() UsernameOptions {
+	return UsernameOptions{Adjectives: 1, Digits: DefaultUsernameDigits, Separator: "_", Quantity: 1}
+}
+
+// GenerateUsernames generates usernames of random adjectives, a random noun
+// and random digits.
+// Parameters:
+//   - ctx (context.Context): Cancels a long batch between two usernames.
+//   - opts (UsernameOptions): Format and quantity of the usernames.
+//
+// Returns:
+//
+//	[]string: The usernames; a batch has no repeats.
+//	error: An error if an option is outside its limits, CodeUsernameQuantity
+//	if fewer distinct usernames exist than requested, or ctx.Err() once
+//	ctx is done.
+//
+// Example:
+//
+//	names, err := g.GenerateUsernames(ctx, DefaultUsernameOptions())
+func (g *Generator) GenerateUsernames(ctx context.Context, opts UsernameOptions) ([]string, error) {
+	if opts.Adjectives < 1 || opts.Adjectives > MaxUsernameAdjectives {
+		return nil, newError(CodeUsernameAdjectives, MaxUsernameAdjectives)
+	}
+	if opts.Digits < 0 || opts.Digits > MaxUsernameDigits {
+		return nil, newError(CodeUsernameDigits, MaxUsernameDigits)
+	}
+	if !validUsernameSeparator(opts.Separator) {
+		return nil, newError(CodeUsernameSeparator, opts.Separator)
+	}
+	// A batch larger than the number of possible handles could never
+	// finish without repeats.
+	bits := UsernameEntropy(opts)
+	if possible := math.Pow(2, bits); float64(opts.Quantity) > possible {
+		return nil, newError(CodeUsernameQuantity, possible, opts.Quantity, UsernamesBeforeCollision(bits, 0.5))
+	}
+	seen := make(map[string]bool, opts.Quantity)
+	var names []string
+	for len(names) < opts.Quantity {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		name, err := g.generateUsername(opts)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// generateUsername draws one username.
+func (g *Generator) generateUsername(opts UsernameOptions) (string, error) {
+	words := make([]string, 0, opts.Adjectives+1)
+	for i := 0; i <= opts.Adjectives; i++ {
+		list := Adjectives
+		if i == opts.Adjectives {
+			list = Nouns
+		}
+		index, err := g.intn(len(list))
+		if err != nil {
+			return "", err
+		}
+		word := list[index]
+		if opts.Capitalize {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		words = append(words, word)
+	}
+	digits := make([]byte, opts.Digits)
+	for i := range digits {
+		digit, err := g.randomChar(Digits)
+		if err != nil {
+			return "", err
+		}
+		digits[i] = digit
+	}
+	return strings.Join(words, opts.Separator) + string(digits), nil
+}
+
+// UsernameEntropy returns the number of bits of a username with opts: the
+// base 2 logarithm of the number of different usernames.
+// Example:
+//
+//	bits := UsernameEntropy(DefaultUsernameOptions()) // ~22.4
+func UsernameEntropy(opts UsernameOptions) float64 {
+	return float64(opts.Adjectives)*math.Log2(float64(len(Adjectives))) +
+		math.Log2(float64(len(Nouns))) +
+		float64(opts.Digits)*math.Log2(10)
+}
+
+// UsernamesBeforeCollision returns how many usernames with bits entropy can
+// be generated, in total, before two are the same with the given
+// probability, by the birthday bound.
+// Example:
+//
+//	n := UsernamesBeforeCollision(UsernameEntropy(opts), 0.01)
+func UsernamesBeforeCollision(bits, probability float64) float64 {
+	return math.Sqrt(2 * math.Pow(2, bits) * math.Log(1/(1-probability)))
+}
+
+// validUsernameSeparator reports whether separator is one of UsernameSeparators.
+func validUsernameSeparator(separator string) bool {
+	for _, s := range UsernameSeparators {
+		if s == separator {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package passgen
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestGenerateUsernames verifies the format and uniqueness of usernames.
+func TestGenerateUsernames(t *testing.T) {
+	tests := []struct {
+		opts    UsernameOptions
+		pattern string
+	}{
+		{DefaultUsernameOptions(), `^[a-z]+_[a-z]+[0-9]{2}$`},
+		{UsernameOptions{Adjectives: 2, Separator: "", Capitalize: true}, `^[A-Z][a-z]+[A-Z][a-z]+[A-Z][a-z]+$`},
+		{UsernameOptions{Adjectives: 1, Digits: 4, Separator: "."}, `^[a-z]+\.[a-z]+[0-9]{4}$`},
+	}
+	for _, tt := range tests {
+		tt.opts.Quantity = 50
+		names, err := GenerateUsernames(context.Background(), tt.opts)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		seen := map[string]bool{}
+		for _, name := range names {
+			if !regexp.MustCompile(tt.pattern).MatchString(name) {
+				t.Errorf("Expected a username matching %s, but got %s", tt.pattern, name)
+			}
+			if seen[name] {
+				t.Errorf("Expected distinct usernames, but got %s twice", name)
+			}
+			seen[name] = true
+		}
+	}
+}
+
+// TestGenerateUsernames_Invalid verifies that options outside the limits are rejected.
+func TestGenerateUsernames_Invalid(t *testing.T) {
+	for _, opts := range []UsernameOptions{
+		{Adjectives: 0, Quantity: 1},
+		{Adjectives: MaxUsernameAdjectives + 1, Quantity: 1},
+		{Adjectives: 1, Digits: MaxUsernameDigits + 1, Quantity: 1},
+		{Adjectives: 1, Separator: "+", Quantity: 1},
+	} {
+		if _, err := GenerateUsernames(context.Background(), opts); err == nil {
+			t.Errorf("Expected an error for %+v, but got none", opts)
+		}
+	}
+}
+
+// TestGenerateUsernames_TooMany verifies that a batch larger than the number
+// of distinct usernames is refused with its own code and an estimate.
+func TestGenerateUsernames_TooMany(t *testing.T) {
+	opts := UsernameOptions{Adjectives: 1, Quantity: len(Adjectives)*len(Nouns) + 1}
+	_, err := GenerateUsernames(context.Background(), opts)
+	if code := ErrorCode(err); code != CodeUsernameQuantity {
+		t.Fatalf("Expected %s, but got %v", CodeUsernameQuantity, err)
+	}
+	if message := err.Error(); !strings.Contains(message, fmt.Sprint(len(Adjectives)*len(Nouns))) || !strings.Contains(message, "add digits or adjectives") {
+		t.Errorf("Expected the number of usernames and a hint, but got %q", message)
+	}
+}
+
+// TestUsernamesBeforeCollision verifies the birthday bound: 365 days give a
+// 50% chance of a shared birthday at about 23 people.
+func TestUsernamesBeforeCollision(t *testing.T) {
+	if n := UsernamesBeforeCollision(math.Log2(365), 0.5); math.Abs(n-22.5) > 0.5 {
+		t.Errorf("Expected about 22.5, but got %.1f", n)
+	}
+}
@@ -0,0 +1,144 @@
+package passgen
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Violation describes one constraint a generated password does not meet.
+type Violation struct {
+	Constraint string
+	Detail     string
+}
+
+// String formats the violation as "constraint: detail".
+func (v Violation) String() string {
+	return v.Constraint + ": " + v.Detail
+}
+
+// Verify re-checks a generated password against the options it was generated with.
+// Purpose:
+//
+//	Confirms the grouping, the length, that the password uses only and every
+//	enabled character class, the exclusions, MustInclude, BeginWithLetter,
+//	NoSimilar, NoDuplicates, NoSequential and AlternateHands independently of
+//	the generator, so that a generator bug cannot go unnoticed. Group
+//	separators are removed before the other checks.
+//
+// Parameters:
+//   - password (string): The generated password.
+//   - opts (PasswordOptions): The options the password was requested with.
+//
+// Returns:
+//
+//	[]Violation: Every constraint the password breaks; empty if it meets them all.
+//
+// Example:
+//
+//	for _, v := range Verify(password, opts) {
+//		fmt.Println(v)
+//	}
+func Verify(password string, opts PasswordOptions) []Violation {
+	var violations []Violation
+	add := func(constraint, format string, args ...interface{}) {
+		violations = append(violations, Violation{Constraint: constraint, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	if grouped := password; opts.GroupSize > 0 {
+		password = Ungroup(grouped, opts)
+		if Group(password, opts) != grouped {
+			add("groups", "is not split into groups of %d separated by %q", opts.GroupSize, groupSeparator(opts))
+		}
+	}
+
+	if n := utf8.RuneCountInString(password); n != opts.Length {
+		add("length", "has %d characters, want %d", n, opts.Length)
+	}
+
+	allowed := buildCharacterSet(opts) + opts.MustInclude
+	for i, r := range []rune(password) {
+		if !strings.ContainsRune(allowed, r) {
+			add("characters", "%q at position %d is not in an enabled class or is excluded", r, i+1)
+		}
+	}
+
+	for _, class := range enabledClasses(opts) {
+		if !strings.ContainsAny(password, class.chars) {
+			add("each type", "contains no %s", class.name)
+		}
+	}
+
+	for _, r := range opts.MustInclude {
+		if !strings.ContainsRune(password, r) {
+			add("must include", "does not contain %q", r)
+		}
+	}
+
+	if opts.BeginWithLetter && password != "" {
+		first, _ := utf8.DecodeRuneInString(password)
+		if !strings.ContainsRune(letterCharacters(opts), first) {
+			add("begin with letter", "starts with %q", first)
+		}
+	}
+
+	if opts.NoSimilar {
+		for _, r := range password {
+			if strings.ContainsRune(similarCharacters, r) {
+				add("no similar", "contains %q", r)
+			}
+		}
+	}
+
+	if opts.NoDuplicates {
+		seen := make(map[rune]bool)
+		for _, r := range password {
+			if seen[r] {
+				add("no duplicates", "repeats %q", r)
+			}
+			seen[r] = true
+		}
+	}
+
+	runes := []rune(password)
+	if opts.NoSequential {
+		for i := 0; i+2 < len(runes); i++ {
+			if isSequential(runes[i], runes[i+1], runes[i+2]) {
+				add("no sequential", "contains %q", string(runes[i:i+3]))
+			}
+		}
+	}
+
+	if opts.AlternateHands {
+		name := opts.KeyboardLayout
+		if name == "" {
+			name = DefaultHandLayout
+		}
+		if layout, ok := HandLayouts[name]; ok {
+			for i := 1; i < len(runes); i++ {
+				if strings.ContainsRune(layout.Left, runes[i-1]) == strings.ContainsRune(layout.Left, runes[i]) {
+					add("alternate hands", "positions %d and %d use the same hand", i, i+1)
+				}
+			}
+		} else {
+			add("alternate hands", "unknown keyboard layout %q", name)
+		}
+	}
+
+	return violations
+}
+
+// VerifyPasswords runs Verify on every password and returns an error listing
+// all violations, or nil if every password meets the options.
+func VerifyPasswords(passwords []string, opts PasswordOptions) error {
+	var failures []string
+	for i, password := range passwords {
+		for _, v := range Verify(password, opts) {
+			failures = append(failures, fmt.Sprintf("password %d: %s", i+1, v))
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return newError(CodeVerificationFailed, strings.Join(failures, "\n  "))
+}
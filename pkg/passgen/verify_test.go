@@ -0,0 +1,68 @@
+package passgen
+
+import (
+	"context"
+	"testing"
+)
+
+// TestVerify_GeneratedPasswords verifies that plain generated passwords pass verification.
+func TestVerify_GeneratedPasswords(t *testing.T) {
+	opts := PasswordOptions{
+		Length:          16,
+		Quantity:        50,
+		IncludeSymbols:  true,
+		IncludeNumbers:  true,
+		IncludeUpper:    true,
+		IncludeLower:    true,
+		BeginWithLetter: true,
+		AlternateHands:  true,
+	}
+	passwords, err := GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := VerifyPasswords(passwords, opts); err != nil {
+		t.Errorf("Expected generated passwords to verify, but got %v", err)
+	}
+}
+
+// TestVerify_Violations verifies that each broken constraint is reported.
+func TestVerify_Violations(t *testing.T) {
+	opts := PasswordOptions{
+		Length:            8,
+		IncludeNumbers:    true,
+		IncludeLower:      true,
+		BeginWithLetter:   true,
+		NoSimilar:         true,
+		NoDuplicates:      true,
+		NoSequential:      true,
+		ExcludeCharacters: "z",
+	}
+	tests := []struct {
+		password   string
+		constraint string
+	}{
+		{"abcd", "length"},
+		{"kmnpqrsZ", "characters"},
+		{"kmnpqrsz", "characters"},
+		{"2kmnpqrs", "begin with letter"},
+		{"kmnpqrs1", "no similar"},
+		{"kmnpqrsk", "no duplicates"},
+		{"kmnpqrst", "no sequential"},
+	}
+	for _, tt := range tests {
+		found := false
+		for _, v := range Verify(tt.password, opts) {
+			if v.Constraint == tt.constraint {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected a %q violation for %q, but got %v", tt.constraint, tt.password, Verify(tt.password, opts))
+		}
+	}
+
+	if violations := Verify("k2m4p6r8", opts); len(violations) != 0 {
+		t.Errorf("Expected no violations, but got %v", violations)
+	}
+}
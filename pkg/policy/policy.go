@@ -0,0 +1,420 @@
+/**
+ * Password Policies
+ *
+ * This file reads password policies, such as those of a compliance standard
+ * or a company handbook, from JSON or YAML files:
+ *
+ *   name: Company
+ *   min_length: 14
+ *   required: [lower, upper, digit]
+ *   banned_substrings: [acme, password]
+ *   max_repeats: 2
+ *
+ * A policy maps onto PasswordOptions for the parts the generator can follow
+ * directly (length, character types, excluded characters) and checks every
+ * generated password for the rest, so that a password breaking the policy
+ * is generated again rather than handed out.
+ */
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File formats of a policy.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Character classes a policy can require.
+const (
+	ClassLower  = "lower"
+	ClassUpper  = "upper"
+	ClassLetter = "letter"
+	ClassDigit  = "digit"
+	ClassSymbol = "symbol"
+)
+
+// categories are the classes counted by MinClasses.
+var categories = []string{ClassLower, ClassUpper, ClassDigit, ClassSymbol}
+
+// Policy describes the passwords an organization or standard accepts.
+// Fields:
+//   - Name (string): A short name, shown in lists.
+//   - Description (string): Where the policy comes from and what it asks.
+//   - MinLength, MaxLength (int): Length limits; 0 means unrestricted.
+//   - Required ([]string): Classes of which every password has at least one
+//     character: lower, upper, letter, digit or symbol.
+//   - MinClasses (int): Of lower, upper, digit and symbol, how many every
+//     password uses, as in Active Directory's "three of four categories".
+//   - BannedCharacters (string): Characters never used.
+//   - BannedSubstrings ([]string): Text that must not appear, ignoring case,
+//     e.g. the company or account name.
+//   - MaxRepeats (int): The most times the same character may appear in a
+//     row; 0 means unrestricted.
+type Policy struct {
+	Name             string   `json:"name" yaml:"name"`
+	Description      string   `json:"description,omitempty" yaml:"description,omitempty"`
+	MinLength        int      `json:"min_length,omitempty" yaml:"min_length,omitempty"`
+	MaxLength        int      `json:"max_length,omitempty" yaml:"max_length,omitempty"`
+	Required         []string `json:"required,omitempty" yaml:"required,omitempty"`
+	MinClasses       int      `json:"min_classes,omitempty" yaml:"min_classes,omitempty"`
+	BannedCharacters string   `json:"banned_characters,omitempty" yaml:"banned_characters,omitempty"`
+	BannedSubstrings []string `json:"banned_substrings,omitempty" yaml:"banned_substrings,omitempty"`
+	MaxRepeats       int      `json:"max_repeats,omitempty" yaml:"max_repeats,omitempty"`
+}
+
+// Parse reads a policy in the given format and validates it.
+// Purpose:
+//
+//	Unknown fields are rejected, so that a misspelt rule is reported
+//	instead of silently not enforced.
+//
+// Parameters:
+//   - data ([]byte): The policy file.
+//   - format (string): FormatJSON or FormatYAML.
+//
+// Returns:
+//
+//	Policy: The parsed policy.
+//	error: An error if the file cannot be parsed or the policy is invalid.
+//
+// Example:
+//
+//	p, err := policy.Parse(data, policy.FormatYAML)
+func Parse(data []byte, format string) (Policy, error) {
+	var p Policy
+	switch format {
+	case FormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&p); err != nil {
+			return Policy{}, err
+		}
+	case FormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&p); err != nil {
+			return Policy{}, err
+		}
+	default:
+		return Policy{}, fmt.Errorf("unknown policy format %q", format)
+	}
+	return p, p.Validate()
+}
+
+// LoadFile reads the policy at path, in JSON for a .json file and in YAML
+// for a .yaml or .yml file. A policy without a name is named after the file.
+func LoadFile(path string) (Policy, error) {
+	var format string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = FormatJSON
+	case ".yaml", ".yml":
+		format = FormatYAML
+	default:
+		return Policy{}, fmt.Errorf("%s: policy files end in .json, .yaml or .yml", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Policy{}, err
+	}
+	p, err := Parse(data, format)
+	if err != nil {
+		return Policy{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return p, nil
+}
+
+// Validate reports rules that contradict each other or name unknown classes.
+func (p Policy) Validate() error {
+	if p.MinLength < 0 || p.MaxLength < 0 || p.MinClasses < 0 || p.MaxRepeats < 0 {
+		return errors.New("lengths and counts must not be negative")
+	}
+	if p.MaxLength > 0 && p.MinLength > p.MaxLength {
+		return fmt.Errorf("min_length %d is above max_length %d", p.MinLength, p.MaxLength)
+	}
+	if p.MinClasses > len(categories) {
+		return fmt.Errorf("min_classes %d is above the %d classes", p.MinClasses, len(categories))
+	}
+	for _, class := range p.Required {
+		if classNames[class] == "" {
+			return fmt.Errorf("unknown class %q in required; use lower, upper, letter, digit or symbol", class)
+		}
+	}
+	for _, s := range p.BannedSubstrings {
+		if s == "" {
+			return errors.New("banned_substrings contains an empty string")
+		}
+	}
+	return nil
+}
+
+// Apply adjusts opts so that generated passwords follow the policy as far
+// as options can express it.
+// Purpose:
+//
+//	Sets the length limits and keeps the length within them, turns on
+//	every required class (lowercase for "letter" if no letters are on),
+//	turns on further classes until MinClasses are on, and excludes the
+//	banned characters. Banned substrings and repeats are left to
+//	Constraint. Exclusions already present in opts are kept.
+//
+// Parameters:
+//   - opts (passgen.PasswordOptions): The options to adjust.
+//
+// Returns:
+//
+//	passgen.PasswordOptions: The adjusted options.
+func (p Policy) Apply(opts passgen.PasswordOptions) passgen.PasswordOptions {
+	flags := map[string]*bool{
+		ClassLower:  &opts.IncludeLower,
+		ClassUpper:  &opts.IncludeUpper,
+		ClassDigit:  &opts.IncludeNumbers,
+		ClassSymbol: &opts.IncludeSymbols,
+	}
+	for _, class := range p.Required {
+		if class == ClassLetter {
+			if !opts.IncludeLower && !opts.IncludeUpper {
+				opts.IncludeLower = true
+			}
+			continue
+		}
+		if flag, ok := flags[class]; ok {
+			*flag = true
+		}
+	}
+	enabled := 0
+	for _, class := range categories {
+		if *flags[class] {
+			enabled++
+		}
+	}
+	for _, class := range categories {
+		if enabled >= p.MinClasses {
+			break
+		}
+		if !*flags[class] {
+			*flags[class] = true
+			enabled++
+		}
+	}
+
+	for _, c := range p.BannedCharacters {
+		if !strings.ContainsRune(opts.ExcludeCharacters, c) {
+			opts.ExcludeCharacters += string(c)
+		}
+	}
+
+	if p.MinLength > opts.MinLength {
+		opts.MinLength = p.MinLength
+	}
+	if p.MaxLength > 0 && (opts.MaxLength == 0 || p.MaxLength < opts.MaxLength) {
+		opts.MaxLength = p.MaxLength
+	}
+	if opts.MaxLength > 0 && opts.MaxLength < opts.MinLength {
+		opts.MaxLength = opts.MinLength
+	}
+	opts.DefaultLength = clamp(opts.DefaultLength, opts.MinLength, opts.MaxLength)
+	if opts.Length != 0 {
+		opts.Length = clamp(opts.Length, opts.MinLength, opts.MaxLength)
+	}
+	return opts
+}
+
+// Check returns the rules password breaks, in words; none if it follows
+// the policy.
+func (p Policy) Check(password string) []string {
+	var violations []string
+	length := len([]rune(password))
+	if length < p.MinLength {
+		violations = append(violations, fmt.Sprintf("shorter than %d characters", p.MinLength))
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, fmt.Sprintf("longer than %d characters", p.MaxLength))
+	}
+	for _, class := range p.Required {
+		if !hasClass(password, class) {
+			violations = append(violations, "missing "+classNames[class])
+		}
+	}
+	if p.MinClasses > 0 {
+		if used := len(Classes(password)); used < p.MinClasses {
+			violations = append(violations, fmt.Sprintf("uses %d of the %d required character classes", used, p.MinClasses))
+		}
+	}
+	if i := strings.IndexAny(password, p.BannedCharacters); p.BannedCharacters != "" && i >= 0 {
+		violations = append(violations, fmt.Sprintf("contains the banned character %q", password[i:i+1]))
+	}
+	lower := strings.ToLower(password)
+	for _, s := range p.BannedSubstrings {
+		if strings.Contains(lower, strings.ToLower(s)) {
+			violations = append(violations, fmt.Sprintf("contains %q", s))
+		}
+	}
+	if p.MaxRepeats > 0 && longestRun(password) > p.MaxRepeats {
+		violations = append(violations, fmt.Sprintf("repeats a character more than %d times in a row", p.MaxRepeats))
+	}
+	return violations
+}
+
+// Verify checks every password and returns an error listing each
+// violation, numbered from 1, or nil if all follow the policy.
+func (p Policy) Verify(passwords []string) error {
+	var failures []string
+	for i, password := range passwords {
+		for _, v := range p.Check(password) {
+			failures = append(failures, fmt.Sprintf("password %d: %s", i+1, v))
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("passwords break the %s policy:\n  %s", p.Name, strings.Join(failures, "\n  "))
+}
+
+// Constraint returns a constraint that rejects passwords breaking the
+// policy, so that a Generator built with passgen.WithConstraints generates
+// them again. Use it together with the options from Apply.
+func (p Policy) Constraint() passgen.Constraint {
+	return constraint{p}
+}
+
+// constraint implements Policy.Constraint.
+type constraint struct {
+	policy Policy
+}
+
+func (constraint) Apply(pool string, _ []byte, _ int) string { return pool }
+
+func (c constraint) Check(candidate []byte) bool {
+	return len(c.policy.Check(string(candidate))) == 0
+}
+
+// Describe summarises the policy in one line for display.
+func (p Policy) Describe() string {
+	var parts []string
+	switch {
+	case p.MinLength > 0 && p.MaxLength > 0:
+		parts = append(parts, fmt.Sprintf("%d-%d characters", p.MinLength, p.MaxLength))
+	case p.MinLength > 0:
+		parts = append(parts, fmt.Sprintf("at least %d characters", p.MinLength))
+	case p.MaxLength > 0:
+		parts = append(parts, fmt.Sprintf("at most %d characters", p.MaxLength))
+	}
+	for _, class := range p.Required {
+		parts = append(parts, classNames[class])
+	}
+	if p.MinClasses > 0 {
+		parts = append(parts, fmt.Sprintf("%d of %d character classes", p.MinClasses, len(categories)))
+	}
+	if p.BannedCharacters != "" {
+		parts = append(parts, "no "+p.BannedCharacters)
+	}
+	if len(p.BannedSubstrings) > 0 {
+		parts = append(parts, fmt.Sprintf("%d banned words", len(p.BannedSubstrings)))
+	}
+	if p.MaxRepeats > 0 {
+		parts = append(parts, fmt.Sprintf("no more than %d identical characters in a row", p.MaxRepeats))
+	}
+	if len(parts) == 0 {
+		return "no composition rules"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Classes returns which of lower, upper, digit and symbol password uses,
+// in that order.
+func Classes(password string) []string {
+	var used []string
+	for _, class := range categories {
+		if hasClass(password, class) {
+			used = append(used, class)
+		}
+	}
+	return used
+}
+
+// classNames holds how each class is described.
+var classNames = map[string]string{
+	ClassLower:  "a lowercase letter",
+	ClassUpper:  "an uppercase letter",
+	ClassLetter: "a letter",
+	ClassDigit:  "a digit",
+	ClassSymbol: "a symbol",
+}
+
+// hasClass reports whether password has a character of class. Any
+// character that is neither a letter nor a digit counts as a symbol, as
+// passwords typed by people may use symbols the generator does not.
+func hasClass(password, class string) bool {
+	for _, r := range password {
+		switch class {
+		case ClassLower:
+			if unicode.IsLower(r) {
+				return true
+			}
+		case ClassUpper:
+			if unicode.IsUpper(r) {
+				return true
+			}
+		case ClassLetter:
+			if unicode.IsLetter(r) {
+				return true
+			}
+		case ClassDigit:
+			if unicode.IsDigit(r) {
+				return true
+			}
+		case ClassSymbol:
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// clamp returns n limited to min and, if it is set, max.
+func clamp(n, min, max int) int {
+	if n < min {
+		n = min
+	}
+	if max > 0 && n > max {
+		n = max
+	}
+	return n
+}
+
+// longestRun returns the length of the longest run of one character.
+func longestRun(password string) int {
+	longest, run := 0, 0
+	var last rune = -1
+	for _, r := range password {
+		if r == last {
+			run++
+		} else {
+			last, run = r, 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
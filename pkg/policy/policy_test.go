@@ -0,0 +1,154 @@
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// TestParse verifies that JSON and YAML policies read the same.
+func TestParse(t *testing.T) {
+	fromJSON, err := Parse([]byte(`{"name": "Acme", "min_length": 14, "required": ["lower", "digit"], "banned_substrings": ["acme"], "max_repeats": 2}`), FormatJSON)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	fromYAML, err := Parse([]byte("name: Acme\nmin_length: 14\nrequired: [lower, digit]\nbanned_substrings: [acme]\nmax_repeats: 2\n"), FormatYAML)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if fromJSON.Describe() != fromYAML.Describe() {
+		t.Errorf("Expected the same policy, but got %q and %q", fromJSON.Describe(), fromYAML.Describe())
+	}
+	if fromYAML.MinLength != 14 || fromYAML.MaxRepeats != 2 || len(fromYAML.Required) != 2 {
+		t.Errorf("Unexpected policy %+v", fromYAML)
+	}
+}
+
+// TestParse_Invalid ensures misspelt fields and contradictory rules are
+// reported.
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format string
+	}{
+		{"unknown JSON field", `{"min_lenght": 12}`, FormatJSON},
+		{"unknown YAML field", "min_lenght: 12\n", FormatYAML},
+		{"unknown class", "required: [digits]\n", FormatYAML},
+		{"min above max", "min_length: 20\nmax_length: 10\n", FormatYAML},
+		{"too many classes", "min_classes: 5\n", FormatYAML},
+		{"unknown format", "min_length: 12\n", "toml"},
+	}
+	for _, tt := range tests {
+		if _, err := Parse([]byte(tt.data), tt.format); err == nil {
+			t.Errorf("%s: Expected an error, but got nil", tt.name)
+		}
+	}
+}
+
+// TestLoadFile verifies that the format follows the extension and that a
+// policy without a name is named after its file.
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "handbook.yml")
+	if err := os.WriteFile(path, []byte("min_length: 10\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if p.Name != "handbook" || p.MinLength != 10 {
+		t.Errorf("Unexpected policy %+v", p)
+	}
+	if _, err := LoadFile(filepath.Join(dir, "handbook.txt")); err == nil {
+		t.Errorf("Expected an error for an unknown extension, but got nil")
+	}
+}
+
+// TestApply verifies that generated passwords follow a policy.
+func TestApply(t *testing.T) {
+	p := Policy{Name: "Test", MinLength: 16, MaxLength: 20, MinClasses: 3, Required: []string{ClassSymbol}, BannedCharacters: "#$", BannedSubstrings: []string{"ab"}, MaxRepeats: 1}
+	opts := p.Apply(passgen.PasswordOptions{MaxLength: 32, Length: 8, Quantity: 20, IncludeLower: true})
+
+	if opts.Length != 16 || opts.MinLength != 16 || opts.MaxLength != 20 {
+		t.Errorf("Expected length 16 within 16-20, but got %d within %d-%d", opts.Length, opts.MinLength, opts.MaxLength)
+	}
+	if !opts.IncludeLower || !opts.IncludeSymbols || !opts.IncludeUpper {
+		t.Errorf("Unexpected character classes %+v", opts)
+	}
+	if opts.ExcludeCharacters != "#$" {
+		t.Errorf("Expected the banned characters to be excluded, but got %q", opts.ExcludeCharacters)
+	}
+
+	generator := passgen.NewGenerator(passgen.WithConstraints(p.Constraint()))
+	passwords, err := generator.GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := p.Verify(passwords); err != nil {
+		t.Errorf("Expected every password to follow the policy, but got %v", err)
+	}
+}
+
+// TestCheck verifies the violations reported for passwords people chose.
+func TestCheck(t *testing.T) {
+	p := Policy{Name: "Test", MinLength: 8, Required: []string{ClassDigit}, MinClasses: 3, BannedCharacters: " ", BannedSubstrings: []string{"Acme"}, MaxRepeats: 2}
+	tests := []struct {
+		password string
+		want     []string
+	}{
+		{"Tr0ub4dor&3", nil},
+		{"short1A", []string{"shorter than 8"}},
+		{"acmeCorp2024", []string{`contains "Acme"`}},
+		{"passwordpassword", []string{"missing a digit", "uses 1 of"}},
+		{"Paaassword1", []string{"more than 2 times"}},
+		{"Pass word1", []string{"banned character"}},
+	}
+	for _, tt := range tests {
+		got := p.Check(tt.password)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: Expected %d violations, but got %q", tt.password, len(tt.want), got)
+			continue
+		}
+		for i, want := range tt.want {
+			if !strings.Contains(got[i], want) {
+				t.Errorf("%s: Expected a violation containing %q, but got %q", tt.password, want, got[i])
+			}
+		}
+	}
+	if err := p.Verify([]string{"Tr0ub4dor&3", "short1A"}); err == nil || !strings.Contains(err.Error(), "password 2") {
+		t.Errorf("Expected an error naming password 2, but got %v", err)
+	}
+}
+
+// TestPresets verifies that every bundled policy loads and that generated
+// passwords follow it.
+func TestPresets(t *testing.T) {
+	presets, err := Presets()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(presets) != 3 {
+		t.Fatalf("Expected 3 presets, but got %d", len(presets))
+	}
+	for _, preset := range presets {
+		opts := preset.Policy.Apply(passgen.PasswordOptions{MinLength: 6, MaxLength: 32, DefaultLength: 12, Length: 12, Quantity: 10, IncludeLower: true})
+		generator := passgen.NewGenerator(passgen.WithConstraints(preset.Policy.Constraint()))
+		passwords, err := generator.GeneratePasswords(context.Background(), opts)
+		if err != nil {
+			t.Errorf("%s: Expected no error, but got %v", preset.Key, err)
+			continue
+		}
+		if err := preset.Policy.Verify(passwords); err != nil {
+			t.Errorf("%s: Expected passwords to follow the policy, but got %v", preset.Key, err)
+		}
+	}
+	if p, err := Lookup("pci-dss"); err != nil || p.MinLength != 12 {
+		t.Errorf("Expected the PCI DSS preset, but got %+v, %v", p, err)
+	}
+}
@@ -0,0 +1,63 @@
+package policy
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+//go:embed presets/*.yaml
+var presetFiles embed.FS
+
+// Preset is a policy bundled with the binary.
+// Fields:
+//   - Key (string): The short name used on the command line, e.g. "pci-dss".
+//   - Policy (Policy): The policy itself.
+type Preset struct {
+	Key    string
+	Policy Policy
+}
+
+// Presets returns the bundled policies of common standards, ordered by key:
+// "ad-complexity", "nist-800-63b" and "pci-dss".
+func Presets() ([]Preset, error) {
+	entries, err := presetFiles.ReadDir("presets")
+	if err != nil {
+		return nil, err
+	}
+	presets := make([]Preset, 0, len(entries))
+	for _, entry := range entries {
+		data, err := presetFiles.ReadFile(path.Join("presets", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		p, err := Parse(data, FormatYAML)
+		if err != nil {
+			return nil, fmt.Errorf("preset %s: %w", entry.Name(), err)
+		}
+		presets = append(presets, Preset{Key: strings.TrimSuffix(entry.Name(), ".yaml"), Policy: p})
+	}
+	return presets, nil
+}
+
+// Lookup returns the bundled policy with the given key or, failing that,
+// the policy in the file at keyOrPath.
+func Lookup(keyOrPath string) (Policy, error) {
+	presets, err := Presets()
+	if err != nil {
+		return Policy{}, err
+	}
+	keys := make([]string, len(presets))
+	for i, preset := range presets {
+		if preset.Key == keyOrPath {
+			return preset.Policy, nil
+		}
+		keys[i] = preset.Key
+	}
+	if filepath.Ext(keyOrPath) == "" {
+		return Policy{}, fmt.Errorf("no preset policy %q; use %s or a policy file", keyOrPath, strings.Join(keys, ", "))
+	}
+	return LoadFile(keyOrPath)
+}
@@ -0,0 +1,352 @@
+/**
+ * Website Password Requirements
+ *
+ * This file reads password rules of known websites, written in the syntax of
+ * Apple's password-manager-resources project, e.g.
+ *
+ *   minlength: 8; maxlength: 20; required: lower, upper; required: digit; allowed: [-_!];
+ *
+ * and maps them onto PasswordOptions. A small snapshot is bundled in
+ * rules.json; the complete, community-maintained password-rules.json from
+ * that project can be loaded with Load.
+ */
+
+package siterules
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+//go:embed rules.json
+var bundledRules []byte
+
+// asciiPrintable holds every printable ASCII character including space.
+var asciiPrintable = func() string {
+	var b strings.Builder
+	for c := byte(0x20); c <= 0x7e; c++ {
+		b.WriteByte(c)
+	}
+	return b.String()
+}()
+
+// namedClasses maps the class names of the rules syntax to their characters.
+// The generator only produces ASCII, so unicode is treated as ascii-printable.
+var namedClasses = map[string]string{
+	"upper":           passgen.Uppercase,
+	"lower":           passgen.Lowercase,
+	"digit":           passgen.Digits,
+	"special":         "-~!@#$%^&*_+=`|(){}[:;\"'<>,.? ]",
+	"ascii-printable": asciiPrintable,
+	"unicode":         asciiPrintable,
+}
+
+// Rules describes the password requirements of a single website.
+// Fields:
+//   - MinLength, MaxLength (int): Length limits; 0 means unrestricted.
+//   - MaxConsecutive (int): Longest run of identical characters; 0 means unrestricted.
+//   - Required ([]string): Character sets of which at least one character must appear.
+//   - Allowed (string): Every character the site accepts.
+type Rules struct {
+	MinLength      int
+	MaxLength      int
+	MaxConsecutive int
+	Required       []string
+	Allowed        string
+}
+
+// Parse reads a rule string such as "minlength: 8; required: lower, upper;".
+// Purpose:
+//
+//	Converts the password-rules syntax into a Rules value. Unknown properties
+//	are ignored so newer rule files keep loading.
+//
+// Parameters:
+//   - rule (string): The password rules of one site.
+//
+// Returns:
+//
+//	Rules: The parsed requirements.
+//	error: An error if a length or class cannot be parsed.
+//
+// Example:
+//
+//	rules, err := siterules.Parse("minlength: 8; required: digit;")
+func Parse(rule string) (Rules, error) {
+	var rules Rules
+	allowed := ""
+	for _, property := range splitOutsideBrackets(rule, ';') {
+		name, value, found := strings.Cut(property, ":")
+		if !found {
+			continue
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+
+		switch name {
+		case "minlength", "maxlength", "max-consecutive":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return Rules{}, fmt.Errorf("invalid %s %q", name, value)
+			}
+			switch name {
+			case "minlength":
+				rules.MinLength = n
+			case "maxlength":
+				rules.MaxLength = n
+			default:
+				rules.MaxConsecutive = n
+			}
+		case "required", "allowed":
+			chars, err := parseClasses(value)
+			if err != nil {
+				return Rules{}, err
+			}
+			if name == "required" {
+				rules.Required = append(rules.Required, chars)
+			}
+			allowed += chars
+		}
+	}
+	if allowed == "" {
+		allowed = asciiPrintable
+	}
+	rules.Allowed = uniqueCharacters(allowed)
+	return rules, nil
+}
+
+// Apply adjusts opts so generated passwords satisfy the rules.
+// Purpose:
+//
+//	Enables exactly the character classes the site accepts, excludes symbols
+//	it rejects, and clamps the length into the permitted range. Exclusions
+//	already present in opts are kept.
+//
+// Parameters:
+//   - opts (passgen.PasswordOptions): The options to adjust.
+//
+// Returns:
+//
+//	passgen.PasswordOptions: The adjusted options.
+func (r Rules) Apply(opts passgen.PasswordOptions) passgen.PasswordOptions {
+	opts.IncludeSymbols = strings.ContainsAny(r.Allowed, passgen.Symbols)
+	opts.IncludeNumbers = strings.ContainsAny(r.Allowed, passgen.Digits)
+	opts.IncludeUpper = strings.ContainsAny(r.Allowed, passgen.Uppercase)
+	opts.IncludeLower = strings.ContainsAny(r.Allowed, passgen.Lowercase)
+
+	excluded := opts.ExcludeCharacters
+	for _, char := range passgen.Symbols + passgen.Digits + passgen.Uppercase + passgen.Lowercase {
+		if !strings.ContainsRune(r.Allowed, char) && !strings.ContainsRune(excluded, char) {
+			excluded += string(char)
+		}
+	}
+	opts.ExcludeCharacters = excluded
+
+	if r.MinLength > 0 && opts.Length < r.MinLength {
+		opts.Length = r.MinLength
+	}
+	if r.MaxLength > 0 && opts.Length > r.MaxLength {
+		opts.Length = r.MaxLength
+	}
+	return opts
+}
+
+// Constraint returns a passgen.Constraint that rejects passwords missing a
+// character of a required group or repeating a character more than
+// MaxConsecutive times in a row, which Apply cannot express as options.
+// Example:
+//
+//	g := passgen.NewGenerator(passgen.WithConstraints(rules.Constraint()))
+func (r Rules) Constraint() passgen.Constraint {
+	return constraint{r}
+}
+
+// constraint implements Rules.Constraint.
+type constraint struct {
+	rules Rules
+}
+
+func (constraint) Apply(pool string, _ []byte, _ int) string { return pool }
+
+func (c constraint) Check(candidate []byte) bool {
+	return c.rules.Satisfied(string(candidate))
+}
+
+// Satisfied reports whether password has a character of every required
+// group and no run of identical characters longer than MaxConsecutive.
+func (r Rules) Satisfied(password string) bool {
+	for _, group := range r.Required {
+		if !strings.ContainsAny(password, group) {
+			return false
+		}
+	}
+	if r.MaxConsecutive > 0 {
+		run := 0
+		var previous rune
+		for i, char := range password {
+			if i > 0 && char == previous {
+				run++
+			} else {
+				run = 1
+			}
+			if run > r.MaxConsecutive {
+				return false
+			}
+			previous = char
+		}
+	}
+	return true
+}
+
+// Describe summarises the rules in one line for display.
+func (r Rules) Describe() string {
+	var parts []string
+	switch {
+	case r.MinLength > 0 && r.MaxLength > 0:
+		parts = append(parts, fmt.Sprintf("%d-%d characters", r.MinLength, r.MaxLength))
+	case r.MinLength > 0:
+		parts = append(parts, fmt.Sprintf("at least %d characters", r.MinLength))
+	case r.MaxLength > 0:
+		parts = append(parts, fmt.Sprintf("at most %d characters", r.MaxLength))
+	}
+	if len(r.Required) > 0 {
+		parts = append(parts, fmt.Sprintf("%d required character groups", len(r.Required)))
+	}
+	if r.MaxConsecutive > 0 {
+		parts = append(parts, fmt.Sprintf("no more than %d identical characters in a row", r.MaxConsecutive))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Database maps website domains to their password rules.
+type Database map[string]Rules
+
+// Bundled returns the rules database embedded in the binary.
+func Bundled() (Database, error) {
+	return Load(strings.NewReader(string(bundledRules)))
+}
+
+// Load reads a database in the password-rules.json format, where each domain
+// maps to an object with a "password-rules" string.
+func Load(r io.Reader) (Database, error) {
+	var raw map[string]struct {
+		PasswordRules string `json:"password-rules"`
+	}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+	db := make(Database, len(raw))
+	for domain, entry := range raw {
+		rules, err := Parse(entry.PasswordRules)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", domain, err)
+		}
+		db[strings.ToLower(domain)] = rules
+	}
+	return db, nil
+}
+
+// Lookup finds the rules for a site given as a domain or URL, falling back to
+// parent domains so "login.example.com" matches "example.com".
+// Returns:
+//
+//	string: The domain whose rules matched.
+//	Rules: The matching rules.
+//	bool: False if no rules are known for the site.
+func (db Database) Lookup(site string) (string, Rules, bool) {
+	for domain := NormalizeSite(site); domain != ""; {
+		if rules, ok := db[domain]; ok {
+			return domain, rules, true
+		}
+		_, parent, found := strings.Cut(domain, ".")
+		if !found || !strings.Contains(parent, ".") {
+			break
+		}
+		domain = parent
+	}
+	return "", Rules{}, false
+}
+
+// Sites returns the known domains in alphabetical order.
+func (db Database) Sites() []string {
+	sites := make([]string, 0, len(db))
+	for domain := range db {
+		sites = append(sites, domain)
+	}
+	sort.Strings(sites)
+	return sites
+}
+
+// NormalizeSite reduces a URL or host name to a lowercase domain without "www.".
+func NormalizeSite(site string) string {
+	site = strings.ToLower(strings.TrimSpace(site))
+	if !strings.Contains(site, "://") {
+		site = "//" + site
+	}
+	if u, err := url.Parse(site); err == nil {
+		site = u.Hostname()
+	}
+	return strings.TrimPrefix(site, "www.")
+}
+
+// parseClasses resolves a comma-separated list of named classes and [custom] sets.
+func parseClasses(value string) (string, error) {
+	var chars strings.Builder
+	for _, class := range splitOutsideBrackets(value, ',') {
+		class = strings.TrimSpace(class)
+		switch {
+		case class == "":
+			continue
+		case strings.HasPrefix(class, "[") && strings.HasSuffix(class, "]"):
+			chars.WriteString(class[1 : len(class)-1])
+		default:
+			named, ok := namedClasses[class]
+			if !ok {
+				return "", errors.New("unknown character class " + strconv.Quote(class))
+			}
+			chars.WriteString(named)
+		}
+	}
+	return chars.String(), nil
+}
+
+// splitOutsideBrackets splits s at sep, ignoring separators inside [custom]
+// sets. A "]" directly after the opening "[" belongs to the set.
+func splitOutsideBrackets(s string, sep byte) []string {
+	var parts []string
+	start, inBracket := 0, false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case !inBracket && s[i] == '[':
+			inBracket = true
+			if i+1 < len(s) && s[i+1] == ']' {
+				i++
+			}
+		case inBracket && s[i] == ']':
+			inBracket = false
+		case !inBracket && s[i] == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// uniqueCharacters removes repeated characters while keeping their order.
+func uniqueCharacters(s string) string {
+	var b strings.Builder
+	for i, char := range s {
+		if !strings.ContainsRune(s[:i], char) {
+			b.WriteRune(char)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,117 @@
+package siterules
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// TestParse checks lengths, required groups and allowed characters.
+func TestParse(t *testing.T) {
+	rules, err := Parse("minlength: 8; maxlength: 20; max-consecutive: 3; required: lower, upper; required: digit; allowed: [-_!];")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if rules.MinLength != 8 || rules.MaxLength != 20 || rules.MaxConsecutive != 3 {
+		t.Errorf("Expected limits 8/20/3, but got %d/%d/%d", rules.MinLength, rules.MaxLength, rules.MaxConsecutive)
+	}
+	if len(rules.Required) != 2 {
+		t.Errorf("Expected 2 required groups, but got %d", len(rules.Required))
+	}
+	if strings.ContainsAny(rules.Allowed, "#@") || !strings.ContainsAny(rules.Allowed, "-_!") {
+		t.Errorf("Unexpected allowed characters %q", rules.Allowed)
+	}
+}
+
+// TestParse_BracketEdgeCases verifies separators and "]" inside custom sets.
+func TestParse_BracketEdgeCases(t *testing.T) {
+	rules, err := Parse("allowed: lower, []-;,];")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, char := range "]-;," {
+		if !strings.ContainsRune(rules.Allowed, char) {
+			t.Errorf("Expected %q to be allowed", char)
+		}
+	}
+}
+
+// TestParse_UnknownClass ensures misspelled classes are reported.
+func TestParse_UnknownClass(t *testing.T) {
+	if _, err := Parse("required: digits;"); err == nil {
+		t.Errorf("Expected an error for an unknown class, but got nil")
+	}
+}
+
+// TestApply verifies that generated passwords follow a site's rules.
+func TestApply(t *testing.T) {
+	rules, _ := Parse("minlength: 16; maxlength: 20; required: lower; required: digit; allowed: [-_];")
+	opts := rules.Apply(passgen.PasswordOptions{Length: 8, Quantity: 20, IncludeUpper: true, IncludeSymbols: true})
+
+	if opts.Length != 16 {
+		t.Errorf("Expected length 16, but got %d", opts.Length)
+	}
+	if opts.IncludeUpper || !opts.IncludeLower || !opts.IncludeNumbers || !opts.IncludeSymbols {
+		t.Errorf("Unexpected character classes %+v", opts)
+	}
+	passwords, err := passgen.GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, password := range passwords {
+		for _, char := range password {
+			if !strings.ContainsRune(rules.Allowed, char) {
+				t.Errorf("Password %q contains disallowed character %q", password, char)
+			}
+		}
+	}
+}
+
+// TestConstraint verifies that required groups and max-consecutive are
+// enforced on generated passwords.
+func TestConstraint(t *testing.T) {
+	rules, err := Parse("minlength: 8; maxlength: 8; max-consecutive: 1; required: digit; required: [#]; allowed: lower;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for password, expected := range map[string]bool{"ab1#cdef": true, "abc1defg": false, "aa1#bcde": false, "ab1#cded": true} {
+		if got := rules.Satisfied(password); got != expected {
+			t.Errorf("Expected Satisfied(%q) to be %v, but got %v", password, expected, got)
+		}
+	}
+
+	opts := rules.Apply(passgen.PasswordOptions{Length: 8, Quantity: 50})
+	g := passgen.NewGenerator(passgen.WithConstraints(rules.Constraint()))
+	passwords, err := g.GeneratePasswords(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, password := range passwords {
+		if !strings.ContainsAny(password, passgen.Digits) || !strings.Contains(password, "#") {
+			t.Errorf("Expected a digit and #, but got %q", password)
+		}
+		for i := 1; i < len(password); i++ {
+			if password[i] == password[i-1] {
+				t.Errorf("Expected no repeated characters in a row, but got %q", password)
+			}
+		}
+	}
+}
+
+// TestBundledLookup checks the embedded database and domain normalisation.
+func TestBundledLookup(t *testing.T) {
+	db, err := Bundled()
+	if err != nil {
+		t.Fatalf("Expected bundled rules to load, but got %v", err)
+	}
+	for _, site := range []string{"apple.com", "https://www.apple.com/account", "appleid.apple.com", "APPLE.COM:443"} {
+		if domain, _, ok := db.Lookup(site); !ok || domain != "apple.com" {
+			t.Errorf("Expected %q to match apple.com, but got %q", site, domain)
+		}
+	}
+	if _, _, ok := db.Lookup("example.invalid"); ok {
+		t.Errorf("Expected no rules for an unknown site")
+	}
+}
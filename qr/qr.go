@@ -0,0 +1,53 @@
+/**
+ * Password Generator - QR Codes
+ *
+ * This file renders text such as a password or a secret share as a QR code
+ * PNG, so it can be scanned or printed without going through the clipboard,
+ * and builds the Wi-Fi codes phones join a network from.
+ */
+
+package qr
+
+import (
+	"os"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// DefaultSize is the default edge length of generated images in pixels.
+const DefaultSize = 256
+
+// PNG encodes text as a QR code image.
+// Parameters:
+//   - text (string): The content of the code.
+//   - size (int): Edge length of the image in pixels.
+//
+// Returns:
+//
+//	[]byte: The PNG image.
+//	error: An error if the text is too long for a QR code.
+func PNG(text string, size int) ([]byte, error) {
+	return qrcode.Encode(text, qrcode.Medium, size)
+}
+
+// WriteFile writes text as a QR code PNG that only the current user can read.
+func WriteFile(path, text string, size int) error {
+	png, err := PNG(text, size)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, png, 0600)
+}
+
+// wifiEscaper escapes the characters with a meaning in Wi-Fi codes.
+var wifiEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, `:`, `\:`, `"`, `\"`)
+
+// WiFi returns the text of a QR code that joins the WPA/WPA2 network ssid
+// with password when scanned by a phone's camera.
+// Example:
+//
+//	png, err := qr.PNG(qr.WiFi("Office Guest", key), qr.DefaultSize)
+func WiFi(ssid, password string) string {
+	return "WIFI:T:WPA;S:" + wifiEscaper.Replace(ssid) + ";P:" + wifiEscaper.Replace(password) + ";;"
+}
@@ -0,0 +1,13 @@
+package qr
+
+import "testing"
+
+// TestWiFi verifies the Wi-Fi code layout and the escaping of its special
+// characters.
+func TestWiFi(t *testing.T) {
+	got := WiFi(`Cafe "Bean"`, `a;b,c:d\e`)
+	want := `WIFI:T:WPA;S:Cafe \"Bean\";P:a\;b\,c\:d\\e;;`
+	if got != want {
+		t.Errorf("Expected %q, but got %q", want, got)
+	}
+}
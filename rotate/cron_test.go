@@ -0,0 +1,46 @@
+package rotate
+
+import (
+	"testing"
+	"time"
+)
+
+// TestScheduleNext checks common expressions against known next run times.
+func TestScheduleNext(t *testing.T) {
+	from := time.Date(2024, time.March, 15, 10, 20, 30, 0, time.UTC) // a Friday
+	tests := map[string]time.Time{
+		"*/15 * * * *": time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC),
+		"0 3 * * 0":    time.Date(2024, time.March, 17, 3, 0, 0, 0, time.UTC),
+		"0 0 1 * *":    time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		"@yearly":      time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"30 9 1-5 * 7": time.Date(2024, time.March, 17, 9, 30, 0, 0, time.UTC),
+		"0 12 29 2 *":  time.Date(2028, time.February, 29, 12, 0, 0, 0, time.UTC),
+	}
+	for expr, want := range tests {
+		schedule, err := ParseSchedule(expr)
+		if err != nil {
+			t.Errorf("Expected %q to parse, but got %v", expr, err)
+			continue
+		}
+		if got := schedule.Next(from); !got.Equal(want) {
+			t.Errorf("Expected %q to run next at %v, but got %v", expr, want, got)
+		}
+	}
+}
+
+// TestScheduleNext_Impossible returns the zero time for dates that never occur.
+func TestScheduleNext_Impossible(t *testing.T) {
+	schedule, _ := ParseSchedule("0 0 30 2 *")
+	if got := schedule.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Expected no next run, but got %v", got)
+	}
+}
+
+// TestParseSchedule_Invalid rejects malformed expressions.
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, expr := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseSchedule(expr); err == nil {
+			t.Errorf("Expected %q to be rejected, but got nil", expr)
+		}
+	}
+}
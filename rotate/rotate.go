@@ -0,0 +1,255 @@
+/**
+ * Password Generator - Scheduled Rotation
+ *
+ * This file implements the rotation daemon. A JSON configuration lists
+ * secrets, each with a cron schedule, password options and delivery targets.
+ * When a secret is due, a new value is generated and delivered to every
+ * target. The log only ever records metadata such as names, lengths and
+ * targets, never the secret values.
+ */
+
+package rotate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/delivery"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/policy"
+)
+
+// SecretConfig describes one rotated secret in the configuration file.
+// Fields:
+//   - Name (string): A unique name used in logs and passed to targets.
+//   - Schedule (string): A cron expression, see ParseSchedule.
+//   - Options (passgen.PasswordOptions): Generation options; unset fields
+//     keep the application defaults.
+//   - Targets ([]delivery.Config): Where each new value is delivered.
+type SecretConfig struct {
+	Name     string                  `json:"name"`
+	Schedule string                  `json:"schedule"`
+	Options  passgen.PasswordOptions `json:"options"`
+	Targets  []delivery.Config       `json:"targets"`
+}
+
+// Config is the rotation configuration file.
+type Config struct {
+	Secrets []SecretConfig `json:"secrets"`
+}
+
+// job is a secret ready to be rotated.
+type job struct {
+	name     string
+	schedule Schedule
+	options  passgen.PasswordOptions
+	targets  []delivery.Target
+}
+
+// Rotator regenerates and delivers the configured secrets.
+type Rotator struct {
+	jobs      []job
+	logger    *log.Logger
+	managed   config.Managed
+	policy    *policy.Policy
+	generator *passgen.Generator
+}
+
+// Option configures a Rotator.
+type Option func(*Rotator)
+
+// WithManaged enforces the organization's managed settings on every secret:
+// its options are raised to the minimum length, the safety floor and the
+// locked character types before the first rotation.
+func WithManaged(managed config.Managed) Option {
+	return func(r *Rotator) { r.managed = managed }
+}
+
+// WithPolicy adjusts the options of every secret to p.
+func WithPolicy(p *policy.Policy) Option {
+	return func(r *Rotator) { r.policy = p }
+}
+
+// WithGenerator generates the secrets with g instead of the package-level
+// passgen functions, e.g. one with the constraints of a policy or breach
+// list.
+func WithGenerator(g *passgen.Generator) Option {
+	return func(r *Rotator) { r.generator = g }
+}
+
+// LoadConfig reads a rotation configuration file.
+func LoadConfig(path string) (Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Config{}, err
+	}
+	defer file.Close()
+	return ReadConfig(file)
+}
+
+// ReadConfig parses a rotation configuration, starting every secret's options
+// from the application defaults.
+func ReadConfig(r io.Reader) (Config, error) {
+	var raw struct {
+		Secrets []json.RawMessage `json:"secrets"`
+	}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return Config{}, err
+	}
+	var cfg Config
+	for _, message := range raw.Secrets {
+		secret := SecretConfig{Options: *config.GetDefaultOptions()}
+		if err := json.Unmarshal(message, &secret); err != nil {
+			return Config{}, err
+		}
+		cfg.Secrets = append(cfg.Secrets, secret)
+	}
+	return cfg, nil
+}
+
+// New validates cfg and prepares the schedules and targets.
+// Parameters:
+//   - cfg (Config): The rotation configuration.
+//   - logger (*log.Logger): Receives one line per rotation, without secrets.
+//   - options (...Option): E.g. WithManaged, to enforce the settings of the
+//     organization on unattended rotations.
+//
+// Returns:
+//
+//	*Rotator: A rotator ready to Run.
+//	error: An error describing the first invalid secret, including options
+//	that cannot meet the managed settings or the policy.
+//
+// Example:
+//
+//	r, err := rotate.New(cfg, log.New(os.Stderr, "", log.LstdFlags), rotate.WithManaged(managed))
+func New(cfg Config, logger *log.Logger, options ...Option) (*Rotator, error) {
+	if len(cfg.Secrets) == 0 {
+		return nil, errors.New("no secrets configured")
+	}
+	r := &Rotator{logger: logger}
+	for _, option := range options {
+		option(r)
+	}
+	names := make(map[string]bool)
+	for _, secret := range cfg.Secrets {
+		if secret.Name == "" || names[secret.Name] {
+			return nil, fmt.Errorf("secret names must be unique and not empty: %q", secret.Name)
+		}
+		names[secret.Name] = true
+
+		schedule, err := ParseSchedule(secret.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", secret.Name, err)
+		}
+		if len(secret.Targets) == 0 {
+			return nil, fmt.Errorf("%s: no targets configured", secret.Name)
+		}
+		j := job{name: secret.Name, schedule: schedule, options: secret.Options}
+		if j.options.Length == 0 {
+			j.options.Length = j.options.DefaultLength
+		}
+		j.options.Quantity = 1
+		if r.policy != nil {
+			j.options = r.policy.Apply(j.options)
+		}
+		j.options = r.managed.Apply(j.options)
+		if err := passgen.Validate(j.options); err != nil {
+			return nil, fmt.Errorf("%s: %w", secret.Name, err)
+		}
+		for _, targetCfg := range secret.Targets {
+			target, err := delivery.New(targetCfg)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", secret.Name, err)
+			}
+			j.targets = append(j.targets, target)
+		}
+		r.jobs = append(r.jobs, j)
+	}
+	return r, nil
+}
+
+// RotateAll rotates every secret once, regardless of its schedule.
+func (r *Rotator) RotateAll(ctx context.Context) error {
+	var failed error
+	for _, j := range r.jobs {
+		if err := r.rotate(ctx, j); err != nil && failed == nil {
+			failed = err
+		}
+	}
+	return failed
+}
+
+// Run rotates secrets as their schedules come due until ctx is cancelled.
+// Failed rotations are logged and retried at the next scheduled time.
+func (r *Rotator) Run(ctx context.Context) error {
+	next := make([]time.Time, len(r.jobs))
+	now := time.Now()
+	for i, j := range r.jobs {
+		next[i] = j.schedule.Next(now)
+		r.logger.Printf("%s: next rotation at %s", j.name, next[i].Format(time.RFC3339))
+	}
+
+	for {
+		earliest := time.Time{}
+		for _, t := range next {
+			if !t.IsZero() && (earliest.IsZero() || t.Before(earliest)) {
+				earliest = t
+			}
+		}
+		if earliest.IsZero() {
+			return errors.New("no schedule has a future run time")
+		}
+
+		timer := time.NewTimer(time.Until(earliest))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		for i, j := range r.jobs {
+			if next[i].IsZero() || now.Before(next[i]) {
+				continue
+			}
+			_ = r.rotate(ctx, j)
+			next[i] = j.schedule.Next(now)
+		}
+	}
+}
+
+// rotate generates a new value for j and delivers it to every target.
+func (r *Rotator) rotate(ctx context.Context, j job) error {
+	generate := passgen.GeneratePasswords
+	if r.generator != nil {
+		generate = r.generator.GeneratePasswords
+	}
+	passwords, err := generate(ctx, j.options)
+	if err != nil {
+		r.logger.Printf("%s: generation failed: %v", j.name, err)
+		return err
+	}
+	secret := delivery.Secret{Name: j.name, Value: passwords[0], Generated: time.Now()}
+
+	var failed error
+	for _, target := range j.targets {
+		if err := target.Deliver(ctx, secret); err != nil {
+			r.logger.Printf("%s: delivery to %s failed: %v", j.name, target, err)
+			if failed == nil {
+				failed = err
+			}
+			continue
+		}
+		r.logger.Printf("%s: rotated (%d characters), delivered to %s", j.name, len(secret.Value), target)
+	}
+	return failed
+}
@@ -0,0 +1,92 @@
+package rotate
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/delivery"
+)
+
+// TestRotateAll reads a configuration, rotates once and checks the log.
+func TestRotateAll(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db-password")
+	cfg, err := ReadConfig(strings.NewReader(`{"secrets": [{
+		"name": "db", "schedule": "@daily",
+		"options": {"Length": 24, "IncludeSymbols": false},
+		"targets": [{"type": "file", "path": "` + filepath.ToSlash(path) + `"}]
+	}]}`))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	var logs bytes.Buffer
+	rotator, err := New(cfg, log.New(&logs, "", 0))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := rotator.RotateAll(context.Background()); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	secret, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the secret file, but got %v", err)
+	}
+	if len(secret) != 24 || strings.ContainsAny(string(secret), "!@#$%^&*") {
+		t.Errorf("Expected 24 characters without symbols, but got %q", secret)
+	}
+	if strings.Contains(logs.String(), string(secret)) {
+		t.Errorf("The log must not contain the secret: %q", logs.String())
+	}
+}
+
+// TestNew_Managed verifies that managed settings raise the length of a
+// rotated secret and reject options below the safety floor.
+func TestNew_Managed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db-password")
+	secret := SecretConfig{Name: "db", Schedule: "@daily", Options: *config.GetDefaultOptions(), Targets: []delivery.Config{{Type: "file", Path: path}}}
+	secret.Options.Length = 12
+	cfg := Config{Secrets: []SecretConfig{secret}}
+
+	rotator, err := New(cfg, log.New(&bytes.Buffer{}, "", 0), WithManaged(config.Managed{MinLength: 20}))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := rotator.RotateAll(context.Background()); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	value, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(value) != 20 {
+		t.Errorf("Expected the managed minimum of 20 characters, but got %d", len(value))
+	}
+
+	cfg.Secrets[0].Options.IncludeSymbols = false
+	cfg.Secrets[0].Options.IncludeUpper = false
+	cfg.Secrets[0].Options.IncludeNumbers = false
+	if _, err := New(cfg, log.New(&bytes.Buffer{}, "", 0), WithManaged(config.Managed{SafetyFloor: 200})); err == nil {
+		t.Error("Expected an error for options below the managed safety floor, but got nil")
+	}
+}
+
+// TestNew_Invalid rejects incomplete configurations.
+func TestNew_Invalid(t *testing.T) {
+	valid := SecretConfig{Name: "a", Schedule: "@daily", Targets: []delivery.Config{{Type: "file", Path: "x"}}}
+	noTargets := valid
+	noTargets.Targets = nil
+	badSchedule := valid
+	badSchedule.Schedule = "daily"
+	for _, cfg := range []Config{{}, {Secrets: []SecretConfig{valid, valid}}, {Secrets: []SecretConfig{noTargets}}, {Secrets: []SecretConfig{badSchedule}}} {
+		if _, err := New(cfg, log.New(&bytes.Buffer{}, "", 0)); err == nil {
+			t.Errorf("Expected an error for %+v, but got nil", cfg)
+		}
+	}
+}
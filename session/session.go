@@ -0,0 +1,166 @@
+/**
+ * Password Generator - Session Autosave
+ *
+ * This file periodically saves the GUI session so it can be restored after a
+ * crash or an accidental close: the selected options and, if the user opts
+ * in, the results that were not copied yet. Results are only ever written
+ * encrypted for the current user (DPAPI on Windows); where no such encryption
+ * is available, only the options are saved.
+ */
+
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/dpapi"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// DefaultInterval is how often the Autosaver writes pending changes.
+const DefaultInterval = 30 * time.Second
+
+// Encryption of saved results, replaced in tests.
+var (
+	protect   = dpapi.Protect
+	unprotect = dpapi.Unprotect
+	// CanSaveResults reports whether results can be saved encrypted here.
+	CanSaveResults = dpapi.Supported
+)
+
+// State is a saved session.
+// Fields:
+//   - Options (passgen.PasswordOptions): The options selected in the form.
+//   - Passwords ([]string): Un-copied results; never written unencrypted.
+//   - SavedAt (time.Time): When the state was saved.
+type State struct {
+	Options   passgen.PasswordOptions
+	Passwords []string
+	SavedAt   time.Time
+}
+
+// file is the on-disk form of State.
+type file struct {
+	Options          passgen.PasswordOptions `json:"options"`
+	EncryptedResults []byte                  `json:"encrypted_results,omitempty"`
+	SavedAt          time.Time               `json:"saved_at"`
+}
+
+// Save writes state to path, readable only by the current user. Passwords are
+// encrypted, or left out if CanSaveResults is false.
+func Save(path string, state State) error {
+	f := file{Options: state.Options, SavedAt: state.SavedAt}
+	if len(state.Passwords) > 0 && CanSaveResults {
+		blob, err := protect([]byte(strings.Join(state.Passwords, "\n")))
+		if err != nil {
+			return err
+		}
+		f.EncryptedResults = blob
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load reads the session saved at path. ok is false if there is none.
+func Load(path string) (state State, ok bool, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return State{}, false, nil
+	}
+	if err != nil {
+		return State{}, false, err
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return State{}, false, err
+	}
+	state = State{Options: f.Options, SavedAt: f.SavedAt}
+	if len(f.EncryptedResults) > 0 {
+		plain, err := unprotect(f.EncryptedResults)
+		if err != nil {
+			// The options are still worth restoring.
+			return state, true, err
+		}
+		state.Passwords = strings.Split(string(plain), "\n")
+	}
+	return state, true, nil
+}
+
+// Autosaver saves the latest session state in the background.
+type Autosaver struct {
+	path string
+
+	mu      sync.Mutex
+	pending *State
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewAutosaver returns an Autosaver writing to path.
+func NewAutosaver(path string) *Autosaver {
+	return &Autosaver{path: path}
+}
+
+// Update records the state to save at the next interval.
+func (a *Autosaver) Update(state State) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pending = &state
+}
+
+// Flush saves the pending state now, if there is one.
+func (a *Autosaver) Flush() error {
+	a.mu.Lock()
+	pending := a.pending
+	a.pending = nil
+	a.mu.Unlock()
+	if pending == nil {
+		return nil
+	}
+	if pending.SavedAt.IsZero() {
+		pending.SavedAt = time.Now()
+	}
+	return Save(a.path, *pending)
+}
+
+// Start saves pending changes every interval until Stop is called.
+func (a *Autosaver) Start(interval time.Duration) {
+	a.stop = make(chan struct{})
+	a.done = make(chan struct{})
+	go func() {
+		defer close(a.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-a.stop:
+				return
+			case <-ticker.C:
+				_ = a.Flush()
+			}
+		}
+	}()
+}
+
+// Stop ends background saving and saves any pending state.
+func (a *Autosaver) Stop() error {
+	if a.stop != nil {
+		close(a.stop)
+		<-a.done
+		a.stop = nil
+	}
+	return a.Flush()
+}
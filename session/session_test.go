@@ -0,0 +1,89 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// fakeEncryption replaces DPAPI with a reversible transformation for the test.
+func fakeEncryption(t *testing.T, supported bool) {
+	oldProtect, oldUnprotect, oldSupported := protect, unprotect, CanSaveResults
+	t.Cleanup(func() { protect, unprotect, CanSaveResults = oldProtect, oldUnprotect, oldSupported })
+	reverse := func(data []byte) ([]byte, error) {
+		out := make([]byte, len(data))
+		for i, b := range data {
+			out[len(data)-1-i] = b
+		}
+		return out, nil
+	}
+	protect, unprotect, CanSaveResults = reverse, reverse, supported
+}
+
+// TestSaveAndLoad verifies that options and results survive a round trip and
+// that results are never stored in plain text.
+func TestSaveAndLoad(t *testing.T) {
+	fakeEncryption(t, true)
+	path := filepath.Join(t.TempDir(), "session.json")
+	want := State{
+		Options:   passgen.PasswordOptions{Length: 20, IncludeLower: true, NoSimilar: true},
+		Passwords: []string{"first-secret", "second-secret"},
+		SavedAt:   time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC),
+	}
+	if err := Save(path, want); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "first-secret") {
+		t.Errorf("Expected results to be encrypted, but found them in %s", data)
+	}
+
+	got, ok, err := Load(path)
+	if err != nil || !ok {
+		t.Fatalf("Expected a saved session, but got ok=%v err=%v", ok, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, but got %+v", want, got)
+	}
+}
+
+// TestSave_WithoutEncryption verifies that results are dropped when they cannot be encrypted.
+func TestSave_WithoutEncryption(t *testing.T) {
+	fakeEncryption(t, false)
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := Save(path, State{Options: passgen.PasswordOptions{Length: 8}, Passwords: []string{"secret"}}); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	got, _, err := Load(path)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if got.Options.Length != 8 || len(got.Passwords) != 0 {
+		t.Errorf("Expected only the options to be saved, but got %+v", got)
+	}
+}
+
+// TestAutosaver verifies that the pending state is written on Stop and that
+// a missing session loads as none.
+func TestAutosaver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	if _, ok, err := Load(path); ok || err != nil {
+		t.Fatalf("Expected no session, but got ok=%v err=%v", ok, err)
+	}
+
+	saver := NewAutosaver(path)
+	saver.Start(time.Hour)
+	saver.Update(State{Options: passgen.PasswordOptions{Length: 14}})
+	if err := saver.Stop(); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	got, ok, err := Load(path)
+	if err != nil || !ok || got.Options.Length != 14 {
+		t.Errorf("Expected the pending state to be saved, but got %+v ok=%v err=%v", got, ok, err)
+	}
+}
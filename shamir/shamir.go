@@ -0,0 +1,202 @@
+/**
+ * Password Generator - Shamir Secret Sharing
+ *
+ * This file splits a secret into N shares of which any K recover it, using
+ * Shamir's scheme over GF(2^8). Fewer than K shares reveal nothing about the
+ * secret. A short checksum is shared along with the secret so that combining
+ * unrelated or corrupted shares is detected instead of yielding garbage.
+ */
+
+package shamir
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// checksumSize is the number of SHA-256 bytes appended to the secret.
+const checksumSize = 4
+
+// sharePrefix starts the text form of every share.
+const sharePrefix = "PGS1"
+
+// Share is one piece of a split secret.
+// Fields:
+//   - Threshold (int): How many shares are needed to recover the secret.
+//   - X (byte): The share number, 1-255.
+//   - Y ([]byte): The share data.
+type Share struct {
+	Threshold int
+	X         byte
+	Y         []byte
+}
+
+// Split divides secret into n shares, any k of which recover it.
+// Purpose:
+//
+//	Evaluates a random polynomial of degree k-1 per byte, whose constant term
+//	is the secret byte, at the points 1..n.
+//
+// Parameters:
+//   - secret ([]byte): The secret to split, e.g. a generated password.
+//   - n (int): Number of shares to create (2-255).
+//   - k (int): Number of shares needed to recover the secret (2-n).
+//
+// Returns:
+//
+//	[]Share: The n shares.
+//	error: An error if the parameters are invalid or randomness fails.
+//
+// Example:
+//
+//	shares, err := shamir.Split([]byte(password), 5, 3)
+func Split(secret []byte, n, k int) ([]Share, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("secret must not be empty")
+	}
+	if k < 2 || k > n || n > 255 {
+		return nil, errors.New("need 2 <= threshold <= shares <= 255")
+	}
+
+	sum := sha256.Sum256(secret)
+	data := append(append([]byte(nil), secret...), sum[:checksumSize]...)
+
+	shares := make([]Share, n)
+	for i := range shares {
+		shares[i] = Share{Threshold: k, X: byte(i + 1), Y: make([]byte, len(data))}
+	}
+	coefficients := make([]byte, k)
+	for j, b := range data {
+		coefficients[0] = b
+		if _, err := rand.Read(coefficients[1:]); err != nil {
+			return nil, err
+		}
+		for i := range shares {
+			shares[i].Y[j] = evaluate(coefficients, shares[i].X)
+		}
+	}
+	return shares, nil
+}
+
+// Combine recovers the secret from at least Threshold distinct shares.
+// Returns:
+//
+//	[]byte: The secret.
+//	error: An error if shares are missing, mismatched, or corrupted.
+func Combine(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no shares given")
+	}
+	k := shares[0].Threshold
+	seen := make(map[byte]bool)
+	var used []Share
+	for _, share := range shares {
+		if share.Threshold != k || len(share.Y) != len(shares[0].Y) {
+			return nil, errors.New("shares belong to different secrets")
+		}
+		if share.X == 0 || seen[share.X] {
+			continue
+		}
+		seen[share.X] = true
+		used = append(used, share)
+	}
+	if len(used) < k {
+		return nil, fmt.Errorf("need %d distinct shares, got %d", k, len(used))
+	}
+	used = used[:k]
+
+	data := make([]byte, len(used[0].Y))
+	for j := range data {
+		data[j] = interpolateAtZero(used, j)
+	}
+	if len(data) <= checksumSize {
+		return nil, errors.New("share data is too short")
+	}
+	secret, checksum := data[:len(data)-checksumSize], data[len(data)-checksumSize:]
+	sum := sha256.Sum256(secret)
+	if !bytes.Equal(sum[:checksumSize], checksum) {
+		return nil, errors.New("shares do not match; wrong or corrupted share")
+	}
+	return secret, nil
+}
+
+// String returns the text form of the share, e.g. "PGS1-3-2-9f04...".
+func (s Share) String() string {
+	return fmt.Sprintf("%s-%d-%d-%s", sharePrefix, s.Threshold, s.X, hex.EncodeToString(s.Y))
+}
+
+// ParseShare reads the text form produced by Share.String.
+func ParseShare(text string) (Share, error) {
+	parts := strings.Split(strings.TrimSpace(text), "-")
+	if len(parts) != 4 || parts[0] != sharePrefix {
+		return Share{}, errors.New("not a share: expected " + sharePrefix + "-<threshold>-<number>-<data>")
+	}
+	threshold, err := strconv.Atoi(parts[1])
+	if err != nil || threshold < 2 || threshold > 255 {
+		return Share{}, errors.New("invalid share threshold")
+	}
+	x, err := strconv.Atoi(parts[2])
+	if err != nil || x < 1 || x > 255 {
+		return Share{}, errors.New("invalid share number")
+	}
+	y, err := hex.DecodeString(parts[3])
+	if err != nil || len(y) == 0 {
+		return Share{}, errors.New("invalid share data")
+	}
+	return Share{Threshold: threshold, X: byte(x), Y: y}, nil
+}
+
+// evaluate computes the polynomial with the given coefficients at x (Horner).
+func evaluate(coefficients []byte, x byte) byte {
+	var result byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result = mul(result, x) ^ coefficients[i]
+	}
+	return result
+}
+
+// interpolateAtZero evaluates the Lagrange polynomial through byte j of the
+// shares at x = 0.
+func interpolateAtZero(shares []Share, j int) byte {
+	var result byte
+	for i, si := range shares {
+		basis := byte(1)
+		for m, sm := range shares {
+			if m == i {
+				continue
+			}
+			// x_m / (x_m - x_i); subtraction is XOR in GF(2^8).
+			basis = mul(basis, mul(sm.X, inverse(sm.X^si.X)))
+		}
+		result ^= mul(si.Y[j], basis)
+	}
+	return result
+}
+
+// mul multiplies in GF(2^8) with the AES polynomial x^8+x^4+x^3+x+1,
+// without data-dependent branches.
+func mul(a, b byte) byte {
+	var product byte
+	for i := 0; i < 8; i++ {
+		product ^= -(b & 1) & a
+		carry := -(a >> 7)
+		a = (a << 1) ^ (carry & 0x1b)
+		b >>= 1
+	}
+	return product
+}
+
+// inverse returns the multiplicative inverse, a^254.
+func inverse(a byte) byte {
+	result := byte(1)
+	for i := 0; i < 254; i++ {
+		result = mul(result, a)
+	}
+	return result
+}
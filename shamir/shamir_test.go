@@ -0,0 +1,72 @@
+package shamir
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestSplitCombine recovers the secret from every sufficient subset order.
+func TestSplitCombine(t *testing.T) {
+	secret := []byte("correct-horse-battery-staple")
+	shares, err := Split(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+		var picked []Share
+		for _, i := range subset {
+			picked = append(picked, shares[i])
+		}
+		got, err := Combine(picked)
+		if err != nil || !bytes.Equal(got, secret) {
+			t.Errorf("Expected %q from shares %v, but got %q (%v)", secret, subset, got, err)
+		}
+	}
+}
+
+// TestCombine_TooFewShares refuses to combine below the threshold.
+func TestCombine_TooFewShares(t *testing.T) {
+	shares, _ := Split([]byte("secret"), 3, 3)
+	if _, err := Combine(shares[:2]); err == nil {
+		t.Errorf("Expected an error with too few shares, but got nil")
+	}
+	if _, err := Combine([]Share{shares[0], shares[0], shares[1]}); err == nil {
+		t.Errorf("Expected duplicate shares not to count, but got nil")
+	}
+}
+
+// TestCombine_DetectsCorruption relies on the checksum to reject bad shares.
+func TestCombine_DetectsCorruption(t *testing.T) {
+	shares, _ := Split([]byte("secret"), 2, 2)
+	shares[1].Y[0] ^= 0x01
+	if _, err := Combine(shares); err == nil {
+		t.Errorf("Expected an error for a corrupted share, but got nil")
+	}
+}
+
+// TestParseShare round-trips the text form.
+func TestParseShare(t *testing.T) {
+	shares, _ := Split([]byte("secret"), 2, 2)
+	parsed, err := ParseShare(shares[1].String())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if parsed.X != shares[1].X || parsed.Threshold != 2 || !bytes.Equal(parsed.Y, shares[1].Y) {
+		t.Errorf("Expected %v, but got %v", shares[1], parsed)
+	}
+	if _, err := ParseShare("PGS1-2-0-ab"); err == nil {
+		t.Errorf("Expected an error for share number 0, but got nil")
+	}
+}
+
+// TestMulInverse checks the field arithmetic against known AES values.
+func TestMulInverse(t *testing.T) {
+	if got := mul(0x57, 0x83); got != 0xc1 {
+		t.Errorf("Expected 0x57*0x83 = 0xc1, but got %#x", got)
+	}
+	for a := 1; a < 256; a++ {
+		if mul(byte(a), inverse(byte(a))) != 1 {
+			t.Errorf("Expected %#x * inverse to be 1", a)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package sharelink
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// idleBucket is how long a client's bucket is kept after its last request.
+const idleBucket = 10 * time.Minute
+
+// bucket holds the requests a client may still make.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter allows each client, by IP address, Rate requests per second
+// with bursts of up to Burst, so that one caller cannot flood the relay or
+// guess link ids.
+// Fields:
+//   - Rate (float64): Requests per second a client regains.
+//   - Burst (int): Requests a client may make at once.
+//   - Now (func() time.Time): The clock, replaceable in tests.
+type RateLimiter struct {
+	Rate  float64
+	Burst int
+	Now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewRateLimiter returns a limiter allowing perMinute requests a minute per
+// client, in bursts of up to burst.
+func NewRateLimiter(perMinute float64, burst int) *RateLimiter {
+	return &RateLimiter{Rate: perMinute / 60, Burst: burst, Now: time.Now, buckets: make(map[string]*bucket)}
+}
+
+// Allow reports whether client may make a request now, and if not, how long
+// until it may.
+func (l *RateLimiter) Allow(client string) (bool, time.Duration) {
+	now := l.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &bucket{tokens: float64(l.Burst), last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(float64(l.Burst), b.tokens+now.Sub(b.last).Seconds()*l.Rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	if l.Rate <= 0 {
+		return false, time.Hour
+	}
+	return false, time.Duration((1 - b.tokens) / l.Rate * float64(time.Second))
+}
+
+// expire forgets clients idle for longer than idleBucket.
+func (l *RateLimiter) expire() {
+	now := l.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for client, b := range l.buckets {
+		if now.Sub(b.last) > idleBucket {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// clientAddress returns the IP address a request came from. Headers such as
+// X-Forwarded-For are ignored, as any client can set them.
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
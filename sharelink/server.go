@@ -0,0 +1,238 @@
+package sharelink
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often Sweep drops expired secrets.
+const sweepInterval = time.Minute
+
+// entry is one stored ciphertext.
+type entry struct {
+	ciphertext string
+	expires    time.Time
+}
+
+// Server is a minimal relay that keeps ciphertexts in memory. It never sees
+// a key, so it cannot read the secrets it stores; restarting it discards
+// every unread link.
+// Fields:
+//   - MaxTTL (time.Duration): The longest expiry accepted; longer requests
+//     are shortened to it.
+//   - Now (func() time.Time): The clock, replaceable in tests.
+//   - APIKeys ([]string): Keys of which one must be sent as a Bearer token
+//     to store a secret; anyone may store secrets when empty. Opening a
+//     link needs no key, as recipients open it in their browser.
+//   - Limiter (*RateLimiter): Limits API requests per client; nil for no
+//     limit.
+type Server struct {
+	MaxTTL  time.Duration
+	Now     func() time.Time
+	APIKeys []string
+	Limiter *RateLimiter
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+// NewServer returns a relay accepting expiries up to MaxTTL.
+func NewServer() *Server {
+	return &Server{MaxTTL: MaxTTL, Now: time.Now, entries: make(map[string]entry)}
+}
+
+// ServeHTTP implements the relay API and the page that opens a link:
+//
+//	POST /api/secrets      {"ciphertext", "ttl_seconds"} -> {"id", "expires"}
+//	GET  /api/secrets/{id} {"ciphertext"} once, then 404
+//	GET  /s/{id}           a page that fetches and decrypts in the browser
+//
+// The page only consumes the secret when the recipient presses its button, so
+// link previews in chat apps do not burn the link.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	if s.Limiter != nil && strings.HasPrefix(r.URL.Path, "/api/") {
+		ok, wait := s.Limiter.Allow(clientAddress(r))
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+	}
+	switch {
+	case r.URL.Path == "/api/secrets" && r.Method == http.MethodPost:
+		s.store(w, r)
+	case strings.HasPrefix(r.URL.Path, "/api/secrets/") && r.Method == http.MethodGet:
+		s.take(w, strings.TrimPrefix(r.URL.Path, "/api/secrets/"))
+	case strings.HasPrefix(r.URL.Path, "/s/") && r.Method == http.MethodGet:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; script-src 'unsafe-inline'; style-src 'unsafe-inline'; connect-src 'self'")
+		_, _ = w.Write([]byte(openPage))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// store saves a ciphertext under a new random id.
+func (s *Server) store(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="share relay"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	var request publishRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 2*MaxSecretSize)).Decode(&request); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if request.Ciphertext == "" || len(request.Ciphertext) > MaxSecretSize || request.TTLSeconds <= 0 {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	ttl := time.Duration(request.TTLSeconds) * time.Second
+	if ttl > s.MaxTTL || ttl <= 0 {
+		ttl = s.MaxTTL
+	}
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	id := base64.RawURLEncoding.EncodeToString(raw)
+	expires := s.Now().Add(ttl)
+
+	s.mu.Lock()
+	s.entries[id] = entry{ciphertext: request.Ciphertext, expires: expires}
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(publishResponse{ID: id, Expires: expires.UTC()})
+}
+
+// LoadAPIKeys reads API keys from path, one per line. Blank lines and lines
+// starting with # are skipped; a file without keys is an error, so that a
+// relay meant to be closed does not start open.
+func LoadAPIKeys(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			keys = append(keys, line)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%s: no API keys", path)
+	}
+	return keys, nil
+}
+
+// authorized reports whether r carries one of the API keys as a Bearer
+// token, or no keys are configured. Keys are compared in constant time.
+func (s *Server) authorized(r *http.Request) bool {
+	if len(s.APIKeys) == 0 {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return false
+	}
+	authorized := false
+	for _, key := range s.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(key)) == 1 {
+			authorized = true
+		}
+	}
+	return authorized
+}
+
+// take returns a ciphertext and deletes it, so every link opens only once.
+func (s *Server) take(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	e, ok := s.entries[id]
+	delete(s.entries, id)
+	s.mu.Unlock()
+	if !ok || !s.Now().Before(e.expires) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"ciphertext": e.ciphertext})
+}
+
+// Sweep drops expired secrets every minute until ctx is cancelled, so unread
+// ciphertexts do not linger in memory after their expiry.
+func (s *Server) Sweep(ctx context.Context) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.expire()
+		}
+	}
+}
+
+// expire deletes every entry whose expiry has passed, and the rate limits
+// of idle clients.
+func (s *Server) expire() {
+	if s.Limiter != nil {
+		s.Limiter.expire()
+	}
+	now := s.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, e := range s.entries {
+		if !now.Before(e.expires) {
+			delete(s.entries, id)
+		}
+	}
+}
+
+// openPage decrypts a link in the recipient's browser with WebCrypto. The
+// key is read from location.hash and never sent to the relay.
+const openPage = `<!DOCTYPE html>
+<html><head><meta charset="utf-8"><meta name="robots" content="noindex">
+<title>One-time secret</title>
+<style>body{font-family:sans-serif;max-width:36em;margin:4em auto}code{font-size:1.4em;word-break:break-all}</style>
+</head><body>
+<h1>One-time secret</h1>
+<p id="note">This secret can be revealed only once. After that, the link stops working.</p>
+<button id="reveal">Reveal secret</button>
+<p><code id="secret"></code></p>
+<script>
+function decode(s){s=s.replace(/-/g,'+').replace(/_/g,'/');while(s.length%4)s+='=';return Uint8Array.from(atob(s),c=>c.charCodeAt(0));}
+document.getElementById('reveal').onclick=async function(){
+  this.disabled=true;
+  const note=document.getElementById('note');
+  try{
+    const id=location.pathname.split('/s/')[1];
+    const r=await fetch(location.pathname.split('/s/')[0]+'/api/secrets/'+id,{cache:'no-store'});
+    if(!r.ok){note.textContent='This link was already opened or has expired.';return;}
+    const data=decode((await r.json()).ciphertext);
+    const key=await crypto.subtle.importKey('raw',decode(location.hash.slice(1)),'AES-GCM',false,['decrypt']);
+    const plain=await crypto.subtle.decrypt({name:'AES-GCM',iv:data.slice(0,12)},key,data.slice(12));
+    document.getElementById('secret').textContent=new TextDecoder().decode(plain);
+    note.textContent='The secret has been deleted from the relay. Store it now.';
+  }catch(e){note.textContent='The secret could not be decrypted. Check that the whole link was copied.';}
+};
+</script>
+</body></html>
+`
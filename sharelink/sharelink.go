@@ -0,0 +1,282 @@
+/**
+ * Password Generator - One-Time Share Links
+ *
+ * This file publishes a password as a self-destructing link. The password is
+ * encrypted with AES-256-GCM before it leaves the machine; the relay only
+ * stores the ciphertext, and the key travels in the link's #fragment, which
+ * browsers never send to the server. The relay hands the ciphertext out once
+ * and forgets it, or drops it when it expires unread.
+ */
+
+package sharelink
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTTL is how long an unread link stays valid by default.
+	DefaultTTL = 24 * time.Hour
+	// MaxTTL is the longest expiry a relay accepts by default.
+	MaxTTL = 7 * 24 * time.Hour
+	// MaxSecretSize bounds the ciphertext a relay stores.
+	MaxSecretSize = 64 << 10
+
+	keySize        = 32
+	requestTimeout = 30 * time.Second
+)
+
+// Errors of the relay.
+var (
+	// ErrGone is returned when a link was already opened, expired or never existed.
+	ErrGone = errors.New("the link was already opened or has expired")
+	// ErrUnauthorized is returned when the relay requires an API key that
+	// was not given or is wrong.
+	ErrUnauthorized = errors.New("the relay requires a valid API key")
+	// ErrRateLimited is returned when the relay refuses further requests
+	// for now.
+	ErrRateLimited = errors.New("the relay received too many requests; try again later")
+)
+
+// publishRequest and publishResponse are the JSON bodies of POST /api/secrets.
+type publishRequest struct {
+	Ciphertext string `json:"ciphertext"`
+	TTLSeconds int64  `json:"ttl_seconds"`
+}
+
+type publishResponse struct {
+	ID      string    `json:"id"`
+	Expires time.Time `json:"expires"`
+}
+
+// Seal encrypts secret with a fresh random key.
+// Returns:
+//
+//	[]byte: The nonce followed by the AES-256-GCM ciphertext and tag.
+//	[]byte: The key, which must travel separately from the ciphertext.
+//	error: An error if the random source fails.
+func Seal(secret []byte) ([]byte, []byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+	return aead.Seal(nonce, nonce, secret, nil), key, nil
+}
+
+// Open decrypts a ciphertext produced by Seal.
+func Open(ciphertext, key []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, errors.New("the link key does not match the secret")
+	}
+	return plain, nil
+}
+
+// newAEAD returns AES-256-GCM for key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("the key must be %d bytes", keySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// CheckRelay validates a relay URL. Relays must use HTTPS, except on the
+// loopback interface, where the built-in server may run without TLS.
+func CheckRelay(relay string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimRight(relay, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid relay URL: %w", err)
+	}
+	if u.Host == "" || (u.Scheme != "https" && !(u.Scheme == "http" && isLoopback(u.Hostname()))) {
+		return nil, errors.New("relay URL must use https:// (http:// is only allowed for localhost)")
+	}
+	return u, nil
+}
+
+// CheckListenAddr validates the address of the built-in server. Without
+// TLS it may only listen on the loopback interface, as clients send the
+// encrypted secrets and API keys in the clear; ":8089" listens on every
+// interface and needs TLS.
+func CheckListenAddr(addr string, tls bool) error {
+	if tls {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid listen address: %w", err)
+	}
+	if !isLoopback(host) {
+		return fmt.Errorf("listening on %s needs -tls-cert and -tls-key; without TLS only localhost is allowed", addr)
+	}
+	return nil
+}
+
+// isLoopback reports whether host names the local machine.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Publish encrypts secret, stores it on the relay and returns the link.
+// Parameters:
+//   - ctx (context.Context): Cancels the request.
+//   - client (*http.Client): The HTTP client; http.DefaultClient when nil.
+//   - relay (string): Base URL of the relay, e.g. https://share.example.com.
+//   - apiKey (string): The key the relay requires to store secrets; "" for
+//     relays open to everyone.
+//   - secret (string): The password to share.
+//   - ttl (time.Duration): How long the link stays valid if nobody opens it.
+//
+// Returns:
+//
+//	string: The one-time link, including the key in its fragment.
+//	error: An error if the relay is invalid or refuses the secret.
+//
+// Example:
+//
+//	link, err := sharelink.Publish(ctx, nil, "https://share.example.com", apiKey, password, time.Hour)
+func Publish(ctx context.Context, client *http.Client, relay, apiKey, secret string, ttl time.Duration) (string, error) {
+	base, err := CheckRelay(relay)
+	if err != nil {
+		return "", err
+	}
+	if ttl <= 0 {
+		return "", errors.New("the expiry must be positive")
+	}
+	ciphertext, key, err := Seal([]byte(secret))
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(publishRequest{
+		Ciphertext: base64.RawURLEncoding.EncodeToString(ciphertext),
+		TTLSeconds: int64(ttl / time.Second),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var published publishResponse
+	if err := call(ctx, client, http.MethodPost, base.String()+"/api/secrets", apiKey, body, &published); err != nil {
+		return "", err
+	}
+	if published.ID == "" {
+		return "", errors.New("the relay did not return a link id")
+	}
+	return base.String() + "/s/" + url.PathEscape(published.ID) + "#" + base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+// Fetch opens a one-time link and returns the decrypted secret. The relay
+// deletes the secret, so a second Fetch of the same link returns ErrGone.
+func Fetch(ctx context.Context, client *http.Client, link string) (string, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("invalid link: %w", err)
+	}
+	key, err := base64.RawURLEncoding.DecodeString(u.Fragment)
+	if err != nil || len(key) != keySize {
+		return "", errors.New("the link has no valid key after #")
+	}
+	prefix, id, found := strings.Cut(u.Path, "/s/")
+	if !found || id == "" || strings.Contains(id, "/") {
+		return "", errors.New("the link is not a share link")
+	}
+	base := *u
+	base.Path, base.RawPath, base.Fragment, base.RawQuery = prefix, "", "", ""
+	if _, err := CheckRelay(base.String()); err != nil {
+		return "", err
+	}
+
+	var fetched struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	if err := call(ctx, client, http.MethodGet, base.String()+"/api/secrets/"+url.PathEscape(id), "", nil, &fetched); err != nil {
+		return "", err
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(fetched.Ciphertext)
+	if err != nil {
+		return "", errors.New("the relay returned a malformed secret")
+	}
+	plain, err := Open(ciphertext, key)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// call sends a JSON request to the relay, with apiKey as a Bearer token if
+// given, and decodes the JSON answer into out.
+func call(ctx context.Context, client *http.Client, method, target, apiKey string, body []byte, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if apiKey != "" {
+		request.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	switch {
+	case response.StatusCode == http.StatusNotFound && method == http.MethodGet:
+		return ErrGone
+	case response.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case response.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("relay answered %s", response.Status)
+	}
+	return json.NewDecoder(io.LimitReader(response.Body, 2*MaxSecretSize)).Decode(out)
+}
@@ -0,0 +1,186 @@
+package sharelink
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestPublishFetch shares a secret once and checks the link then stops working.
+func TestPublishFetch(t *testing.T) {
+	relay := httptest.NewServer(NewServer())
+	defer relay.Close()
+
+	link, err := Publish(context.Background(), relay.Client(), relay.URL, "", "s3cret-Pa55", time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if strings.Contains(link, "s3cret") || !strings.Contains(link, "/s/") || !strings.Contains(link, "#") {
+		t.Fatalf("Unexpected link %q", link)
+	}
+
+	secret, err := Fetch(context.Background(), relay.Client(), link)
+	if err != nil || secret != "s3cret-Pa55" {
+		t.Fatalf("Expected the secret back, but got %q, %v", secret, err)
+	}
+	if _, err := Fetch(context.Background(), relay.Client(), link); !errors.Is(err, ErrGone) {
+		t.Errorf("Expected ErrGone on the second open, but got %v", err)
+	}
+}
+
+// TestServer_Expiry refuses links after their expiry.
+func TestServer_Expiry(t *testing.T) {
+	server := NewServer()
+	now := time.Now()
+	server.Now = func() time.Time { return now }
+	relay := httptest.NewServer(server)
+	defer relay.Close()
+
+	link, err := Publish(context.Background(), relay.Client(), relay.URL, "", "secret", time.Minute)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	now = now.Add(2 * time.Minute)
+	if _, err := Fetch(context.Background(), relay.Client(), link); !errors.Is(err, ErrGone) {
+		t.Errorf("Expected ErrGone for an expired link, but got %v", err)
+	}
+
+	if _, err := Publish(context.Background(), relay.Client(), relay.URL, "", "secret", time.Minute); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	now = now.Add(2 * time.Minute)
+	server.expire()
+	if len(server.entries) != 0 {
+		t.Errorf("Expected expired entries to be swept, but %d remain", len(server.entries))
+	}
+}
+
+// TestFetch_WrongKey fails when the key in the fragment was altered.
+func TestFetch_WrongKey(t *testing.T) {
+	relay := httptest.NewServer(NewServer())
+	defer relay.Close()
+
+	link, err := Publish(context.Background(), relay.Client(), relay.URL, "", "secret", time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	base, _, _ := strings.Cut(link, "#")
+	if _, err := Fetch(context.Background(), relay.Client(), base+"#"+strings.Repeat("A", 43)); err == nil {
+		t.Errorf("Expected an error for a wrong key, but got nil")
+	}
+}
+
+// TestCheckRelay only allows plain HTTP on the loopback interface.
+func TestCheckRelay(t *testing.T) {
+	tests := map[string]bool{
+		"https://share.example.com": true,
+		"http://localhost:8080":     true,
+		"http://127.0.0.1:8080/":    true,
+		"http://share.example.com":  false,
+		"ftp://share.example.com":   false,
+		"share.example.com":         false,
+	}
+	for relay, ok := range tests {
+		if _, err := CheckRelay(relay); (err == nil) != ok {
+			t.Errorf("Expected CheckRelay(%q) ok=%v, but got %v", relay, ok, err)
+		}
+	}
+}
+
+// TestCheckListenAddr only allows serving without TLS on the loopback
+// interface.
+func TestCheckListenAddr(t *testing.T) {
+	tests := map[string]bool{
+		"127.0.0.1:8089": true,
+		"localhost:8089": true,
+		"[::1]:8089":     true,
+		":8089":          false,
+		"0.0.0.0:8089":   false,
+		"10.0.0.5:8089":  false,
+		"127.0.0.1":      false,
+	}
+	for addr, ok := range tests {
+		if err := CheckListenAddr(addr, false); (err == nil) != ok {
+			t.Errorf("Expected CheckListenAddr(%q) ok=%v, but got %v", addr, ok, err)
+		}
+	}
+	if err := CheckListenAddr(":8443", true); err != nil {
+		t.Errorf("Expected TLS to allow every interface, but got %v", err)
+	}
+}
+
+// TestServer_APIKeys verifies that storing a secret needs one of the keys
+// while opening the link does not.
+func TestServer_APIKeys(t *testing.T) {
+	server := NewServer()
+	server.APIKeys = []string{"team-a-key", "team-b-key"}
+	relay := httptest.NewServer(server)
+	defer relay.Close()
+
+	for _, key := range []string{"", "wrong-key"} {
+		if _, err := Publish(context.Background(), relay.Client(), relay.URL, key, "secret", time.Hour); !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("Expected ErrUnauthorized with key %q, but got %v", key, err)
+		}
+	}
+	link, err := Publish(context.Background(), relay.Client(), relay.URL, "team-b-key", "secret", time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if secret, err := Fetch(context.Background(), relay.Client(), link); err != nil || secret != "secret" {
+		t.Errorf("Expected the secret back without a key, but got %q, %v", secret, err)
+	}
+}
+
+// TestServer_RateLimit verifies that a client over its burst is refused
+// until its bucket refills, while other clients are not affected.
+func TestServer_RateLimit(t *testing.T) {
+	now := time.Now()
+	limiter := NewRateLimiter(60, 2)
+	limiter.Now = func() time.Time { return now }
+	server := NewServer()
+	server.Limiter = limiter
+	relay := httptest.NewServer(server)
+	defer relay.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := Publish(context.Background(), relay.Client(), relay.URL, "", "secret", time.Hour); err != nil {
+			t.Fatalf("Expected request %d to pass, but got %v", i+1, err)
+		}
+	}
+	if _, err := Publish(context.Background(), relay.Client(), relay.URL, "", "secret", time.Hour); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited over the burst, but got %v", err)
+	}
+	if ok, _ := limiter.Allow("192.0.2.1"); !ok {
+		t.Error("Expected another client to be allowed")
+	}
+
+	now = now.Add(time.Second)
+	if _, err := Publish(context.Background(), relay.Client(), relay.URL, "", "secret", time.Hour); err != nil {
+		t.Errorf("Expected a request after a second to pass, but got %v", err)
+	}
+}
+
+// TestLoadAPIKeys verifies that comments and blank lines are skipped and a
+// file without keys is refused.
+func TestLoadAPIKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys")
+	if err := os.WriteFile(path, []byte("# helpdesk\nteam-a-key\n\n  team-b-key  \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := LoadAPIKeys(path)
+	if err != nil || len(keys) != 2 || keys[0] != "team-a-key" || keys[1] != "team-b-key" {
+		t.Errorf("Expected both keys, but got %q, %v", keys, err)
+	}
+
+	if err := os.WriteFile(path, []byte("# none yet\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAPIKeys(path); err == nil {
+		t.Error("Expected an error for a file without keys, but got none")
+	}
+}
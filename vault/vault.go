@@ -0,0 +1,286 @@
+/**
+ * Password Generator - History Vault
+ *
+ * This file keeps an optional history of generated passwords, with their
+ * labels, the time they were generated and the options used, in a file
+ * encrypted with AES-256-GCM. The key is derived from a master password with
+ * Argon2id and only kept in memory while the vault is unlocked; the master
+ * password itself is never stored.
+ */
+
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/kdf"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// Format identifies the vault file layout.
+const Format = "passgen-vault-v1"
+
+// Sizes of the key material.
+const (
+	keySize  = 32
+	saltSize = 16
+)
+
+// Errors of the vault.
+var (
+	// ErrWrongPassword is returned when the master password does not open
+	// the vault, or the file was tampered with.
+	ErrWrongPassword = errors.New("wrong master password, or the vault file is damaged")
+	// ErrLocked is returned when a locked vault is used.
+	ErrLocked = errors.New("the vault is locked")
+	// ErrNotFound is returned for an entry id the vault does not hold.
+	ErrNotFound = errors.New("no such entry in the vault")
+)
+
+// newKDF is the Argon2id cost of new vaults; it is stored in the file, so
+// it can be raised without breaking existing vaults.
+var newKDF = kdf.Default
+
+// Entry is one stored password.
+// Fields:
+//   - ID (string): Random and unique within the vault.
+//   - Password (string): The generated password.
+//   - Label (string): What the password is for, e.g. a site; optional.
+//   - Note (string): Free text; optional.
+//   - CreatedAt (time.Time): When the password was generated.
+//   - Options (passgen.PasswordOptions): The options it was generated with.
+type Entry struct {
+	ID        string                  `json:"id"`
+	Password  string                  `json:"password"`
+	Label     string                  `json:"label,omitempty"`
+	Note      string                  `json:"note,omitempty"`
+	CreatedAt time.Time               `json:"created_at"`
+	Options   passgen.PasswordOptions `json:"options"`
+}
+
+// file is the on-disk form of a vault. Everything but the KDF parameters,
+// salt and nonce is encrypted; the header is authenticated as well.
+type file struct {
+	Format     string     `json:"format"`
+	KDF        kdf.Params `json:"kdf"`
+	Salt       []byte     `json:"salt"`
+	Nonce      []byte     `json:"nonce"`
+	Ciphertext []byte     `json:"ciphertext"`
+}
+
+// Vault is an unlocked vault. It is not safe for concurrent use.
+type Vault struct {
+	path    string
+	kdf     kdf.Params
+	salt    []byte
+	key     []byte
+	entries []Entry
+}
+
+// Exists reports whether a vault file exists at path.
+func Exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Create makes a new, empty vault at path protected by master. An existing
+// vault is not overwritten.
+func Create(path, master string) (*Vault, error) {
+	if master == "" {
+		return nil, errors.New("the master password is empty")
+	}
+	if Exists(path) {
+		return nil, fmt.Errorf("%s: a vault already exists", path)
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key, err := newKDF.Key([]byte(master), salt, keySize)
+	if err != nil {
+		return nil, err
+	}
+	v := &Vault{path: path, kdf: newKDF, salt: salt, key: key}
+	return v, v.save()
+}
+
+// Open unlocks the vault at path with master.
+func Open(path, master string) (*Vault, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%s: no vault yet", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if f.Format != Format {
+		return nil, fmt.Errorf("%s: unknown vault format %q", path, f.Format)
+	}
+	if len(f.Salt) == 0 {
+		return nil, fmt.Errorf("%s: the vault has no salt", path)
+	}
+	v := &Vault{path: path, kdf: f.KDF, salt: f.Salt}
+	if v.key, err = f.KDF.Key([]byte(master), f.Salt, keySize); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	aead, err := newAEAD(v.key)
+	if err != nil {
+		return nil, err
+	}
+	if len(f.Nonce) != aead.NonceSize() {
+		v.Lock()
+		return nil, fmt.Errorf("%s: the nonce is %d bytes instead of %d", path, len(f.Nonce), aead.NonceSize())
+	}
+	plain, err := aead.Open(nil, f.Nonce, f.Ciphertext, header(f))
+	if err != nil {
+		v.Lock()
+		return nil, ErrWrongPassword
+	}
+	defer kdf.Wipe(plain)
+	if err := json.Unmarshal(plain, &v.entries); err != nil {
+		v.Lock()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return v, nil
+}
+
+// Locked reports whether Lock was called.
+func (v *Vault) Locked() bool {
+	return v.key == nil
+}
+
+// Lock wipes the key and forgets the entries; the vault must be opened
+// again to be used.
+func (v *Vault) Lock() {
+	kdf.Wipe(v.key)
+	v.key = nil
+	v.entries = nil
+}
+
+// Add stores new entries, giving each an id, and saves the vault.
+func (v *Vault) Add(entries ...Entry) error {
+	if v.Locked() {
+		return ErrLocked
+	}
+	for _, e := range entries {
+		id := make([]byte, 9)
+		if _, err := rand.Read(id); err != nil {
+			return err
+		}
+		e.ID = base64.RawURLEncoding.EncodeToString(id)
+		if e.CreatedAt.IsZero() {
+			e.CreatedAt = time.Now()
+		}
+		v.entries = append(v.entries, e)
+	}
+	return v.save()
+}
+
+// SetLabel changes the label and note of the entry id and saves the vault.
+func (v *Vault) SetLabel(id, label, note string) error {
+	if v.Locked() {
+		return ErrLocked
+	}
+	for i := range v.entries {
+		if v.entries[i].ID == id {
+			v.entries[i].Label, v.entries[i].Note = label, note
+			return v.save()
+		}
+	}
+	return ErrNotFound
+}
+
+// Delete removes the entry id and saves the vault, encrypted again under a
+// new nonce, over the previous file.
+func (v *Vault) Delete(id string) error {
+	if v.Locked() {
+		return ErrLocked
+	}
+	for i := range v.entries {
+		if v.entries[i].ID == id {
+			v.entries = append(v.entries[:i], v.entries[i+1:]...)
+			return v.save()
+		}
+	}
+	return ErrNotFound
+}
+
+// Search returns the entries whose label or note contains query, ignoring
+// case, newest first; all of them for an empty query.
+func (v *Vault) Search(query string) []Entry {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var found []Entry
+	for _, e := range v.entries {
+		if query == "" || strings.Contains(strings.ToLower(e.Label), query) || strings.Contains(strings.ToLower(e.Note), query) {
+			found = append(found, e)
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].CreatedAt.After(found[j].CreatedAt) })
+	return found
+}
+
+// Len returns the number of entries.
+func (v *Vault) Len() int {
+	return len(v.entries)
+}
+
+// save encrypts the entries under a fresh nonce and replaces the file,
+// readable only by the current user.
+func (v *Vault) save() error {
+	plain, err := json.Marshal(v.entries)
+	if err != nil {
+		return err
+	}
+	defer kdf.Wipe(plain)
+	if v.entries == nil {
+		plain = []byte("[]")
+	}
+	aead, err := newAEAD(v.key)
+	if err != nil {
+		return err
+	}
+	f := file{Format: Format, KDF: v.kdf, Salt: v.salt, Nonce: make([]byte, aead.NonceSize())}
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return err
+	}
+	f.Ciphertext = aead.Seal(nil, f.Nonce, plain, header(f))
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := v.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, v.path)
+}
+
+// header returns the unencrypted fields of f, which are authenticated with
+// the ciphertext so that the KDF parameters cannot be swapped.
+func header(f file) []byte {
+	return []byte(fmt.Sprintf("%s|%d|%d|%d|%x|%x", f.Format, f.KDF.Time, f.KDF.Memory, f.KDF.Threads, f.Salt, f.Nonce))
+}
+
+// newAEAD returns AES-256-GCM with key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,159 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/kdf"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// fastKDF lowers the Argon2id cost so the tests run quickly.
+func fastKDF(t *testing.T) {
+	old := newKDF
+	t.Cleanup(func() { newKDF = old })
+	newKDF = kdf.Params{Time: 1, Memory: 64, Threads: 1}
+}
+
+// TestVault_RoundTrip verifies that entries survive locking and reopening
+// and are never written in plain text.
+func TestVault_RoundTrip(t *testing.T) {
+	fastKDF(t)
+	path := filepath.Join(t.TempDir(), "vault.json")
+	v, err := Create(path, "correct horse")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	opts := passgen.PasswordOptions{Length: 16, IncludeLower: true, Quantity: 1}
+	if err := v.Add(Entry{Password: "s3cret-Pa55word", Label: "example.com", Options: opts}); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	v.Lock()
+	if err := v.Add(Entry{Password: "x"}); !errors.Is(err, ErrLocked) {
+		t.Errorf("Expected ErrLocked, but got %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("s3cret")) || bytes.Contains(data, []byte("example.com")) {
+		t.Error("Expected the vault file to be encrypted, but found an entry in plain text")
+	}
+
+	v, err = Open(path, "correct horse")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	entries := v.Search("")
+	if len(entries) != 1 || entries[0].Password != "s3cret-Pa55word" || entries[0].Options != opts || entries[0].ID == "" {
+		t.Errorf("Expected the stored entry, but got %+v", entries)
+	}
+}
+
+// TestOpen_WrongPassword verifies that a wrong master password or a changed
+// header does not open the vault.
+func TestOpen_WrongPassword(t *testing.T) {
+	fastKDF(t)
+	path := filepath.Join(t.TempDir(), "vault.json")
+	if _, err := Create(path, "correct horse"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path, "battery staple"); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("Expected ErrWrongPassword, but got %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	data = bytes.Replace(data, []byte(`"time": 1`), []byte(`"time": 2`), 1)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path, "correct horse"); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("Expected ErrWrongPassword for a changed header, but got %v", err)
+	}
+}
+
+// TestOpen_Damaged verifies that a file with an unusable nonce, salt or KDF
+// parameters is reported as an error rather than a panic.
+func TestOpen_Damaged(t *testing.T) {
+	fastKDF(t)
+	path := filepath.Join(t.TempDir(), "vault.json")
+	if _, err := Create(path, "pw"); err != nil {
+		t.Fatal(err)
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f file
+	if err := json.Unmarshal(original, &f); err != nil {
+		t.Fatal(err)
+	}
+	damages := map[string]func(f *file){
+		"short nonce": func(f *file) { f.Nonce = f.Nonce[:4] },
+		"no salt":     func(f *file) { f.Salt = nil },
+		"no threads":  func(f *file) { f.KDF.Threads = 0 },
+		"no passes":   func(f *file) { f.KDF.Time = 0 },
+		"no memory":   func(f *file) { f.KDF.Memory = 0 },
+	}
+	for name, damage := range damages {
+		damaged := f
+		damage(&damaged)
+		data, err := json.Marshal(damaged)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Open(path, "pw"); err == nil {
+			t.Errorf("Expected an error for %s, but got none", name)
+		}
+	}
+}
+
+// TestVault_SearchAndDelete verifies that search matches labels and notes,
+// newest first, and that deleted entries are gone after reopening.
+func TestVault_SearchAndDelete(t *testing.T) {
+	fastKDF(t)
+	path := filepath.Join(t.TempDir(), "vault.json")
+	v, err := Create(path, "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	err = v.Add(
+		Entry{Password: "a", Label: "Mail", CreatedAt: now.Add(-time.Hour)},
+		Entry{Password: "b", Label: "Bank", Note: "joint account", CreatedAt: now},
+		Entry{Password: "c", Label: "mailing list", CreatedAt: now.Add(time.Minute)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := v.Search("MAIL")
+	if len(found) != 2 || found[0].Password != "c" || found[1].Password != "a" {
+		t.Errorf("Expected the two mail entries, newest first, but got %+v", found)
+	}
+	if found := v.Search("joint"); len(found) != 1 || found[0].Password != "b" {
+		t.Errorf("Expected the entry with the note, but got %+v", found)
+	}
+
+	if err := v.Delete(found[0].ID); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := v.Delete("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, but got %v", err)
+	}
+	v, err = Open(path, "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Len() != 2 || len(v.Search("mail")) != 1 {
+		t.Errorf("Expected 2 entries left with one matching, but got %d", v.Len())
+	}
+}
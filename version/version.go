@@ -0,0 +1,28 @@
+/**
+ * Password Generator - Build Information
+ *
+ * This file holds version details embedded at build time via ldflags, e.g.:
+ *
+ *   go build -ldflags "-X github.com/PaulBaker1/Password-Generator-GO/version.Version=v1.2.0
+ *     -X github.com/PaulBaker1/Password-Generator-GO/version.Commit=$(git rev-parse --short HEAD)
+ *     -X github.com/PaulBaker1/Password-Generator-GO/version.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/gui
+ */
+
+package version
+
+import "fmt"
+
+// Build details, overridden with -ldflags "-X" at build time.
+var (
+	Version = "dev"
+	Commit  = "unknown"
+	Date    = "unknown"
+)
+
+// IssuesURL is where users can report problems.
+const IssuesURL = "https://github.com/PaulBaker1/Password-Generator-GO/issues"
+
+// String returns the version, commit, and build date on a single line.
+func String() string {
+	return fmt.Sprintf("%s (commit %s, built %s)", Version, Commit, Date)
+}
@@ -0,0 +1,35 @@
+/**
+ * Password Generator - About Dialog
+ *
+ * This file shows the build information embedded at compile time together
+ * with a link for reporting issues.
+ */
+
+package view
+
+import (
+	"net/url"
+
+	"github.com/PaulBaker1/Password-Generator-GO/version"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showAbout displays the version, commit, and build date of the application.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialog.
+func showAbout(w fyne.Window) {
+	content := container.NewVBox(
+		widget.NewLabel("Password Generator"),
+		widget.NewLabel("Version: "+version.Version),
+		widget.NewLabel("Commit: "+version.Commit),
+		widget.NewLabel("Built: "+version.Date),
+	)
+	if issues, err := url.Parse(version.IssuesURL); err == nil {
+		content.Add(widget.NewHyperlink("Report an issue", issues))
+	}
+	dialog.ShowCustom("About", "Close", content, w)
+}
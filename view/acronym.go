@@ -0,0 +1,87 @@
+/**
+ * Password Generator - Password from a Sentence
+ *
+ * This file derives a password from a sentence the user remembers: first
+ * letters, numbers and punctuation, optional look-alike substitutions and
+ * random characters appended. A warning below the result says how little of
+ * it is random, as a known sentence gives the acronym away.
+ */
+
+package view
+
+import (
+	"fmt"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// acronymCounts lists the numbers of random digits or symbols offered.
+var acronymCounts = []string{"0", "1", "2", "3", "4", "5", "6"}
+
+// showAcronym opens a window that turns a sentence into a password.
+func showAcronym() {
+	window := fyne.CurrentApp().NewWindow("Password from Sentence")
+
+	sentenceEntry := widget.NewPasswordEntry()
+	sentenceEntry.SetPlaceHolder("e.g. My cat Tom was born in 2015!")
+	substitute := widget.NewCheck("Substitute Look-alikes (o → 0, s → $, ...)", nil)
+	digitsSelect := widget.NewSelect(acronymCounts, nil)
+	digitsSelect.SetSelected("2")
+	symbolsSelect := widget.NewSelect(acronymCounts, nil)
+	symbolsSelect.SetSelected("1")
+
+	result := widget.NewEntry()
+	result.SetPlaceHolder("The password will appear here")
+	warning := widget.NewLabel("")
+	warning.Wrapping = fyne.TextWrapWord
+
+	options := func() passgen.AcronymOptions {
+		return passgen.AcronymOptions{
+			Substitute:    substitute.Checked,
+			RandomDigits:  digitsSelect.SelectedIndex(),
+			RandomSymbols: symbolsSelect.SelectedIndex(),
+		}
+	}
+	updateWarning := func() {
+		bits := passgen.AcronymEntropy(options())
+		text := fmt.Sprintf("The random characters give about %.0f bits. The rest is only as strong as the sentence is unknown: quotes, lyrics and sayings are guessed early, and look-alikes add nothing.", bits)
+		if bits < passgen.AcronymWeakBits {
+			text = "Weak: " + text + " Add random digits or symbols."
+		}
+		warning.SetText(text)
+	}
+	digitsSelect.OnChanged = func(string) { updateWarning() }
+	symbolsSelect.OnChanged = func(string) { updateWarning() }
+	updateWarning()
+
+	generateButton := widget.NewButton("Generate", func() {
+		acronym, err := passgen.GenerateAcronym(sentenceEntry.Text, options())
+		if err != nil {
+			result.SetText("")
+			warning.SetText(errorText(err))
+			return
+		}
+		result.SetText(acronym.Password)
+		updateWarning()
+	})
+	copyButton := widget.NewButton("Copy", func() { copySecret(window, result.Text) })
+
+	form := widget.NewForm(
+		widget.NewFormItem("Sentence", sentenceEntry),
+		widget.NewFormItem("", substitute),
+		widget.NewFormItem("Random digits", digitsSelect),
+		widget.NewFormItem("Random symbols", symbolsSelect),
+	)
+	window.SetContent(container.NewVBox(
+		form,
+		generateButton,
+		container.NewBorder(nil, nil, nil, copyButton, result),
+		warning,
+	))
+	window.Resize(fyne.NewSize(520, 340))
+	window.Show()
+}
@@ -0,0 +1,154 @@
+/**
+ * Password Generator - Browser Export Audit
+ *
+ * This file lets the user load a password export from Chrome, Edge or Firefox,
+ * charts how old the saved passwords are, lists every entry with its rating
+ * and issues, and copies a strong replacement for flagged entries with one
+ * click. The export is only read locally and never stored.
+ */
+
+package view
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/audit"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/siterules"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// staleAge is the password age from which the audit flags entries as stale.
+const staleAge = 365 * audit.Day
+
+// showAuditImport asks for a browser export and shows the audit results.
+// Purpose:
+//
+//	Opens a file dialog for a CSV export, audits it, and opens a results window.
+//	Replacements are generated with the options currently selected in the form.
+//
+// Parameters:
+//   - w (fyne.Window): The parent window of the file dialog.
+//   - checks (audit.Options): The options used for replacement passwords,
+//     the breach list, whose passwords are flagged and never offered as
+//     replacements, and the policy.
+//   - sites (siterules.Database): Site rules applied to replacements.
+//
+// Example:
+//
+//	showAuditImport(myWindow, auditOptions(), siteRules)
+func showAuditImport(w fyne.Window, checks audit.Options, sites siterules.Database) {
+	open := dialog.NewFileOpen(func(file fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if file == nil {
+			return
+		}
+		defer file.Close()
+
+		entries, err := audit.ReadBrowserCSV(file)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("%s: %w", file.URI().Name(), err), w)
+			return
+		}
+		checks.Sites = sites
+		checks.MaxAge = staleAge
+		showAuditResults(audit.Run(entries, checks))
+	}, w)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".csv"}))
+	open.Show()
+}
+
+// showAuditResults opens a window with a password age chart and one row per
+// audited entry. Tapping an age bucket lists only its entries, so the oldest
+// passwords can be rotated first.
+func showAuditResults(findings []audit.Finding) {
+	results := fyne.CurrentApp().NewWindow("Audit Results")
+
+	flagged := 0
+	for _, finding := range findings {
+		if finding.Flagged() {
+			flagged++
+		}
+	}
+
+	rows := container.NewVBox()
+	showRows := func(indexes []int) {
+		rows.RemoveAll()
+		for _, i := range indexes {
+			rows.Add(auditRow(results, findings[i]))
+		}
+	}
+	all := make([]int, len(findings))
+	for i := range findings {
+		all[i] = i
+	}
+	showRows(all)
+
+	chart := ageChart(audit.AgeHistogram(findings, time.Now()), showRows)
+	chart.Add(widget.NewButton("Show All Entries", func() { showRows(all) }))
+
+	header := widget.NewLabel(fmt.Sprintf("%d of %d entries need attention. Paste each replacement into the site's change-password form.", flagged, len(findings)))
+	header.Wrapping = fyne.TextWrapWord
+	results.SetContent(container.NewBorder(container.NewVBox(header, chart), nil, nil, nil, container.NewVScroll(rows)))
+	results.Resize(fyne.NewSize(600, 650))
+	results.Show()
+}
+
+// auditRow shows one finding, with a copy button for flagged entries.
+func auditRow(w fyne.Window, finding audit.Finding) fyne.CanvasObject {
+	site := finding.Entry.URL
+	if site == "" {
+		site = finding.Entry.Name
+	}
+	summary := fmt.Sprintf("%s (%s): %s", site, finding.Entry.Username, finding.Strength)
+	if !finding.Flagged() {
+		return widget.NewLabel(summary)
+	}
+	summary += " - " + strings.Join(finding.Issues(), ", ")
+	if finding.ReplacementError != nil {
+		return widget.NewLabel(summary + " - " + finding.Proposal())
+	}
+
+	replacement := finding.Replacement
+	copyButton := widget.NewButton("Copy Replacement", func() {
+		copySecret(w, replacement)
+	})
+	return container.NewBorder(nil, nil, nil, copyButton, widget.NewLabel(summary))
+}
+
+// ageChart draws one bar per age bucket, shaded from green for recent to red
+// for old passwords. Tapping a bucket passes its findings to onSelect.
+func ageChart(buckets []audit.AgeBucket, onSelect func(indexes []int)) *fyne.Container {
+	largest := 1
+	for _, bucket := range buckets {
+		if len(bucket.Findings) > largest {
+			largest = len(bucket.Findings)
+		}
+	}
+
+	chart := container.NewVBox(widget.NewLabelWithStyle("Password age", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	for i, bucket := range buckets {
+		heat := color.NRGBA{R: uint8(60 + 180*i/len(buckets)), G: uint8(200 - 160*i/len(buckets)), B: 80, A: 255}
+		if i == len(buckets)-1 { // unknown age
+			heat = color.NRGBA{R: 150, G: 150, B: 150, A: 255}
+		}
+		bar := canvas.NewRectangle(heat)
+		bar.SetMinSize(fyne.NewSize(float32(300*len(bucket.Findings)/largest), 16))
+
+		indexes := bucket.Findings
+		button := widget.NewButton(fmt.Sprintf("%s (%d)", bucket.Label, len(indexes)), func() { onSelect(indexes) })
+		chart.Add(container.NewBorder(nil, nil, button, nil, container.NewHBox(bar)))
+	}
+	return chart
+}
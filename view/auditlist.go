@@ -0,0 +1,160 @@
+/**
+ * Password Generator - Audit Tab
+ *
+ * This file builds the Audit tab, which rates a pasted or loaded list of
+ * existing passwords: length, entropy estimate, character classes, the
+ * weak, reused and breached ones and the rules of the selected policy they
+ * break. The results can be exported as a CSV report without the passwords.
+ */
+
+package view
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/audit"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// auditTab returns the content of the Audit tab.
+// Parameters:
+//   - w (fyne.Window): The parent window of dialogs and the clipboard.
+//   - checks (func() audit.Options): Returns the replacement options, breach
+//     list and policy currently selected, read when Audit is tapped.
+func auditTab(w fyne.Window, checks func() audit.Options) fyne.CanvasObject {
+	listEntry := widget.NewMultiLineEntry()
+	listEntry.SetPlaceHolder("Paste passwords here, one per line")
+	listEntry.Wrapping = fyne.TextWrapOff
+	listEntry.SetMinRowsVisible(6)
+
+	summary := widget.NewLabel("")
+	summary.Wrapping = fyne.TextWrapWord
+	rows := container.NewVBox()
+	var findings []audit.Finding
+
+	exportButton := widget.NewButton("Export Report...", func() {
+		var report bytes.Buffer
+		if err := audit.WriteListReport(&report, findings); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		save := dialog.NewFileSave(func(file fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if file == nil {
+				return
+			}
+			_, writeErr := file.Write(report.Bytes())
+			if closeErr := file.Close(); writeErr == nil {
+				writeErr = closeErr
+			}
+			if writeErr != nil {
+				dialog.ShowError(fmt.Errorf("export failed: %w", writeErr), w)
+			}
+		}, w)
+		save.SetFileName("password-audit.csv")
+		save.Show()
+	})
+	exportButton.Disable()
+
+	auditButton := widget.NewButton("Audit", func() {
+		entries, err := audit.ReadList(strings.NewReader(listEntry.Text))
+		if err == nil {
+			findings = audit.Run(entries, checks())
+		}
+		rows.RemoveAll()
+		if err != nil {
+			findings = nil
+			exportButton.Disable()
+			summary.SetText(errorText(err))
+			return
+		}
+		flagged := 0
+		for _, finding := range findings {
+			if finding.Flagged() {
+				flagged++
+			}
+			rows.Add(auditListRow(w, finding))
+		}
+		summary.SetText(fmt.Sprintf("%d of %d passwords need attention.", flagged, len(findings)))
+		exportButton.Enable()
+	})
+
+	loadButton := widget.NewButton("Load File...", func() {
+		open := dialog.NewFileOpen(func(file fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if file == nil {
+				return
+			}
+			defer file.Close()
+			data, err := io.ReadAll(file)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			listEntry.SetText(string(data))
+		}, w)
+		open.SetFilter(storage.NewExtensionFileFilter([]string{".txt"}))
+		open.Show()
+	})
+	clearButton := widget.NewButton("Clear", func() {
+		listEntry.SetText("")
+		findings = nil
+		rows.RemoveAll()
+		summary.SetText("")
+		exportButton.Disable()
+	})
+
+	note := widget.NewLabel("Passwords are checked locally against the breach list and policy selected on the Password tab; the report leaves them out.")
+	note.Wrapping = fyne.TextWrapWord
+	top := container.NewVBox(
+		note,
+		listEntry,
+		container.NewHBox(loadButton, clearButton, auditButton, exportButton),
+		summary,
+	)
+	return container.NewBorder(top, nil, nil, nil, container.NewVScroll(rows))
+}
+
+// auditListRow shows the rating of one listed password, with the policy
+// violations below and a copy button for the replacement of flagged ones.
+func auditListRow(w fyne.Window, finding audit.Finding) fyne.CanvasObject {
+	text := fmt.Sprintf("%s: %d characters, ≈ %.0f bits, %s", finding.Entry.Name, finding.Length, finding.Entropy, finding.Strength)
+	if len(finding.Classes) > 0 {
+		text += " (" + strings.Join(finding.Classes, ", ") + ")"
+	}
+	if !finding.Flagged() {
+		return widget.NewLabel(text)
+	}
+	text += " - " + strings.Join(finding.Issues(), ", ")
+	for _, violation := range finding.Violations {
+		text += "\n    " + violation
+	}
+	if finding.ReplacementError != nil {
+		text += "\n    " + finding.Proposal()
+	}
+	label := widget.NewLabel(text)
+	label.Wrapping = fyne.TextWrapWord
+	if finding.ReplacementError != nil {
+		return label
+	}
+
+	replacement := finding.Replacement
+	copyButton := widget.NewButton("Copy Replacement", func() {
+		copySecret(w, replacement)
+	})
+	return container.NewBorder(nil, nil, nil, copyButton, label)
+}
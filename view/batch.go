@@ -0,0 +1,69 @@
+/**
+ * Password Generator - Large Batches
+ *
+ * This file generates a batch in chunks, so that a quantity of thousands of
+ * passwords reports its progress and can be cancelled between chunks, while
+ * the generation itself runs off the UI thread.
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// maxQuantity bounds the quantity of one batch in the GUI; the CLI's -out
+// streams larger batches to a file.
+const maxQuantity = 100000
+
+// batchChunk is the number of passwords generated between two progress
+// updates.
+const batchChunk = 250
+
+// parseQuantity reads the quantity typed in the form.
+func parseQuantity(text string) (int, error) {
+	quantity, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || quantity < 1 || quantity > maxQuantity {
+		return 0, fmt.Errorf("the quantity must be a number from 1 to %d", maxQuantity)
+	}
+	return quantity, nil
+}
+
+// generateBatch generates opts.Quantity passwords with generate, a chunk at a
+// time.
+// Parameters:
+//   - ctx (context.Context): Cancels the batch between two chunks, or within
+//     one if generate checks it.
+//   - generate (func): Generates opts.Quantity passwords, e.g.
+//     ctrl.GeneratePasswords.
+//   - opts (passgen.PasswordOptions): The options and total quantity.
+//   - progress (func(done int)): Called after every chunk with the number of
+//     passwords generated so far.
+//
+// Returns:
+//
+//	[]string: All passwords of the batch, in order.
+//	error: The first error of generate, e.g. context.Canceled.
+func generateBatch(ctx context.Context, generate func(context.Context, passgen.PasswordOptions) ([]string, error), opts passgen.PasswordOptions, progress func(done int)) ([]string, error) {
+	total := opts.Quantity
+	passwords := make([]string, 0, total)
+	for len(passwords) < total {
+		chunk := opts
+		chunk.Quantity = total - len(passwords)
+		if chunk.Quantity > batchChunk {
+			chunk.Quantity = batchChunk
+		}
+		generated, err := generate(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		passwords = append(passwords, generated...)
+		progress(len(passwords))
+	}
+	return passwords, nil
+}
@@ -0,0 +1,158 @@
+/**
+ * Password Generator - Offline Breach List
+ *
+ * This file lets users of airgapped machines choose a local breach list,
+ * a Bloom filter built with the CLI's breach-import command or the sorted
+ * SHA-1 file of Have I Been Pwned. Every generated password found on the
+ * list is generated again, and audits flag the passwords on it. The list
+ * is remembered in the profile and opened again at startup.
+ */
+
+package view
+
+import (
+	"fmt"
+
+	"github.com/PaulBaker1/Password-Generator-GO/audit"
+	"github.com/PaulBaker1/Password-Generator-GO/breach"
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// breachFilter holds the breach list in use while the GUI runs.
+// Fields:
+//   - list (*breach.List): The open list; nil when none is in use.
+//   - err (error): Why the list of the profile could not be opened, if so.
+type breachFilter struct {
+	list *breach.List
+	err  error
+}
+
+// load opens the list at path in place of the current one. An empty path
+// removes the filter. On error the current list stays in use.
+func (b *breachFilter) load(path string) error {
+	var list *breach.List
+	if path != "" {
+		var err error
+		if list, err = breach.Open(path); err != nil {
+			b.err = err
+			return err
+		}
+	}
+	b.close()
+	b.list, b.err = list, nil
+	return nil
+}
+
+// required returns an error if the organization requires a breach list
+// that is not open, so that nothing is generated unchecked.
+func (b *breachFilter) required(managed config.Managed) error {
+	if managed.BreachList != "" && b.list == nil {
+		return fmt.Errorf("the breach list required by your organization, %s, cannot be opened: %v", managed.BreachList, b.err)
+	}
+	return nil
+}
+
+// close closes the current list, if any.
+func (b *breachFilter) close() {
+	if b.list != nil {
+		b.list.Close()
+		b.list = nil
+	}
+}
+
+// constraints returns the constraint that skips breached passwords, or
+// none without a list.
+func (b *breachFilter) constraints() []passgen.Constraint {
+	if b.list == nil {
+		return nil
+	}
+	return []passgen.Constraint{b.list.Constraint()}
+}
+
+// checker returns the list for audits, or nil without one.
+func (b *breachFilter) checker() audit.Checker {
+	if b.list == nil {
+		return nil
+	}
+	return b.list
+}
+
+// showBreachList shows the breach list in use and lets the user choose
+// another or remove it, saving the choice in the profile. A list required
+// by the organization is shown but cannot be changed.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialog.
+//   - breaches (*breachFilter): The list in use.
+//   - profile (*config.Profile): Holds the path of the list.
+//   - profilePath (string): Where the profile is saved.
+//   - managed (config.Managed): The settings the organization enforces.
+//   - onChange (func()): Called after the list changes, to generate with it.
+func showBreachList(w fyne.Window, breaches *breachFilter, profile *config.Profile, profilePath string, managed config.Managed, onChange func()) {
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+	chooseButton := widget.NewButton("Choose File...", nil)
+	removeButton := widget.NewButton("Remove", nil)
+	refresh := func() {
+		switch {
+		case breaches.list != nil:
+			status.SetText(fmt.Sprintf("Generated passwords are checked against %s (%s).", breaches.list.Path, breaches.list.Format))
+			removeButton.Enable()
+		case profile.BreachList != "":
+			status.SetText(fmt.Sprintf("%s cannot be opened: %v", profile.BreachList, breaches.err))
+			removeButton.Enable()
+		default:
+			status.SetText("No breach list is in use.")
+			removeButton.Disable()
+		}
+		if managed.BreachList != "" {
+			status.SetText(status.Text + " The list is required by your organization.")
+			chooseButton.Disable()
+			removeButton.Disable()
+		}
+	}
+	save := func(path string) {
+		profile.BreachList = path
+		if err := config.SaveProfile(profilePath, *profile); err != nil {
+			dialog.ShowError(err, w)
+		}
+		refresh()
+	}
+	removeButton.OnTapped = func() {
+		_ = breaches.load("")
+		onChange()
+		save("")
+	}
+	chooseButton.OnTapped = func() {
+		open := dialog.NewFileOpen(func(file fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if file == nil {
+				return
+			}
+			path := file.URI().Path()
+			file.Close()
+			if err := breaches.load(path); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			onChange()
+			save(path)
+		}, w)
+		open.Show()
+	}
+	refresh()
+
+	note := widget.NewLabel("Choose a filter built with \"password-generator-cli breach-import\" or the SHA-1 file of Have I Been Pwned, ordered by hash. Passwords found on it are generated again; patterns, PINs and keys are not checked.")
+	note.Wrapping = fyne.TextWrapWord
+	d := dialog.NewCustom("Breach List", "Close", container.NewVBox(status, note, container.NewHBox(chooseButton, removeButton)), w)
+	d.Resize(fyne.NewSize(520, 260))
+	d.Show()
+}
@@ -0,0 +1,87 @@
+/**
+ * Password Generator - Broken Keys
+ *
+ * This file lets users mark keys that are broken or missing on their
+ * keyboard. Both characters of every marked key are saved in the personal
+ * profile and excluded from all generated passwords.
+ */
+
+package view
+
+import (
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// keyboardRows lists the keys of a US keyboard, each as its unshifted and
+// shifted character.
+var keyboardRows = [][]string{
+	{"`~", "1!", "2@", "3#", "4$", "5%", "6^", "7&", "8*", "9(", "0)", "-_", "=+"},
+	{"qQ", "wW", "eE", "rR", "tT", "yY", "uU", "iI", "oO", "pP", "[{", "]}", "\\|"},
+	{"aA", "sS", "dD", "fF", "gG", "hH", "jJ", "kK", "lL", ";:", "'\""},
+	{"zZ", "xX", "cC", "vV", "bB", "nN", "mM", ",<", ".>", "/?"},
+}
+
+// keyLabel returns the caption of a key: the letter for letter keys, both
+// characters otherwise.
+func keyLabel(key string) string {
+	if strings.ToUpper(key[:1]) == key[1:] {
+		return key[1:]
+	}
+	return key[:1] + " " + key[1:]
+}
+
+// showBrokenKeys lets the user mark broken keys and saves them to the profile.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialog.
+//   - profile (*config.Profile): The profile to update; unchanged on cancel.
+//   - path (string): Where the profile is saved.
+//   - onSaved (func()): Called after the profile was saved.
+func showBrokenKeys(w fyne.Window, profile *config.Profile, path string, onSaved func()) {
+	var checks []*widget.Check
+	var keys []string
+	rows := container.NewVBox()
+	for _, row := range keyboardRows {
+		line := container.NewHBox()
+		for _, key := range row {
+			check := widget.NewCheck(keyLabel(key), nil)
+			check.SetChecked(strings.ContainsAny(profile.BrokenKeys, key))
+			checks = append(checks, check)
+			keys = append(keys, key)
+			line.Add(check)
+		}
+		rows.Add(line)
+	}
+
+	note := widget.NewLabel("Mark the keys that do not work on your keyboard. Their characters are never used in generated passwords.")
+	note.Wrapping = fyne.TextWrapWord
+	content := container.NewBorder(note, nil, nil, nil, container.NewHScroll(rows))
+
+	d := dialog.NewCustomConfirm("Broken Keys", "Save", "Cancel", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		var broken strings.Builder
+		for i, check := range checks {
+			if check.Checked {
+				broken.WriteString(keys[i])
+			}
+		}
+		updated := *profile
+		updated.BrokenKeys = broken.String()
+		if err := config.SaveProfile(path, updated); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		*profile = updated
+		onSaved()
+	}, w)
+	d.Resize(fyne.NewSize(720, 320))
+	d.Show()
+}
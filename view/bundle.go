@@ -0,0 +1,76 @@
+/**
+ * Password Generator - Reproducibility Bundle
+ *
+ * This file saves the latest run as a signed JSON bundle: the options, the
+ * generator version and the results. The bundle can later be checked with
+ * "password-generator-cli -verify-bundle" for audit purposes.
+ */
+
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/export"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/version"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+// showBundleExport signs a bundle of the latest run and asks where to save it.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialogs.
+//   - opts (passgen.PasswordOptions): The options of the latest run.
+//   - results ([]export.Result): The results of the latest run.
+func showBundleExport(w fyne.Window, opts passgen.PasswordOptions, results []export.Result) {
+	if len(results) == 0 {
+		dialog.ShowInformation("Export Bundle", "Generate passwords first.", w)
+		return
+	}
+	keyPath, err := config.SigningKeyPath()
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+	key, err := export.LoadSigningKey(keyPath)
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+	bundle := export.NewBundle(version.String(), opts, "", results, time.Now())
+	if err := bundle.Sign(key); err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+
+	save := dialog.NewFileSave(func(file fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if file == nil {
+			return
+		}
+		_, writeErr := file.Write(append(data, '\n'))
+		if closeErr := file.Close(); writeErr == nil {
+			writeErr = closeErr
+		}
+		if writeErr != nil {
+			dialog.ShowError(fmt.Errorf("export failed: %w", writeErr), w)
+			return
+		}
+		dialog.ShowInformation("Export Bundle", "Signed by key "+export.KeyFingerprint(bundle.PublicKey)+".\nThe bundle contains the passwords in plain text; store it securely.\nCheck it with: password-generator-cli -verify-bundle <file>", w)
+	}, w)
+	save.SetFileName("passwords-bundle.json")
+	save.Show()
+}
@@ -0,0 +1,130 @@
+/**
+ * Password Generator - Clipboard Auto-Clear
+ *
+ * This file copies secrets to the clipboard and clears them again after a
+ * delay chosen in the profile, 30 seconds by default, so that a copied
+ * password is not left for any other program or paste to pick up. The
+ * clipboard is only cleared while it still holds the secret, so text the
+ * user copied since is left alone.
+ */
+
+package view
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// clipboardClearDelay is how long copied secrets stay on the clipboard; set
+// at start from the profile. 0 never clears them.
+var clipboardClearDelay = config.DefaultClipboardClear
+
+// copiedSecret is the secret last copied and the timer that clears it.
+var copiedSecret struct {
+	sync.Mutex
+	text  string
+	timer *time.Timer
+}
+
+// clipboardChoices are the delays offered, in seconds as stored in the
+// profile.
+var clipboardChoices = []struct {
+	label   string
+	seconds int
+}{
+	{"10 seconds", 10},
+	{"30 seconds", 0},
+	{"1 minute", 60},
+	{"2 minutes", 120},
+	{"Never", -1},
+}
+
+// copySecret puts secret on the clipboard of w and clears it after
+// clipboardClearDelay, unless something else was copied in the meantime.
+func copySecret(w fyne.Window, secret string) {
+	clipboard := w.Clipboard()
+	clipboard.SetContent(secret)
+
+	copiedSecret.Lock()
+	defer copiedSecret.Unlock()
+	if copiedSecret.timer != nil {
+		copiedSecret.timer.Stop()
+		copiedSecret.timer = nil
+	}
+	copiedSecret.text = secret
+	if clipboardClearDelay > 0 {
+		copiedSecret.timer = time.AfterFunc(clipboardClearDelay, func() { clearCopiedSecret(clipboard) })
+	}
+}
+
+// clearCopiedSecret empties clipboard if it still holds the secret last
+// copied, e.g. when the timer fires or the application exits.
+func clearCopiedSecret(clipboard fyne.Clipboard) {
+	copiedSecret.Lock()
+	defer copiedSecret.Unlock()
+	if copiedSecret.timer != nil {
+		copiedSecret.timer.Stop()
+		copiedSecret.timer = nil
+	}
+	if copiedSecret.text != "" && clipboard.Content() == copiedSecret.text {
+		clipboard.SetContent("")
+	}
+	copiedSecret.text = ""
+}
+
+// clipboardClearText describes the delay of the profile.
+func clipboardClearText(profile config.Profile) string {
+	delay := profile.ClipboardClearDelay()
+	if delay == 0 {
+		return "Off: copied passwords stay on the clipboard"
+	}
+	return fmt.Sprintf("Copied passwords are cleared after %v", delay)
+}
+
+// showClipboardSettings lets the user choose how long copied passwords stay
+// on the clipboard, saving the choice in the profile.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialog.
+//   - profile (*config.Profile): Holds the delay.
+//   - profilePath (string): Where the profile is saved.
+func showClipboardSettings(w fyne.Window, profile *config.Profile, profilePath string) {
+	labels := make([]string, len(clipboardChoices))
+	for i, choice := range clipboardChoices {
+		labels[i] = choice.label
+	}
+	status := widget.NewLabel(clipboardClearText(*profile))
+	status.Wrapping = fyne.TextWrapWord
+	delaySelect := widget.NewSelect(labels, nil)
+	for _, choice := range clipboardChoices {
+		if choice.seconds == profile.ClipboardClear {
+			delaySelect.SetSelected(choice.label)
+		}
+	}
+	delaySelect.OnChanged = func(label string) {
+		for _, choice := range clipboardChoices {
+			if choice.label != label {
+				continue
+			}
+			profile.ClipboardClear = choice.seconds
+			clipboardClearDelay = profile.ClipboardClearDelay()
+			if err := config.SaveProfile(profilePath, *profile); err != nil {
+				dialog.ShowError(err, w)
+			}
+			status.SetText(clipboardClearText(*profile))
+		}
+	}
+
+	note := widget.NewLabel("The clipboard is only cleared while it still holds the copied password, and again when the application exits.")
+	note.Wrapping = fyne.TextWrapWord
+	d := dialog.NewCustom("Clipboard", "Close", container.NewVBox(widget.NewForm(widget.NewFormItem("Clear after", delaySelect)), status, note), w)
+	d.Resize(fyne.NewSize(420, 220))
+	d.Show()
+}
@@ -0,0 +1,149 @@
+/**
+ * Password Generator - Structured Copy
+ *
+ * This file copies the latest results to the clipboard as JSON or through a
+ * user-defined template, for pasting into tools that expect metadata such as
+ * the entropy estimate alongside the password, and saves them as CSV, also
+ * in the import layouts of Bitwarden, 1Password and LastPass.
+ */
+
+package view
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/PaulBaker1/Password-Generator-GO/export"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// copyAsJSON copies the results as a JSON object with the options used and
+// the generation time, in the format of the CLI's -format json.
+// Parameters:
+//   - w (fyne.Window): The window whose clipboard is used.
+//   - batch (export.Batch): The results to copy.
+func copyAsJSON(w fyne.Window, batch export.Batch) {
+	if len(batch.Results) == 0 {
+		dialog.ShowInformation("Copy as JSON", "Generate passwords first.", w)
+		return
+	}
+	data, err := batch.JSON()
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+	copySecret(w, string(data))
+}
+
+// showCopyTemplate lets the user edit the template and copies the rendered
+// results. The template is kept in *text for the next copy.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialog.
+//   - results ([]export.Result): The results to copy.
+//   - text (*string): The current template, updated when the user confirms.
+func showCopyTemplate(w fyne.Window, results []export.Result, text *string) {
+	if len(results) == 0 {
+		dialog.ShowInformation("Copy with Template", "Generate passwords first.", w)
+		return
+	}
+	templateEntry := widget.NewMultiLineEntry()
+	templateEntry.SetText(*text)
+	hint := widget.NewLabel("Fields: {{.Password}} {{.Length}} {{.Entropy}} {{.GeneratedAt}}")
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Template", templateEntry),
+		widget.NewFormItem("", hint),
+	}
+	form := dialog.NewForm("Copy with Template", "Copy", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		rendered, err := export.Template(templateEntry.Text, results)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		*text = templateEntry.Text
+		copySecret(w, rendered)
+	}, w)
+	form.Resize(fyne.NewSize(480, 260))
+	form.Show()
+}
+
+// csvFormats are the layouts offered when saving results as CSV: the
+// generator's own columns, then the import layouts of password managers.
+var csvFormats = []struct {
+	label, manager, file string
+}{
+	{"Password Generator (all columns)", "", "passwords.csv"},
+	{"Bitwarden", export.Bitwarden, "bitwarden-import.csv"},
+	{"1Password", export.OnePassword, "1password-import.csv"},
+	{"LastPass", export.LastPass, "lastpass-import.csv"},
+}
+
+// saveAsCSV asks for a format and where to save the results as CSV: with
+// their index, entropy, character classes and generation time, or in the
+// layout a password manager imports, for bulk provisioning.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialog.
+//   - results ([]export.Result): The results to save.
+func saveAsCSV(w fyne.Window, results []export.Result) {
+	if len(results) == 0 {
+		dialog.ShowInformation("Export CSV", "Generate passwords first.", w)
+		return
+	}
+	labels := make([]string, len(csvFormats))
+	for i, format := range csvFormats {
+		labels[i] = format.label
+	}
+	formatSelect := widget.NewSelect(labels, nil)
+	formatSelect.SetSelectedIndex(0)
+	items := []*widget.FormItem{widget.NewFormItem("Format", formatSelect)}
+	dialog.ShowForm("Export CSV", "Save...", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		format := csvFormats[formatSelect.SelectedIndex()]
+		writeCSV(w, results, format.manager, format.file)
+	}, w)
+}
+
+// writeCSV asks where to save the results as CSV in the layout of manager,
+// or the generator's own if it is empty, suggesting fileName.
+func writeCSV(w fyne.Window, results []export.Result, manager, fileName string) {
+	var data bytes.Buffer
+	var err error
+	if manager == "" {
+		err = export.CSV(&data, results)
+	} else {
+		err = export.ManagerCSV(&data, manager, results)
+	}
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+
+	save := dialog.NewFileSave(func(file fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if file == nil {
+			return
+		}
+		_, writeErr := file.Write(data.Bytes())
+		if closeErr := file.Close(); writeErr == nil {
+			writeErr = closeErr
+		}
+		if writeErr != nil {
+			dialog.ShowError(fmt.Errorf("export failed: %w", writeErr), w)
+			return
+		}
+		dialog.ShowInformation("Export CSV", "The file contains the passwords in plain text; store it securely and delete it once the accounts are provisioned.", w)
+	}, w)
+	save.SetFileName(fileName)
+	save.Show()
+}
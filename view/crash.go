@@ -0,0 +1,65 @@
+/**
+ * Password Generator - Crash Handling
+ *
+ * This file recovers panics raised by GUI callbacks, stores a local crash
+ * report, and lets the user choose whether to open a pre-filled GitHub issue.
+ */
+
+package view
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/crashreport"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// recoverCrash handles a panic raised by a GUI callback.
+// Purpose:
+//
+//	Must be deferred directly by the callback. Writes a crash report containing
+//	the stack trace and options (never passwords) and offers to open an issue.
+//
+// Parameters:
+//   - w (fyne.Window): The window used to show the crash dialog.
+//   - opts (passgen.PasswordOptions): The options in effect when the panic occurred.
+//
+// Example:
+//
+//	defer recoverCrash(myWindow, opts)
+func recoverCrash(w fyne.Window, opts passgen.PasswordOptions) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	report := crashreport.New(r, debug.Stack(), &opts)
+
+	message := "The application hit an unexpected error."
+	if dir, err := config.Dir(); err == nil {
+		if path, err := report.Write(filepath.Join(dir, "crashes")); err == nil {
+			message += fmt.Sprintf("\nA crash report was saved to:\n%s", path)
+		}
+	}
+
+	content := container.NewVBox(
+		widget.NewLabel(message),
+		widget.NewLabel("The report contains the stack trace and options, never generated passwords."),
+	)
+	dialog.ShowCustomConfirm("Something went wrong", "Report on GitHub", "Close", content, func(open bool) {
+		if !open {
+			return
+		}
+		if link, err := url.Parse(report.IssueURL()); err == nil {
+			_ = fyne.CurrentApp().OpenURL(link)
+		}
+	}, w)
+}
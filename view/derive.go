@@ -0,0 +1,102 @@
+/**
+ * Password Generator - Site Password Tab
+ *
+ * This file builds the Site tab, which derives a per-site password from a
+ * master secret, site, login and counter instead of generating a random one.
+ * The same inputs give the same password on every device, so nothing is
+ * stored. The master secret is cleared from the form after every derivation
+ * and its byte copy is wiped; the text the entry widget held cannot be wiped
+ * from memory, as Go strings are immutable.
+ */
+
+package view
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/derive"
+	"github.com/PaulBaker1/Password-Generator-GO/kdf"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// deriveTab returns the content of the Site tab.
+// Parameters:
+//   - w (fyne.Window): The window whose clipboard receives the password.
+func deriveTab(w fyne.Window) fyne.CanvasObject {
+	defaults := derive.NewProfile("", "")
+	masterEntry := widget.NewPasswordEntry()
+	masterEntry.SetPlaceHolder("Master secret")
+	siteEntry := widget.NewEntry()
+	siteEntry.SetPlaceHolder("example.com")
+	loginEntry := widget.NewEntry()
+	loginEntry.SetPlaceHolder("User name or e-mail")
+	counterEntry := widget.NewEntry()
+	counterEntry.SetText("1")
+	lengthEntry := widget.NewEntry()
+	lengthEntry.SetText(strconv.Itoa(defaults.Length))
+	lowercase := widget.NewCheck("Lowercase", nil)
+	lowercase.SetChecked(defaults.Lowercase)
+	uppercase := widget.NewCheck("Uppercase", nil)
+	uppercase.SetChecked(defaults.Uppercase)
+	digits := widget.NewCheck("Numbers", nil)
+	digits.SetChecked(defaults.Digits)
+	symbols := widget.NewCheck("Symbols", nil)
+	symbols.SetChecked(defaults.Symbols)
+
+	result := widget.NewEntry()
+	result.SetPlaceHolder("The derived password will appear here")
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+
+	deriveButton := widget.NewButton("Derive Password", func() {
+		counter, err := strconv.ParseUint(strings.TrimSpace(counterEntry.Text), 10, 32)
+		if err != nil {
+			status.SetText("Error: the counter must be a number")
+			return
+		}
+		length, err := strconv.Atoi(strings.TrimSpace(lengthEntry.Text))
+		if err != nil {
+			status.SetText("Error: the length must be a number")
+			return
+		}
+		profile := derive.Profile{
+			Site:      siteEntry.Text,
+			Login:     loginEntry.Text,
+			Counter:   uint32(counter),
+			Length:    length,
+			Lowercase: lowercase.Checked,
+			Uppercase: uppercase.Checked,
+			Digits:    digits.Checked,
+			Symbols:   symbols.Checked,
+		}
+
+		master := []byte(masterEntry.Text)
+		masterEntry.SetText("")
+		password, err := derive.Password(master, profile, kdf.Default)
+		kdf.Wipe(master)
+		if err != nil {
+			status.SetText("Error: " + err.Error())
+			return
+		}
+		result.SetText(password)
+		status.SetText(fmt.Sprintf("Derived for %s (counter %d). Enter the master secret again for the next site.", profile.Site, profile.Counter))
+	})
+	copyButton := widget.NewButton("Copy", func() { copySecret(w, result.Text) })
+
+	note := widget.NewLabel("Same master secret, site, login and counter always give the same password; nothing is saved. Increase the counter to change a password.")
+	note.Wrapping = fyne.TextWrapWord
+	form := widget.NewForm(
+		widget.NewFormItem("Master secret", masterEntry),
+		widget.NewFormItem("Site", siteEntry),
+		widget.NewFormItem("Login", loginEntry),
+		widget.NewFormItem("Counter", counterEntry),
+		widget.NewFormItem(fmt.Sprintf("Length (%d-%d)", derive.MinLength, derive.MaxLength), lengthEntry),
+		widget.NewFormItem("Characters", container.NewGridWithColumns(2, lowercase, uppercase, digits, symbols)),
+	)
+	return container.NewVBox(note, form, deriveButton, container.NewBorder(nil, nil, nil, copyButton, result), status)
+}
@@ -0,0 +1,55 @@
+/**
+ * Password Generator - Encrypted Export on Windows
+ *
+ * This file saves the generated passwords to a file encrypted with DPAPI for
+ * the current Windows user, so other accounts on the machine cannot read it.
+ */
+
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/dpapi"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+// showDPAPIExport asks for a file name and writes the encrypted passwords.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialogs.
+//   - passwords ([]string): The passwords to export.
+func showDPAPIExport(w fyne.Window, passwords []string) {
+	if len(passwords) == 0 {
+		dialog.ShowInformation("Export Encrypted", "Generate passwords first.", w)
+		return
+	}
+	blob, err := dpapi.Protect([]byte(strings.Join(passwords, "\n") + "\n"))
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+
+	save := dialog.NewFileSave(func(file fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if file == nil {
+			return
+		}
+		_, writeErr := file.Write(blob)
+		if closeErr := file.Close(); writeErr == nil {
+			writeErr = closeErr
+		}
+		if writeErr != nil {
+			dialog.ShowError(fmt.Errorf("export failed: %w", writeErr), w)
+			return
+		}
+		dialog.ShowInformation("Export Encrypted", "Only your Windows account can open this file.\nRead it with: password-generator-cli -dpapi-read <file>", w)
+	}, w)
+	save.SetFileName("passwords.dpapi")
+	save.Show()
+}
@@ -0,0 +1,81 @@
+/**
+ * Password Generator - Safety Floor
+ *
+ * This file lets users set a minimum entropy below which the GUI refuses to
+ * generate. It protects less technical users from options such as six
+ * lowercase letters: generation stops with an explanation of what to change.
+ */
+
+package view
+
+import (
+	"fmt"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// safetyFloor is a floor offered, in bits, with its label.
+type safetyFloor struct {
+	label string
+	bits  float64
+}
+
+// safetyFloors lists the floors offered.
+var safetyFloors = []safetyFloor{
+	{"Off", 0},
+	{"36 bits (fair)", 36},
+	{"60 bits (strong)", 60},
+	{"80 bits", 80},
+	{"128 bits (very strong)", 128},
+}
+
+// showSafetyFloor asks for the safety floor and saves it in the profile.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialog.
+//   - profile (*config.Profile): Holds the current floor and receives the new one.
+//   - profilePath (string): Where the profile is saved.
+//   - managed (config.Managed): Floors below its SafetyFloor are not offered.
+func showSafetyFloor(w fyne.Window, profile *config.Profile, profilePath string, managed config.Managed) {
+	var floors []safetyFloor
+	if managed.SafetyFloor > 0 {
+		floors = append(floors, safetyFloor{fmt.Sprintf("%.0f bits (set by your organization)", managed.SafetyFloor), managed.SafetyFloor})
+	}
+	for _, floor := range safetyFloors {
+		if floor.bits > managed.SafetyFloor || managed.SafetyFloor == 0 {
+			floors = append(floors, floor)
+		}
+	}
+	labels := make([]string, len(floors))
+	for i, floor := range floors {
+		labels[i] = floor.label
+	}
+	floorSelect := widget.NewSelect(labels, nil)
+	floorSelect.SetSelectedIndex(0)
+	for i, floor := range floors {
+		if floor.bits == profile.SafetyFloor {
+			floorSelect.SetSelectedIndex(i)
+		}
+	}
+
+	note := widget.NewLabel("Generation is refused, with an explanation, when the selected options are estimated below this entropy.")
+	note.Wrapping = fyne.TextWrapWord
+	items := []*widget.FormItem{
+		widget.NewFormItem("", note),
+		widget.NewFormItem("Minimum entropy", floorSelect),
+	}
+	form := dialog.NewForm("Safety Floor", "Save", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		profile.SafetyFloor = floors[floorSelect.SelectedIndex()].bits
+		if err := config.SaveProfile(profilePath, *profile); err != nil {
+			dialog.ShowError(err, w)
+		}
+	}, w)
+	form.Resize(fyne.NewSize(380, 220))
+	form.Show()
+}
@@ -0,0 +1,36 @@
+package view
+
+import (
+	"strconv"
+	"strings"
+)
+
+// groupPrefix starts the name of every group size option.
+const groupPrefix = "Groups of "
+
+// groupSizes lists the group sizes offered; the first turns grouping off.
+var groupSizes = []string{"No Groups", groupPrefix + "3", groupPrefix + "4", groupPrefix + "5", groupPrefix + "6"}
+
+// groupSize returns the size of the group option name, 0 for no groups.
+func groupSize(name string) int {
+	size, _ := strconv.Atoi(strings.TrimPrefix(name, groupPrefix))
+	return size
+}
+
+// groupSizeName returns the option name for size.
+func groupSizeName(size int) string {
+	if size <= 0 {
+		return groupSizes[0]
+	}
+	return groupPrefix + strconv.Itoa(size)
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -9,15 +9,27 @@
 package view
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"password-generator/controller"
-	"password-generator/model"
-	"strconv"
+	"github.com/PaulBaker1/Password-Generator-GO/audit"
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/controller"
+	"github.com/PaulBaker1/Password-Generator-GO/dpapi"
+	"github.com/PaulBaker1/Password-Generator-GO/export"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/siterules"
+	"github.com/PaulBaker1/Password-Generator-GO/session"
+	"sort"
 	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -29,25 +41,39 @@ import (
 //
 // Parameters:
 //   - ctrl (*controller.GeneratorController): The controller that manages password generation.
+//   - managed (config.Managed): The settings the organization enforces; the
+//     controls they lock are shown disabled.
+//   - settings (config.Settings): The settings file: its policy is selected
+//     and its profiles are offered as presets, and its preferences apply
+//     where the profile has none.
 //
 // Example:
 //
-//	StartGUI(ctrl)
-func StartGUI(ctrl *controller.GeneratorController) {
+//	StartGUI(ctrl, managed, settings)
+func StartGUI(ctrl *controller.GeneratorController, managed config.Managed, settings config.Settings) {
 	myApp := app.New()
 	myWindow := myApp.NewWindow("Password Generator")
 
-	// Set up the length slider with min, max, and default values from the controller config
-	lengthSlider := widget.NewSlider(float64(ctrl.Config.MinLength), float64(ctrl.Config.MaxLength))
+	// Set up the length slider with min, max, and default values from the
+	// controller config; maxLength grows for audiences and presets that
+	// need longer secrets
+	maxLength := ctrl.Config.MaxLength
+	lengthSlider := widget.NewSlider(float64(ctrl.Config.MinLength), float64(maxLength))
 	lengthSlider.Value = float64(ctrl.Config.DefaultLength)
 	lengthLabel := widget.NewLabel(fmt.Sprintf("Length: %.0f", lengthSlider.Value))
-	lengthSlider.OnChanged = func(value float64) {
-		lengthLabel.SetText(fmt.Sprintf("Length: %.0f", value))
-	}
+	// entropyLabel shows the estimated entropy of the selected options live
+	entropyLabel := widget.NewLabel("")
 
-	// Quantity selection dropdown to determine how many passwords to generate.
-	quantitySelect := widget.NewSelect([]string{"1", "5", "10", "20"}, nil)
-	quantitySelect.SetSelected("1") // Default selection to 1 password
+	// Quantity entry to determine how many passwords to generate; large
+	// batches show their progress below the Generate button.
+	quantityEntry := widget.NewEntry()
+	quantityEntry.SetText("1") // Default to 1 password
+	quantityEntry.Validator = func(text string) error {
+		_, err := parseQuantity(text)
+		return err
+	}
+	quantityProgress := widget.NewProgressBar()
+	quantityProgress.Hide()
 
 	// Options for character inclusion in the generated password
 	includeSymbols := widget.NewCheck("Include Symbols", nil)
@@ -55,6 +81,22 @@ func StartGUI(ctrl *controller.GeneratorController) {
 	includeUpper := widget.NewCheck("Include Uppercase Letters", nil)
 	includeLower := widget.NewCheck("Include Lowercase Letters", nil)
 	includeLower.SetChecked(true) // Default to lowercase inclusion
+	// Character types fixed by the organization cannot be changed
+	for _, lock := range []struct {
+		check *widget.Check
+		value *bool
+	}{
+		{includeSymbols, managed.IncludeSymbols},
+		{includeNumbers, managed.IncludeNumbers},
+		{includeUpper, managed.IncludeUpper},
+		{includeLower, managed.IncludeLower},
+	} {
+		if lock.value != nil {
+			lock.check.SetChecked(*lock.value)
+			lock.check.SetText(lock.check.Text + " (set by your organization)")
+			lock.check.Disable()
+		}
+	}
 
 	// Additional options for password customization
 	beginWithLetter := widget.NewCheck("Begin With Letters", nil)
@@ -62,57 +104,639 @@ func StartGUI(ctrl *controller.GeneratorController) {
 	noDuplicates := widget.NewCheck("No Duplicate Characters", nil)
 	noSequential := widget.NewCheck("No Sequential Characters", nil)
 
-	// passwordEntry allows generated passwords to be displayed and edited.
-	passwordEntry := widget.NewMultiLineEntry()
-	passwordEntry.SetPlaceHolder("Generated passwords will appear here")
-	passwordEntry.Wrapping = fyne.TextWrapWord // Allows word wrapping for multi-line display
+	// Alternating-hand typing on the selected keyboard layout
+	alternateHands := widget.NewCheck("Alternate Hands (faster typing)", nil)
+	layoutSelect := widget.NewSelect(handLayoutNames(), nil)
+	layoutSelect.SetSelected(passgen.DefaultHandLayout)
+	handsImpact := widget.NewLabel("")
+
+	// Characters that must never appear, e.g. keys that do not work
+	excludeEntry := widget.NewEntry()
+	excludeEntry.SetPlaceHolder("Characters to exclude")
+	requireEntry := widget.NewEntry()
+	requireEntry.SetPlaceHolder("Characters to require")
+
+	// Optional grouping of the output, e.g. x7Kp-93fQ-LmR2
+	groupSelect := widget.NewSelect(groupSizes, nil)
+	groupSelect.SetSelected(groupSizes[0])
+	groupSeparatorEntry := widget.NewEntry()
+	groupSeparatorEntry.SetText(passgen.DefaultGroupSeparator)
+
+	// A pattern such as Cvcvc-99-!! replaces the options above, for sites
+	// with a fixed password format
+	patternEntry := widget.NewEntry()
+	patternEntry.SetPlaceHolder("Pattern (optional), e.g. Cvcvc-99-!!")
+	patternSelect := widget.NewSelect(patternPresetNames(), func(name string) {
+		if pattern := patternPreset(name); pattern != "" {
+			patternEntry.SetText(pattern)
+		}
+	})
+	patternSelect.PlaceHolder = "Presets"
+
+	// Broken keys from the personal profile are excluded from every password
+	profilePath, _ := config.ProfilePath()
+	profile, _ := config.LoadProfile(profilePath)
+	settings.UI.ApplyProfile(&profile)
+	managed.ApplyProfile(&profile)
+	applyTheme(myApp, profile.Theme, profile.Density)
+	brokenKeysLabel := widget.NewLabel("")
+	updateBrokenKeys := func() {
+		if profile.BrokenKeys == "" {
+			brokenKeysLabel.SetText("")
+			return
+		}
+		brokenKeysLabel.SetText("Never using broken keys: " + profile.BrokenKeys)
+	}
+	updateBrokenKeys()
+
+	// Passwords on the breach list of the profile are generated again
+	breaches := &breachFilter{}
+	if profile.BreachList != "" {
+		_ = breaches.load(profile.BreachList)
+	}
+
+	// Passwords breaking the selected policy are generated again, too
+	policies := &policyChoice{}
+	var siteConstraints []passgen.Constraint
+	updateConstraints := func() {
+		constraints := append(breaches.constraints(), policies.constraints()...)
+		ctrl.SetConstraints(append(constraints, siteConstraints...)...)
+	}
+	updateConstraints()
+
+	// Errors of the model are shown in the profile's language, else the system's
+	language := profile.Language
+	if language == "" {
+		language = config.SystemLocale()
+	}
+	messageLocale = passgen.NormalizeLocale(language)
+
+	// Copied passwords are cleared from the clipboard after the profile's delay
+	clipboardClearDelay = profile.ClipboardClearDelay()
+
+	// Re-check every generated password against the selected options
+	verifyResults := widget.NewCheck("Verify Results", nil)
+
+	// Known website password rules pre-configure the options above
+	siteRules, _ := siterules.Bundled()
+	siteSelect := widget.NewSelectEntry(siteRules.Sites())
+	siteSelect.SetPlaceHolder("Website (optional)")
+	siteInfo := widget.NewLabel("")
+	siteInfo.Wrapping = fyne.TextWrapWord
+
+	// currentOptions collects the password options selected in the form.
+	currentOptions := func() passgen.PasswordOptions {
+		return passgen.PasswordOptions{
+			Length:            int(lengthSlider.Value),
+			IncludeSymbols:    includeSymbols.Checked,
+			IncludeNumbers:    includeNumbers.Checked,
+			IncludeUpper:      includeUpper.Checked,
+			IncludeLower:      includeLower.Checked,
+			BeginWithLetter:   beginWithLetter.Checked,
+			NoSimilar:         noSimilar.Checked,
+			NoDuplicates:      noDuplicates.Checked,
+			NoSequential:      noSequential.Checked,
+			AlternateHands:    alternateHands.Checked,
+			KeyboardLayout:    layoutSelect.Selected,
+			ExcludeCharacters: excludeEntry.Text + profile.BrokenKeys,
+			MustInclude:       requireEntry.Text,
+			MaxLength:         maxLength,
+			MinEntropy:        profile.SafetyFloor,
+			GroupSize:         groupSize(groupSelect.Selected),
+			GroupSeparator:    groupSeparatorEntry.Text,
+		}
+	}
+
+	// The profile's global hotkey generates and copies from any application,
+	// with the options and checks of the Generate button
+	hotkeyOptions := func() (passgen.PasswordOptions, error) {
+		if err := breaches.required(managed); err != nil {
+			return passgen.PasswordOptions{}, err
+		}
+		if err := policies.required(managed); err != nil {
+			return passgen.PasswordOptions{}, err
+		}
+		opts := policies.apply(currentOptions())
+		opts.Quantity = 1
+		return opts, nil
+	}
+	hotkeys := &globalHotkey{}
+	registerHotkey := func(spec string) error {
+		return hotkeys.register(spec, func() { generateAndCopy(myApp, myWindow, ctrl, hotkeyOptions) })
+	}
+	if profile.Hotkey != "" {
+		go func() {
+			if err := registerHotkey(profile.Hotkey); err != nil {
+				dialog.ShowError(err, myWindow)
+			}
+		}()
+	}
+
+	// applyOptions updates the form to show the given password options, as
+	// far as the organization's managed settings allow.
+	applyOptions := func(opts passgen.PasswordOptions) {
+		opts = managed.Apply(opts)
+		if opts.Length > maxLength {
+			maxLength = opts.Length
+		}
+		lengthSlider.Max = float64(maxLength)
+		lengthSlider.SetValue(float64(opts.Length))
+		includeSymbols.SetChecked(opts.IncludeSymbols)
+		includeNumbers.SetChecked(opts.IncludeNumbers)
+		includeUpper.SetChecked(opts.IncludeUpper)
+		includeLower.SetChecked(opts.IncludeLower)
+		beginWithLetter.SetChecked(opts.BeginWithLetter)
+		noSimilar.SetChecked(opts.NoSimilar)
+		noDuplicates.SetChecked(opts.NoDuplicates)
+		noSequential.SetChecked(opts.NoSequential)
+		alternateHands.SetChecked(opts.AlternateHands)
+		if opts.KeyboardLayout != "" {
+			layoutSelect.SetSelected(opts.KeyboardLayout)
+		}
+		excludeEntry.SetText(withoutCharacters(opts.ExcludeCharacters, profile.BrokenKeys))
+		requireEntry.SetText(opts.MustInclude)
+		if name := groupSizeName(opts.GroupSize); !containsString(groupSelect.Options, name) {
+			groupSelect.Options = append(groupSelect.Options, name)
+		}
+		groupSelect.SetSelected(groupSizeName(opts.GroupSize))
+		if opts.GroupSeparator != "" {
+			groupSeparatorEntry.SetText(opts.GroupSeparator)
+		}
+	}
+
+	// Picking a website applies the options last used for it, else its
+	// known password rules, to the form
+	sitesPath, _ := config.SiteOptionsPath()
+	rememberedSites, _ := config.LoadSiteOptions(sitesPath)
+	siteSelect.OnChanged = func(site string) {
+		// Required groups and max-consecutive of the site's rules are
+		// enforced on every password, also with remembered options
+		siteConstraints = nil
+		if _, rules, ok := siteRules.Lookup(site); ok {
+			siteConstraints = []passgen.Constraint{rules.Constraint()}
+		}
+		updateConstraints()
+		if opts, ok := rememberedSites.Lookup(site); ok {
+			applyOptions(opts)
+			siteInfo.SetText("Using the options last used for " + siterules.NormalizeSite(site))
+			return
+		}
+		domain, rules, ok := siteRules.Lookup(site)
+		if !ok {
+			siteInfo.SetText("")
+			return
+		}
+		applyOptions(rules.Apply(currentOptions()))
+		siteInfo.SetText(domain + ": " + rules.Describe())
+	}
+
+	// Picking an audience applies its defaults: long secrets without
+	// readability rules for machines, readable ones for people
+	audienceSelect := widget.NewSelect(audienceLabels(), func(label string) {
+		opts, err := config.AudienceOptions(audienceFor(label))
+		if err != nil {
+			return
+		}
+		maxLength = opts.MaxLength
+		applyOptions(*opts)
+		patternEntry.SetText("")
+	})
+	audienceSelect.PlaceHolder = "Audience (optional)"
+
+	// Picking a preset applies its options, and its pattern if it has one,
+	// and shows the audience it was saved for
+	presetsPath, _ := config.PresetsPath()
+	presets, _ := config.LoadPresets(presetsPath)
+	// availablePresets are the saved presets and the profiles of the
+	// settings file
+	availablePresets := func() config.Presets { return presets.With(settings.Profiles) }
+	presetSelect := widget.NewSelect(availablePresets().Names(), func(name string) {
+		if preset, ok := availablePresets()[name]; ok {
+			applyOptions(preset.Options)
+			patternEntry.SetText(preset.Pattern)
+			audienceSelect.Selected = audienceLabel(preset.Audience)
+			audienceSelect.Refresh()
+		}
+	})
+	presetSelect.PlaceHolder = "Preset (optional)"
+
+	// Picking a password policy applies it to the form and describes it
+	policyInfo := widget.NewLabel("")
+	policyInfo.Wrapping = fyne.TextWrapWord
+	// A policy required by the organization replaces the settings file's
+	// and cannot be changed
+	initialPolicy := settings.Policy
+	if managed.Policy != "" {
+		initialPolicy = managed.Policy
+	}
+	policySelect, policyErr := newPolicySelect(myWindow, policies, initialPolicy, func() {
+		updateConstraints()
+		applyOptions(policies.apply(currentOptions()))
+		policyInfo.SetText(policies.describe())
+	})
+	if managed.Policy != "" {
+		policySelect.Disable()
+	}
+
+	// auditOptions returns what audits check existing passwords against:
+	// the breach list and policy in use, and the options of the form for
+	// replacements.
+	auditOptions := func() audit.Options {
+		return audit.Options{
+			Base:      policies.apply(currentOptions()),
+			Breached:  breaches.checker(),
+			Generator: ctrl.Generator,
+			Policy:    policies.policy,
+		}
+	}
+
+	// updateHandsImpact shows the entropy cost of alternating hands.
+	updateHandsImpact := func() {
+		if !alternateHands.Checked {
+			handsImpact.SetText("")
+			return
+		}
+		opts := currentOptions()
+		alternating := passgen.EstimateEntropy(opts)
+		opts.AlternateHands = false
+		handsImpact.SetText(fmt.Sprintf("≈ %.0f bits (%.0f bits without alternating)", alternating, passgen.EstimateEntropy(opts)))
+	}
+	for _, check := range []*widget.Check{includeSymbols, includeNumbers, includeUpper, includeLower, beginWithLetter, noSimilar, alternateHands} {
+		check.OnChanged = func(bool) { updateHandsImpact() }
+	}
+	layoutSelect.OnChanged = func(string) { updateHandsImpact() }
+	lengthSlider.OnChanged = func(value float64) {
+		lengthLabel.SetText(fmt.Sprintf("Length: %.0f", value))
+		updateHandsImpact()
+	}
+
+	// lastPasswords holds the passwords of the most recent generation, with the
+	// options and time they were generated with for structured copies.
+	var lastPasswords []string
+	var lastOptions passgen.PasswordOptions
+	// lastEstimate is the entropy the last batch's options or pattern give.
+	var lastEstimate float64
+	var lastGenerated time.Time
+	// lastTags holds the label and note typed for each password of the batch.
+	var lastTags []resultTag
+	// lastRegenerate generates one more password like those of the batch, for
+	// replacing a single row; nil for a restored session, whose pattern is
+	// not known.
+	var lastRegenerate func() (string, error)
+	copyTemplate := export.DefaultTemplate
+
+	// Autosave the options and, if enabled in the profile, the results that
+	// were not copied yet, so the session survives a crash or accidental close
+	sessionPath, _ := config.SessionPath()
+	// The encrypted history; generated passwords are saved while it is unlocked
+	vaultPath, _ := config.VaultPath()
+	passwordHistory := &history{path: vaultPath}
+	autosave := session.NewAutosaver(sessionPath)
+	copied := false
+	sessionReady := false // set once a saved session was restored or declined
+	saveSession := func() {
+		if !sessionReady {
+			return
+		}
+		state := session.State{Options: currentOptions()}
+		if profile.RestoreResults && !copied {
+			state.Passwords = lastPasswords
+			state.SavedAt = lastGenerated
+		}
+		autosave.Update(state)
+	}
+
+	// optionsChanged disables or adjusts dependent controls, following the
+	// rules the model validates with, shows what the controller would still
+	// reject under the options, and autosaves the session
+	optionError := widget.NewLabel("")
+	optionError.Importance = widget.DangerImportance
+	optionError.Wrapping = fyne.TextWrapWord
+	ruleControls := map[string]*widget.Check{
+		"IncludeSymbols":  includeSymbols,
+		"IncludeNumbers":  includeNumbers,
+		"IncludeUpper":    includeUpper,
+		"IncludeLower":    includeLower,
+		"BeginWithLetter": beginWithLetter,
+		"NoSimilar":       noSimilar,
+		"NoDuplicates":    noDuplicates,
+		"NoSequential":    noSequential,
+		"AlternateHands":  alternateHands,
+	}
+	optionsChanged := func() {
+		enforceOptionRules(currentOptions(), ruleControls, lengthSlider, float64(maxLength))
+		if patternEntry.Text != "" {
+			optionError.SetText("") // the pattern replaces the options
+		} else {
+			optionError.SetText(validationText(ctrl.Validate(currentOptions())))
+		}
+		entropyLabel.SetText(entropyText(currentOptions(), patternEntry.Text))
+		saveSession()
+	}
+	for _, check := range ruleControls {
+		changed := check.OnChanged
+		check.OnChanged = func(checked bool) {
+			if changed != nil {
+				changed(checked)
+			}
+			optionsChanged()
+		}
+	}
+	sliderChanged := lengthSlider.OnChanged
+	lengthSlider.OnChanged = func(value float64) {
+		sliderChanged(value)
+		optionsChanged()
+	}
+	layoutChanged := layoutSelect.OnChanged
+	layoutSelect.OnChanged = func(layout string) {
+		layoutChanged(layout)
+		optionsChanged()
+	}
+	excludeEntry.OnChanged = func(string) { optionsChanged() }
+	patternEntry.OnChanged = func(string) { optionsChanged() }
+	requireEntry.OnChanged = func(string) { optionsChanged() }
+	groupSelect.OnChanged = func(string) { optionsChanged() }
+	groupSeparatorEntry.OnChanged = func(string) { optionsChanged() }
+	restoreResults := widget.NewCheck("Remember Un-copied Results (encrypted)", func(checked bool) {
+		profile.RestoreResults = checked
+		if err := config.SaveProfile(profilePath, profile); err != nil {
+			dialog.ShowError(err, myWindow)
+		}
+		saveSession()
+	})
+	restoreResults.SetChecked(profile.RestoreResults)
+	if !session.CanSaveResults {
+		restoreResults.Hide()
+	}
+	if managed.RestoreResults != nil {
+		restoreResults.Disable()
+	}
+
+	// Tell users why locked controls cannot be changed
+	managedLabel := widget.NewLabel("Set by your organization and cannot be changed:\n" + strings.Join(managed.Describe(), "\n"))
+	managedLabel.Wrapping = fyne.TextWrapWord
+	if !managed.Active() {
+		managedLabel.Hide()
+	}
+
+	// Sort and filter controls for the strength-badged results
+	orderSelect := widget.NewSelect([]string{orderGenerated, orderStrongest}, nil)
+	orderSelect.SetSelected(orderGenerated)
+	showSelect := widget.NewSelect([]string{showAll, showGood, showExcellent}, nil)
+	showSelect.SetSelected(showAll)
+	onCopy := func() {
+		copied = true
+		saveSession()
+	}
+	popout := &resultsPopout{onCopy: onCopy}
+
+	// results lists the generated passwords with a Copy button per row
+	results := newResultList("Generated passwords will appear here", func(password string) {
+		copySecret(myWindow, password)
+		onCopy()
+	})
+	results.showQR = func(password string) { showQR(myWindow, password) }
+	showResults := func() {
+		rows := resultRows(lastPasswords, lastOptions, lastEstimate, orderSelect.Selected, showSelect.Selected)
+		if len(lastPasswords) > 0 {
+			results.setRows(rows, len(lastPasswords), lastTags)
+		}
+		popout.update(rows)
+	}
+	// Regenerate replaces one password of the batch; its label and note stay
+	results.regenerate = func(number int) {
+		if lastRegenerate == nil {
+			dialog.ShowInformation("Regenerate", "Generate a new batch first: the options of a restored session are not complete.", myWindow)
+			return
+		}
+		password, err := lastRegenerate()
+		if err != nil {
+			dialog.ShowError(localized(err), myWindow)
+			return
+		}
+		lastPasswords[number-1] = password
+		copied = false
+		saveSession()
+		rows := resultRows(lastPasswords, lastOptions, lastEstimate, orderSelect.Selected, showSelect.Selected)
+		results.updateRows(rows, len(lastPasswords), lastTags)
+		popout.update(rows)
+	}
+	// The eye button masks the results against shoulder surfing, and is
+	// remembered; each row can still be revealed on its own
+	var maskButton *widget.Button
+	setMasked := func(masked bool) {
+		results.setMasked(masked)
+		popout.setMasked(masked)
+		if masked {
+			maskButton.SetText("Show")
+			maskButton.SetIcon(theme.VisibilityIcon())
+		} else {
+			maskButton.SetText("Hide")
+			maskButton.SetIcon(theme.VisibilityOffIcon())
+		}
+	}
+	maskButton = widget.NewButtonWithIcon("", nil, func() {
+		profile.MaskPasswords = !profile.MaskPasswords
+		setMasked(profile.MaskPasswords)
+		if err := config.SaveProfile(profilePath, profile); err != nil {
+			dialog.ShowError(err, myWindow)
+		}
+	})
+	setMasked(profile.MaskPasswords)
+	orderSelect.OnChanged = func(string) { showResults() }
+	showSelect.OnChanged = func(string) { showResults() }
+	lastResults := func() []export.Result {
+		tagged := export.NewResults(lastPasswords, lastOptions, lastGenerated)
+		for i, tag := range lastTags {
+			tagged[i].Label, tagged[i].Note = tag.label, tag.note
+		}
+		return tagged
+	}
+
+	// cancelGeneration stops the running generation; nil while none runs.
+	var cancelGeneration context.CancelFunc
+
+	// Cancel Button
+	// Purpose: Stops a long generation, e.g. a large quantity with heavy
+	// constraints. The previous results stay in place.
+	cancelButton := widget.NewButton("Cancel", func() {
+		if cancelGeneration != nil {
+			cancelGeneration()
+		}
+	})
+	cancelButton.Disable()
 
 	// Generate Button
 	// Purpose: Triggers password generation based on selected options.
 	// Example:
 	//   Clicking the button generates and displays passwords.
-	generateButton := widget.NewButton("Generate", func() {
-		// Convert selected quantity to integer
-		quantity, err := strconv.Atoi(quantitySelect.Selected)
+	var generateButton *widget.Button
+	generateButton = widget.NewButton("Generate", func() {
+		if cancelGeneration != nil {
+			return
+		}
+		// Convert the typed quantity to an integer
+		quantity, err := parseQuantity(quantityEntry.Text)
 		if err != nil {
-			passwordEntry.SetText("Error: invalid quantity selected")
+			results.setMessage("Error: " + err.Error())
 			return
 		}
 
 		// Set up password options for generation
-		opts := model.PasswordOptions{
-			Length:          int(lengthSlider.Value),
-			Quantity:        quantity,
-			IncludeSymbols:  includeSymbols.Checked,
-			IncludeNumbers:  includeNumbers.Checked,
-			IncludeUpper:    includeUpper.Checked,
-			IncludeLower:    includeLower.Checked,
-			BeginWithLetter: beginWithLetter.Checked,
-			NoSimilar:       noSimilar.Checked,
-			NoDuplicates:    noDuplicates.Checked,
-			NoSequential:    noSequential.Checked,
-		}
-
-		// Generate passwords and display them in a numbered format
-		passwords, err := ctrl.GeneratePasswords(opts)
-		if err != nil {
-			passwordEntry.SetText("Error: " + err.Error())
-		} else {
-			var formattedPasswords strings.Builder
-			for i, password := range passwords {
-				formattedPasswords.WriteString(fmt.Sprintf("%d. %s\n", i+1, password))
+		opts := policies.apply(currentOptions())
+		opts.Quantity = quantity
+		if err := breaches.required(managed); err != nil {
+			results.setMessage(errorText(err))
+			return
+		}
+		if err := policies.required(managed); err != nil {
+			results.setMessage(errorText(err))
+			return
+		}
+
+		pattern := patternEntry.Text
+		generate := ctrl.GeneratePasswords
+		estimate := passgen.EstimateEntropy(opts)
+		if pattern != "" {
+			// The pattern fixes the format, so it is not grouped either.
+			opts.GroupSize = 0
+			if parsed, err := passgen.ParsePattern(pattern, opts); err == nil {
+				if err := managed.CheckPattern(parsed); err != nil {
+					results.setMessage(errorText(err))
+					return
+				}
+				estimate = parsed.Entropy()
 			}
-			passwordEntry.SetText(formattedPasswords.String())
+			generate = func(ctx context.Context, opts passgen.PasswordOptions) ([]string, error) {
+				return ctrl.GeneratePatternPasswords(ctx, pattern, opts)
+			}
+		}
+		site := siteSelect.Text
+
+		// Generate in the background so that Cancel stays responsive
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelGeneration = cancel
+		generateButton.Disable()
+		cancelButton.Enable()
+		results.setMessage("Generating...")
+		if quantity > batchChunk {
+			quantityProgress.SetValue(0)
+			quantityProgress.Show()
 		}
+		go func() {
+			defer func() {
+				cancel()
+				cancelGeneration = nil
+				cancelButton.Disable()
+				generateButton.Enable()
+				quantityProgress.Hide()
+			}()
+			// Recover from panics with a local crash report instead of exiting
+			defer recoverCrash(myWindow, opts)
+
+			passwords, err := generateBatch(ctx, generate, opts, func(done int) {
+				quantityProgress.SetValue(float64(done) / float64(quantity))
+			})
+			if errors.Is(err, context.Canceled) {
+				results.setMessage("Generation cancelled.")
+				showResults()
+				return
+			}
+			if err == nil && verifyResults.Checked {
+				if err = verifyGenerated(passwords, pattern, opts); err != nil {
+					passwords = nil
+					dialog.ShowError(localized(err), myWindow)
+				}
+			}
+			if err == nil && pattern == "" && site != "" {
+				rememberedSites.Remember(site, opts)
+				if err := config.SaveSiteOptions(sitesPath, rememberedSites); err != nil {
+					dialog.ShowError(err, myWindow)
+				}
+			}
+			if err == nil {
+				if err := passwordHistory.record(passwords, opts, site); err != nil {
+					dialog.ShowError(err, myWindow)
+				}
+			}
+			lastPasswords, lastOptions, lastEstimate, lastGenerated = passwords, opts, estimate, time.Now()
+			lastTags = make([]resultTag, len(passwords))
+			lastRegenerate = func() (string, error) {
+				one := opts
+				one.Quantity = 1
+				passwords, err := generate(context.Background(), one)
+				if err == nil && verifyResults.Checked {
+					err = verifyGenerated(passwords, pattern, one)
+				}
+				if err == nil {
+					err = passwordHistory.record(passwords, one, site)
+				}
+				if err != nil {
+					return "", err
+				}
+				return passwords[0], nil
+			}
+			copied = false
+			saveSession()
+			if err != nil {
+				results.setMessage(errorText(err))
+			} else {
+				showResults()
+			}
+		}()
+	})
+
+	// "Broken Keys..." edits the keys saved in the personal profile
+	brokenKeysButton := widget.NewButton("Broken Keys...", func() {
+		showBrokenKeys(myWindow, &profile, profilePath, func() {
+			updateBrokenKeys()
+			updateHandsImpact()
+			optionsChanged()
+		})
+	})
+
+	// Result ordering, and copy actions for tooling that expects metadata
+	// with the password
+	resultBar := container.NewHBox(
+		orderSelect,
+		showSelect,
+		maskButton,
+		widget.NewButton("Copy as JSON", func() {
+			copyAsJSON(myWindow, export.NewBatch(lastResults(), lastOptions, lastGenerated))
+			copied = true
+			saveSession()
+		}),
+		widget.NewButton("Copy with Template...", func() {
+			showCopyTemplate(myWindow, lastResults(), &copyTemplate)
+			copied = true
+			saveSession()
+		}),
+		widget.NewButton("Pop Out", func() {
+			popout.open(resultRows(lastPasswords, lastOptions, lastEstimate, orderSelect.Selected, showSelect.Selected))
+		}),
+	)
+
+	// "?" opens the help overlay with shortcuts and option explanations
+	helpButton := widget.NewButton("?", func() { showHelp(myWindow) })
+
+	// Layout configuration - the results list expands to fill available space.
+	// Reset to Defaults Button
+	// Purpose: Puts the form back to the application defaults; the saved
+	// session follows with the next autosave.
+	resetButton := widget.NewButton("Reset to Defaults", func() {
+		opts := *ctrl.Config
+		opts.Length = opts.DefaultLength
+		applyOptions(opts)
+		quantityEntry.SetText("1")
+		patternEntry.SetText("")
 	})
 
-	// Layout configuration - passwordEntry expands to fill available space.
 	content := container.NewBorder(
 		container.NewVBox(
-			widget.NewLabel("Password Generator"),
-			lengthLabel,
+			container.NewBorder(nil, nil, nil, helpButton, widget.NewLabel("Password Generator")),
+			container.NewBorder(nil, nil, nil, entropyLabel, lengthLabel),
 			lengthSlider,
-			quantitySelect,
+			widget.NewForm(widget.NewFormItem("Quantity", quantityEntry)),
 			includeSymbols,
 			includeNumbers,
 			includeUpper,
@@ -121,13 +745,242 @@ func StartGUI(ctrl *controller.GeneratorController) {
 			noSimilar,
 			noDuplicates,
 			noSequential,
-			generateButton,
+			container.NewBorder(nil, nil, nil, layoutSelect, alternateHands),
+			handsImpact,
+			container.NewBorder(nil, nil, nil, brokenKeysButton, excludeEntry),
+			requireEntry,
+			container.NewGridWithColumns(2, groupSelect, groupSeparatorEntry),
+			container.NewBorder(nil, nil, nil, patternSelect, patternEntry),
+			brokenKeysLabel,
+			managedLabel,
+			container.NewGridWithColumns(2, audienceSelect, presetSelect),
+			siteSelect,
+			siteInfo,
+			policySelect,
+			policyInfo,
+			verifyResults,
+			restoreResults,
+			optionError,
+			container.NewBorder(nil, nil, resetButton, cancelButton, generateButton),
+			quantityProgress,
 		),
-		nil, nil, nil, passwordEntry, // passwordEntry fills remaining space
+		resultBar, nil, nil, results.object(), // the results fill remaining space
 	)
 
+	// Tools menu for auditing, secret sharing and, on Windows, encrypted
+	// export; Help menu with the shortcut cheat sheet, the strength tutorial
+	// and build information
+	toolsMenu := fyne.NewMenu("Tools",
+		fyne.NewMenuItem("Audit Browser Export...", func() { showAuditImport(myWindow, auditOptions(), siteRules) }),
+		fyne.NewMenuItem("Split Password into Shares...", func() {
+			password := ""
+			if len(lastPasswords) > 0 {
+				password = lastPasswords[0]
+			}
+			showShamirSplit(myWindow, password)
+		}),
+		fyne.NewMenuItem("Share as One-Time Link...", func() {
+			password := ""
+			if len(lastPasswords) > 0 {
+				password = lastPasswords[0]
+			}
+			showShareLink(myWindow, password, &profile, profilePath)
+		}),
+		fyne.NewMenuItem("Safety Floor...", func() { showSafetyFloor(myWindow, &profile, profilePath, managed) }),
+		fyne.NewMenuItem("Breach List...", func() { showBreachList(myWindow, breaches, &profile, profilePath, managed, updateConstraints) }),
+		fyne.NewMenuItem("Clipboard...", func() { showClipboardSettings(myWindow, &profile, profilePath) }),
+		fyne.NewMenuItem("Global Hotkey...", func() { showHotkeySettings(myWindow, &profile, profilePath, registerHotkey) }),
+		fyne.NewMenuItem("Security Check...", func() {
+			showSecurityCheck(myWindow, &profile, profilePath, breaches, managed, func() {
+				restoreResults.SetChecked(profile.RestoreResults)
+				clipboardClearDelay = profile.ClipboardClearDelay()
+			})
+		}),
+		fyne.NewMenuItem("New Entry from Template...", func() { showEntryTemplates(currentOptions()) }),
+		fyne.NewMenuItem("Passphrase Calculator...", showPassphraseCalculator),
+		fyne.NewMenuItem("Check Password Strength...", showStrengthCheck),
+		fyne.NewMenuItem("Password from Sentence...", showAcronym),
+		fyne.NewMenuItem("QA Coverage Matrix...", func() { showCoverageMatrix(myWindow, currentOptions()) }),
+		fyne.NewMenuItem("Export Reproducibility Bundle...", func() { showBundleExport(myWindow, lastOptions, lastResults()) }),
+		fyne.NewMenuItem("Export CSV...", func() { saveAsCSV(myWindow, lastResults()) }),
+		fyne.NewMenuItem("Save Receipts...", func() { showReceiptExport(myWindow, lastResults()) }),
+		fyne.NewMenuItem("Save Options as Preset...", func() {
+			opts := currentOptions()
+			opts.ExcludeCharacters = withoutCharacters(opts.ExcludeCharacters, profile.BrokenKeys)
+			preset := config.Preset{Options: opts, Pattern: patternEntry.Text, Audience: audienceFor(audienceSelect.Selected)}
+			showSavePreset(myWindow, preset, presets, presetsPath, func() {
+				presetSelect.Options = availablePresets().Names()
+				presetSelect.Refresh()
+			})
+		}),
+		fyne.NewMenuItem("Manage Presets...", func() {
+			showManagePresets(myWindow, presets, presetsPath, func() {
+				if _, ok := availablePresets()[presetSelect.Selected]; !ok {
+					presetSelect.Selected = ""
+				}
+				presetSelect.Options = availablePresets().Names()
+				presetSelect.Refresh()
+			})
+		}),
+		fyne.NewMenuItem("Compare Preset Files...", func() { showPresetDiff(myWindow) }),
+		fyne.NewMenuItem("Import KeePass Profiles...", func() {
+			showKeePassImport(myWindow, currentOptions(), presets, presetsPath, func() {
+				presetSelect.Options = availablePresets().Names()
+				presetSelect.Refresh()
+			})
+		}),
+	)
+	if dpapi.Supported {
+		toolsMenu.Items = append(toolsMenu.Items, fyne.NewMenuItem("Export Encrypted for This User...", func() { showDPAPIExport(myWindow, lastPasswords) }))
+	}
+	languageItem := fyne.NewMenuItem("Message Language", nil)
+	themeItem := fyne.NewMenuItem("Theme", nil)
+	densityItem := fyne.NewMenuItem("Layout Density", nil)
+	mainMenu := fyne.NewMainMenu(
+		toolsMenu,
+		fyne.NewMenu("Help",
+			fyne.NewMenuItem("Shortcuts and Options", func() { showHelp(myWindow) }),
+			fyne.NewMenuItem("Strength Tutorial", showStrengthTutorial),
+			languageItem,
+			themeItem,
+			densityItem,
+			fyne.NewMenuItem("About", func() { showAbout(myWindow) }),
+		),
+	)
+	languageItem.ChildMenu = languageMenu(func(locale string) {
+		profile.Language = locale
+		if err := config.SaveProfile(profilePath, profile); err != nil {
+			dialog.ShowError(err, myWindow)
+		}
+		mainMenu.Refresh()
+	})
+	themeItem.ChildMenu = choiceMenu(themeChoices, profile.Theme, func(name string) {
+		profile.Theme = name
+		applyTheme(myApp, profile.Theme, profile.Density)
+		if err := config.SaveProfile(profilePath, profile); err != nil {
+			dialog.ShowError(err, myWindow)
+		}
+		mainMenu.Refresh()
+	})
+	densityItem.ChildMenu = choiceMenu(densityChoices, profile.Density, func(name string) {
+		profile.Density = name
+		applyTheme(myApp, profile.Theme, profile.Density)
+		if err := config.SaveProfile(profilePath, profile); err != nil {
+			dialog.ShowError(err, myWindow)
+		}
+		mainMenu.Refresh()
+	})
+	myWindow.SetMainMenu(mainMenu)
+
+	// Keyboard shortcuts, listed in the help overlay
+	myWindow.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyReturn, Modifier: fyne.KeyModifierControl}, func(fyne.Shortcut) {
+		generateButton.OnTapped()
+	})
+	myWindow.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyC, Modifier: fyne.KeyModifierControl | fyne.KeyModifierShift}, func(fyne.Shortcut) {
+		if password, ok := results.first(); ok {
+			copySecret(myWindow, password)
+			onCopy()
+		}
+	})
+	myWindow.Canvas().SetOnTypedKey(func(event *fyne.KeyEvent) {
+		if event.Name == fyne.KeyF1 {
+			showHelp(myWindow)
+		}
+	})
+	myWindow.Canvas().SetOnTypedRune(func(r rune) {
+		if r == '?' {
+			showHelp(myWindow)
+		}
+	})
+
 	// Set the content and display the window
-	myWindow.SetContent(content)
-	myWindow.Resize(fyne.NewSize(400, 500)) // Initial window size
+	myWindow.SetContent(container.NewAppTabs(
+		container.NewTabItem("Password", content),
+		container.NewTabItem("PIN", pinTab(myWindow, ctrl)),
+		container.NewTabItem("Key", tokenTab(myWindow, ctrl)),
+		container.NewTabItem("Username", usernameTab(myWindow, ctrl, currentOptions)),
+		container.NewTabItem("Site", deriveTab(myWindow)),
+		container.NewTabItem("2FA", otpTab(myWindow)),
+		container.NewTabItem("Recovery", recoveryTab(myWindow, ctrl)),
+		container.NewTabItem("Audit", auditTab(myWindow, auditOptions)),
+		container.NewTabItem("History", historyTab(myWindow, passwordHistory)),
+	))
+	// Initial window size, or the size it had when last closed
+	if profile.WindowWidth > 0 && profile.WindowHeight > 0 {
+		myWindow.Resize(fyne.NewSize(profile.WindowWidth, profile.WindowHeight))
+	} else {
+		myWindow.Resize(fyne.NewSize(400, 500))
+	}
+
+	// Restore the autosaved session, asking before restoring results; saving
+	// starts once the user has decided, so an unanswered prompt loses nothing
+	startAutosave := func() {
+		sessionReady = true
+		optionsChanged()
+		autosave.Start(session.DefaultInterval)
+	}
+	if state, ok, err := session.Load(sessionPath); ok && err == nil && len(state.Passwords) > 0 {
+		applyOptions(state.Options)
+		message := fmt.Sprintf("Restore %d un-copied passwords from %s?", len(state.Passwords), state.SavedAt.Format("2006-01-02 15:04"))
+		dialog.ShowConfirm("Restore Session", message, func(restore bool) {
+			if restore {
+				lastPasswords, lastOptions, lastGenerated = state.Passwords, state.Options, state.SavedAt
+				lastTags = make([]resultTag, len(state.Passwords))
+				lastRegenerate = nil
+				lastEstimate = passgen.EstimateEntropy(state.Options)
+				showResults()
+			}
+			startAutosave()
+		}, myWindow)
+	} else {
+		if ok {
+			applyOptions(state.Options)
+		} else {
+			defaults := *ctrl.Config
+			defaults.Length = defaults.DefaultLength
+			applyOptions(defaults)
+		}
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("the saved session could not be fully restored: %w", err), myWindow)
+		}
+		startAutosave()
+	}
+	if policyErr != nil {
+		dialog.ShowError(fmt.Errorf("the policy %s could not be loaded: %w", initialPolicy, policyErr), myWindow)
+	}
+	if breaches.err != nil {
+		dialog.ShowError(fmt.Errorf("the breach list could not be opened, so passwords are not checked against it: %w", breaches.err), myWindow)
+	}
+	myWindow.SetOnClosed(func() {
+		size := myWindow.Canvas().Size()
+		profile.WindowWidth, profile.WindowHeight = size.Width, size.Height
+		_ = config.SaveProfile(profilePath, profile)
+		clearCopiedSecret(myWindow.Clipboard())
+		hotkeys.unregister()
+		popout.close()
+		breaches.close()
+		saveSession()
+		_ = autosave.Stop()
+	})
 	myWindow.ShowAndRun()
 }
+
+// handLayoutNames returns the registered keyboard layouts in alphabetical order.
+func handLayoutNames() []string {
+	names := make([]string, 0, len(passgen.HandLayouts))
+	for name := range passgen.HandLayouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// withoutCharacters returns chars without any character listed in removed.
+func withoutCharacters(chars, removed string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(removed, r) {
+			return -1
+		}
+		return r
+	}, chars)
+}
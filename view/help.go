@@ -0,0 +1,137 @@
+/**
+ * Password Generator - Help Overlay
+ *
+ * This file renders an in-app cheat sheet listing the keyboard shortcuts and
+ * a short explanation of every option. The overlay is dismissed with Esc or
+ * the Close button.
+ */
+
+package view
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// helpEntry pairs a key or option name with its explanation.
+type helpEntry struct {
+	Name        string
+	Description string
+}
+
+// helpShortcuts lists the keyboard shortcuts registered by the GUI.
+var helpShortcuts = []helpEntry{
+	{"Ctrl+Enter", "Generate passwords"},
+	{"Ctrl+Shift+C", "Copy the first password shown"},
+	{"F1 or ?", "Show this help"},
+	{"Esc", "Close this help"},
+}
+
+// helpOptions explains each generation option shown in the main window.
+var helpOptions = []helpEntry{
+	{"Length", "Number of characters in each password. The label next to it shows the estimated entropy of the current options, e.g. ≈ 87 bits (strong)."},
+	{"Quantity", "How many passwords to generate at once, from 1 to 100,000; large batches show a progress bar, the window stays responsive, and Cancel stops the batch and keeps the previous results."},
+	{"Include Symbols", "Adds characters such as ! @ # $ % and brackets."},
+	{"Include Numbers", "Adds the digits 0-9."},
+	{"Include Uppercase / Lowercase", "Adds the letters A-Z and a-z."},
+	{"Begin With Letters", "The first character is always a letter."},
+	{"No Similar Characters", "Leaves out look-alikes such as i, l, 1, o, 0 and O."},
+	{"No Duplicate Characters", "Every character appears at most once."},
+	{"No Sequential Characters", "Avoids runs such as abc or 321."},
+	{"Alternate Hands", "Switches between left- and right-hand keys of the chosen layout for faster typing, at some cost in entropy."},
+	{"Characters to exclude", "Characters that never appear, for example keys that do not work on your keyboard."},
+	{"Characters to require", "Characters that appear at least once in every password, at random positions, e.g. a symbol a site insists on."},
+	{"Groups", "Splits each password into groups with a separator, e.g. x7Kp-93fQ-LmR2; separators do not count toward the length or strength."},
+	{"Pattern", "Generates from a template instead of the options above: C/c consonant, V/v vowel, A/a letter, 9 digit, ! symbol, * any character; other characters are kept, \\ keeps the next one. Pick a preset or type your own."},
+	{"Broken Keys", "Marks keys that are broken or missing on your keyboard; they are remembered and never used."},
+	{"Remember Un-copied Results", "Windows: autosaved sessions also keep results you have not copied yet, encrypted for your account, to restore after a crash."},
+	{"Verify Results", "Re-checks every generated password against the selected options and shows an error instead of passwords that break them."},
+	{"Strength badges", "Every result is rated weak, good or excellent with a coloured bar; words, keyboard walks and dates that happen to appear lower the rating. Sort the batch strongest first or hide weaker results."},
+	{"Copy", "Each result has its own Copy button; copying marks the batch as copied for Remember Un-copied Results."},
+	{"QR", "Shows a result as a QR code to scan with a phone, or as a code that joins a Wi-Fi network when you type its name; Save PNG writes it as an image."},
+	{"Regenerate", "The circular arrow next to a result replaces just that password with a new one of the same options or pattern; its label and note stay."},
+	{"Hide / Show", "The eye button above the results shows bullets instead of passwords, e.g. in an open-plan office, and is remembered; hover over a password or press the eye on its row to reveal it. Copy and QR still work."},
+	{"Label / Note", "Type a label and a note next to any result, e.g. the server it is for; both are included in JSON, template and bundle exports."},
+	{"Copy as JSON", "Copies the passwords with their length, entropy estimate, the options used and the generation time as JSON, as the CLI prints with -format json."},
+	{"Export CSV", "Tools menu: saves the latest results as CSV with index, entropy, character classes, generation time, label and note, for bulk provisioning, or in the import layout of Bitwarden, 1Password or LastPass."},
+	{"Copy with Template", "Copies the passwords through your own template, e.g. {{.Password}}, {{.Entropy}} and {{.Label}}."},
+	{"Pop Out", "Opens the results in a separate small window with a Copy button per password, to keep on another monitor while filling in forms."},
+	{"Safety Floor", "Tools menu: a minimum entropy; options estimated below it are refused with advice on what to change."},
+	{"Password from Sentence", "Tools menu: first letters, numbers and punctuation of a sentence you remember, plus random digits and symbols; only those count as random."},
+	{"Save Receipts", "Tools menu: salted hashes of the latest results, for whom and when, so a recipient can later confirm a password without anyone keeping it."},
+	{"Managed Settings", "Options shown disabled are set by your organization in managed.json, managed.yaml or, on Windows, Group Policy, and cannot be changed here; the note above the preset list says what each enforces."},
+	{"Breach List", "Tools menu: a local breach filter or Have I Been Pwned hash file; passwords found on it are generated again and flagged by audits."},
+	{"Clipboard", "Tools menu: how long copied passwords stay on the clipboard before they are cleared; 30 seconds by default."},
+	{"Global Hotkey", "Tools menu: a system-wide combination such as Ctrl+Alt+P that generates a password with the options of the main window and copies it."},
+	{"Reset to Defaults", "Puts the form back to the application defaults; the window size and theme are kept."},
+	{"Theme", "Help menu: a light or dark theme, or the system setting; remembered with the window size between runs."},
+	{"Layout Density", "Help menu: Compact shrinks padding and text for small screens; applied at once and remembered."},
+	{"Manage Presets", "Tools menu: renames and deletes the presets saved with Save Options as Preset."},
+	{"Security Check", "Tools menu: the current state of result history, the safety floor and other protections, with Harden to fix them in one click."},
+	{"Entry Templates", "Tools menu: creates a login, Wi-Fi, server or database entry with its own fields and a secret suited to it, copied as JSON."},
+	{"Check Password Strength", "Tools menu: rates a typed or pasted password the way crackers attack it, with crack times and the common words, keyboard walks, sequences and dates found."},
+	{"Strength Tutorial", "Help menu: a guided walk from six lowercase letters to sixteen random characters for awareness sessions, with live examples and crack times as options change."},
+	{"Passphrase Calculator", "Tools menu: shows the entropy and crack times of passphrases for a wordlist size, word count and separators."},
+	{"Preset", "Applies a saved preset: the options saved with Tools > Save Options as Preset, or a generator profile imported from KeePass with Tools > Import KeePass Profiles."},
+	{"Audience", "Starts from the defaults for human-memorable passwords (14 characters, no look-alikes or runs) or machine secrets (48 characters of letters and digits, up to 128, no readability rules)."},
+	{"Compare Preset Files", "Tools menu: the differences between two presets files, such as a team's old and proposed presets, with the entropy before and after; weaker presets come first."},
+	{"Website", "Applies the options last used for the site, or else its known password rules: length limits and which characters it accepts."},
+	{"Policy", "Applies NIST SP 800-63B, PCI DSS, Active Directory complexity or a policy file to the options; passwords breaking it are generated again."},
+	{"Key Tab", "Generates raw random keys of a byte length and encoding, or API keys: a prefix such as sk_live_, random base62 characters and optionally a CRC32 checksum that secret scanners can validate."},
+	{"Username Tab", "Generates handles such as brave_otter42 from adjectives, a noun and digits for throwaway accounts, optionally each with a password of the Password tab's options; more words and digits make repeats rarer."},
+	{"History Tab", "Keeps generated passwords in a vault encrypted with a master password: unlock it to search labels and notes, copy, relabel or delete them; Lock forgets the key."},
+	{"2FA Tab", "Generates a TOTP secret for an issuer and account, shown as text, as an otpauth URI and as a QR code for authenticator apps; Current Code checks that the app was set up right."},
+	{"Recovery Tab", "Generates a set of one-time recovery codes such as 7KQP-M3XD, without look-alike characters and with no code twice; choose the number of codes and their groups."},
+	{"Audit Tab", "Rates a pasted or loaded list of passwords: length, entropy, character classes and the rules of the selected policy each breaks; Export Report leaves the passwords out."},
+}
+
+// helpSection renders a titled two-column list of help entries.
+func helpSection(title string, entries []helpEntry) fyne.CanvasObject {
+	form := widget.NewForm()
+	for _, entry := range entries {
+		description := widget.NewLabel(entry.Description)
+		description.Wrapping = fyne.TextWrapWord
+		form.Append(entry.Name, description)
+	}
+	return container.NewVBox(widget.NewLabelWithStyle(title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}), form)
+}
+
+// showHelp overlays the cheat sheet on top of the window.
+// Purpose:
+//
+//	Shows keyboard shortcuts and option explanations in a modal overlay and
+//	restores the previous key handler once the overlay is dismissed.
+//
+// Parameters:
+//   - w (fyne.Window): The window to cover with the overlay.
+//
+// Example:
+//
+//	showHelp(myWindow)
+func showHelp(w fyne.Window) {
+	c := w.Canvas()
+	previousKeyHandler := c.OnTypedKey()
+
+	var overlay *widget.PopUp
+	closeHelp := func() {
+		overlay.Hide()
+		c.SetOnTypedKey(previousKeyHandler)
+	}
+
+	content := container.NewVBox(
+		helpSection("Keyboard Shortcuts", helpShortcuts),
+		widget.NewSeparator(),
+		helpSection("Options", helpOptions),
+		widget.NewButton("Close", closeHelp),
+	)
+	overlay = widget.NewModalPopUp(container.NewVScroll(content), c)
+	overlay.Resize(fyne.NewSize(c.Size().Width*0.9, c.Size().Height*0.9))
+
+	c.SetOnTypedKey(func(event *fyne.KeyEvent) {
+		if event.Name == fyne.KeyEscape {
+			closeHelp()
+		}
+	})
+	overlay.Show()
+}
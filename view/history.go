@@ -0,0 +1,229 @@
+/**
+ * Password Generator - History Tab
+ *
+ * This file builds the History tab, which keeps generated passwords in the
+ * encrypted vault: it creates or unlocks the vault with a master password,
+ * searches the entries by label, copies them, and deletes them for good.
+ * While the vault is unlocked and saving is on, each generated batch is
+ * stored with its options and the site it was generated for.
+ */
+
+package view
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/vault"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// history holds the vault while it is unlocked. Generation runs in the
+// background, so access goes through mu.
+// Fields:
+//   - path (string): The vault file.
+//   - vault (*vault.Vault): The unlocked vault, or nil while locked.
+//   - recording (bool): Whether generated passwords are saved.
+//   - changed (func()): Redraws the tab after entries were added.
+type history struct {
+	mu        sync.Mutex
+	path      string
+	vault     *vault.Vault
+	recording bool
+	changed   func()
+}
+
+// record saves passwords, generated with opts for label, if the vault is
+// unlocked and saving is on.
+func (h *history) record(passwords []string, opts passgen.PasswordOptions, label string) error {
+	h.mu.Lock()
+	if h.vault == nil || !h.recording || len(passwords) == 0 {
+		h.mu.Unlock()
+		return nil
+	}
+	now := time.Now()
+	entries := make([]vault.Entry, len(passwords))
+	for i, password := range passwords {
+		entries[i] = vault.Entry{Password: password, Label: label, CreatedAt: now, Options: opts}
+	}
+	err := h.vault.Add(entries...)
+	h.mu.Unlock()
+	if h.changed != nil {
+		h.changed()
+	}
+	return err
+}
+
+// update runs change on the unlocked vault.
+func (h *history) update(change func(v *vault.Vault) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.vault == nil {
+		return vault.ErrLocked
+	}
+	return change(h.vault)
+}
+
+// historyTab returns the content of the History tab.
+// Parameters:
+//   - w (fyne.Window): The parent window of dialogs and the clipboard.
+//   - h (*history): The vault the tab unlocks and shows.
+func historyTab(w fyne.Window, h *history) fyne.CanvasObject {
+	content := container.NewStack()
+
+	// Locked: ask for the master password, or a new one if there is no vault
+	masterEntry := widget.NewPasswordEntry()
+	masterEntry.SetPlaceHolder("Master password")
+	confirmEntry := widget.NewPasswordEntry()
+	confirmEntry.SetPlaceHolder("Repeat the master password")
+	lockedHint := widget.NewLabel("")
+	lockedHint.Wrapping = fyne.TextWrapWord
+	var unlockButton *widget.Button
+
+	// Unlocked: search, the save switch and the entries
+	searchEntry := widget.NewEntry()
+	searchEntry.SetPlaceHolder("Search labels and notes")
+	countLabel := widget.NewLabel("")
+	rows := container.NewVBox()
+	recordCheck := widget.NewCheck("Save generated passwords", func(on bool) {
+		h.mu.Lock()
+		h.recording = on
+		h.mu.Unlock()
+	})
+	recordCheck.SetChecked(true)
+
+	var showLocked, showEntries func()
+	showEntries = func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if h.vault == nil {
+			return
+		}
+		found := h.vault.Search(searchEntry.Text)
+		countLabel.SetText(fmt.Sprintf("%d of %d passwords", len(found), h.vault.Len()))
+		objects := make([]fyne.CanvasObject, 0, len(found))
+		for _, entry := range found {
+			entry := entry
+			label := entry.Label
+			if label == "" {
+				label = "(no label)"
+			}
+			title := widget.NewLabel(fmt.Sprintf("%s, %s, %d characters", label, entry.CreatedAt.Format("2006-01-02 15:04"), len([]rune(entry.Password))))
+			title.Truncation = fyne.TextTruncateEllipsis
+			copyButton := widget.NewButton("Copy", func() { copySecret(w, entry.Password) })
+			labelButton := widget.NewButton("Label...", func() { showHistoryLabel(w, h, entry, showEntries) })
+			deleteButton := widget.NewButton("Delete", func() {
+				message := fmt.Sprintf("Delete the password for %s from %s? It cannot be recovered.", label, entry.CreatedAt.Format("2006-01-02 15:04"))
+				dialog.ShowConfirm("Delete Password", message, func(ok bool) {
+					if !ok {
+						return
+					}
+					err := h.update(func(v *vault.Vault) error { return v.Delete(entry.ID) })
+					if err != nil {
+						dialog.ShowError(err, w)
+					}
+					showEntries()
+				}, w)
+			})
+			objects = append(objects, container.NewBorder(nil, nil, nil, container.NewHBox(copyButton, labelButton, deleteButton), title))
+		}
+		rows.Objects = objects
+		rows.Refresh()
+	}
+	h.changed = showEntries
+	searchEntry.OnChanged = func(string) { showEntries() }
+
+	lockButton := widget.NewButton("Lock", func() {
+		h.mu.Lock()
+		if h.vault != nil {
+			h.vault.Lock()
+			h.vault = nil
+		}
+		h.mu.Unlock()
+		rows.Objects = nil
+		searchEntry.SetText("")
+		showLocked()
+	})
+	unlocked := container.NewBorder(
+		container.NewVBox(container.NewBorder(nil, nil, nil, lockButton, searchEntry), recordCheck, countLabel),
+		nil, nil, nil,
+		container.NewVScroll(rows),
+	)
+
+	unlockButton = widget.NewButton("", func() {
+		master := masterEntry.Text
+		var v *vault.Vault
+		var err error
+		if vault.Exists(h.path) {
+			v, err = vault.Open(h.path, master)
+		} else if master != confirmEntry.Text {
+			err = errors.New("the master passwords do not match")
+		} else {
+			v, err = vault.Create(h.path, master)
+		}
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		masterEntry.SetText("")
+		confirmEntry.SetText("")
+		h.mu.Lock()
+		h.vault = v
+		h.mu.Unlock()
+		content.Objects = []fyne.CanvasObject{unlocked}
+		content.Refresh()
+		showEntries()
+	})
+	masterEntry.OnSubmitted = func(string) { unlockButton.OnTapped() }
+	locked := container.NewVBox(lockedHint, masterEntry, confirmEntry, unlockButton)
+
+	showLocked = func() {
+		if vault.Exists(h.path) {
+			lockedHint.SetText("The history is locked. Enter the master password to see and search it.")
+			unlockButton.SetText("Unlock")
+			confirmEntry.Hide()
+		} else {
+			lockedHint.SetText("Keep generated passwords in a vault encrypted with a master password. The master password cannot be recovered if it is lost.")
+			unlockButton.SetText("Create Vault")
+			confirmEntry.Show()
+		}
+		content.Objects = []fyne.CanvasObject{locked}
+		content.Refresh()
+	}
+	showLocked()
+	return content
+}
+
+// showHistoryLabel edits the label and note of entry.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialog.
+//   - h (*history): The unlocked vault holding entry.
+//   - entry (vault.Entry): The entry to edit.
+//   - changed (func()): Called after the vault was saved.
+func showHistoryLabel(w fyne.Window, h *history, entry vault.Entry, changed func()) {
+	labelEntry := widget.NewEntry()
+	labelEntry.SetText(entry.Label)
+	noteEntry := widget.NewEntry()
+	noteEntry.SetText(entry.Note)
+	items := []*widget.FormItem{
+		widget.NewFormItem("Label", labelEntry),
+		widget.NewFormItem("Note", noteEntry),
+	}
+	dialog.ShowForm("Label Password", "Save", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		err := h.update(func(v *vault.Vault) error { return v.SetLabel(entry.ID, labelEntry.Text, noteEntry.Text) })
+		if err != nil {
+			dialog.ShowError(err, w)
+		}
+		changed()
+	}, w)
+}
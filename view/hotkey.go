@@ -0,0 +1,169 @@
+/**
+ * Password Generator - Global Hotkey
+ *
+ * This file registers the profile's system-wide hotkey, which generates a
+ * password with the options of the main window while another application has
+ * the focus, copies it with the usual auto-clear and reports it in a
+ * notification. The Global Hotkey dialog of the Tools menu sets or removes
+ * the combination.
+ */
+
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/controller"
+	"github.com/PaulBaker1/Password-Generator-GO/hotkey"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// hotkeyTimeout bounds how long a registration waits for the desktop, e.g.
+// for the user to confirm it in the portal's dialog.
+const hotkeyTimeout = 2 * time.Minute
+
+// globalHotkey holds the registration of the profile's hotkey.
+// Registrations run one at a time; a new one, or unregister, gives up a
+// registration still waiting for the desktop instead of waiting behind it.
+type globalHotkey struct {
+	sync.Mutex
+	busy    sync.Mutex
+	stop    func()
+	pending context.CancelFunc
+}
+
+// register replaces the registered hotkey with spec, or only removes it if
+// spec is empty. On Linux it waits until the desktop has answered.
+func (g *globalHotkey) register(spec string, onPress func()) error {
+	ctx, cancel := context.WithTimeout(context.Background(), hotkeyTimeout)
+	defer cancel()
+	g.Lock()
+	if g.pending != nil {
+		g.pending()
+	}
+	g.pending = cancel
+	g.Unlock()
+
+	g.busy.Lock()
+	defer g.busy.Unlock()
+	if g.stop != nil {
+		g.stop()
+		g.stop = nil
+	}
+	if spec == "" {
+		return nil
+	}
+	h, err := hotkey.Parse(spec)
+	if err != nil {
+		return err
+	}
+	stop, err := hotkey.Listen(ctx, h, "Generate a password and copy it", onPress)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("could not register the hotkey %s: the desktop did not answer in time", h)
+	}
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("the hotkey %s was not registered, as it was changed again meanwhile", h)
+	}
+	if err != nil {
+		return fmt.Errorf("could not register the hotkey %s: %w", h, err)
+	}
+	g.stop = stop
+	return nil
+}
+
+// unregister removes the registered hotkey, e.g. when the application exits.
+func (g *globalHotkey) unregister() {
+	_ = g.register("", nil)
+}
+
+// generateAndCopy generates a password with the options of the main window,
+// copies it and shows a notification, since the window may not be visible.
+// Parameters:
+//   - a (fyne.App): Sends the notification.
+//   - w (fyne.Window): The window whose clipboard receives the password.
+//   - ctrl (*controller.GeneratorController): Generates with its constraints.
+//   - options (func): Returns the options of the Generate button, with the
+//     policy, broken keys and safety floor applied, or the error of a
+//     required policy or breach list.
+func generateAndCopy(a fyne.App, w fyne.Window, ctrl *controller.GeneratorController, options func() (passgen.PasswordOptions, error)) {
+	opts, err := options()
+	if err == nil {
+		var passwords []string
+		passwords, err = ctrl.GeneratePasswords(context.Background(), opts)
+		if err == nil {
+			copySecret(w, passwords[0])
+		}
+	}
+	if err != nil {
+		a.SendNotification(fyne.NewNotification("No password generated", strings.TrimPrefix(errorText(err), "Error: ")))
+		return
+	}
+	content := "A new password is on the clipboard."
+	if clipboardClearDelay > 0 {
+		content = fmt.Sprintf("A new password is on the clipboard; it is cleared in %s.", clipboardClearDelay)
+	}
+	a.SendNotification(fyne.NewNotification("Password copied", content))
+}
+
+// showHotkeySettings asks for the global hotkey, registers it and saves it in
+// the profile.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialog.
+//   - profile (*config.Profile): Holds the current hotkey and receives the new one.
+//   - profilePath (string): Where the profile is saved.
+//   - register (func(spec string) error): Registers spec, or removes the
+//     hotkey if it is empty.
+func showHotkeySettings(w fyne.Window, profile *config.Profile, profilePath string, register func(spec string) error) {
+	enabledCheck := widget.NewCheck("Generate and copy a password from any application", nil)
+	enabledCheck.SetChecked(profile.Hotkey != "")
+	keyEntry := widget.NewEntry()
+	keyEntry.SetText(profile.Hotkey)
+	if profile.Hotkey == "" {
+		keyEntry.SetText(hotkey.Default)
+	}
+	keyEntry.Validator = func(text string) error {
+		_, err := hotkey.Parse(text)
+		return err
+	}
+
+	note := widget.NewLabel("The password uses the options of the main window, like Generate, and is cleared from the clipboard like copied results. " +
+		"On Linux the desktop may ask to confirm the hotkey, or offer another; macOS is not supported.")
+	note.Wrapping = fyne.TextWrapWord
+	items := []*widget.FormItem{
+		widget.NewFormItem("", note),
+		widget.NewFormItem("", enabledCheck),
+		widget.NewFormItem("Hotkey", keyEntry),
+	}
+	form := dialog.NewForm("Global Hotkey", "Save", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		spec := ""
+		if enabledCheck.Checked {
+			h, _ := hotkey.Parse(keyEntry.Text)
+			spec = h.String()
+		}
+		go func() {
+			if err := register(spec); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			profile.Hotkey = spec
+			if err := config.SaveProfile(profilePath, *profile); err != nil {
+				dialog.ShowError(err, w)
+			}
+		}()
+	}, w)
+	form.Resize(fyne.NewSize(420, 260))
+	form.Show()
+}
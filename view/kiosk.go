@@ -0,0 +1,116 @@
+/**
+ * Password Generator - Kiosk Mode
+ *
+ * This file implements the locked-down window for shared helpdesk or lab
+ * machines. It shows only the configured preset with Generate and Copy
+ * buttons; settings, tools and file export are not reachable.
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/controller"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/policy"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// StartKiosk runs the kiosk window, which generates passwords with preset only.
+// Parameters:
+//   - ctrl (*controller.GeneratorController): The controller that manages password generation.
+//   - preset (passgen.PasswordOptions): The fixed options, from config.LoadKiosk.
+//   - managed (config.Managed): The settings the organization enforces; only
+//     its breach list matters here, as preset already follows the rest.
+//
+// Example:
+//
+//	StartKiosk(ctrl, managed.Apply(kiosk.Preset), managed)
+func StartKiosk(ctrl *controller.GeneratorController, preset passgen.PasswordOptions, managed config.Managed) {
+	messageLocale = passgen.NormalizeLocale(config.SystemLocale())
+	myApp := app.New()
+	myWindow := myApp.NewWindow("Password Generator")
+
+	breaches := &breachFilter{}
+	if managed.BreachList != "" {
+		_ = breaches.load(managed.BreachList)
+	}
+	policies := &policyChoice{}
+	if managed.Policy != "" {
+		if p, err := policy.Lookup(managed.Policy); err == nil {
+			policies.policy = &p
+		}
+	}
+	ctrl.SetConstraints(append(breaches.constraints(), policies.constraints()...)...)
+
+	passwordLabel := widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{Monospace: true})
+	passwordLabel.Wrapping = fyne.TextWrapBreak
+
+	generateButton := widget.NewButton("Generate", func() {
+		opts := policies.apply(preset)
+		opts.Quantity = 1
+		defer recoverCrash(myWindow, opts)
+		if err := breaches.required(managed); err != nil {
+			passwordLabel.SetText(errorText(err))
+			return
+		}
+		if err := policies.required(managed); err != nil {
+			passwordLabel.SetText(errorText(err))
+			return
+		}
+		passwords, err := ctrl.GeneratePasswords(context.Background(), opts)
+		if err != nil {
+			passwordLabel.SetText(errorText(err))
+			return
+		}
+		passwordLabel.SetText(passwords[0])
+	})
+	copyButton := widget.NewButton("Copy", func() {
+		if !strings.HasPrefix(passwordLabel.Text, "Error: ") {
+			copySecret(myWindow, passwordLabel.Text)
+		}
+	})
+
+	myWindow.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyReturn, Modifier: fyne.KeyModifierControl}, func(fyne.Shortcut) {
+		generateButton.OnTapped()
+	})
+
+	myWindow.SetContent(container.NewVBox(
+		widget.NewLabel(describePreset(preset)),
+		passwordLabel,
+		container.NewGridWithColumns(2, generateButton, copyButton),
+	))
+	myWindow.Resize(fyne.NewSize(400, 200))
+	generateButton.OnTapped()
+	myWindow.SetOnClosed(func() { clearCopiedSecret(myWindow.Clipboard()) })
+	myWindow.ShowAndRun()
+}
+
+// describePreset summarises the kiosk preset, e.g. "16 characters: lowercase,
+// uppercase, digits".
+func describePreset(opts passgen.PasswordOptions) string {
+	var classes []string
+	for _, class := range []struct {
+		enabled bool
+		name    string
+	}{
+		{opts.IncludeLower, "lowercase"},
+		{opts.IncludeUpper, "uppercase"},
+		{opts.IncludeNumbers, "digits"},
+		{opts.IncludeSymbols, "symbols"},
+	} {
+		if class.enabled {
+			classes = append(classes, class.name)
+		}
+	}
+	return fmt.Sprintf("%d characters: %s", opts.Length, strings.Join(classes, ", "))
+}
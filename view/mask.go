@@ -0,0 +1,71 @@
+/**
+ * Password Generator - Masked Passwords
+ *
+ * This file masks passwords on screen with bullets, for generating them in
+ * an open-plan office or while sharing the screen. A masked password shows
+ * itself while the mouse pointer rests on it, and can be revealed for good
+ * with the eye button of its row.
+ */
+
+package view
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// maskText returns a bullet for every character of password.
+func maskText(password string) string {
+	return strings.Repeat("•", len([]rune(password)))
+}
+
+// revealLabel is a label that shows its masked text, and its plain text
+// while hovered.
+type revealLabel struct {
+	widget.Label
+	plain   string
+	masked  string
+	hovered bool
+}
+
+// newRevealLabel creates an empty monospace label.
+func newRevealLabel() *revealLabel {
+	l := &revealLabel{}
+	l.TextStyle = fyne.TextStyle{Monospace: true}
+	l.ExtendBaseWidget(l)
+	return l
+}
+
+// setValue shows plain, or masked unless the label is hovered; an empty
+// masked shows plain always.
+func (l *revealLabel) setValue(plain, masked string) {
+	l.plain, l.masked = plain, masked
+	l.show()
+}
+
+// show updates the text for the current value and hover state.
+func (l *revealLabel) show() {
+	if l.masked == "" || l.hovered {
+		l.SetText(l.plain)
+	} else {
+		l.SetText(l.masked)
+	}
+}
+
+// MouseIn reveals the password while the pointer is over it.
+func (l *revealLabel) MouseIn(*desktop.MouseEvent) {
+	l.hovered = true
+	l.show()
+}
+
+// MouseMoved is required by desktop.Hoverable.
+func (l *revealLabel) MouseMoved(*desktop.MouseEvent) {}
+
+// MouseOut masks the password again.
+func (l *revealLabel) MouseOut() {
+	l.hovered = false
+	l.show()
+}
@@ -0,0 +1,70 @@
+package view
+
+import (
+	"errors"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"fyne.io/fyne/v2"
+)
+
+// messageLocale is the locale errors of the model are shown in; set once at
+// start from the profile or the system.
+var messageLocale = passgen.DefaultLocale
+
+// errorHints suggests what to change for each kind of model error.
+var errorHints = []struct {
+	kind error
+	hint string
+}{
+	{passgen.ErrEmptyCharset, "Select more character types or exclude fewer characters."},
+	{passgen.ErrLengthOutOfRange, "Adjust the length."},
+	{passgen.ErrInfeasibleConstraints, "Turn off a rule or remove required characters."},
+}
+
+// errorText renders err for a result field in messageLocale, followed by a
+// hint on what to change if the model reports the kind of the error.
+func errorText(err error) string {
+	text := "Error: " + passgen.Localize(err, messageLocale)
+	for _, h := range errorHints {
+		if errors.Is(err, h.kind) {
+			return text + ". " + h.hint
+		}
+	}
+	return text
+}
+
+// localized returns err with its message in messageLocale, for dialogs.
+func localized(err error) error {
+	return errors.New(passgen.Localize(err, messageLocale))
+}
+
+// localeNames holds the display name of each message locale.
+var localeNames = map[string]string{
+	"en": "English",
+	"de": "Deutsch",
+}
+
+// languageMenu returns a menu with one checkable item per message locale.
+// Choosing one sets messageLocale and calls onChanged with the locale.
+func languageMenu(onChanged func(locale string)) *fyne.Menu {
+	menu := fyne.NewMenu("")
+	for _, locale := range passgen.Locales() {
+		locale := locale
+		name := localeNames[locale]
+		if name == "" {
+			name = locale
+		}
+		item := fyne.NewMenuItem(name, nil)
+		item.Checked = locale == messageLocale
+		item.Action = func() {
+			messageLocale = locale
+			for _, other := range menu.Items {
+				other.Checked = other == item
+			}
+			onChanged(locale)
+		}
+		menu.Items = append(menu.Items, item)
+	}
+	return menu
+}
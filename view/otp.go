@@ -0,0 +1,175 @@
+/**
+ * Password Generator - 2FA Tab
+ *
+ * This file builds the 2FA tab, which generates TOTP secrets for enrolling
+ * an authenticator app: an issuer and account, the code settings, and the
+ * secret shown as text, as an otpauth:// URI and as a QR code to scan.
+ */
+
+package view
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/otp"
+	"github.com/PaulBaker1/Password-Generator-GO/qr"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// otpTab returns the content of the 2FA tab.
+// Parameters:
+//   - w (fyne.Window): The window whose clipboard receives copied secrets.
+func otpTab(w fyne.Window) fyne.CanvasObject {
+	issuerEntry := widget.NewEntry()
+	issuerEntry.SetPlaceHolder("e.g. GitLab")
+	accountEntry := widget.NewEntry()
+	accountEntry.SetPlaceHolder("e.g. alice@example.com")
+	algorithmSelect := widget.NewSelect(otp.Algorithms, nil)
+	algorithmSelect.SetSelected(otp.SHA1)
+	digitsSelect := widget.NewSelect([]string{"6", "7", "8"}, nil)
+	digitsSelect.SetSelected(strconv.Itoa(otp.DefaultDigits))
+	periodSelect := widget.NewSelect([]string{"30", "60"}, nil)
+	periodSelect.SetSelected(strconv.Itoa(otp.DefaultPeriod))
+
+	secretLabel := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	secretLabel.Wrapping = fyne.TextWrapBreak
+	status := widget.NewLabel("Enter an account and generate a secret, then scan the code with an authenticator app.")
+	status.Wrapping = fyne.TextWrapWord
+	image := canvas.NewImageFromResource(nil)
+	image.FillMode = canvas.ImageFillContain
+	image.SetMinSize(fyne.NewSize(200, 200))
+
+	var key otp.Key
+	var png []byte
+	generated := func() bool { return key.Secret != "" }
+	// show renders the current key; the fields may have changed since it
+	// was generated, so only the secret is kept from it
+	show := func() {
+		digits, _ := strconv.Atoi(digitsSelect.Selected)
+		period, _ := strconv.Atoi(periodSelect.Selected)
+		key.Issuer = strings.TrimSpace(issuerEntry.Text)
+		key.Account = strings.TrimSpace(accountEntry.Text)
+		key.Digits, key.Period = digits, period
+		if err := key.Validate(); err != nil {
+			png = nil
+			image.Resource = nil
+			image.Refresh()
+			status.SetText(errorText(err))
+			return
+		}
+		var err error
+		png, err = qr.PNG(key.URI(), qr.DefaultSize)
+		if err != nil {
+			png = nil
+			image.Resource = nil
+			status.SetText(fmt.Sprintf("No QR code: %v", err))
+		} else {
+			image.Resource = fyne.NewStaticResource("totp-qr.png", png)
+			status.SetText("Scan the code with an authenticator app, then check that it shows the current code. Anyone who can see the screen can scan it too.")
+		}
+		image.Refresh()
+		secretLabel.SetText(groupSecret(key.Secret))
+	}
+
+	generateButton := widget.NewButton("Generate Secret", func() {
+		key.Algorithm = algorithmSelect.Selected
+		if err := key.NewSecret(); err != nil {
+			status.SetText(errorText(err))
+			return
+		}
+		show()
+	})
+	// The secret stays valid for new names and code settings; only a new
+	// algorithm needs a secret of a different size.
+	refresh := func() {
+		if generated() {
+			show()
+		}
+	}
+	issuerEntry.OnChanged = func(string) { refresh() }
+	accountEntry.OnChanged = func(string) { refresh() }
+	digitsSelect.OnChanged = func(string) { refresh() }
+	periodSelect.OnChanged = func(string) { refresh() }
+	algorithmSelect.OnChanged = func(string) {
+		if generated() {
+			generateButton.OnTapped()
+		}
+	}
+
+	copySecretButton := widget.NewButton("Copy Secret", func() {
+		if generated() {
+			copySecret(w, key.Secret)
+		}
+	})
+	copyURIButton := widget.NewButton("Copy URI", func() {
+		if png != nil {
+			copySecret(w, key.URI())
+		}
+	})
+	codeButton := widget.NewButton("Current Code", func() {
+		if !generated() {
+			return
+		}
+		now := time.Now()
+		code, err := key.Code(now)
+		if err != nil {
+			status.SetText(errorText(err))
+			return
+		}
+		dialog.ShowInformation("Current Code", fmt.Sprintf("%s\n\nValid for another %s. The authenticator app should show the same code.", code, key.Remaining(now)), w)
+	})
+	saveButton := widget.NewButton("Save QR PNG...", func() {
+		if png == nil {
+			return
+		}
+		data := png
+		save := dialog.NewFileSave(func(file fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if file == nil {
+				return
+			}
+			_, writeErr := file.Write(data)
+			if closeErr := file.Close(); writeErr == nil {
+				writeErr = closeErr
+			}
+			if writeErr != nil {
+				dialog.ShowError(fmt.Errorf("saving the QR code failed: %w", writeErr), w)
+			}
+		}, w)
+		save.SetFileName("totp-qr.png")
+		save.Show()
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem("Issuer", issuerEntry),
+		widget.NewFormItem("Account", accountEntry),
+		widget.NewFormItem("Algorithm", algorithmSelect),
+		widget.NewFormItem("Digits", digitsSelect),
+		widget.NewFormItem("Period (seconds)", periodSelect),
+	)
+	top := container.NewVBox(form, generateButton, secretLabel, status)
+	buttons := container.NewGridWithColumns(2, copySecretButton, copyURIButton, codeButton, saveButton)
+	return container.NewBorder(top, buttons, nil, nil, image)
+}
+
+// groupSecret splits a base32 secret into groups of four characters, for
+// typing it into an app that cannot scan the code.
+func groupSecret(secret string) string {
+	var groups []string
+	for len(secret) > 4 {
+		groups = append(groups, secret[:4])
+		secret = secret[4:]
+	}
+	return strings.Join(append(groups, secret), " ")
+}
@@ -0,0 +1,35 @@
+package view
+
+import "github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+// patternPresetNames returns the names of passgen.PatternPresets for the preset dropdown.
+func patternPresetNames() []string {
+	names := make([]string, len(passgen.PatternPresets))
+	for i, preset := range passgen.PatternPresets {
+		names[i] = preset.Name
+	}
+	return names
+}
+
+// patternPreset returns the pattern of the preset called name, or "".
+func patternPreset(name string) string {
+	for _, preset := range passgen.PatternPresets {
+		if preset.Name == name {
+			return preset.Pattern
+		}
+	}
+	return ""
+}
+
+// verifyGenerated re-checks passwords against pattern, or against opts when
+// no pattern was used.
+func verifyGenerated(passwords []string, pattern string, opts passgen.PasswordOptions) error {
+	if pattern == "" {
+		return passgen.VerifyPasswords(passwords, opts)
+	}
+	parsed, err := passgen.ParsePattern(pattern, opts)
+	if err != nil {
+		return err
+	}
+	return parsed.Verify(passwords)
+}
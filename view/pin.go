@@ -0,0 +1,65 @@
+/**
+ * Password Generator - PIN Tab
+ *
+ * This file builds the PIN tab: a digit count, a quantity and a Generate
+ * button. Trivially weak PINs such as 1234, 0000 or a birth year are never
+ * offered.
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/controller"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// pinTab returns the content of the PIN tab.
+// Parameters:
+//   - w (fyne.Window): The window whose clipboard receives copied PINs.
+//   - ctrl (*controller.GeneratorController): Supplies the PIN defaults and generation.
+func pinTab(w fyne.Window, ctrl *controller.GeneratorController) fyne.CanvasObject {
+	defaults := *ctrl.PINConfig
+
+	lengthLabel := widget.NewLabel(fmt.Sprintf("Digits: %d", defaults.DefaultLength))
+	lengthSlider := widget.NewSlider(float64(defaults.MinLength), float64(defaults.MaxLength))
+	lengthSlider.SetValue(float64(defaults.DefaultLength))
+	lengthSlider.OnChanged = func(value float64) {
+		lengthLabel.SetText(fmt.Sprintf("Digits: %.0f", value))
+	}
+
+	quantitySelect := widget.NewSelect([]string{"1", "5", "10", "20"}, nil)
+	quantitySelect.SetSelected(strconv.Itoa(defaults.Quantity))
+
+	pinEntry := widget.NewMultiLineEntry()
+	pinEntry.SetPlaceHolder("Generated PINs will appear here")
+
+	generateButton := widget.NewButton("Generate PIN", func() {
+		opts := defaults
+		opts.Length = int(lengthSlider.Value)
+		opts.Quantity, _ = strconv.Atoi(quantitySelect.Selected)
+		pins, err := ctrl.GeneratePINs(context.Background(), opts)
+		if err != nil {
+			pinEntry.SetText(errorText(err))
+			return
+		}
+		pinEntry.SetText(strings.Join(pins, "\n"))
+	})
+	copyButton := widget.NewButton("Copy", func() {
+		copySecret(w, strings.TrimSpace(pinEntry.Text))
+	})
+
+	note := widget.NewLabel("Sequences, repeated digits, common PINs and dates or years are never generated.")
+	note.Wrapping = fyne.TextWrapWord
+	return container.NewBorder(
+		container.NewVBox(lengthLabel, lengthSlider, quantitySelect, note, generateButton),
+		copyButton, nil, nil, pinEntry,
+	)
+}
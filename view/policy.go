@@ -0,0 +1,155 @@
+/**
+ * Password Generator - Password Policies
+ *
+ * This file lets users pick the password policy of a standard, such as
+ * NIST SP 800-63B, PCI DSS or Active Directory complexity, or load their
+ * organization's policy from a JSON or YAML file. The policy adjusts the
+ * options in the form, and every generated password that breaks it is
+ * generated again.
+ */
+
+package view
+
+import (
+	"fmt"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/policy"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Entries of the policy dropdown besides the policies themselves.
+const (
+	policyNone     = "No Policy"
+	policyFromFile = "Load from File..."
+)
+
+// policyChoice holds the password policy selected in the GUI.
+// Fields:
+//   - policy (*policy.Policy): The selected policy; nil when none is.
+type policyChoice struct {
+	policy *policy.Policy
+}
+
+// apply adjusts opts to the selected policy, if any.
+func (c *policyChoice) apply(opts passgen.PasswordOptions) passgen.PasswordOptions {
+	if c.policy == nil {
+		return opts
+	}
+	return c.policy.Apply(opts)
+}
+
+// constraints returns the constraint that skips passwords breaking the
+// selected policy, or none without one.
+func (c *policyChoice) constraints() []passgen.Constraint {
+	if c.policy == nil {
+		return nil
+	}
+	return []passgen.Constraint{c.policy.Constraint()}
+}
+
+// required returns an error if the organization requires a policy that is
+// not selected, e.g. because it could not be loaded, so that nothing is
+// generated without it.
+func (c *policyChoice) required(managed config.Managed) error {
+	if managed.Policy != "" && c.policy == nil {
+		return fmt.Errorf("the policy required by your organization, %s, could not be loaded", managed.Policy)
+	}
+	return nil
+}
+
+// describe returns the selected policy in one line, or "" without one.
+func (c *policyChoice) describe() string {
+	if c.policy == nil {
+		return ""
+	}
+	return c.policy.Name + ": " + c.policy.Describe()
+}
+
+// newPolicySelect returns a dropdown of the bundled policies that also
+// loads policy files, which are then listed as well.
+// Parameters:
+//   - w (fyne.Window): The parent window of the file dialog.
+//   - choice (*policyChoice): Receives the selected policy.
+//   - initial (string): A bundled policy or policy file to select at
+//     first, e.g. from the settings file; "" for none.
+//   - onChange (func()): Called after the selection changes.
+//
+// Returns:
+//
+//	*widget.Select: The dropdown.
+//	error: Why initial could not be loaded; nothing is selected then.
+func newPolicySelect(w fyne.Window, choice *policyChoice, initial string, onChange func()) (*widget.Select, error) {
+	policies := map[string]policy.Policy{}
+	options := []string{policyNone}
+	if presets, err := policy.Presets(); err == nil {
+		for _, preset := range presets {
+			policies[preset.Policy.Name] = preset.Policy
+			options = append(options, preset.Policy.Name)
+		}
+	}
+	options = append(options, policyFromFile)
+
+	policySelect := widget.NewSelect(options, nil)
+	policySelect.PlaceHolder = "Policy (optional)"
+	previous := ""
+	policySelect.OnChanged = func(name string) {
+		if name == policyFromFile {
+			// The file dialog picks the entry; until then the previous
+			// one stays selected.
+			policySelect.Selected = previous
+			policySelect.Refresh()
+			open := dialog.NewFileOpen(func(file fyne.URIReadCloser, err error) {
+				if err != nil {
+					dialog.ShowError(err, w)
+					return
+				}
+				if file == nil {
+					return
+				}
+				path := file.URI().Path()
+				file.Close()
+				p, err := policy.LoadFile(path)
+				if err != nil {
+					dialog.ShowError(err, w)
+					return
+				}
+				if _, ok := policies[p.Name]; !ok {
+					last := len(policySelect.Options) - 1
+					policySelect.Options = append(policySelect.Options[:last:last], p.Name, policyFromFile)
+				}
+				policies[p.Name] = p
+				policySelect.SetSelected(p.Name)
+			}, w)
+			open.SetFilter(storage.NewExtensionFileFilter([]string{".json", ".yaml", ".yml"}))
+			open.Show()
+			return
+		}
+		previous = name
+		if p, ok := policies[name]; ok {
+			choice.policy = &p
+		} else {
+			choice.policy = nil
+		}
+		onChange()
+	}
+	if initial == "" {
+		return policySelect, nil
+	}
+	p, err := policy.Lookup(initial)
+	if err != nil {
+		return policySelect, err
+	}
+	if _, ok := policies[p.Name]; !ok {
+		last := len(policySelect.Options) - 1
+		policySelect.Options = append(policySelect.Options[:last:last], p.Name, policyFromFile)
+	}
+	policies[p.Name] = p
+	policySelect.SetSelected(p.Name)
+	return policySelect, nil
+}
@@ -0,0 +1,71 @@
+/**
+ * Password Generator - Pop-Out Results
+ *
+ * This file shows the results in a small window of their own, separate from
+ * the options, so it can be dragged to another monitor next to a form that is
+ * being filled in. Every row has its own Copy button, and the window follows
+ * each new batch until it is closed.
+ *
+ * Fyne does not offer always-on-top windows or window placement yet, so the
+ * window is a normal top-level window: move it once and keep the target
+ * application beside it rather than over it.
+ */
+
+package view
+
+import "fyne.io/fyne/v2"
+
+// resultsPopout is the pop-out results window; window is nil while closed.
+type resultsPopout struct {
+	window fyne.Window
+	list   *resultList
+	// onCopy is called after a password was copied from the window.
+	onCopy func()
+	// masked hides the passwords, as in the main window.
+	masked bool
+}
+
+// open shows the window, creating it if needed, with the given rows.
+func (p *resultsPopout) open(rows []resultRow) {
+	if p.window == nil {
+		window := fyne.CurrentApp().NewWindow("Passwords")
+		p.window = window
+		p.list = newResultList("", func(password string) {
+			copySecret(window, password)
+			if p.onCopy != nil {
+				p.onCopy()
+			}
+		})
+		p.list.showQR = func(password string) { showQR(window, password) }
+		p.list.masked = p.masked
+		window.SetContent(p.list.object())
+		window.Resize(fyne.NewSize(320, 360))
+		window.SetOnClosed(func() { p.window = nil })
+	}
+	p.update(rows)
+	p.window.Show()
+	p.window.RequestFocus()
+}
+
+// update replaces the rows if the window is open.
+func (p *resultsPopout) update(rows []resultRow) {
+	if p.window == nil {
+		return
+	}
+	p.list.setRows(rows, len(rows), nil)
+}
+
+// setMasked hides or shows the passwords, now and when the window opens.
+func (p *resultsPopout) setMasked(masked bool) {
+	p.masked = masked
+	if p.window != nil {
+		p.list.setMasked(masked)
+	}
+}
+
+// close closes the window if it is open.
+func (p *resultsPopout) close() {
+	if p.window != nil {
+		p.window.Close()
+	}
+}
@@ -0,0 +1,94 @@
+/**
+ * Password Generator - Preset Review
+ *
+ * This file compares two versions of a shared presets file, so that security
+ * reviewers see what a change does before approving it: presets added or
+ * removed, every changed setting, and the entropy before and after, with
+ * presets that got weaker listed first.
+ */
+
+package view
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showPresetDiff asks for the old and then the new presets file and shows
+// their differences.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialogs.
+func showPresetDiff(w fyne.Window) {
+	openPresets(w, "Open the old presets file", func(from config.Presets, fromName string) {
+		openPresets(w, "Open the new presets file", func(to config.Presets, toName string) {
+			showPresetChanges(fmt.Sprintf("%s → %s", fromName, toName), config.DiffPresets(from, to))
+		})
+	})
+}
+
+// openPresets shows title and, once closed, a file dialog. The presets read
+// from the chosen file and its name are passed to opened.
+func openPresets(w fyne.Window, title string, opened func(config.Presets, string)) {
+	open := dialog.NewFileOpen(func(file fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if file == nil {
+			return
+		}
+		defer file.Close()
+		presets, err := config.ReadPresets(file)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("%s: %w", file.URI().Name(), err), w)
+			return
+		}
+		opened(presets, file.URI().Name())
+	}, w)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	prompt := dialog.NewInformation("Compare Preset Files", title+".", w)
+	prompt.SetOnClosed(open.Show)
+	prompt.Show()
+}
+
+// showPresetChanges opens a window listing changes, weaker presets first.
+func showPresetChanges(title string, changes []config.PresetChange) {
+	window := fyne.CurrentApp().NewWindow("Preset Review: " + title)
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Weaker() && !changes[j].Weaker() })
+
+	list := container.NewVBox()
+	if len(changes) == 0 {
+		list.Add(widget.NewLabel("The files define the same presets."))
+	}
+	for _, change := range changes {
+		heading := widget.NewLabelWithStyle(change.String(), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		if change.Weaker() {
+			heading.Importance = widget.DangerImportance
+		}
+		list.Add(heading)
+		fields := widget.NewForm()
+		for _, field := range change.Fields {
+			value := field.Old + " → " + field.New
+			switch change.Kind {
+			case config.PresetAdded:
+				value = field.New
+			case config.PresetRemoved:
+				value = field.Old
+			}
+			fields.Append(field.Field, widget.NewLabel(value))
+		}
+		list.Add(fields)
+		list.Add(widget.NewSeparator())
+	}
+	window.SetContent(container.NewVScroll(list))
+	window.Resize(fyne.NewSize(480, 560))
+	window.Show()
+}
@@ -0,0 +1,194 @@
+/**
+ * Password Generator - Presets
+ *
+ * This file saves the options of the form, and imports KeePass generator
+ * profiles, as named presets, and lets users rename and delete them. Picking
+ * a preset in the main window applies its options, or its pattern, to the
+ * form. It also names the audiences whose
+ * defaults the form can start from.
+ */
+
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/keepass"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// audienceNames maps the audiences to the labels shown in the GUI.
+var audienceNames = map[config.Audience]string{
+	config.AudienceHuman:   "Human-memorable",
+	config.AudienceMachine: "Machine secret",
+}
+
+// audienceLabels returns the labels of config.Audiences, in order.
+func audienceLabels() []string {
+	labels := make([]string, len(config.Audiences))
+	for i, audience := range config.Audiences {
+		labels[i] = audienceNames[audience]
+	}
+	return labels
+}
+
+// audienceLabel returns the label of audience, or "" if none is set.
+func audienceLabel(audience config.Audience) string {
+	return audienceNames[audience]
+}
+
+// audienceFor returns the audience labelled label, or "" if none matches.
+func audienceFor(label string) config.Audience {
+	for audience, name := range audienceNames {
+		if name == label {
+			return audience
+		}
+	}
+	return ""
+}
+
+// showSavePreset asks for a name and saves preset under it.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialog.
+//   - preset (config.Preset): The options, pattern and audience to save.
+//   - presets (config.Presets): The presets to add to; saved to path.
+//   - path (string): The presets file.
+//   - saved (func()): Called after the presets were saved.
+func showSavePreset(w fyne.Window, preset config.Preset, presets config.Presets, path string, saved func()) {
+	if err := passgen.Validate(preset.Options); preset.Pattern == "" && err != nil {
+		dialog.ShowError(localized(err), w)
+		return
+	}
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("e.g. Work")
+	items := []*widget.FormItem{widget.NewFormItem("Name", nameEntry)}
+	dialog.ShowForm("Save Options as Preset", "Save", "Cancel", items, func(ok bool) {
+		name := strings.TrimSpace(nameEntry.Text)
+		if !ok || name == "" {
+			return
+		}
+		preset.Options.Quantity = 1
+		presets[name] = preset
+		if err := config.SavePresets(path, presets); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		saved()
+	}, w)
+}
+
+// showManagePresets lists the presets with buttons to rename and delete
+// them.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialogs.
+//   - presets (config.Presets): The presets to manage; saved to path.
+//   - path (string): The presets file.
+//   - changed (func()): Called after the presets were saved.
+func showManagePresets(w fyne.Window, presets config.Presets, path string, changed func()) {
+	rows := container.NewVBox()
+	save := func() bool {
+		if err := config.SavePresets(path, presets); err != nil {
+			dialog.ShowError(err, w)
+			return false
+		}
+		changed()
+		return true
+	}
+	var refresh func()
+	refresh = func() {
+		rows.RemoveAll()
+		if len(presets) == 0 {
+			rows.Add(widget.NewLabel("No presets saved yet. Use Tools > Save Options as Preset... to add one."))
+			return
+		}
+		for _, name := range presets.Names() {
+			name := name
+			renameButton := widget.NewButton("Rename", func() {
+				nameEntry := widget.NewEntry()
+				nameEntry.SetText(name)
+				items := []*widget.FormItem{widget.NewFormItem("New name", nameEntry)}
+				dialog.ShowForm("Rename Preset", "Rename", "Cancel", items, func(ok bool) {
+					if !ok {
+						return
+					}
+					if err := presets.Rename(name, strings.TrimSpace(nameEntry.Text)); err != nil {
+						dialog.ShowError(err, w)
+						return
+					}
+					save()
+					refresh()
+				}, w)
+			})
+			deleteButton := widget.NewButton("Delete", func() {
+				dialog.ShowConfirm("Delete Preset", fmt.Sprintf("Delete the preset %q?", name), func(ok bool) {
+					if !ok {
+						return
+					}
+					delete(presets, name)
+					save()
+					refresh()
+				}, w)
+			})
+			rows.Add(container.NewBorder(nil, nil, nil, container.NewHBox(renameButton, deleteButton), widget.NewLabel(name)))
+		}
+	}
+	refresh()
+
+	d := dialog.NewCustom("Manage Presets", "Close", container.NewVScroll(rows), w)
+	d.Resize(fyne.NewSize(420, 320))
+	d.Show()
+}
+
+// showKeePassImport asks for a KeePass.config.xml and saves its generator
+// profiles as presets.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialogs.
+//   - base (passgen.PasswordOptions): Options the profiles do not set.
+//   - presets (config.Presets): The presets to add to; saved to path.
+//   - path (string): The presets file.
+//   - imported (func()): Called after the presets were saved.
+func showKeePassImport(w fyne.Window, base passgen.PasswordOptions, presets config.Presets, path string, imported func()) {
+	open := dialog.NewFileOpen(func(file fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if file == nil {
+			return
+		}
+		defer file.Close()
+
+		result, err := keepass.ReadProfiles(file, base)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("%s: %w", file.URI().Name(), err), w)
+			return
+		}
+		var summary strings.Builder
+		for _, profile := range result.Profiles {
+			presets[profile.Name] = config.Preset{Options: profile.Options, Pattern: profile.Pattern}
+			fmt.Fprintf(&summary, "Imported %s\n", profile.Name)
+			for _, note := range profile.Notes {
+				fmt.Fprintf(&summary, "    %s\n", note)
+			}
+		}
+		for _, skipped := range result.Skipped {
+			fmt.Fprintf(&summary, "Skipped %s\n", skipped)
+		}
+		if err := config.SavePresets(path, presets); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		imported()
+		dialog.ShowInformation("Import KeePass Profiles", summary.String(), w)
+	}, w)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".xml"}))
+	open.Show()
+}
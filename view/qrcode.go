@@ -0,0 +1,94 @@
+/**
+ * Password Generator - QR Code Dialog
+ *
+ * This file shows a password as a QR code, so a phone can scan a Wi-Fi key
+ * or credential without it passing through the clipboard or the network.
+ * With a network name, the code joins the Wi-Fi network directly. The code
+ * can also be saved as a PNG image.
+ */
+
+package view
+
+import (
+	"fmt"
+
+	"github.com/PaulBaker1/Password-Generator-GO/qr"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showQR shows password as a QR code with a Save PNG button.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialog.
+//   - password (string): The password to encode.
+func showQR(w fyne.Window, password string) {
+	image := canvas.NewImageFromResource(nil)
+	image.FillMode = canvas.ImageFillContain
+	image.SetMinSize(fyne.NewSize(240, 240))
+	status := widget.NewLabel("")
+	status.Wrapping = fyne.TextWrapWord
+
+	ssidEntry := widget.NewEntry()
+	ssidEntry.SetPlaceHolder("Wi-Fi network name (optional)")
+	// text is what the code holds: the password, or a Wi-Fi join code
+	text := func() string {
+		if ssidEntry.Text == "" {
+			return password
+		}
+		return qr.WiFi(ssidEntry.Text, password)
+	}
+	var png []byte
+	render := func() {
+		var err error
+		png, err = qr.PNG(text(), qr.DefaultSize)
+		if err != nil {
+			png = nil
+			image.Resource = nil
+			status.SetText(fmt.Sprintf("No QR code: %v", err))
+		} else {
+			image.Resource = fyne.NewStaticResource("password-qr.png", png)
+			if ssidEntry.Text == "" {
+				status.SetText("Scan to read the password. Anyone who can see the screen can scan it too.")
+			} else {
+				status.SetText(fmt.Sprintf("Scan with a phone camera to join %q.", ssidEntry.Text))
+			}
+		}
+		image.Refresh()
+	}
+	ssidEntry.OnChanged = func(string) { render() }
+	render()
+
+	saveButton := widget.NewButton("Save PNG...", func() {
+		if png == nil {
+			return
+		}
+		data := png
+		save := dialog.NewFileSave(func(file fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if file == nil {
+				return
+			}
+			_, writeErr := file.Write(data)
+			if closeErr := file.Close(); writeErr == nil {
+				writeErr = closeErr
+			}
+			if writeErr != nil {
+				dialog.ShowError(fmt.Errorf("saving the QR code failed: %w", writeErr), w)
+			}
+		}, w)
+		save.SetFileName("password-qr.png")
+		save.Show()
+	})
+
+	content := container.NewBorder(ssidEntry, container.NewVBox(status, saveButton), nil, nil, image)
+	qrDialog := dialog.NewCustom("QR Code", "Close", content, w)
+	qrDialog.Resize(fyne.NewSize(340, 440))
+	qrDialog.Show()
+}
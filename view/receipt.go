@@ -0,0 +1,81 @@
+/**
+ * Password Generator - Password Receipts
+ *
+ * This file saves receipts for the latest results: salted hashes that let a
+ * recipient later confirm which password was generated for them and when,
+ * without the generator keeping the plaintext. Receipts are checked with
+ * "password-generator-cli -verify-receipt".
+ */
+
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/export"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showReceiptExport asks who the latest results are for and where to save
+// their receipts.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialogs.
+//   - results ([]export.Result): The results of the latest run.
+func showReceiptExport(w fyne.Window, results []export.Result) {
+	if len(results) == 0 {
+		dialog.ShowInformation("Save Receipts", "Generate passwords first.", w)
+		return
+	}
+	labelEntry := widget.NewEntry()
+	labelEntry.SetPlaceHolder("e.g. alice@example.com")
+	items := []*widget.FormItem{widget.NewFormItem("For", labelEntry)}
+	dialog.ShowForm("Save Receipts", "Next", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		receipts := make([]export.Receipt, len(results))
+		for i, result := range results {
+			receipt, err := export.NewReceipt(result.Password, strings.TrimSpace(labelEntry.Text), result.GeneratedAt)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			receipts[i] = receipt
+		}
+		data, err := json.MarshalIndent(receipts, "", "  ")
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		saveReceipts(w, append(data, '\n'))
+	}, w)
+}
+
+// saveReceipts asks where to save the receipts file data.
+func saveReceipts(w fyne.Window, data []byte) {
+	save := dialog.NewFileSave(func(file fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if file == nil {
+			return
+		}
+		_, writeErr := file.Write(data)
+		if closeErr := file.Close(); writeErr == nil {
+			writeErr = closeErr
+		}
+		if writeErr != nil {
+			dialog.ShowError(fmt.Errorf("export failed: %w", writeErr), w)
+			return
+		}
+		dialog.ShowInformation("Save Receipts", "The receipts contain no passwords and can be shared.\nCheck a password with: password-generator-cli -verify-receipt <file>", w)
+	}, w)
+	save.SetFileName("password-receipts.json")
+	save.Show()
+}
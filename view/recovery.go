@@ -0,0 +1,72 @@
+/**
+ * Password Generator - Recovery Tab
+ *
+ * This file builds the Recovery tab, which generates a set of one-time
+ * recovery codes such as 7KQP-M3XD for two-factor login backups: the number
+ * of codes, their groups and a Generate button.
+ */
+
+package view
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/controller"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// recoveryTab returns the content of the Recovery tab.
+// Parameters:
+//   - w (fyne.Window): The window whose clipboard receives copied codes.
+//   - ctrl (*controller.GeneratorController): Generates the codes.
+func recoveryTab(w fyne.Window, ctrl *controller.GeneratorController) fyne.CanvasObject {
+	defaults := passgen.DefaultRecoveryOptions()
+	countSelect := widget.NewSelect([]string{"5", "8", "10", "12", "16", "20"}, nil)
+	countSelect.SetSelected(strconv.Itoa(defaults.Codes))
+	var groupCounts, groupSizes []string
+	for i := 1; i <= passgen.MaxRecoveryGroups; i++ {
+		groupCounts = append(groupCounts, strconv.Itoa(i))
+	}
+	for i := passgen.MinRecoveryGroupSize; i <= passgen.MaxRecoveryGroupSize; i++ {
+		groupSizes = append(groupSizes, strconv.Itoa(i))
+	}
+	groupsSelect := widget.NewSelect(groupCounts, nil)
+	groupsSelect.SetSelected(strconv.Itoa(defaults.Groups))
+	sizeSelect := widget.NewSelect(groupSizes, nil)
+	sizeSelect.SetSelected(strconv.Itoa(defaults.GroupSize))
+
+	codesEntry := widget.NewMultiLineEntry()
+	codesEntry.SetPlaceHolder("Generated recovery codes will appear here")
+	codesEntry.TextStyle = fyne.TextStyle{Monospace: true}
+
+	generateButton := widget.NewButton("Generate Codes", func() {
+		opts := defaults
+		opts.Codes, _ = strconv.Atoi(countSelect.Selected)
+		opts.Groups, _ = strconv.Atoi(groupsSelect.Selected)
+		opts.GroupSize, _ = strconv.Atoi(sizeSelect.Selected)
+		codes, err := ctrl.GenerateRecoveryCodes(context.Background(), opts)
+		if err != nil {
+			codesEntry.SetText(errorText(err))
+			return
+		}
+		codesEntry.SetText(strings.Join(codes, "\n"))
+	})
+	copyButton := widget.NewButton("Copy", func() {
+		copySecret(w, strings.TrimSpace(codesEntry.Text))
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem("Codes", countSelect),
+		widget.NewFormItem("Groups", groupsSelect),
+		widget.NewFormItem("Characters per group", sizeSelect),
+	)
+	note := widget.NewLabel("Each code works once. Letters and digits that look alike (0, O, 1, I, L) are never used.")
+	note.Wrapping = fyne.TextWrapWord
+	return container.NewBorder(container.NewVBox(form, generateButton, note), copyButton, nil, nil, codesEntry)
+}
@@ -0,0 +1,172 @@
+package view
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// resultList shows result rows in a virtualized widget.List: only the rows
+// on screen have widgets, and no text of the whole batch is built, so tens of
+// thousands of passwords stay responsive. A status line above the list holds
+// the row count or an error. With tags, every row also has inline entries
+// for the label and note of its password. Masked lists show bullets instead
+// of the passwords; see mask.go.
+type resultList struct {
+	rows   []resultRow
+	tags   []resultTag
+	list   *widget.List
+	status *widget.Label
+	// masked hides the passwords; revealed holds the numbers of the rows
+	// shown anyway.
+	masked   bool
+	revealed map[int]bool
+	// copy receives a password copied with a row's Copy button.
+	copy func(password string)
+	// showQR, if set, shows a row's password as a QR code.
+	showQR func(password string)
+	// regenerate, if set, replaces the password of the row with the given
+	// number with a new one.
+	regenerate func(number int)
+}
+
+// newResultList creates an empty list that shows placeholder until rows are set.
+func newResultList(placeholder string, copy func(password string)) *resultList {
+	l := &resultList{status: widget.NewLabel(placeholder), copy: copy, revealed: map[int]bool{}}
+	l.status.Wrapping = fyne.TextWrapWord
+	l.list = widget.NewList(
+		func() int { return len(l.rows) },
+		func() fyne.CanvasObject {
+			value := newRevealLabel()
+			value.Truncation = fyne.TextTruncateEllipsis
+			label := widget.NewEntry()
+			label.SetPlaceHolder("Label")
+			note := widget.NewEntry()
+			note.SetPlaceHolder("Note")
+			rating := container.NewHBox(widget.NewLabel(""), newStrengthBar())
+			actions := container.NewHBox(
+				widget.NewButtonWithIcon("", theme.VisibilityIcon(), nil),
+				widget.NewButton("Copy", nil),
+				widget.NewButton("QR", nil),
+				widget.NewButtonWithIcon("", theme.ViewRefreshIcon(), nil),
+			)
+			return container.NewBorder(nil, nil, rating, actions, container.NewGridWithColumns(3, value, label, note))
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			// NewBorder keeps the center object first, then left and right.
+			objects := item.(*fyne.Container).Objects
+			r := l.rows[id]
+			fields := objects[0].(*fyne.Container).Objects
+			fields[0].(*revealLabel).setValue(r.value, l.mask(r))
+			for i, entry := range []*widget.Entry{fields[1].(*widget.Entry), fields[2].(*widget.Entry)} {
+				// Rows reuse their widgets: detach the entry before showing
+				// the text of this row's tag.
+				entry.OnChanged = nil
+				if l.tags == nil {
+					entry.Hide()
+					continue
+				}
+				tag := &l.tags[r.number-1]
+				text := []*string{&tag.label, &tag.note}[i]
+				entry.SetText(*text)
+				entry.OnChanged = func(s string) { *text = s }
+				entry.Show()
+			}
+			rating := objects[1].(*fyne.Container).Objects
+			rating[0].(*widget.Label).SetText(fmt.Sprintf("%d. [%s]", r.number, badgeNames[r.badge]))
+			setStrengthBar(rating[1].(*fyne.Container), r.strength)
+			actions := objects[2].(*fyne.Container).Objects
+			reveal := actions[0].(*widget.Button)
+			if l.masked {
+				reveal.SetIcon(theme.VisibilityIcon())
+				if l.revealed[r.number] {
+					reveal.SetIcon(theme.VisibilityOffIcon())
+				}
+				reveal.OnTapped = func() {
+					l.revealed[r.number] = !l.revealed[r.number]
+					l.list.RefreshItem(id)
+				}
+				reveal.Show()
+			} else {
+				reveal.Hide()
+			}
+			actions[1].(*widget.Button).OnTapped = func() { l.copy(r.value) }
+			if l.showQR == nil {
+				actions[2].Hide()
+			} else {
+				actions[2].(*widget.Button).OnTapped = func() { l.showQR(r.value) }
+				actions[2].Show()
+			}
+			if l.regenerate == nil {
+				actions[3].Hide()
+			} else {
+				actions[3].(*widget.Button).OnTapped = func() { l.regenerate(r.number) }
+				actions[3].Show()
+			}
+		},
+	)
+	return l
+}
+
+// setRows shows rows; total is the size of the batch they were chosen from.
+// tags holds one tag per password of the batch and is edited in place; nil
+// hides the label and note entries.
+func (l *resultList) setRows(rows []resultRow, total int, tags []resultTag) {
+	l.revealed = map[int]bool{}
+	l.updateRows(rows, total, tags)
+	l.list.UnselectAll()
+	l.list.ScrollToTop()
+}
+
+// updateRows shows rows like setRows, but keeps the scroll position and the
+// revealed rows, e.g. after one password of the batch was regenerated.
+func (l *resultList) updateRows(rows []resultRow, total int, tags []resultTag) {
+	l.rows, l.tags = rows, tags
+	switch hidden := total - len(rows); {
+	case len(rows) == 0 && total == 0:
+		l.status.SetText("No passwords to show.")
+	case hidden > 0:
+		l.status.SetText(fmt.Sprintf("%d passwords (%d weaker passwords hidden)", len(rows), hidden))
+	default:
+		l.status.SetText(fmt.Sprintf("%d passwords", len(rows)))
+	}
+	l.list.Refresh()
+}
+
+// setMasked hides or shows the passwords of all rows.
+func (l *resultList) setMasked(masked bool) {
+	l.masked = masked
+	l.revealed = map[int]bool{}
+	l.list.Refresh()
+}
+
+// mask returns the bullets shown for r, or "" when its password is shown.
+func (l *resultList) mask(r resultRow) string {
+	if l.masked && !l.revealed[r.number] {
+		return maskText(r.value)
+	}
+	return ""
+}
+
+// first returns the password of the top row, as shown, if there is one.
+func (l *resultList) first() (string, bool) {
+	if len(l.rows) == 0 {
+		return "", false
+	}
+	return l.rows[0].value, true
+}
+
+// setMessage clears the rows and shows text instead, e.g. an error.
+func (l *resultList) setMessage(text string) {
+	l.rows = nil
+	l.status.SetText(text)
+	l.list.Refresh()
+}
+
+// object returns the status line above the list, for placing in a layout.
+func (l *resultList) object() fyne.CanvasObject {
+	return container.NewBorder(l.status, nil, nil, nil, l.list)
+}
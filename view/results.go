@@ -0,0 +1,104 @@
+/**
+ * Password Generator - Results List
+ *
+ * This file rates the generated batch with a strength badge and bar on
+ * every row, and sorts or filters it by score so the best candidates of a
+ * large batch come first. Ratings come from passgen.AnalyzeStrength, so a
+ * random password that happens to contain a word or a year is rated lower.
+ * Rows keep the number of their generation order.
+ */
+
+package view
+
+import (
+	"sort"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+)
+
+// Sort orders and filters offered above the results.
+const (
+	orderGenerated = "Generated order"
+	orderStrongest = "Strongest first"
+
+	showAll       = "All strengths"
+	showGood      = "Good or better"
+	showExcellent = "Excellent only"
+)
+
+// Strength badges shown on each row, from weakest to strongest.
+const (
+	badgeWeak = iota
+	badgeGood
+	badgeExcellent
+)
+
+// badgeNames holds the display name of each badge.
+var badgeNames = []string{"weak", "good", "excellent"}
+
+// strengthBadge maps the five-step strength scale onto the three badges.
+func strengthBadge(s passgen.Strength) int {
+	switch {
+	case s >= passgen.Strong:
+		return badgeExcellent
+	case s >= passgen.Fair:
+		return badgeGood
+	default:
+		return badgeWeak
+	}
+}
+
+// resultTag is the label and note attached to a password of the batch.
+type resultTag struct {
+	label string
+	note  string
+}
+
+// resultRow is one shown password with its generation number and rating.
+type resultRow struct {
+	number   int
+	value    string
+	entropy  float64
+	strength passgen.Strength
+	badge    int
+}
+
+// resultRows orders and filters passwords for display.
+// Parameters:
+//   - passwords ([]string): The batch in generation order.
+//   - opts (passgen.PasswordOptions): The options of the batch; group
+//     separators are not rated.
+//   - estimate (float64): The entropy the options give, e.g. from
+//     passgen.EstimateEntropy; no password is rated above it. 0 for none.
+//   - order (string): orderGenerated or orderStrongest.
+//   - show (string): showAll, showGood or showExcellent.
+//
+// Returns:
+//
+//	[]resultRow: The shown rows, in display order.
+func resultRows(passwords []string, opts passgen.PasswordOptions, estimate float64, order, show string) []resultRow {
+	minBadge := badgeWeak
+	switch show {
+	case showGood:
+		minBadge = badgeGood
+	case showExcellent:
+		minBadge = badgeExcellent
+	}
+
+	var rows []resultRow
+	for i, password := range passwords {
+		entropy := passgen.AnalyzeStrength(passgen.Ungroup(password, opts)).Bits
+		if estimate > 0 && estimate < entropy {
+			entropy = estimate
+		}
+		strength := passgen.RateEntropy(entropy)
+		r := resultRow{number: i + 1, value: password, entropy: entropy, strength: strength, badge: strengthBadge(strength)}
+		if r.badge >= minBadge {
+			rows = append(rows, r)
+		}
+	}
+	if order == orderStrongest {
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].entropy > rows[j].entropy })
+	}
+	return rows
+}
@@ -0,0 +1,129 @@
+/**
+ * Password Generator - Dependent Controls
+ *
+ * This file keeps the form consistent with passgen.OptionRules, the table the
+ * model validates options with: options that cannot work with the current
+ * selection are disabled, and the length slider is capped where a rule
+ * limits the length. Anything the controller would still reject is named
+ * under the options, with the control to change.
+ */
+
+package view
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/PaulBaker1/Password-Generator-GO/controller"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+// enforceOptionRules disables and adjusts the controls that depend on others.
+// Parameters:
+//   - opts (passgen.PasswordOptions): The options currently selected.
+//   - checks (map[string]*widget.Check): The check of each boolean option,
+//     keyed by its PasswordOptions field name.
+//   - slider (*widget.Slider): The length slider.
+//   - maxLength (float64): The slider's configured maximum.
+func enforceOptionRules(opts passgen.PasswordOptions, checks map[string]*widget.Check, slider *widget.Slider, maxLength float64) {
+	disabled := make(map[string]bool)
+	limit := maxLength
+	for _, rule := range passgen.OptionRules {
+		if rule.Adjust == nil {
+			continue // the user has to resolve it, e.g. by picking a class
+		}
+		if _, ok := checks[rule.Option]; ok {
+			// Disable an option if turning it on would break the rule.
+			probe := opts
+			reflect.ValueOf(&probe).Elem().FieldByName(rule.Option).SetBool(true)
+			if rule.Violated(probe) {
+				disabled[rule.Option] = true
+			}
+		}
+		if rule.Option == "Length" {
+			probe := opts
+			probe.Length = int(maxLength)
+			if rule.Violated(probe) {
+				rule.Adjust(&probe)
+				if l := float64(probe.Length); l < limit {
+					limit = l
+				}
+			}
+		}
+	}
+
+	for name, check := range checks {
+		if disabled[name] {
+			check.SetChecked(false)
+			check.Disable()
+		} else {
+			check.Enable()
+		}
+	}
+
+	if limit < slider.Min {
+		limit = slider.Min
+	}
+	if slider.Max != limit {
+		slider.Max = limit
+		slider.Refresh()
+	}
+	if slider.Value > limit {
+		slider.SetValue(limit)
+	}
+}
+
+// optionLabels names the control of each option a controller.ValidationError
+// can point at; rules on the character types point at IncludeLower.
+var optionLabels = map[string]string{
+	"Length":          "Length",
+	"IncludeLower":    "Character types",
+	"BeginWithLetter": "Begin With Letters",
+	"NoSimilar":       "No Similar Characters",
+	"NoDuplicates":    "No Duplicate Characters",
+	"KeyboardLayout":  "Keyboard layout",
+	"MustInclude":     "Characters to require",
+	"GroupSize":       "Group size",
+	"GroupSeparator":  "Group separator",
+	"MinEntropy":      "Safety floor",
+}
+
+// validationText renders an error of controller.Validate as the control to
+// change and the message, or "" if err is nil.
+func validationText(err error) string {
+	if err == nil {
+		return ""
+	}
+	var invalid *controller.ValidationError
+	if !errors.As(err, &invalid) {
+		return errorText(err)
+	}
+	label := optionLabels[invalid.Option]
+	if label == "" {
+		label = invalid.Option
+	}
+	return label + ": " + passgen.Localize(invalid.Err, messageLocale)
+}
+
+// entropyText renders the estimated entropy of the options, or of pattern
+// if it is not empty, e.g. "≈ 87 bits (strong)"; "" if they are invalid.
+func entropyText(opts passgen.PasswordOptions, pattern string) string {
+	var bits float64
+	if pattern != "" {
+		parsed, err := passgen.ParsePattern(pattern, opts)
+		if err != nil {
+			return ""
+		}
+		bits = parsed.Entropy()
+	} else {
+		opts.MinEntropy = 0 // below the floor is exactly when the estimate matters
+		if passgen.Validate(opts) != nil {
+			return ""
+		}
+		bits = passgen.EstimateEntropy(opts)
+	}
+	return fmt.Sprintf("≈ %.0f bits (%s)", bits, passgen.RateEntropy(bits))
+}
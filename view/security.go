@@ -0,0 +1,156 @@
+/**
+ * Password Generator - Security Check
+ *
+ * This file summarizes the security-relevant settings of the GUI as they are
+ * right now, read from the personal profile, and offers to harden them in one
+ * click. Protections this build does not have are listed as such rather than
+ * left out, so the summary never suggests more than the app does.
+ */
+
+package view
+
+import (
+	"fmt"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/session"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// hardenedSafetyFloor is the safety floor, in bits, that hardening sets when
+// none or a lower one is configured.
+const hardenedSafetyFloor = 60
+
+// postureCheck is one line of the security check.
+// Fields:
+//   - name (string): The setting checked.
+//   - status (string): Its current state, in words.
+//   - secure (bool): Whether the state is the hardened one.
+//   - available (bool): Whether this build has the setting at all.
+//   - harden (func(*config.Profile)): Changes the profile to the hardened
+//     state; nil if there is nothing to change.
+type postureCheck struct {
+	name      string
+	status    string
+	secure    bool
+	available bool
+	harden    func(*config.Profile)
+}
+
+// securityPosture computes the security check from the profile, the breach
+// list in use and the settings the organization manages, which hardening
+// leaves alone.
+func securityPosture(profile config.Profile, breaches *breachFilter, managed config.Managed) []postureCheck {
+	history := postureCheck{name: "Result history", available: session.CanSaveResults}
+	if session.CanSaveResults && profile.RestoreResults {
+		history.status = "Un-copied results are kept, encrypted, until copied"
+		history.harden = func(p *config.Profile) { p.RestoreResults = false }
+	} else {
+		history.status, history.secure = "Results are never saved", true
+	}
+	if managed.RestoreResults != nil {
+		history.status += " (set by your organization)"
+		history.harden = nil
+	}
+
+	floor := postureCheck{name: "Safety floor", available: true, secure: profile.SafetyFloor >= hardenedSafetyFloor}
+	if profile.SafetyFloor == 0 {
+		floor.status = "Off: weak options are generated without warning"
+	} else {
+		floor.status = fmt.Sprintf("%.0f bits", profile.SafetyFloor)
+	}
+	if !floor.secure {
+		floor.harden = func(p *config.Profile) { p.SafetyFloor = hardenedSafetyFloor }
+	}
+
+	// A breach list cannot be hardened in one click: only the user has the file.
+	breached := postureCheck{name: "Breach checks", available: true}
+	switch {
+	case breaches.list != nil:
+		breached.status, breached.secure = "Generated passwords are checked against "+breaches.list.Path, true
+	case profile.BreachList != "":
+		breached.status = fmt.Sprintf("Off: %s cannot be opened", profile.BreachList)
+	default:
+		breached.status = "Off: choose a list under Tools > Breach List"
+	}
+	if managed.BreachList != "" {
+		breached.status += " (required by your organization)"
+	}
+
+	clipboard := postureCheck{name: "Clipboard auto-clear", available: true, status: clipboardClearText(profile)}
+	if delay := profile.ClipboardClearDelay(); delay > 0 && delay <= config.DefaultClipboardClear {
+		clipboard.secure = true
+	} else {
+		clipboard.harden = func(p *config.Profile) { p.ClipboardClear = 0 }
+	}
+
+	return []postureCheck{
+		history,
+		floor,
+		clipboard,
+		{name: "Vault lock timeout", status: "Not available: the history vault stays unlocked until locked by hand"},
+		breached,
+	}
+}
+
+// showSecurityCheck shows the security check and a button that applies every
+// available hardening and saves the profile.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialog.
+//   - profile (*config.Profile): The live profile; hardening changes it.
+//   - profilePath (string): Where the profile is saved.
+//   - breaches (*breachFilter): The breach list in use.
+//   - managed (config.Managed): The settings the organization enforces.
+//   - onChange (func()): Called after hardening, to refresh dependent controls.
+func showSecurityCheck(w fyne.Window, profile *config.Profile, profilePath string, breaches *breachFilter, managed config.Managed, onChange func()) {
+	grid := container.NewGridWithColumns(3)
+	hardenButton := widget.NewButton("Harden", nil)
+
+	refresh := func() {
+		grid.RemoveAll()
+		canHarden := false
+		for _, check := range securityPosture(*profile, breaches, managed) {
+			state := "Needs attention"
+			switch {
+			case !check.available:
+				state = "Not available"
+			case check.secure:
+				state = "OK"
+			}
+			status := widget.NewLabel(check.status)
+			status.Wrapping = fyne.TextWrapWord
+			grid.Add(widget.NewLabelWithStyle(check.name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+			grid.Add(widget.NewLabel(state))
+			grid.Add(status)
+			canHarden = canHarden || check.harden != nil
+		}
+		if canHarden {
+			hardenButton.Enable()
+		} else {
+			hardenButton.Disable()
+		}
+	}
+	hardenButton.OnTapped = func() {
+		for _, check := range securityPosture(*profile, breaches, managed) {
+			if check.harden != nil {
+				check.harden(profile)
+			}
+		}
+		if err := config.SaveProfile(profilePath, *profile); err != nil {
+			dialog.ShowError(err, w)
+		}
+		onChange()
+		refresh()
+	}
+	refresh()
+
+	note := widget.NewLabel("Harden applies every available fix and saves it in your profile.")
+	note.Wrapping = fyne.TextWrapWord
+	d := dialog.NewCustom("Security Check", "Close", container.NewVBox(grid, note, hardenButton), w)
+	d.Resize(fyne.NewSize(620, 360))
+	d.Show()
+}
@@ -0,0 +1,89 @@
+/**
+ * Password Generator - Secret Sharing Dialog
+ *
+ * This file splits a generated password into Shamir shares for backup across
+ * several trustees. Each share can be copied as text or scanned as a QR code.
+ */
+
+package view
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/PaulBaker1/Password-Generator-GO/qr"
+	"github.com/PaulBaker1/Password-Generator-GO/shamir"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// shareCounts lists the share counts offered in the dialog.
+var shareCounts = []string{"2", "3", "4", "5", "6", "7", "8", "9", "10"}
+
+// showShamirSplit asks for the number of shares and the threshold, then shows
+// the shares of password.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialog.
+//   - password (string): The password to split; empty if none was generated yet.
+func showShamirSplit(w fyne.Window, password string) {
+	if password == "" {
+		dialog.ShowInformation("Split into Shares", "Generate a password first.", w)
+		return
+	}
+
+	sharesSelect := widget.NewSelect(shareCounts, nil)
+	sharesSelect.SetSelected("5")
+	thresholdSelect := widget.NewSelect(shareCounts, nil)
+	thresholdSelect.SetSelected("3")
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Shares", sharesSelect),
+		widget.NewFormItem("Needed to recover", thresholdSelect),
+	}
+	dialog.ShowForm("Split into Shares", "Split", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		n, _ := strconv.Atoi(sharesSelect.Selected)
+		k, _ := strconv.Atoi(thresholdSelect.Selected)
+		shares, err := shamir.Split([]byte(password), n, k)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		showShares(shares)
+	}, w)
+}
+
+// showShares opens a window with one QR code and copy button per share.
+func showShares(shares []shamir.Share) {
+	window := fyne.CurrentApp().NewWindow("Password Shares")
+
+	list := container.NewVBox()
+	for _, share := range shares {
+		text := share.String()
+		row := container.NewVBox(widget.NewLabelWithStyle(fmt.Sprintf("Share %d", share.X), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		if png, err := qr.PNG(text, qr.DefaultSize); err == nil {
+			image := canvas.NewImageFromResource(fyne.NewStaticResource(fmt.Sprintf("share-%d.png", share.X), png))
+			image.FillMode = canvas.ImageFillContain
+			image.SetMinSize(fyne.NewSize(200, 200))
+			row.Add(image)
+		}
+		label := widget.NewLabel(text)
+		label.Wrapping = fyne.TextWrapBreak
+		row.Add(label)
+		row.Add(widget.NewButton("Copy", func() { copySecret(window, text) }))
+		list.Add(row)
+		list.Add(widget.NewSeparator())
+	}
+
+	header := widget.NewLabel(fmt.Sprintf("Give each trustee one share. Any %d of them recover the password; fewer reveal nothing.", shares[0].Threshold))
+	header.Wrapping = fyne.TextWrapWord
+	window.SetContent(container.NewBorder(header, nil, nil, nil, container.NewVScroll(list)))
+	window.Resize(fyne.NewSize(420, 600))
+	window.Show()
+}
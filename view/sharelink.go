@@ -0,0 +1,82 @@
+/**
+ * Password Generator - One-Time Share Links
+ *
+ * This file shares the latest password as a self-destructing link. The
+ * password is encrypted on this machine and only the ciphertext is sent to
+ * the relay, so it can be handed to a colleague without a chat app storing
+ * the plaintext.
+ */
+
+package view
+
+import (
+	"context"
+	"time"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+	"github.com/PaulBaker1/Password-Generator-GO/sharelink"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// shareExpiries maps the expiry choices of the dialog to durations.
+var shareExpiries = map[string]time.Duration{
+	"1 hour": time.Hour,
+	"1 day":  24 * time.Hour,
+	"7 days": 7 * 24 * time.Hour,
+}
+
+// showShareLink asks for a relay and an expiry, then publishes password.
+// Parameters:
+//   - w (fyne.Window): The parent window of the dialogs.
+//   - password (string): The password to share; empty if none was generated yet.
+//   - profile (*config.Profile): Remembers the relay for next time.
+//   - profilePath (string): Where the profile is saved.
+func showShareLink(w fyne.Window, password string, profile *config.Profile, profilePath string) {
+	if password == "" {
+		dialog.ShowInformation("Share as One-Time Link", "Generate a password first.", w)
+		return
+	}
+
+	relayEntry := widget.NewEntry()
+	relayEntry.SetPlaceHolder("https://share.example.com")
+	relayEntry.SetText(profile.ShareRelay)
+	relayEntry.Validator = func(text string) error {
+		_, err := sharelink.CheckRelay(text)
+		return err
+	}
+	keyEntry := widget.NewPasswordEntry()
+	keyEntry.SetPlaceHolder("Only if the relay requires one")
+	expirySelect := widget.NewSelect([]string{"1 hour", "1 day", "7 days"}, nil)
+	expirySelect.SetSelected("1 day")
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Relay", relayEntry),
+		widget.NewFormItem("API key", keyEntry),
+		widget.NewFormItem("Expires after", expirySelect),
+	}
+	dialog.ShowForm("Share as One-Time Link", "Share", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		link, err := sharelink.Publish(context.Background(), nil, relayEntry.Text, keyEntry.Text, password, shareExpiries[expirySelect.Selected])
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if profile.ShareRelay != relayEntry.Text {
+			profile.ShareRelay = relayEntry.Text
+			_ = config.SaveProfile(profilePath, *profile)
+		}
+
+		linkEntry := widget.NewEntry()
+		linkEntry.SetText(link)
+		note := widget.NewLabel("The link reveals the password once and expires in " + expirySelect.Selected + " if nobody opens it.")
+		note.Wrapping = fyne.TextWrapWord
+		copyButton := widget.NewButton("Copy Link", func() { copySecret(w, link) })
+		dialog.ShowCustom("One-Time Link", "Close", container.NewVBox(note, linkEntry, copyButton), w)
+	}, w)
+}
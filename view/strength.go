@@ -0,0 +1,84 @@
+/**
+ * Password Generator - Password Strength Check
+ *
+ * This file shows a window for checking an existing password, e.g. one a
+ * user is about to keep. It is rated with passgen.AnalyzeStrength, which
+ * finds common words, keyboard walks, sequences, repeats and dates, and the
+ * crack times and advice update as the password is typed. Nothing is saved.
+ */
+
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// matchNames holds how each kind of pattern is listed.
+var matchNames = map[passgen.MatchKind]string{
+	passgen.MatchDictionary: "common word",
+	passgen.MatchKeyboard:   "keyboard walk",
+	passgen.MatchSequence:   "sequence",
+	passgen.MatchRepeat:     "repeat",
+	passgen.MatchDate:       "date",
+}
+
+// showStrengthCheck opens a window that rates a typed or pasted password.
+func showStrengthCheck() {
+	window := fyne.CurrentApp().NewWindow("Check Password Strength")
+
+	passwordEntry := widget.NewPasswordEntry()
+	passwordEntry.SetPlaceHolder("Type or paste a password")
+	bar := newStrengthBar()
+	scoreLabel := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	crackTimes := widget.NewForm()
+	patterns := widget.NewLabel("")
+	patterns.Wrapping = fyne.TextWrapWord
+
+	passwordEntry.OnChanged = func(password string) {
+		score := passgen.AnalyzeStrength(password)
+		setStrengthBar(bar, score.Strength)
+		crackTimes.Items = nil
+		if password == "" {
+			scoreLabel.SetText("")
+			patterns.SetText("")
+			crackTimes.Refresh()
+			return
+		}
+		scoreLabel.SetText(fmt.Sprintf("≈ %.0f bits (%s)", score.Bits, score.Strength))
+		for _, attack := range passgen.Attacks {
+			crackTimes.Append(attack.Name, widget.NewLabel(score.CrackTime(attack)))
+		}
+		crackTimes.Refresh()
+
+		if len(score.Matches) == 0 {
+			patterns.SetText("No common patterns found; every character counts as random.")
+			return
+		}
+		found := make([]string, len(score.Matches))
+		for i, m := range score.Matches {
+			found[i] = fmt.Sprintf("%s %q", matchNames[m.Kind], m.Token)
+		}
+		patterns.SetText("Found: " + strings.Join(found, ", ") + "\n" + strings.Join(score.Feedback(), "\n"))
+	}
+	passwordEntry.OnChanged("")
+
+	note := widget.NewLabel("The estimate assumes an attacker who tries common passwords, words, keyboard walks, sequences and dates first. Crack times are the expected time to find the password.")
+	note.Wrapping = fyne.TextWrapWord
+	window.SetContent(container.NewVBox(
+		widget.NewForm(widget.NewFormItem("Password", passwordEntry)),
+		container.NewHBox(bar, scoreLabel),
+		crackTimes,
+		patterns,
+		widget.NewSeparator(),
+		note,
+	))
+	window.Resize(fyne.NewSize(560, 420))
+	window.Show()
+}
@@ -0,0 +1,54 @@
+package view
+
+import (
+	"image/color"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+)
+
+// strengthSegments is the number of segments of a strength bar, one per
+// step of passgen.Strength.
+const strengthSegments = int(passgen.VeryStrong) + 1
+
+// newStrengthBar creates an empty strength bar of small coloured segments.
+func newStrengthBar() *fyne.Container {
+	bar := container.NewHBox()
+	for i := 0; i < strengthSegments; i++ {
+		segment := canvas.NewRectangle(theme.Color(theme.ColorNameDisabled))
+		segment.SetMinSize(fyne.NewSize(10, 6))
+		bar.Add(container.NewCenter(segment))
+	}
+	return bar
+}
+
+// setStrengthBar fills one segment per step of s, red for weak, yellow for
+// fair and green for strong passwords; the rest stay grey.
+func setStrengthBar(bar *fyne.Container, s passgen.Strength) {
+	fill := strengthColor(s)
+	for i, object := range bar.Objects {
+		segment := object.(*fyne.Container).Objects[0].(*canvas.Rectangle)
+		if i <= int(s) {
+			segment.FillColor = fill
+		} else {
+			segment.FillColor = theme.Color(theme.ColorNameDisabled)
+		}
+		segment.Refresh()
+	}
+}
+
+// strengthColor returns the theme colour of a rating.
+func strengthColor(s passgen.Strength) color.Color {
+	switch {
+	case s >= passgen.Strong:
+		return theme.Color(theme.ColorNameSuccess)
+	case s == passgen.Fair:
+		return theme.Color(theme.ColorNameWarning)
+	default:
+		return theme.Color(theme.ColorNameError)
+	}
+}
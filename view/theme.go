@@ -0,0 +1,105 @@
+/**
+ * Password Generator - Theme
+ *
+ * This file lets users pick a light or dark theme, or follow the system
+ * setting, and a compact layout density for small screens. The choices are
+ * saved in the profile and applied at once and on the next start.
+ */
+
+package view
+
+import (
+	"image/color"
+
+	"github.com/PaulBaker1/Password-Generator-GO/config"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// themeChoices are the themes offered, as stored in the profile.
+var themeChoices = []struct {
+	label string
+	name  string
+}{
+	{"System", ""},
+	{"Light", config.ThemeLight},
+	{"Dark", config.ThemeDark},
+}
+
+// densityChoices are the layout densities offered, as stored in the profile.
+var densityChoices = []struct {
+	label string
+	name  string
+}{
+	{"Normal", ""},
+	{"Compact", config.DensityCompact},
+}
+
+// compactScale is the factor applied to padding and text in the compact
+// density.
+const compactScale = 0.75
+
+// variantTheme is the default theme fixed to one variant, whatever the
+// system prefers.
+type variantTheme struct {
+	fyne.Theme
+	variant fyne.ThemeVariant
+}
+
+// Color returns the colour of the fixed variant.
+func (t variantTheme) Color(name fyne.ThemeColorName, _ fyne.ThemeVariant) color.Color {
+	return t.Theme.Color(name, t.variant)
+}
+
+// compactTheme shrinks the padding and text of a theme, so more of the
+// form fits on a small screen.
+type compactTheme struct {
+	fyne.Theme
+}
+
+// Size returns the padding and text sizes scaled down; icons and other
+// sizes stay as they are, so controls remain easy to hit.
+func (t compactTheme) Size(name fyne.ThemeSizeName) float32 {
+	switch name {
+	case theme.SizeNamePadding, theme.SizeNameInnerPadding, theme.SizeNameLineSpacing,
+		theme.SizeNameText, theme.SizeNameSubHeadingText, theme.SizeNameHeadingText, theme.SizeNameCaptionText:
+		return t.Theme.Size(name) * compactScale
+	}
+	return t.Theme.Size(name)
+}
+
+// applyTheme switches app to the named theme and density; unknown themes
+// follow the system and unknown densities are normal.
+func applyTheme(app fyne.App, name, density string) {
+	var t fyne.Theme = theme.DefaultTheme()
+	switch name {
+	case config.ThemeLight:
+		t = variantTheme{t, theme.VariantLight}
+	case config.ThemeDark:
+		t = variantTheme{t, theme.VariantDark}
+	}
+	if density == config.DensityCompact {
+		t = compactTheme{t}
+	}
+	app.Settings().SetTheme(t)
+}
+
+// choiceMenu returns a menu of choices with current checked; picking one
+// checks it and calls onChanged with its name.
+func choiceMenu(choices []struct{ label, name string }, current string, onChanged func(name string)) *fyne.Menu {
+	menu := fyne.NewMenu("")
+	for _, choice := range choices {
+		choice := choice
+		item := fyne.NewMenuItem(choice.label, nil)
+		item.Checked = choice.name == current
+		item.Action = func() {
+			for _, other := range menu.Items {
+				other.Checked = other == item
+			}
+			onChanged(choice.name)
+		}
+		menu.Items = append(menu.Items, item)
+	}
+	return menu
+}
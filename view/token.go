@@ -0,0 +1,113 @@
+/**
+ * Password Generator - Key Tab
+ *
+ * This file builds the Key tab, which generates raw random keys for API
+ * secrets and session keys: a byte length, an encoding and a Generate button.
+ * The API key style generates prefixed base62 keys such as sk_live_...
+ * instead, optionally with a checksum for secret scanners.
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/controller"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Styles offered in the Key tab.
+const (
+	keyStyleRaw = "Raw key"
+	keyStyleAPI = "API key"
+)
+
+// tokenTab returns the content of the Key tab.
+// Parameters:
+//   - w (fyne.Window): The window whose clipboard receives copied keys.
+//   - ctrl (*controller.GeneratorController): Generates the keys.
+func tokenTab(w fyne.Window, ctrl *controller.GeneratorController) fyne.CanvasObject {
+	sizeEntry := widget.NewEntry()
+	sizeEntry.SetText(strconv.Itoa(passgen.DefaultTokenBytes))
+	encodingSelect := widget.NewSelect(passgen.Encodings, nil)
+	encodingSelect.SetSelected(passgen.EncodingHex)
+	quantitySelect := widget.NewSelect([]string{"1", "5", "10", "20"}, nil)
+	quantitySelect.SetSelected("1")
+	prefixEntry := widget.NewEntry()
+	prefixEntry.SetPlaceHolder("e.g. sk_live_")
+	lengthEntry := widget.NewEntry()
+	lengthEntry.SetText(strconv.Itoa(passgen.DefaultAPIKeyLength))
+	checksumCheck := widget.NewCheck("Append checksum for secret scanners", nil)
+
+	keyEntry := widget.NewMultiLineEntry()
+	keyEntry.SetPlaceHolder("Generated keys will appear here")
+	keyEntry.Wrapping = fyne.TextWrapBreak
+
+	rawForm := widget.NewForm(
+		widget.NewFormItem(fmt.Sprintf("Bytes (%d-%d)", passgen.MinTokenBytes, passgen.MaxTokenBytes), sizeEntry),
+		widget.NewFormItem("Encoding", encodingSelect),
+	)
+	apiForm := widget.NewForm(
+		widget.NewFormItem("Prefix", prefixEntry),
+		widget.NewFormItem(fmt.Sprintf("Characters (%d-%d)", passgen.MinAPIKeyLength, passgen.MaxAPIKeyLength), lengthEntry),
+		widget.NewFormItem("", checksumCheck),
+	)
+	apiForm.Hide()
+	styleRadio := widget.NewRadioGroup([]string{keyStyleRaw, keyStyleAPI}, func(style string) {
+		if style == keyStyleAPI {
+			rawForm.Hide()
+			apiForm.Show()
+		} else {
+			apiForm.Hide()
+			rawForm.Show()
+		}
+	})
+	styleRadio.Horizontal = true
+	styleRadio.Required = true
+	styleRadio.SetSelected(keyStyleRaw)
+
+	generateButton := widget.NewButton("Generate Key", func() {
+		quantity, _ := strconv.Atoi(quantitySelect.Selected)
+		var keys []string
+		if styleRadio.Selected == keyStyleAPI {
+			length, err := strconv.Atoi(strings.TrimSpace(lengthEntry.Text))
+			if err != nil {
+				keyEntry.SetText("Error: the number of characters must be a number")
+				return
+			}
+			opts := passgen.APIKeyOptions{Prefix: strings.TrimSpace(prefixEntry.Text), Length: length, Checksum: checksumCheck.Checked}
+			keys, err = ctrl.GenerateAPIKeys(context.Background(), opts, quantity)
+			if err != nil {
+				keyEntry.SetText(errorText(err))
+				return
+			}
+			keyEntry.SetText(strings.Join(keys, "\n"))
+			return
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(sizeEntry.Text))
+		if err != nil {
+			keyEntry.SetText("Error: the byte length must be a number")
+			return
+		}
+		keys, err = ctrl.GenerateTokens(context.Background(), size, encodingSelect.Selected, quantity)
+		if err != nil {
+			keyEntry.SetText(errorText(err))
+			return
+		}
+		keyEntry.SetText(strings.Join(keys, "\n"))
+	})
+	copyButton := widget.NewButton("Copy", func() {
+		copySecret(w, strings.TrimSpace(keyEntry.Text))
+	})
+
+	quantityForm := widget.NewForm(widget.NewFormItem("Quantity", quantitySelect))
+	top := container.NewVBox(styleRadio, rawForm, apiForm, quantityForm, generateButton)
+	return container.NewBorder(top, copyButton, nil, nil, keyEntry)
+}
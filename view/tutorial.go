@@ -0,0 +1,218 @@
+/**
+ * Password Generator - Strength Tutorial
+ *
+ * This file shows a guided tutorial for security-awareness sessions. It
+ * walks through progressively stronger options, from six lowercase letters
+ * to sixteen characters of every type, and lets the audience change the
+ * options at every step. The example passwords come from the real
+ * generator and the entropy and crack times from the same estimate the
+ * main window uses, so the numbers shown are the generator's own.
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// tutorialStep is one step of the strength tutorial.
+type tutorialStep struct {
+	title  string
+	lesson string
+	opts   passgen.PasswordOptions
+}
+
+// tutorialSteps lists the steps, from weakest to strongest options.
+var tutorialSteps = []tutorialStep{
+	{
+		title:  "Short and simple",
+		lesson: "Six lowercase letters, like many old passwords. Look at the last crack time: an attacker with a stolen database and a graphics card needs no time at all.",
+		opts:   passgen.PasswordOptions{Length: 6, IncludeLower: true},
+	},
+	{
+		title:  "Add capital letters",
+		lesson: "Capitals double the alphabet, which adds one bit per character. Better, but six characters are still found in seconds.",
+		opts:   passgen.PasswordOptions{Length: 6, IncludeLower: true, IncludeUpper: true},
+	},
+	{
+		title:  "Add digits and symbols",
+		lesson: "Every character type helps, but at this length only a little. Complexity rules alone do not make a password strong.",
+		opts:   passgen.PasswordOptions{Length: 6, IncludeLower: true, IncludeUpper: true, IncludeNumbers: true, IncludeSymbols: true},
+	},
+	{
+		title:  "Make it longer",
+		lesson: "Twelve characters instead of six. Each extra character multiplies the attacker's work by the size of the alphabet: length matters most.",
+		opts:   passgen.PasswordOptions{Length: 12, IncludeLower: true, IncludeUpper: true, IncludeNumbers: true, IncludeSymbols: true},
+	},
+	{
+		title:  "Long and random",
+		lesson: "Sixteen random characters are out of reach even for offline attacks on fast hashes. A password manager remembers them for you.",
+		opts:   passgen.PasswordOptions{Length: 16, IncludeLower: true, IncludeUpper: true, IncludeNumbers: true, IncludeSymbols: true},
+	},
+	{
+		title:  "Your turn",
+		lesson: "Try your own combinations: turn character types off and raise the length to keep the same strength, or see how fast short passwords fall. Passwords people make up are weaker than these random ones; Tools > Check Password Strength shows by how much.",
+		opts:   passgen.PasswordOptions{Length: 16, IncludeLower: true, IncludeUpper: true, IncludeNumbers: true, IncludeSymbols: true},
+	},
+}
+
+// tutorialMaxLength is the longest length offered by the tutorial.
+const tutorialMaxLength = 32
+
+// showStrengthTutorial opens the strength tutorial window at its first step.
+func showStrengthTutorial() {
+	window := fyne.CurrentApp().NewWindow("Strength Tutorial")
+
+	stepLabel := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	lessonLabel := widget.NewLabel("")
+	lessonLabel.Wrapping = fyne.TextWrapWord
+
+	lengthLabel := widget.NewLabel("")
+	lengthSlider := widget.NewSlider(4, tutorialMaxLength)
+	lengthSlider.Step = 1
+	lower := widget.NewCheck("Lowercase", nil)
+	upper := widget.NewCheck("Uppercase", nil)
+	numbers := widget.NewCheck("Numbers", nil)
+	symbols := widget.NewCheck("Symbols", nil)
+
+	example := widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{Monospace: true, Bold: true})
+	bar := newStrengthBar()
+	scoreLabel := widget.NewLabel("")
+	crackTimes := widget.NewForm()
+	comparison := widget.NewLabel("")
+	comparison.Wrapping = fyne.TextWrapWord
+
+	step := 0
+	loading := false
+	currentOptions := func() passgen.PasswordOptions {
+		return passgen.PasswordOptions{
+			Length:         int(lengthSlider.Value),
+			Quantity:       1,
+			IncludeLower:   lower.Checked,
+			IncludeUpper:   upper.Checked,
+			IncludeNumbers: numbers.Checked,
+			IncludeSymbols: symbols.Checked,
+		}
+	}
+
+	// update shows a fresh example and the numbers for the current options.
+	update := func() {
+		if loading {
+			return
+		}
+		opts := currentOptions()
+		lengthLabel.SetText(fmt.Sprintf("Length: %d", opts.Length))
+		crackTimes.Items = nil
+		passwords, err := passgen.GeneratePasswords(context.Background(), opts)
+		if err != nil {
+			example.SetText("")
+			scoreLabel.SetText(localized(err).Error())
+			comparison.SetText("")
+			setStrengthBar(bar, passgen.VeryWeak)
+			crackTimes.Refresh()
+			return
+		}
+		example.SetText(passwords[0])
+		bits := passgen.EstimateEntropy(opts)
+		strength := passgen.RateEntropy(bits)
+		setStrengthBar(bar, strength)
+		scoreLabel.SetText(fmt.Sprintf("≈ %.0f bits (%s)", bits, strength))
+		for _, attack := range passgen.Attacks {
+			crackTimes.Append(attack.Name, widget.NewLabel(passgen.FormatCrackTime(passgen.CrackSeconds(bits, attack.GuessesPerSecond))))
+		}
+		crackTimes.Refresh()
+
+		start := tutorialSteps[0]
+		startBits := passgen.EstimateEntropy(start.opts)
+		switch {
+		case bits > startBits:
+			comparison.SetText(fmt.Sprintf("%s as long to crack as \"%s\".", multipleText(math.Pow(2, bits-startBits)), start.title))
+		case bits < startBits:
+			comparison.SetText(fmt.Sprintf("Weaker than \"%s\".", start.title))
+		default:
+			comparison.SetText("This is where we started.")
+		}
+	}
+
+	backButton := widget.NewButton("Back", nil)
+	nextButton := widget.NewButton("Next", nil)
+	// show applies the options of step i and explains them.
+	show := func(i int) {
+		step = i
+		s := tutorialSteps[i]
+		stepLabel.SetText(fmt.Sprintf("Step %d of %d: %s", i+1, len(tutorialSteps), s.title))
+		lessonLabel.SetText(s.lesson)
+		loading = true
+		lengthSlider.SetValue(float64(s.opts.Length))
+		lower.SetChecked(s.opts.IncludeLower)
+		upper.SetChecked(s.opts.IncludeUpper)
+		numbers.SetChecked(s.opts.IncludeNumbers)
+		symbols.SetChecked(s.opts.IncludeSymbols)
+		loading = false
+		if i == 0 {
+			backButton.Disable()
+		} else {
+			backButton.Enable()
+		}
+		if i == len(tutorialSteps)-1 {
+			nextButton.Disable()
+		} else {
+			nextButton.Enable()
+		}
+		update()
+	}
+	backButton.OnTapped = func() { show(step - 1) }
+	nextButton.OnTapped = func() { show(step + 1) }
+
+	lengthSlider.OnChanged = func(float64) { update() }
+	for _, check := range []*widget.Check{lower, upper, numbers, symbols} {
+		check.OnChanged = func(bool) { update() }
+	}
+	show(0)
+
+	window.SetContent(container.NewVBox(
+		stepLabel,
+		lessonLabel,
+		widget.NewSeparator(),
+		container.NewBorder(nil, nil, lengthLabel, nil, lengthSlider),
+		container.NewGridWithColumns(4, lower, upper, numbers, symbols),
+		widget.NewSeparator(),
+		example,
+		container.NewHBox(bar, scoreLabel),
+		crackTimes,
+		comparison,
+		container.NewBorder(nil, nil, backButton, nextButton, widget.NewButton("New Example", update)),
+	))
+	window.Resize(fyne.NewSize(640, 560))
+	window.Show()
+}
+
+// multipleText renders how many times longer something takes, e.g.
+// "About 52 times" or "About 3.4 million times".
+func multipleText(factor float64) string {
+	if factor >= 1e18 {
+		return "More than a billion billion times"
+	}
+	for _, scale := range []struct {
+		name  string
+		value float64
+	}{
+		{"quadrillion", 1e15},
+		{"trillion", 1e12},
+		{"billion", 1e9},
+		{"million", 1e6},
+	} {
+		if factor >= scale.value {
+			return fmt.Sprintf("About %.1f %s times", factor/scale.value, scale.name)
+		}
+	}
+	return fmt.Sprintf("About %.0f times", factor)
+}
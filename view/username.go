@@ -0,0 +1,131 @@
+/**
+ * Password Generator - Username Tab
+ *
+ * This file builds the Username tab, which generates handles such as
+ * brave_otter42 for throwaway accounts, optionally each with a password of
+ * the options chosen in the Password tab. A label shows how many handles can
+ * be generated before a repeat becomes likely.
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PaulBaker1/Password-Generator-GO/controller"
+	"github.com/PaulBaker1/Password-Generator-GO/pkg/passgen"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// usernameSeparatorNames names the separators of passgen.UsernameSeparators.
+var usernameSeparatorNames = map[string]string{"": "None", "_": "Underscore (_)", "-": "Hyphen (-)", ".": "Dot (.)"}
+
+// usernameTab returns the content of the Username tab.
+// Parameters:
+//   - w (fyne.Window): The window whose clipboard receives copied usernames.
+//   - ctrl (*controller.GeneratorController): Generates usernames and passwords.
+//   - passwordOptions (func() passgen.PasswordOptions): Returns the options of
+//     the Password tab, for the passwords generated next to the usernames.
+func usernameTab(w fyne.Window, ctrl *controller.GeneratorController, passwordOptions func() passgen.PasswordOptions) fyne.CanvasObject {
+	defaults := passgen.DefaultUsernameOptions()
+	var adjectiveCounts, digitCounts, separators []string
+	for i := 1; i <= passgen.MaxUsernameAdjectives; i++ {
+		adjectiveCounts = append(adjectiveCounts, strconv.Itoa(i))
+	}
+	for i := 0; i <= passgen.MaxUsernameDigits; i++ {
+		digitCounts = append(digitCounts, strconv.Itoa(i))
+	}
+	separatorValues := map[string]string{}
+	for _, separator := range passgen.UsernameSeparators {
+		separators = append(separators, usernameSeparatorNames[separator])
+		separatorValues[usernameSeparatorNames[separator]] = separator
+	}
+	adjectivesSelect := widget.NewSelect(adjectiveCounts, nil)
+	adjectivesSelect.SetSelected(strconv.Itoa(defaults.Adjectives))
+	digitsSelect := widget.NewSelect(digitCounts, nil)
+	digitsSelect.SetSelected(strconv.Itoa(defaults.Digits))
+	separatorSelect := widget.NewSelect(separators, nil)
+	separatorSelect.SetSelected(usernameSeparatorNames[defaults.Separator])
+	capitalizeCheck := widget.NewCheck("Capitalize words", nil)
+	quantitySelect := widget.NewSelect([]string{"1", "5", "10", "20"}, nil)
+	quantitySelect.SetSelected("1")
+	passwordCheck := widget.NewCheck("Add a password with the Password tab's options", nil)
+
+	options := func() passgen.UsernameOptions {
+		opts := defaults
+		opts.Adjectives, _ = strconv.Atoi(adjectivesSelect.Selected)
+		opts.Digits, _ = strconv.Atoi(digitsSelect.Selected)
+		opts.Separator = separatorValues[separatorSelect.Selected]
+		opts.Capitalize = capitalizeCheck.Checked
+		opts.Quantity, _ = strconv.Atoi(quantitySelect.Selected)
+		return opts
+	}
+	uniqueness := widget.NewLabel("")
+	uniqueness.Wrapping = fyne.TextWrapWord
+	updateUniqueness := func() {
+		bits := passgen.UsernameEntropy(options())
+		uniqueness.SetText(fmt.Sprintf("≈ %.0f bits: about %s usernames before a 1%% chance that two are the same. Add digits or words to make repeats rarer.",
+			bits, formatCount(passgen.UsernamesBeforeCollision(bits, 0.01))))
+	}
+	adjectivesSelect.OnChanged = func(string) { updateUniqueness() }
+	digitsSelect.OnChanged = func(string) { updateUniqueness() }
+	updateUniqueness()
+
+	namesEntry := widget.NewMultiLineEntry()
+	namesEntry.SetPlaceHolder("Generated usernames will appear here")
+	namesEntry.TextStyle = fyne.TextStyle{Monospace: true}
+
+	generateButton := widget.NewButton("Generate Username", func() {
+		opts := options()
+		names, err := ctrl.GenerateUsernames(context.Background(), opts)
+		if err != nil {
+			namesEntry.SetText(errorText(err))
+			return
+		}
+		if passwordCheck.Checked {
+			passwordOpts := passwordOptions()
+			passwordOpts.Quantity = len(names)
+			passwords, err := ctrl.GeneratePasswords(context.Background(), passwordOpts)
+			if err != nil {
+				namesEntry.SetText(errorText(err))
+				return
+			}
+			for i := range names {
+				names[i] += "\t" + passwords[i]
+			}
+		}
+		namesEntry.SetText(strings.Join(names, "\n"))
+	})
+	copyButton := widget.NewButton("Copy", func() {
+		copySecret(w, strings.TrimSpace(namesEntry.Text))
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem("Adjectives", adjectivesSelect),
+		widget.NewFormItem("Digits", digitsSelect),
+		widget.NewFormItem("Separator", separatorSelect),
+		widget.NewFormItem("", capitalizeCheck),
+		widget.NewFormItem("Quantity", quantitySelect),
+		widget.NewFormItem("", passwordCheck),
+	)
+	return container.NewBorder(container.NewVBox(form, uniqueness, generateButton), copyButton, nil, nil, namesEntry)
+}
+
+// formatCount renders a large count roughly, e.g. "4,700" or "2.1 million".
+func formatCount(n float64) string {
+	switch {
+	case n >= 1e9:
+		return fmt.Sprintf("%.1f billion", n/1e9)
+	case n >= 1e6:
+		return fmt.Sprintf("%.1f million", n/1e6)
+	case n >= 1000:
+		return fmt.Sprintf("%d,%03d", int(n)/1000, int(n)%1000)
+	}
+	return fmt.Sprintf("%.0f", n)
+}